@@ -0,0 +1,80 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// NamespaceGroupSpec names a set of namespaces once, for many SharedResources.
+//
+// A SharedResource target with `group: <name>` stands for one target per
+// namespace of the group, with the target's other settings. Members are the
+// listed Namespaces plus every namespace matching Selector; editing the group
+// re-syncs every SharedResource targeting it.
+//
+// =============================================================================
+// +kubebuilder:validation:XValidation:rule="has(self.namespaces) || has(self.selector)",message="namespaces or selector must be set"
+type NamespaceGroupSpec struct {
+	// Namespaces lists member namespaces by name. Like spec.targets[].namespace,
+	// they must exist; the operator does not create them.
+	//
+	// +kubebuilder:validation:MaxItems=1024
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector adds every namespace whose labels match it.
+	//
+	// Example:
+	//   selector:
+	//     matchLabels:
+	//       team: payments
+	//
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// NamespaceGroup is the Schema for the namespacegroups API
+type NamespaceGroup struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the namespaces of the group
+	// +required
+	Spec NamespaceGroupSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceGroupList contains a list of NamespaceGroup
+type NamespaceGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []NamespaceGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespaceGroup{}, &NamespaceGroupList{})
+}
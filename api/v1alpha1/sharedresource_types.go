@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // =============================================================================
@@ -25,24 +27,55 @@ import (
 //
 // This is where users declare WHAT they want to sync and WHERE:
 //   - Source: The Secret or ConfigMap to copy FROM (must exist in same namespace as this CR)
-//   - Targets: List of namespaces to copy TO
+//   - Targets / TargetSelector: Namespaces to copy TO, listed or by label
 //   - SyncPolicy: How to filter/transform data during sync
 //   - DeletionPolicy: What happens to synced resources when this CR is deleted
 //
 // =============================================================================
+// +kubebuilder:validation:XValidation:rule="!has(self.access) || !has(self.access.serviceAccountLinks) || self.source.kind == 'Secret' || (has(self.template) && has(self.template.targetKind) && self.template.targetKind == 'Secret')",message="access.serviceAccountLinks is only supported for Secret sources or template.targetKind Secret"
+// +kubebuilder:validation:XValidation:rule="!has(self.generate) || self.source.kind == 'Secret'",message="generate is only supported for Secret sources"
+// +kubebuilder:validation:XValidation:rule="has(self.targets) || has(self.targetSelector)",message="targets or targetSelector is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.template) || !has(self.template.targetKind) || self.template.targetKind == self.source.kind || self.source.kind == 'ConfigMap'",message="template.targetKind cannot write a Secret source into ConfigMaps"
+// +kubebuilder:validation:XValidation:rule="!has(self.template) || !has(self.template.valuesFrom) || (has(self.template.targetKind) && self.template.targetKind == 'Secret')",message="template.valuesFrom requires template.targetKind Secret"
+// +kubebuilder:validation:XValidation:rule="!has(self.sources) || !has(self.source.__namespace__)",message="sources need spec.source in the SharedResource's namespace"
+// +kubebuilder:validation:XValidation:rule="!has(self.sources) || !has(self.source.name) || self.sources.all(s, s.name != self.source.name)",message="sources must not repeat spec.source.name"
 type SharedResourceSpec struct {
 	// Source specifies the Secret or ConfigMap to synchronize.
-	// The source resource must exist in the SAME namespace as this SharedResource CR.
-	// This design ensures the team owning the secret also controls its distribution.
+	// It is read from this SharedResource's namespace unless source.namespace
+	// names another one. A source in another namespace is only synced with
+	// the consent of the team owning it: the sharedresource.platform.dev/allow-export
+	// annotation on the source or its namespace, or a SharedResourceExport
+	// there admitting the SharedResourceImport this CR belongs to.
 	//
 	// Example:
 	//   source:
 	//     kind: Secret
 	//     name: db-credentials
+	//     namespace: security          # Optional; needs the owner's consent
 	//
 	// +required
 	Source SourceSpec `json:"source"`
 
+	// Sources lists further sources merged into each target on top of
+	// Source, e.g. environment overlays on a base config. They are of
+	// Source's kind and in the SharedResource's namespace. Where sources
+	// share a key, later entries win: Source first, then each entry in order.
+	//
+	// Example:
+	//   source:
+	//     kind: ConfigMap
+	//     name: app-config-base
+	//   sources:
+	//     - name: app-config-prod        # Overrides keys of the base
+	//     - name: app-config-hotfix      # Overrides both, while it exists
+	//       optional: true
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Sources []SourceOverlay `json:"sources,omitempty"`
+
 	// Targets lists the namespaces where the source should be synchronized.
 	// Each target can optionally rename the resource in that namespace.
 	//
@@ -51,10 +84,47 @@ type SharedResourceSpec struct {
 	//     - namespace: backend
 	//     - namespace: jobs
 	//       name: database-creds  # Optional: rename in this namespace
+	//     - group: team-payments  # Every namespace of a NamespaceGroup
+	//
+	// May be omitted if targetSelector is set.
 	//
-	// +required
 	// +kubebuilder:validation:MinItems=1
-	Targets []TargetSpec `json:"targets"`
+	// +optional
+	Targets []TargetSpec `json:"targets,omitempty"`
+
+	// TargetSelector also targets every namespace whose labels match it,
+	// re-evaluated as namespaces gain or lose the labels. Matched namespaces
+	// receive the source under its own name; a spec.targets entry for the
+	// same namespace and name takes precedence. The SharedResource's own
+	// namespace never matches.
+	//
+	// Example:
+	//   targetSelector:
+	//     matchLabels:
+	//       environment: dev
+	//
+	// +optional
+	TargetSelector *metav1.LabelSelector `json:"targetSelector,omitempty"`
+
+	// CreateTargetNamespaces creates a target namespace that does not exist,
+	// with TargetNamespaceLabels, instead of failing the target. Namespaces
+	// created this way are annotated and reported as created in
+	// status.syncedTargets; they are never deleted by the operator.
+	//
+	// +optional
+	CreateTargetNamespaces bool `json:"createTargetNamespaces,omitempty"`
+
+	// TargetNamespaceLabels are the labels of the namespaces created with
+	// CreateTargetNamespaces. SharedResourcePolicy selectors and namespace
+	// rules see a missing namespace with these labels.
+	//
+	// Example:
+	//   createTargetNamespaces: true
+	//   targetNamespaceLabels:
+	//     team: payments
+	//
+	// +optional
+	TargetNamespaceLabels map[string]string `json:"targetNamespaceLabels,omitempty"`
 
 	// SyncPolicy configures how data is copied to targets.
 	// By default, all keys are copied. Use selective mode to filter specific keys.
@@ -66,16 +136,372 @@ type SharedResourceSpec struct {
 	// SharedResource CR is deleted.
 	//   - "orphan" (default): Target resources are left in place (safe)
 	//   - "delete": Target resources are deleted (use with caution)
+	//   - "deleteForeground": Like delete, but the CR is only removed once
+	//     every target is confirmed gone
+	//   - "deleteBackground": The CR is removed immediately; the operator's
+	//     sweeper deletes the targets afterwards
 	//
-	// +kubebuilder:validation:Enum=orphan;delete
+	// +kubebuilder:validation:Enum=orphan;delete;deleteForeground;deleteBackground
 	// +kubebuilder:default=orphan
 	// +optional
 	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// StatusPolicy configures how per-target results are reported in status.
+	// By default every target is listed in status.syncedTargets. CRs fanning out
+	// to a very large number of namespaces can switch to compact mode to keep
+	// the object small and status updates cheap.
+	//
+	// +optional
+	StatusPolicy *StatusPolicySpec `json:"statusPolicy,omitempty"`
+
+	// SourceRetryInterval overrides how often the controller checks for a
+	// missing source resource. Defaults to the operator's --source-retry-interval
+	// (30s unless configured).
+	//
+	// Use a longer interval when the CR is created well before its source
+	// (e.g. cert-manager still issuing), or a shorter one for faster pickup.
+	//
+	// Example:
+	//   sourceRetryInterval: 10s
+	//
+	// +optional
+	SourceRetryInterval *metav1.Duration `json:"sourceRetryInterval,omitempty"`
+
+	// SourcePollInterval makes the controller re-read the source from the API
+	// server at this interval, bypassing its watch cache, for clusters where
+	// watch events are unreliable (e.g. behind some proxies or aggregated API
+	// layers). Defaults to the operator's --source-poll-interval (off unless
+	// configured); "0s" turns polling off for this CR.
+	//
+	// Example:
+	//   sourcePollInterval: 1m
+	//
+	// +optional
+	SourcePollInterval *metav1.Duration `json:"sourcePollInterval,omitempty"`
+
+	// Access optionally distributes read permission alongside the data.
+	// For each target, the operator maintains a Role (get on exactly the synced
+	// resource) and a RoleBinding to the listed ServiceAccounts.
+	//
+	// Example:
+	//   access:
+	//     serviceAccounts:
+	//       - api-server
+	//
+	// +optional
+	Access *AccessSpec `json:"access,omitempty"`
+
+	// TrackTargetDeletion places a finalizer on every target so that deleting
+	// one is observed instead of silently repaired on the next resync. A copy
+	// deleted out-of-band is recreated at once and reported via an event and
+	// the sharedresource_target_deletions_total metric; copies removed because
+	// their namespace is being deleted are released without recreating them.
+	//
+	// +optional
+	TrackTargetDeletion bool `json:"trackTargetDeletion,omitempty"`
+
+	// Template renders source values as Go templates per target, so one source
+	// produces slightly different config in each destination. Templates see
+	// .Values (spec.template.values overlaid with targets[].values),
+	// .Secrets (keys of template.valuesFrom) and .Target.Namespace / .Target.Name.
+	//
+	// Example:
+	//   template:
+	//     keys:
+	//       - app.yaml
+	//     values:
+	//       logLevel: info
+	//
+	// +optional
+	Template *TemplateSpec `json:"template,omitempty"`
+
+	// Substitutions replace tokens in every value with per-environment
+	// strings, picked by a label of the target namespace. A lighter-weight
+	// alternative to template for config that differs only in a hostname or
+	// an environment name.
+	//
+	// Example:
+	//   substitutions:
+	//     namespaceLabel: environment
+	//     values:
+	//       prod:
+	//         "{{ENV}}": prod
+	//         db.internal: db.prod.internal
+	//       dev:
+	//         "{{ENV}}": dev
+	//
+	// +optional
+	Substitutions *SubstitutionSpec `json:"substitutions,omitempty"`
+
+	// Generate lists keys the operator fills with random values in the source
+	// Secret, creating the Secret if it does not exist. Values are rotated
+	// every rotationPeriod and fanned out like any other source change.
+	// Keys that already hold a value the operator did not generate are left alone.
+	//
+	// Example:
+	//   generate:
+	//     - key: password
+	//       length: 40
+	//       rotationPeriod: 720h
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	Generate []GenerateSpec `json:"generate,omitempty"`
+
+	// TargetTemplate is a partial Secret or ConfigMap merged into every target
+	// the operator writes. It may set metadata.labels, metadata.annotations,
+	// immutable and, for Secrets, type; everything else (finalizers, data,
+	// owner references, ...) is rejected by the SharedResource webhook and
+	// reported as a sync error. Tracking annotations always win.
+	//
+	// Example:
+	//   targetTemplate:
+	//     apiVersion: v1
+	//     kind: Secret
+	//     metadata:
+	//       labels:
+	//         team: payments
+	//     immutable: true
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +optional
+	TargetTemplate *runtime.RawExtension `json:"targetTemplate,omitempty"`
+
+	// Verify runs a Job in each target namespace after the target is written,
+	// e.g. to test a database login with the synced credentials. Ready waits
+	// for the Jobs to succeed; each target's result is in
+	// status.syncedTargets[].verification.
+	// +optional
+	Verify *VerifySpec `json:"verify,omitempty"`
+
+	// PreSync is a hook run before a changed source is propagated, e.g. to
+	// take a distributed lock or notify a change-management system: an HTTP
+	// call or a Job in the SharedResource's namespace. Until it succeeds for
+	// the new source data no target is written; the result is in
+	// status.preSync.
+	// +optional
+	PreSync *PreSyncSpec `json:"preSync,omitempty"`
+
+	// ExternallyManaged decides what happens to a target that is also managed
+	// by a GitOps tool (Argo CD or Flux, detected from their labels, annotations
+	// and field managers):
+	// - "backOff": the target is not written and reported failed (default)
+	// - "takeOwnership": the target is written with server-side apply, forcing
+	//   ownership of the fields the operator sets
+	// Either way the ExternallyManaged condition lists such targets.
+	// +optional
+	ExternallyManaged ExternalManagementPolicy `json:"externallyManaged,omitempty"`
+
+	// Suspend stops syncing targets, e.g. during an incident or a migration.
+	// Targets keep their current data, generated keys are not rotated, and
+	// source changes are only counted in status. Deleting the CR still cleans
+	// up targets. Resuming propagates the current source immediately.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// RequireResumeApproval holds a resume if the source changed while
+	// suspended, until the sharedresource.platform.dev/approve-checksum
+	// annotation equals status.pendingSourceChecksum. Prevents a mass update
+	// the moment suspend is turned off. A resume with nothing pending is not held.
+	// +optional
+	RequireResumeApproval bool `json:"requireResumeApproval,omitempty"`
+
+	// MinReadyTargets is how many targets must be synced for Ready to be
+	// True: a count, or a percentage of the targets rounded up (e.g. "95%").
+	// Failed targets are still retried and reported in the Degraded
+	// condition. Unset requires every target.
+	//
+	// Example: Stay Ready while a few namespaces are terminating
+	//   minReadyTargets: 95%
+	//
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:XValidation:rule="type(self) == int ? self >= 0 : self.matches('^(100|[1-9]?[0-9])%$')",message="minReadyTargets must be a non-negative count or a percentage between 0% and 100%"
+	// +optional
+	MinReadyTargets *intstr.IntOrString `json:"minReadyTargets,omitempty"`
+
+	// ExpiresAt ends the share at a fixed time; see ExpiryPolicy for what
+	// happens then. Moving it later renews the share.
+	//
+	// Example:
+	//   expiresAt: "2026-12-31T23:59:59Z"
+	//
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Duration ends the share this long after the CR was created, or after
+	// the time in its sharedresource.platform.dev/renewed-at annotation, which
+	// renews it. With ExpiresAt also set, the earlier expiry applies.
+	//
+	// Example: Share for 30 days, renewable
+	//   duration: 720h
+	//
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="duration must be positive"
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// ExpiryPolicy decides what happens to targets once the share expires:
+	// - "delete": targets (and their access grants) are deleted (default)
+	// - "retain": targets are kept but no longer refreshed, and annotated
+	//   sharedresource.platform.dev/expired with the expiry time
+	// Either way the Expired condition is set. Renewing resumes syncing.
+	//
+	// +kubebuilder:default=delete
+	// +optional
+	ExpiryPolicy ExpiryPolicy `json:"expiryPolicy,omitempty"`
+
+	// RenewBefore is how long before the expiry the RenewalDue condition
+	// turns True and a RenewalDue event is emitted. Defaults to 7 days.
+	//
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// OperatorClass selects the operator instance that manages this CR, like
+	// an IngressClass: an instance started with --operator-class manages only
+	// CRs naming that class, and an instance without one only CRs without it.
+	// Changing the class hands the CR and its targets over to the new instance.
+	//
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	OperatorClass string `json:"operatorClass,omitempty"`
+}
+
+// GenerateCharset selects the characters used for generated values.
+// +kubebuilder:validation:Enum=alphanumeric;ascii;hex
+type GenerateCharset string
+
+const (
+	// GenerateCharsetAlphanumeric uses A-Z, a-z and 0-9
+	GenerateCharsetAlphanumeric GenerateCharset = "alphanumeric"
+
+	// GenerateCharsetASCII uses all printable ASCII characters except space
+	GenerateCharsetASCII GenerateCharset = "ascii"
+
+	// GenerateCharsetHex uses lowercase hexadecimal digits
+	GenerateCharsetHex GenerateCharset = "hex"
+)
+
+// GenerateSpec declares a randomly generated key in the source Secret.
+type GenerateSpec struct {
+	// Key is the data key to generate.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Length is the number of characters to generate.
+	//
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=4096
+	// +kubebuilder:default=32
+	// +optional
+	Length int32 `json:"length,omitempty"`
+
+	// Charset selects the characters used.
+	//
+	// +kubebuilder:default=alphanumeric
+	// +optional
+	Charset GenerateCharset `json:"charset,omitempty"`
+
+	// RotationPeriod regenerates the value once it is this old.
+	// If not set, the value is generated once and never rotated.
+	//
+	// +optional
+	RotationPeriod *metav1.Duration `json:"rotationPeriod,omitempty"`
+
+	// RotationStrategy controls how a rotated value reaches targets:
+	//   - "inPlace" (default): The key is overwritten and fanned out
+	//   - "twoPhase": The new value is first written under a versioned key
+	//     (<key>.v<N>); the primary key is only switched once every target
+	//     holds the versioned key, so no namespace is ever without the new value
+	//
+	// +kubebuilder:validation:Enum=inPlace;twoPhase
+	// +kubebuilder:default=inPlace
+	// +optional
+	RotationStrategy RotationStrategy `json:"rotationStrategy,omitempty"`
+}
+
+// RotationStrategy determines how rotated generated values are propagated.
+type RotationStrategy string
+
+const (
+	// RotationStrategyInPlace overwrites the key directly
+	RotationStrategyInPlace RotationStrategy = "inPlace"
+
+	// RotationStrategyTwoPhase stages the new value under a versioned key first
+	RotationStrategyTwoPhase RotationStrategy = "twoPhase"
+)
+
+// TemplateSpec configures rendering of source values per target.
+type TemplateSpec struct {
+	// Keys limits rendering to these source keys; other keys are copied verbatim.
+	// If empty, every key is rendered.
+	//
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+
+	// Values are template variables shared by all targets.
+	// Each target may override them with targets[].values.
+	//
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+
+	// ValuesFrom names a Secret in this namespace whose keys are exposed to
+	// templates as .Secrets. This keeps the template in a Git-friendly source
+	// ConfigMap while the sensitive values stay in a Secret. Requires
+	// targetKind Secret, so the rendered values never land in a ConfigMap.
+	//
+	// +optional
+	ValuesFrom *TemplateValuesSource `json:"valuesFrom,omitempty"`
+
+	// TargetKind writes the rendered data as this kind instead of the source kind.
+	// It must be Secret when valuesFrom is set, so values rendered into a
+	// ConfigMap template are never stored in a ConfigMap.
+	//
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +optional
+	TargetKind string `json:"targetKind,omitempty"`
+}
+
+// SubstitutionSpec configures token replacement by target namespace label.
+type SubstitutionSpec struct {
+	// NamespaceLabel is the label of the target namespace whose value selects
+	// the replacements.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	NamespaceLabel string `json:"namespaceLabel"`
+
+	// Values maps label values to replacements, each a token and the string
+	// that replaces it. Tokens are replaced in one pass, longest first, so a
+	// replacement is never replaced again.
+	//
+	// +kubebuilder:validation:MaxProperties=64
+	// +required
+	Values map[string]map[string]string `json:"values"`
+
+	// Default are the replacements for target namespaces without the label,
+	// or with a value not in Values. Without it their data is synced as is.
+	//
+	// +optional
+	Default map[string]string `json:"default,omitempty"`
+}
+
+// TemplateValuesSource references the Secret holding sensitive template values.
+type TemplateValuesSource struct {
+	// SecretName is the name of a Secret in the SharedResource's namespace.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
 }
 
 // =============================================================================
 // SourceSpec identifies the source Secret or ConfigMap to sync.
 // =============================================================================
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.nameTemplate)",message="exactly one of name and nameTemplate is required"
 type SourceSpec struct {
 	// Kind specifies the type of Kubernetes resource to sync.
 	// Must be either "Secret" or "ConfigMap".
@@ -87,21 +513,74 @@ type SourceSpec struct {
 	// +required
 	Kind string `json:"kind"`
 
-	// Name is the name of the source resource in the SharedResource's namespace.
+	// Name is the name of the source resource in the SharedResource's namespace,
+	// or in Namespace if it is set.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the namespace of the source resource, if not the
+	// SharedResource's own. The source, or its namespace, must consent with
+	// the sharedresource.platform.dev/allow-export: "true" annotation, or a
+	// SharedResourceExport there must admit this CR's SharedResourceImport;
+	// otherwise nothing is synced and SourceExportDenied is reported.
 	//
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// NameTemplate derives the source name from the SharedResource instead,
+	// as a Go template with .Name and .Namespace of the SharedResource, e.g.
+	// "{{ .Name }}-config". Lets tooling generating many CRs enforce a naming
+	// convention rather than repeat each name.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	NameTemplate string `json:"nameTemplate,omitempty"`
+}
+
+// SourceOverlay names a further source merged on top of spec.source.
+type SourceOverlay struct {
+	// Name is the name of the Secret or ConfigMap, of spec.source.kind, in
+	// the SharedResource's namespace.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
 	// +required
 	Name string `json:"name"`
+
+	// Optional merges nothing while the source does not exist, instead of
+	// reporting SourceNotFound.
+	//
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // =============================================================================
 // TargetSpec identifies a destination namespace for synchronization.
 // =============================================================================
+// +kubebuilder:validation:XValidation:rule="has(self.__namespace__) != has(self.group)",message="exactly one of namespace and group is required"
 type TargetSpec struct {
 	// Namespace is the target namespace to sync the resource to.
-	// The namespace must already exist - the operator will NOT create it.
+	// The namespace must already exist - the operator will NOT create it,
+	// unless spec.createTargetNamespaces is set. A glob pattern such as
+	// "team-*" targets every existing namespace it matches instead; an entry
+	// naming one of them takes precedence.
 	//
-	// +required
-	Namespace string `json:"namespace"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Group targets every namespace of the named NamespaceGroup instead, with
+	// the other settings of this entry. An entry naming one of its namespaces
+	// explicitly takes precedence over the group.
+	//
+	// Example:
+	//   targets:
+	//     - group: team-payments
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Group string `json:"group,omitempty"`
 
 	// Name optionally overrides the resource name in the target namespace.
 	// If not specified, the source resource's name is used.
@@ -111,6 +590,36 @@ type TargetSpec struct {
 	//
 	// +optional
 	Name string `json:"name,omitempty"`
+
+	// DeletionPolicy overrides spec.deletionPolicy for this target, e.g. to
+	// orphan copies in production namespaces while cleaning up preview ones.
+	//
+	// +kubebuilder:validation:Enum=orphan;delete;deleteForeground;deleteBackground
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Values are template variables for this target, overriding spec.template.values.
+	// Only used when spec.template is set.
+	//
+	// Example:
+	//   targets:
+	//     - namespace: staging
+	//       values:
+	//         environment: staging
+	//
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+
+	// KeyPrefix is prepended to every key written to this target. Combined
+	// with syncPolicy.mode "merge", it lets several SharedResources project
+	// into one existing Secret or ConfigMap without key collisions.
+	//
+	// Example: keyPrefix "upstreamdb_" writes "password" as "upstreamdb_password"
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]*$`
+	// +optional
+	KeyPrefix string `json:"keyPrefix,omitempty"`
 }
 
 // =============================================================================
@@ -132,6 +641,106 @@ type SyncPolicySpec struct {
 	//
 	// +optional
 	Keys *KeySelector `json:"keys,omitempty"`
+
+	// Profile limits the synced keys to the standard keys of a well-known
+	// Secret type, so they need not be listed in Keys:
+	//   - "tls": tls.crt, tls.key and ca.crt
+	//   - "dockerconfig": .dockerconfigjson and .dockercfg
+	// Applies in every mode, before Keys.
+	//
+	// Example: Share a certificate without any extra keys of the source
+	//   syncPolicy:
+	//     profile: tls
+	//
+	// +optional
+	Profile SyncProfile `json:"profile,omitempty"`
+
+	// NamespaceRules narrow the synced keys per target, based on the target
+	// namespace's labels. The first rule whose selector matches a target
+	// namespace is applied on top of Mode/Keys; targets matching no rule
+	// receive the data as filtered above. Applies in every mode.
+	//
+	// Example: prod gets everything, dev gets a redacted subset
+	//   namespaceRules:
+	//     - namespaceSelector:
+	//         matchLabels:
+	//           environment: dev
+	//       keys:
+	//         exclude:
+	//           - admin-password
+	//
+	// +optional
+	NamespaceRules []NamespaceKeyRule `json:"namespaceRules,omitempty"`
+
+	// VerifyWrites re-reads every written target from the API server and
+	// compares its data with what was written before marking it synced, so
+	// data altered on admission (e.g. by a mutating webhook) fails the target
+	// at once instead of surfacing at the next drift check. Costs one extra
+	// read per write.
+	//
+	// +optional
+	VerifyWrites bool `json:"verifyWrites,omitempty"`
+
+	// Split turns keys holding a multi-document YAML into one key per
+	// document, so consumers can mount each document as a file of its own.
+	// Applied per target after Keys, Profile and NamespaceRules, before
+	// templates and keyPrefix; the documents keep their original text.
+	//
+	// Example: "manifests.yaml" bundling ConfigMaps "app" and "worker"
+	// becomes the keys "app.yaml" and "worker.yaml"
+	//   split:
+	//     - key: manifests.yaml
+	//       nameField: metadata.name
+	//
+	// +listType=map
+	// +listMapKey=key
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Split []SplitSpec `json:"split,omitempty"`
+}
+
+// SplitSpec splits one key holding a multi-document YAML.
+type SplitSpec struct {
+	// Key is the key holding the documents. A source without it is synced
+	// without splitting.
+	//
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Key string `json:"key"`
+
+	// NameField is the dot-separated path of the field naming each document;
+	// the key of a document is its name plus KeySuffix. Every document must
+	// have it, and names must be unique. Empty documents are skipped.
+	//
+	// +kubebuilder:validation:Pattern=`^[-_a-zA-Z0-9]+(\.[-_a-zA-Z0-9]+)*$`
+	// +kubebuilder:default="metadata.name"
+	// +optional
+	NameField string `json:"nameField,omitempty"`
+
+	// KeySuffix is appended to each document name. Defaults to ".yaml";
+	// set to "" for none.
+	//
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]*$`
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	KeySuffix *string `json:"keySuffix,omitempty"`
+
+	// KeepSource also syncs the key holding the documents.
+	//
+	// +optional
+	KeepSource bool `json:"keepSource,omitempty"`
+}
+
+// NamespaceKeyRule filters keys for targets in namespaces matching a label selector.
+type NamespaceKeyRule struct {
+	// NamespaceSelector selects target namespaces by label
+	// +required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// Keys specifies which keys matching targets receive
+	// +required
+	Keys KeySelector `json:"keys"`
 }
 
 // SyncMode defines how data is copied during synchronization.
@@ -149,8 +758,20 @@ const (
 	SyncModeMerge SyncMode = "merge"
 )
 
+// SyncProfile names a built-in set of keys to sync.
+// +kubebuilder:validation:Enum=tls;dockerconfig
+type SyncProfile string
+
+const (
+	// SyncProfileTLS syncs the keys of a kubernetes.io/tls Secret and its CA
+	SyncProfileTLS SyncProfile = "tls"
+
+	// SyncProfileDockerConfig syncs the keys of an image pull Secret
+	SyncProfileDockerConfig SyncProfile = "dockerconfig"
+)
+
 // DeletionPolicy defines what happens to target resources when the SharedResource is deleted.
-// +kubebuilder:validation:Enum=orphan;delete
+// +kubebuilder:validation:Enum=orphan;delete;deleteForeground;deleteBackground
 type DeletionPolicy string
 
 const (
@@ -161,6 +782,39 @@ const (
 	// DeletionPolicyDelete removes target resources when SharedResource is deleted.
 	// Use with caution - this could break running workloads that depend on these resources.
 	DeletionPolicyDelete DeletionPolicy = "delete"
+
+	// DeletionPolicyDeleteForeground removes target resources and keeps the
+	// SharedResource until all of them are confirmed gone (e.g. after their own
+	// finalizers ran). Slowest, but deletion of the CR implies the copies are gone.
+	DeletionPolicyDeleteForeground DeletionPolicy = "deleteForeground"
+
+	// DeletionPolicyDeleteBackground lets the SharedResource go away immediately
+	// and leaves target removal to the operator's periodic sweeper.
+	DeletionPolicyDeleteBackground DeletionPolicy = "deleteBackground"
+)
+
+// ExpiryPolicy decides what happens to targets when a share expires.
+// +kubebuilder:validation:Enum=delete;retain
+type ExpiryPolicy string
+
+const (
+	// ExpiryPolicyDelete revokes an expired share by deleting its targets.
+	ExpiryPolicyDelete ExpiryPolicy = "delete"
+
+	// ExpiryPolicyRetain keeps the targets of an expired share, unrefreshed.
+	ExpiryPolicyRetain ExpiryPolicy = "retain"
+)
+
+// ExternalManagementPolicy decides what happens to targets also managed by a GitOps tool.
+// +kubebuilder:validation:Enum=backOff;takeOwnership
+type ExternalManagementPolicy string
+
+const (
+	// ExternalManagementBackOff leaves such targets alone and reports them failed.
+	ExternalManagementBackOff ExternalManagementPolicy = "backOff"
+
+	// ExternalManagementTakeOwnership force-applies the operator's fields.
+	ExternalManagementTakeOwnership ExternalManagementPolicy = "takeOwnership"
 )
 
 // =============================================================================
@@ -191,6 +845,273 @@ type KeySelector struct {
 	Exclude []string `json:"exclude,omitempty"`
 }
 
+// =============================================================================
+// AccessSpec configures how consumers in target namespaces get access.
+//
+// Two complementary mechanisms are supported:
+//   - ServiceAccounts: RBAC Role + RoleBinding granting "get" on the synced resource
+//   - ServiceAccountLinks: wire the synced Secret into existing ServiceAccounts
+//
+// =============================================================================
+// +kubebuilder:validation:XValidation:rule="has(self.serviceAccounts) || has(self.serviceAccountLinks)",message="access requires serviceAccounts or serviceAccountLinks"
+type AccessSpec struct {
+	// ServiceAccounts lists ServiceAccount names (in each target namespace)
+	// that are granted "get" on the synced resource.
+	//
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// ServiceAccountLinks binds the synced Secret into existing ServiceAccounts
+	// in each target namespace. Only valid for Secret sources.
+	//
+	// Example:
+	//   serviceAccountLinks:
+	//     - name: default
+	//       mode: imagePullSecrets
+	//
+	// +optional
+	ServiceAccountLinks []ServiceAccountLink `json:"serviceAccountLinks,omitempty"`
+}
+
+// ServiceAccountLink wires the synced Secret into a ServiceAccount.
+type ServiceAccountLink struct {
+	// Name is the ServiceAccount name in the target namespace.
+	// The ServiceAccount must already exist.
+	//
+	// +required
+	Name string `json:"name"`
+
+	// Mode determines how the Secret is linked:
+	//   - "secrets" (default): Appended to the ServiceAccount's secrets list (legacy token consumers)
+	//   - "imagePullSecrets": Appended to imagePullSecrets (registry credentials)
+	//   - "annotation": Listed in the sharedresource.platform.dev/shared-secrets annotation
+	//
+	// +kubebuilder:validation:Enum=secrets;imagePullSecrets;annotation
+	// +kubebuilder:default=secrets
+	// +optional
+	Mode ServiceAccountLinkMode `json:"mode,omitempty"`
+}
+
+// ServiceAccountLinkMode defines how a Secret is linked into a ServiceAccount.
+// +kubebuilder:validation:Enum=secrets;imagePullSecrets;annotation
+type ServiceAccountLinkMode string
+
+const (
+	// ServiceAccountLinkSecrets appends the Secret to the ServiceAccount's secrets list
+	ServiceAccountLinkSecrets ServiceAccountLinkMode = "secrets"
+
+	// ServiceAccountLinkImagePullSecrets appends the Secret to imagePullSecrets
+	ServiceAccountLinkImagePullSecrets ServiceAccountLinkMode = "imagePullSecrets"
+
+	// ServiceAccountLinkAnnotation lists the Secret in a ServiceAccount annotation
+	ServiceAccountLinkAnnotation ServiceAccountLinkMode = "annotation"
+)
+
+// =============================================================================
+// VerifySpec configures the verification Job run after each target write.
+// =============================================================================
+type VerifySpec struct {
+	// JobTemplate is a batch/v1 JobTemplateSpec (metadata and spec) for the
+	// Job. It is rendered as a Go template with .Target.Namespace and
+	// .Target.Name first, so one template can mount each target:
+	//
+	//   jobTemplate:
+	//     spec:
+	//       template:
+	//         spec:
+	//           containers:
+	//           - name: login
+	//             image: postgres:16
+	//             command: ["sh", "-c", "pg_isready -h db"]
+	//             envFrom:
+	//             - secretRef:
+	//                 name: "{{ .Target.Name }}"
+	//
+	// The operator names the Job, and defaults restartPolicy to Never and
+	// activeDeadlineSeconds to the timeout. The pod runs as the namespace's
+	// default ServiceAccount without its token, hardened to the Pod Security
+	// restricted profile. Pod and container fields other than those a check
+	// needs, volumes other than emptyDir, downwardAPI and the target, and
+	// Secrets or ConfigMaps other than the target are rejected. The Jobs only
+	// run if the operator is started with --enable-verify-jobs.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +required
+	JobTemplate runtime.RawExtension `json:"jobTemplate"`
+
+	// Timeout fails a verification Job that has not finished after it,
+	// unless the template sets activeDeadlineSeconds. Defaults to 10m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// HookPhase is the state of a hook: a verification Job or the pre-sync hook.
+type HookPhase string
+
+const (
+	// HookRunning means the hook has not finished yet
+	HookRunning HookPhase = "Running"
+
+	// HookSucceeded means the hook completed
+	HookSucceeded HookPhase = "Succeeded"
+
+	// HookFailed means the hook failed, or could not be started
+	HookFailed HookPhase = "Failed"
+)
+
+// =============================================================================
+// PreSyncSpec configures the hook run before a changed source is propagated.
+// =============================================================================
+// +kubebuilder:validation:XValidation:rule="has(self.http) != has(self.job)",message="exactly one of http and job is required"
+type PreSyncSpec struct {
+	// HTTP POSTs the pending change to a URL; a 2xx answer lets the sync go on.
+	// +optional
+	HTTP *PreSyncHTTP `json:"http,omitempty"`
+
+	// Job is a batch/v1 JobTemplateSpec (metadata and spec) for a Job run in
+	// the SharedResource's namespace; its completing lets the sync go on. The
+	// operator names the Job, adds the SHAREDRESOURCE_NAME,
+	// SHAREDRESOURCE_CHECKSUM and SHAREDRESOURCE_PREVIOUS_CHECKSUM environment
+	// variables to its containers, and defaults restartPolicy to Never and
+	// activeDeadlineSeconds to the timeout. Its pod is restricted like a
+	// verification Job's, and may only read the source.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Job *runtime.RawExtension `json:"job,omitempty"`
+
+	// Timeout fails a hook that has not finished after it. Defaults to 30s
+	// for HTTP calls and 10m for Jobs, unless the Job template sets
+	// activeDeadlineSeconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PreSyncHTTP is the HTTP call of a pre-sync hook.
+type PreSyncHTTP struct {
+	// URL receives a POST with a JSON description of the change: the
+	// SharedResource, the source, the new and previous checksums and the
+	// target namespaces. No source data is sent. Its scheme and host must be
+	// allowed by the operator's --presync-allowed-url; redirects are not
+	// followed.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +required
+	URL string `json:"url"`
+
+	// BearerTokenSecret is a key of a Secret in the SharedResource's
+	// namespace holding a token sent as "Authorization: Bearer <token>".
+	// +optional
+	BearerTokenSecret *SecretKeyRef `json:"bearerTokenSecret,omitempty"`
+}
+
+// SecretKeyRef selects a key of a Secret in the SharedResource's namespace.
+type SecretKeyRef struct {
+	// Name is the Secret's name
+	// +required
+	Name string `json:"name"`
+
+	// Key is the data key holding the value
+	// +required
+	Key string `json:"key"`
+}
+
+// =============================================================================
+// PreSyncStatus is the result of the pre-sync hook for the pending source data.
+// =============================================================================
+type PreSyncStatus struct {
+	// Checksum is the checksum of the source data the hook ran for
+	Checksum string `json:"checksum"`
+
+	// Phase is Running, Succeeded or Failed
+	Phase HookPhase `json:"phase"`
+
+	// Job is the name of the pre-sync Job, for job hooks
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// Message explains a failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastAttemptTime is when the hook was last called or its Job created
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+}
+
+// =============================================================================
+// TargetVerification is the result of verifying one target's data.
+// =============================================================================
+type TargetVerification struct {
+	// Job is the name of the verification Job in the target namespace
+	Job string `json:"job"`
+
+	// Checksum is the checksum of the target data the Job verifies
+	Checksum string `json:"checksum"`
+
+	// Phase is Running, Succeeded or Failed
+	Phase HookPhase `json:"phase"`
+
+	// Message explains a failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CompletionTime is when the Job finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// =============================================================================
+// StatusPolicySpec configures how per-target sync results are reported.
+// =============================================================================
+type StatusPolicySpec struct {
+	// Mode determines how much per-target detail is written to status:
+	//   - "full" (default): Every target is listed in status.syncedTargets
+	//   - "compact": Only failing targets are listed (capped by MaxFailedTargets);
+	//     status.targetSummary always carries the aggregate counters
+	//
+	// +kubebuilder:validation:Enum=full;compact
+	// +kubebuilder:default=full
+	// +optional
+	Mode StatusMode `json:"mode,omitempty"`
+
+	// MaxFailedTargets caps how many failing targets are listed in compact mode.
+	// Failures beyond the cap are only reflected in status.targetSummary.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=20
+	// +optional
+	MaxFailedTargets *int32 `json:"maxFailedTargets,omitempty"`
+
+	// Report enables writing the complete per-target list into a companion
+	// SharedResourceStatusReport object with the same name and namespace.
+	// Combine with compact mode to keep the CR small while retaining full detail.
+	//
+	// +optional
+	Report bool `json:"report,omitempty"`
+
+	// RecordChanges stores a summary of the last data change applied to each
+	// target (key counts only, never values) in status.syncedTargets[].lastChange.
+	//
+	// +optional
+	RecordChanges bool `json:"recordChanges,omitempty"`
+}
+
+// StatusMode defines how much per-target detail is reported in status.
+// +kubebuilder:validation:Enum=full;compact
+type StatusMode string
+
+const (
+	// StatusModeFull lists every target in status.syncedTargets (default behavior)
+	StatusModeFull StatusMode = "full"
+
+	// StatusModeCompact lists only failing targets, capped, alongside aggregate counters
+	StatusModeCompact StatusMode = "compact"
+)
+
 // =============================================================================
 // SharedResourceStatus defines the observed state of SharedResource.
 //
@@ -198,6 +1119,12 @@ type KeySelector struct {
 // Users can check this to see if sync is healthy or has errors.
 // =============================================================================
 type SharedResourceStatus struct {
+	// ObservedGeneration is the metadata.generation last acted on by the controller.
+	// When it matches metadata.generation, the status reflects the current spec.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Conditions represent the overall state of the SharedResource.
 	// Standard condition types:
 	//   - "Ready": True when all targets are successfully synced
@@ -211,10 +1138,23 @@ type SharedResourceStatus struct {
 
 	// SyncedTargets shows the sync status for each target namespace.
 	// This allows users to see which targets succeeded and which failed.
+	// In compact status mode only failing targets are listed.
 	//
 	// +optional
 	SyncedTargets []TargetSyncStatus `json:"syncedTargets,omitempty"`
 
+	// TargetSummary aggregates the per-target results of the last sync.
+	// Always populated, regardless of status mode.
+	//
+	// +optional
+	TargetSummary *TargetSummary `json:"targetSummary,omitempty"`
+
+	// Variants lists the distinct data sets written to targets, when
+	// per-namespace key rules or templates give targets different data.
+	// Omitted while every target receives the same data.
+	// +optional
+	Variants []DataVariant `json:"variants,omitempty"`
+
 	// LastSyncTime is the timestamp of the last successful full sync.
 	//
 	// +optional
@@ -226,6 +1166,131 @@ type SharedResourceStatus struct {
 	//
 	// +optional
 	SourceChecksum string `json:"sourceChecksum,omitempty"`
+
+	// AllTargetsAtChecksum is true when the last sync, made for the current
+	// generation, wrote or verified every target at SourceChecksum. External
+	// rotation tools can wait for it before revoking an old credential.
+	// It is false while any target is failing or the source is missing.
+	//
+	// +optional
+	AllTargetsAtChecksum bool `json:"allTargetsAtChecksum,omitempty"`
+
+	// Consistency counts the targets at SourceChecksum and those still
+	// holding data from older source checksums, to follow a rollout's
+	// convergence. Always populated, regardless of status mode.
+	//
+	// +optional
+	Consistency *ConsistencyStatus `json:"consistency,omitempty"`
+
+	// OperatorVersion is the version and build of the operator that made the
+	// last sync, as also stamped on the targets it wrote. During a staged
+	// upgrade it tells which controller is handling this SharedResource.
+	//
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// RetryCount is the number of consecutive reconciles that failed to sync
+	// every target (or could not find the source). Reset to zero on full success.
+	//
+	// +optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// NextRetryTime is when the controller will next retry after a failure.
+	// Set the sharedresource.platform.dev/sync-now annotation to retry sooner.
+	//
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// LastHandledSyncRequest is the value of the sync-now annotation that was
+	// last acted on, confirming the requested sync has happened.
+	//
+	// +optional
+	LastHandledSyncRequest string `json:"lastHandledSyncRequest,omitempty"`
+
+	// Explanation answers the latest sharedresource.platform.dev/explain
+	// request: why each target is in its state, and what happens next.
+	//
+	// +optional
+	Explanation *Explanation `json:"explanation,omitempty"`
+
+	// Cleanup reports target cleanup progress while the CR is being deleted
+	// with deletionPolicy "delete". RetryCount and NextRetryTime track the
+	// cleanup retries in the meantime.
+	//
+	// +optional
+	Cleanup *CleanupStatus `json:"cleanup,omitempty"`
+
+	// ExpiresAt is when the share expires, from spec.expiresAt and
+	// spec.duration. Unset if the share does not expire.
+	//
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// SuspendedSince is when the controller first saw spec.suspend set.
+	// Cleared on resume.
+	//
+	// +optional
+	SuspendedSince *metav1.Time `json:"suspendedSince,omitempty"`
+
+	// SkippedSyncs counts the source changes not propagated while suspended.
+	// Cleared on resume.
+	//
+	// +optional
+	SkippedSyncs int32 `json:"skippedSyncs,omitempty"`
+
+	// PendingSourceChecksum is the checksum of the source data that resuming
+	// would propagate. Empty while suspended if the targets are still at
+	// SourceChecksum, so resuming writes nothing.
+	//
+	// +optional
+	PendingSourceChecksum string `json:"pendingSourceChecksum,omitempty"`
+
+	// PreSync is the result of spec.preSync for the latest changed source
+	// data. Targets are only written once it succeeded.
+	//
+	// +optional
+	PreSync *PreSyncStatus `json:"preSync,omitempty"`
+}
+
+// =============================================================================
+// Explanation is a human-readable account of the CR's latest reconcile.
+// =============================================================================
+type Explanation struct {
+	// Request is the explain annotation value this explanation answers.
+	Request string `json:"request"`
+
+	// Time is when the explanation was written.
+	Time metav1.Time `json:"time"`
+
+	// Lines explain, in order, the source, key filtering, each target and
+	// the next sync.
+	// +optional
+	Lines []string `json:"lines,omitempty"`
+}
+
+// =============================================================================
+// CleanupStatus tracks target deletion while the finalizer holds the CR.
+// =============================================================================
+type CleanupStatus struct {
+	// TargetsTotal is the number of targets to clean up
+	TargetsTotal int32 `json:"targetsTotal"`
+
+	// TargetsRemaining is the number of targets that could not be cleaned up yet
+	TargetsRemaining int32 `json:"targetsRemaining"`
+
+	// LastAttemptTime is when cleanup was last attempted
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// LastError is the most recent cleanup error
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// CleanedUp lists the targets, as namespace/name, already deleted or
+	// released. Retries, and a controller restarted mid-cleanup, skip them
+	// and only work on the rest.
+	// +optional
+	CleanedUp []string `json:"cleanedUp,omitempty"`
 }
 
 // =============================================================================
@@ -248,6 +1313,166 @@ type TargetSyncStatus struct {
 	// Error contains the error message if sync failed for this target
 	// +optional
 	Error string `json:"error,omitempty"`
+
+	// LastErrorTime is when syncing this target last failed. Kept after the
+	// target recovers.
+	// +optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+
+	// RecentErrors are this target's latest distinct sync errors, oldest first
+	// and at most 5. Repeats of the same error are counted in one entry, so a
+	// flapping target shows the pattern rather than only the last message.
+	// Kept after the target recovers.
+	// +optional
+	// +kubebuilder:validation:MaxItems=5
+	RecentErrors []TargetError `json:"recentErrors,omitempty"`
+
+	// Released indicates the target was released from management via the
+	// sharedresource.platform.dev/release annotation and is no longer synced
+	// +optional
+	Released bool `json:"released,omitempty"`
+
+	// ExternallyManagedBy names the GitOps tool that also manages the target,
+	// if any (see spec.externallyManaged)
+	// +optional
+	ExternallyManagedBy string `json:"externallyManagedBy,omitempty"`
+
+	// MutatedByAdmission is true if admission altered the data last written
+	// to the target (see spec.syncPolicy.verifyWrites)
+	// +optional
+	MutatedByAdmission bool `json:"mutatedByAdmission,omitempty"`
+
+	// LastChange summarizes the last data change applied to this target.
+	// Only set when spec.statusPolicy.recordChanges is true.
+	// +optional
+	LastChange *TargetChange `json:"lastChange,omitempty"`
+
+	// Verification is the result of the target's verification Job, if
+	// spec.verify is set
+	// +optional
+	Verification *TargetVerification `json:"verification,omitempty"`
+
+	// AccessDenied is true if the last sync of this target was forbidden.
+	// Such targets are retried on a short backoff until access is granted.
+	// +optional
+	AccessDenied bool `json:"accessDenied,omitempty"`
+
+	// NamespaceCreated is true if the operator created the target namespace
+	// (spec.createTargetNamespaces), false if it already existed
+	// +optional
+	NamespaceCreated bool `json:"namespaceCreated,omitempty"`
+
+	// SourceChecksum is the status.sourceChecksum of the source data the
+	// target was last written or verified with. Kept while the target fails.
+	// +optional
+	SourceChecksum string `json:"sourceChecksum,omitempty"`
+}
+
+// =============================================================================
+// TargetError is one entry of a target's error history.
+// =============================================================================
+type TargetError struct {
+	// Time is when the error last occurred
+	Time metav1.Time `json:"time"`
+
+	// Message is the error message
+	Message string `json:"message"`
+
+	// Count is how many consecutive failed syncs reported this error
+	Count int32 `json:"count"`
+}
+
+// =============================================================================
+// TargetChange summarizes one applied data change by key counts.
+// =============================================================================
+type TargetChange struct {
+	// Time is when the change was applied
+	Time metav1.Time `json:"time"`
+
+	// Added is the number of keys the change added
+	// +optional
+	Added int32 `json:"added,omitempty"`
+
+	// Removed is the number of keys the change removed
+	// +optional
+	Removed int32 `json:"removed,omitempty"`
+
+	// Changed is the number of keys whose value changed
+	// +optional
+	Changed int32 `json:"changed,omitempty"`
+}
+
+// =============================================================================
+// DataVariant is one distinct data set written to one or more targets.
+// =============================================================================
+type DataVariant struct {
+	// Checksum is the SHA256 of the variant's data
+	Checksum string `json:"checksum"`
+
+	// Keys are the variant's keys, sorted
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+
+	// Targets is the number of targets that received this variant
+	Targets int32 `json:"targets"`
+
+	// Namespaces lists (sorted, capped at 10) the target namespaces that
+	// received this variant
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// =============================================================================
+// ConsistencyStatus reports how far the current source data has propagated.
+// =============================================================================
+type ConsistencyStatus struct {
+	// Current is the number of targets holding data from status.sourceChecksum
+	Current int32 `json:"current"`
+
+	// Outdated is the number of targets still holding data from an older
+	// source checksum
+	// +optional
+	Outdated int32 `json:"outdated,omitempty"`
+
+	// Unknown is the number of targets never synced, or whose source
+	// checksum is not known
+	// +optional
+	Unknown int32 `json:"unknown,omitempty"`
+
+	// OutdatedChecksums lists (most targets first, capped at 10) the older
+	// source checksums targets still hold
+	// +optional
+	OutdatedChecksums []ChecksumTargets `json:"outdatedChecksums,omitempty"`
+}
+
+// =============================================================================
+// ChecksumTargets counts the targets holding one source checksum.
+// =============================================================================
+type ChecksumTargets struct {
+	// Checksum is the source checksum
+	Checksum string `json:"checksum"`
+
+	// Targets is the number of targets holding data from it
+	Targets int32 `json:"targets"`
+}
+
+// =============================================================================
+// TargetSummary aggregates per-target sync results.
+// =============================================================================
+type TargetSummary struct {
+	// Total is the number of targets resolved in the last sync
+	Total int32 `json:"total"`
+
+	// Synced is the number of targets that synced successfully
+	Synced int32 `json:"synced"`
+
+	// Failed is the number of targets that failed to sync
+	Failed int32 `json:"failed"`
+
+	// Omitted is the number of targets not listed in status.syncedTargets
+	// because compact status mode is in effect
+	// +optional
+	Omitted int32 `json:"omitted,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// SharedResourceExportSpec offers a source to other namespaces.
+//
+// An Export lives in the SOURCE namespace and is owned by the team owning the
+// Secret/ConfigMap. It names the namespaces that may import the source; a
+// SharedResourceImport in one of them, owned by the consuming team, receives
+// a copy. Both sides must agree: nothing crosses the namespace boundary
+// without an Export admitting the importer AND an Import asking for it.
+//
+// An Export also consents to SharedResources in the admitted namespaces
+// reading the source with spec.source.namespace. SharedResourcePolicies in
+// the namespace still apply to every copy.
+//
+// =============================================================================
+// +kubebuilder:validation:XValidation:rule="has(self.namespaces) || has(self.namespaceSelector)",message="namespaces or namespaceSelector must be set"
+type SharedResourceExportSpec struct {
+	// Source is the Secret or ConfigMap offered, in the Export's namespace.
+	//
+	// +required
+	Source ExportSource `json:"source"`
+
+	// Namespaces lists the namespaces that may import the source.
+	//
+	// +kubebuilder:validation:MaxItems=1024
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector admits every namespace whose labels match it. A
+	// namespace may import if it is listed in Namespaces OR matches this.
+	//
+	// Example:
+	//   namespaceSelector:
+	//     matchLabels:
+	//       team: payments
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ExportSource identifies the Secret or ConfigMap an Export offers.
+type ExportSource struct {
+	// Kind is the kind of the source, "Secret" or "ConfigMap".
+	//
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +required
+	Kind string `json:"kind"`
+
+	// Name is the name of the source in the Export's namespace.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourceExport is the Schema for the sharedresourceexports API
+type SharedResourceExport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the source offered and who may import it
+	// +required
+	Spec SharedResourceExportSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourceExportList contains a list of SharedResourceExport
+type SharedResourceExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []SharedResourceExport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharedResourceExport{}, &SharedResourceExportList{})
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// SharedResourceImportSpec asks for a copy of another namespace's export.
+//
+// An Import lives in the CONSUMING namespace and is owned by the team using
+// the copy. Once the SharedResourceExport it names admits the namespace, the
+// operator creates a SharedResource of the same name, owned by the Import,
+// that syncs the exported source into this namespace. Deleting the Import,
+// or the Export no longer admitting the namespace, deletes the copy.
+//
+// =============================================================================
+type SharedResourceImportSpec struct {
+	// Export names the SharedResourceExport to import from.
+	//
+	// +required
+	Export ExportReference `json:"export"`
+
+	// TargetName is the name of the copy in this namespace. Defaults to the
+	// name of the exported source.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	TargetName string `json:"targetName,omitempty"`
+}
+
+// ExportReference identifies a SharedResourceExport.
+type ExportReference struct {
+	// Namespace is the namespace of the Export, and of its source.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the Export.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+}
+
+// SharedResourceImportStatus defines the observed state of SharedResourceImport.
+type SharedResourceImportStatus struct {
+	// ObservedGeneration is the metadata.generation last acted on by the controller.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the state of the Import. "Ready" is True once the
+	// copy is synced; its reason tells why it is not (e.g. ExportNotFound,
+	// NotAdmitted).
+	//
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// SharedResource is the name of the SharedResource syncing the copy,
+	// while there is one.
+	//
+	// +optional
+	SharedResource string `json:"sharedResource,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SharedResourceImport is the Schema for the sharedresourceimports API
+type SharedResourceImport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the Export to import
+	// +required
+	Spec SharedResourceImportSpec `json:"spec"`
+
+	// status defines the observed state of SharedResourceImport
+	// +optional
+	Status SharedResourceImportStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourceImportList contains a list of SharedResourceImport
+type SharedResourceImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []SharedResourceImport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharedResourceImport{}, &SharedResourceImportList{})
+}
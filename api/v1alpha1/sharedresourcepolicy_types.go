@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// SharedResourcePolicySpec lets the owner of a source namespace veto what
+// SharedResources in that namespace may share.
+//
+// A policy lives in the SOURCE namespace, next to the Secrets/ConfigMaps it
+// protects. Every SharedResource in the namespace whose source matches
+// Sources is checked against it:
+//   - Targets outside AllowedTargetNamespaces / TargetNamespaceSelector are not synced
+//   - Keys outside AllowedKeys are stripped before syncing
+//
+// Violations are reported on the SharedResource via the PolicyDenied condition.
+// When several policies apply, a target or key must be allowed by all of them.
+//
+// =============================================================================
+type SharedResourcePolicySpec struct {
+	// Sources limits the policy to specific source resources.
+	// If empty, the policy applies to every source in the namespace.
+	// A SharedResource with source.nameTemplate is matched by the name it renders.
+	//
+	// +optional
+	Sources []PolicySource `json:"sources,omitempty"`
+
+	// AllowedTargetNamespaces lists namespaces that may receive copies.
+	// Entries may use shell-style globs (e.g. "team-a-*").
+	//
+	// If neither this nor TargetNamespaceSelector is set, any namespace is allowed.
+	//
+	// +optional
+	AllowedTargetNamespaces []string `json:"allowedTargetNamespaces,omitempty"`
+
+	// TargetNamespaceSelector allows target namespaces by label, e.g. for
+	// namespaces belonging to a team. A namespace is allowed if it matches
+	// AllowedTargetNamespaces OR this selector.
+	//
+	// Example:
+	//   targetNamespaceSelector:
+	//     matchLabels:
+	//       team: payments
+	//
+	// +optional
+	TargetNamespaceSelector *metav1.LabelSelector `json:"targetNamespaceSelector,omitempty"`
+
+	// AllowedKeys lists the data keys that may leave the namespace.
+	// If unset, all keys are allowed.
+	//
+	// +optional
+	AllowedKeys []string `json:"allowedKeys,omitempty"`
+}
+
+// PolicySource identifies a source Secret or ConfigMap a policy applies to.
+type PolicySource struct {
+	// Kind is the kind of the source, "Secret" or "ConfigMap".
+	//
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +required
+	Kind string `json:"kind"`
+
+	// Name is the name of the source in the policy's namespace.
+	//
+	// +required
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourcePolicy is the Schema for the sharedresourcepolicies API
+type SharedResourcePolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines what SharedResources in this namespace may share
+	// +required
+	Spec SharedResourcePolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourcePolicyList contains a list of SharedResourcePolicy
+type SharedResourcePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []SharedResourcePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharedResourcePolicy{}, &SharedResourcePolicyList{})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// SharedResourceSetSpec shares several sources with the same targets.
+//
+// Teams often distribute a handful of Secrets and ConfigMaps to the same
+// namespaces. A Set lists them once, with one target specification, and the
+// operator keeps a SharedResource per source, named "<set>-<source>" and
+// owned by the Set. The Set's status aggregates theirs; removing a source
+// from the list deletes its SharedResource, whose deletionPolicy decides
+// what happens to the copies.
+//
+// =============================================================================
+// +kubebuilder:validation:XValidation:rule="has(self.targets) || has(self.targetSelector)",message="targets or targetSelector is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.targets) || self.targets.all(t, !has(t.name))",message="targets of a set cannot rename; each source keeps its name"
+type SharedResourceSetSpec struct {
+	// Sources lists the Secrets and ConfigMaps in this namespace to share.
+	// Names must be unique across kinds.
+	//
+	// Example:
+	//   sources:
+	//     - kind: Secret
+	//       name: db-credentials
+	//     - kind: ConfigMap
+	//       name: app-config
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +required
+	Sources []SetSource `json:"sources"`
+
+	// Targets lists the namespaces every source is synced to, as in a
+	// SharedResource; they cannot rename.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	Targets []TargetSpec `json:"targets,omitempty"`
+
+	// TargetSelector also syncs every source to each namespace whose labels
+	// match, as in a SharedResource.
+	//
+	// +optional
+	TargetSelector *metav1.LabelSelector `json:"targetSelector,omitempty"`
+
+	// SyncPolicy configures how data is copied, for every source.
+	//
+	// +optional
+	SyncPolicy *SyncPolicySpec `json:"syncPolicy,omitempty"`
+
+	// DeletionPolicy decides what happens to the copies of a source when its
+	// SharedResource is deleted: with the Set, or when the source is removed
+	// from the list.
+	//
+	// +kubebuilder:validation:Enum=orphan;delete;deleteForeground;deleteBackground
+	// +kubebuilder:default=orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SetSource is one source of a SharedResourceSet.
+type SetSource struct {
+	// Kind is the kind of the source.
+	//
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +required
+	Kind string `json:"kind"`
+
+	// Name is the name of the source in the Set's namespace.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+}
+
+// SharedResourceSetStatus defines the observed state of SharedResourceSet.
+type SharedResourceSetStatus struct {
+	// ObservedGeneration is the metadata.generation last acted on by the controller.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the state of the Set. "Ready" is True once every
+	// source's SharedResource is Ready.
+	//
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ReadyMembers is how many of the sources' SharedResources are Ready.
+	//
+	// +optional
+	ReadyMembers int32 `json:"readyMembers"`
+
+	// Members reports each source's SharedResource, in the order of
+	// spec.sources.
+	//
+	// +optional
+	Members []SetMemberStatus `json:"members,omitempty"`
+}
+
+// SetMemberStatus reports the SharedResource of one source of a Set.
+type SetMemberStatus struct {
+	// Kind and Name identify the source
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// SharedResource is the name of the source's SharedResource.
+	SharedResource string `json:"sharedResource"`
+
+	// Ready mirrors the SharedResource's Ready condition: Unknown until it
+	// has synced its current spec.
+	Ready metav1.ConditionStatus `json:"ready"`
+
+	// Reason and Message explain a member that is not Ready.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SharedResourceSet is the Schema for the sharedresourcesets API
+type SharedResourceSet struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the sources and their common targets
+	// +required
+	Spec SharedResourceSetSpec `json:"spec"`
+
+	// status defines the observed state of SharedResourceSet
+	// +optional
+	Status SharedResourceSetStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourceSetList contains a list of SharedResourceSet
+type SharedResourceSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []SharedResourceSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharedResourceSet{}, &SharedResourceSetList{})
+}
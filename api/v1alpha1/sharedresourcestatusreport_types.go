@@ -0,0 +1,77 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =============================================================================
+// StatusReport holds the detailed per-target state of a SharedResource.
+//
+// The report is written by the controller into a companion
+// SharedResourceStatusReport object (same name and namespace as the CR) when
+// spec.statusPolicy.report is enabled. Keeping the full target list here means
+// the primary CR stays small and spec updates never conflict with large
+// status writes.
+// =============================================================================
+type StatusReport struct {
+	// ObservedGeneration is the SharedResource generation this report describes
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SourceChecksum is the checksum of the source data at the time of the report
+	// +optional
+	SourceChecksum string `json:"sourceChecksum,omitempty"`
+
+	// Summary aggregates the per-target results
+	// +optional
+	Summary TargetSummary `json:"summary,omitempty"`
+
+	// Targets lists the sync status of every resolved target
+	// +optional
+	Targets []TargetSyncStatus `json:"targets,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourceStatusReport is the Schema for the sharedresourcestatusreports API.
+// It is owned and written by the controller; users should treat it as read-only.
+type SharedResourceStatusReport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// report holds the detailed per-target state of the owning SharedResource
+	// +optional
+	Report StatusReport `json:"report,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedResourceStatusReportList contains a list of SharedResourceStatusReport
+type SharedResourceStatusReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []SharedResourceStatusReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharedResourceStatusReport{}, &SharedResourceStatusReportList{})
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// loadgen creates SharedResource load and reports how fast it converges.
+//
+//	loadgen [--envtest] [--sources N] [--sharedresources M] [--namespaces K] ...
+//
+// By default the load goes to the cluster of the current kubeconfig, where
+// the operator must be running. With --envtest, loadgen starts a local API
+// server and an in-process reconciler instead; KUBEBUILDER_ASSETS must point
+// at the envtest binaries (see make setup-envtest).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/loadgen"
+)
+
+func main() {
+	config := loadgen.Config{RunID: strconv.FormatInt(time.Now().Unix(), 36)}
+	var useEnvtest, cleanup, quiet bool
+	var crds, output string
+	flag.StringVar(&config.RunID, "run-id", config.RunID, "Names the run's namespaces and labels its objects.")
+	flag.IntVar(&config.Sources, "sources", 10, "Number of source Secrets.")
+	flag.IntVar(&config.SharedResources, "sharedresources", 100, "Number of SharedResources, spread over the sources.")
+	flag.IntVar(&config.Namespaces, "namespaces", 20, "Number of target namespaces.")
+	flag.IntVar(&config.TargetsPerSharedResource, "targets-per-sharedresource", 5,
+		"Number of target namespaces each SharedResource targets, at most --namespaces.")
+	flag.IntVar(&config.Keys, "keys", 4, "Number of keys in each source.")
+	flag.IntVar(&config.ValueSize, "value-size", 256, "Size in bytes of each source value.")
+	flag.StringVar(&config.OperatorClass, "operator-class", "",
+		"spec.operatorClass of the SharedResources, to load one operator instance.")
+	flag.IntVar(&config.Workers, "workers", 16, "Number of concurrent creates and updates.")
+	flag.DurationVar(&config.PollInterval, "poll-interval", 200*time.Millisecond,
+		"How often convergence is checked. Bounds the latency resolution.")
+	flag.DurationVar(&config.Timeout, "timeout", 5*time.Minute, "Time allowed for each phase to converge.")
+	flag.BoolVar(&useEnvtest, "envtest", false, "Start a local API server and an in-process reconciler.")
+	flag.StringVar(&crds, "crds", filepath.Join("config", "crd", "bases"), "CRD directory installed with --envtest.")
+	flag.StringVar(&output, "output", "text", "Report format: text or json.")
+	flag.BoolVar(&cleanup, "cleanup", true, "Delete the run's objects afterwards.")
+	flag.BoolVar(&quiet, "quiet", false, "Do not print progress to stderr.")
+	flag.Parse()
+	if output != "text" && output != "json" {
+		fail(fmt.Errorf("unknown --output %q", output))
+	}
+	if err := config.Validate(); err != nil {
+		fail(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, config, useEnvtest, crds, output, cleanup, quiet); err != nil {
+		stop()
+		fail(err)
+	}
+}
+
+// run runs the load test and writes its report to stdout.
+func run(ctx context.Context, config loadgen.Config, useEnvtest bool, crds, output string, cleanup, quiet bool) error {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(platformv1alpha1.AddToScheme(scheme))
+
+	var cfg *rest.Config
+	if useEnvtest {
+		env := &envtest.Environment{CRDDirectoryPaths: []string{crds}, ErrorIfCRDPathMissing: true}
+		var err error
+		if cfg, err = env.Start(); err != nil {
+			return fmt.Errorf("failed to start envtest: %w", err)
+		}
+		defer func() { _ = env.Stop() }()
+		// Stop the manager before the API server it talks to
+		managerCtx, stopManager := context.WithCancel(ctx)
+		defer stopManager()
+		if err := startReconciler(managerCtx, cfg, scheme, config.OperatorClass); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if cfg, err = ctrl.GetConfig(); err != nil {
+			return err
+		}
+	}
+	// The load itself must not be what is throttled
+	cfg = rest.CopyConfig(cfg)
+	cfg.QPS, cfg.Burst = 500, 1000
+
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+	runner := &loadgen.Runner{Client: c, Config: config}
+	if !quiet {
+		runner.Progress = os.Stderr
+	}
+	report, err := runner.Run(ctx)
+	if cleanup {
+		// Clean up even if the run was interrupted
+		if cleanupErr := runner.Cleanup(context.WithoutCancel(ctx)); cleanupErr != nil {
+			fmt.Fprintln(os.Stderr, "loadgen: cleanup failed:", cleanupErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if output == "json" {
+		return report.WriteJSON(os.Stdout)
+	}
+	return report.WriteText(os.Stdout)
+}
+
+// startReconciler runs a manager with the SharedResource reconciler against
+// the envtest API server until ctx is done.
+func startReconciler(ctx context.Context, cfg *rest.Config, scheme *runtime.Scheme, operatorClass string) error {
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create manager: %w", err)
+	}
+	if err := (&controller.SharedResourceReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		SourceOptions:   controller.SourceOptions{APIReader: mgr.GetAPIReader()},
+		Recorder:        mgr.GetEventRecorderFor("sharedresource-controller"),
+		InstanceOptions: controller.InstanceOptions{OperatorClass: operatorClass},
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to set up reconciler: %w", err)
+	}
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			fmt.Fprintln(os.Stderr, "loadgen: manager stopped:", err)
+		}
+	}()
+	if !mgr.GetCache().WaitForCacheSync(ctx) {
+		return fmt.Errorf("failed to sync the manager's cache")
+	}
+	return nil
+}
+
+// fail reports an error and exits.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "loadgen:", err)
+	os.Exit(1)
+}
@@ -17,18 +17,30 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/url"
 	"os"
+	"runtime/debug"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"go.uber.org/zap/zapcore"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -36,17 +48,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/alerts"
 	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+	webhookv1 "github.com/vijay-papanaboina/sharedresource-operator/internal/webhook/v1"
+	webhookv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+
+	// version is set at build time via -ldflags "-X main.version=..."
+	version = "dev"
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(platformv1alpha1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
@@ -56,11 +75,37 @@ func init() {
 func main() {
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
-	var webhookCertPath, webhookCertName, webhookCertKey string
+	var webhooks webhookOptions
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var compactStatusThreshold int
+	var compactTrackingThreshold int
+	var sourceRetryInterval time.Duration
+	var sourcePollInterval time.Duration
+	var namespaceTiersPath string
+	var targetIdentitiesPath string
+	var sweepObservationPeriod time.Duration
+	var migrateStorage bool
+	var startupScan bool
+	var enableDiffAPI bool
+	var enableVerifyJobs bool
+	var watchRoleBindings bool
+	var maxShareHops int
+	var sweepInterval time.Duration
+	var userAgent string
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var fieldManager string
+	var operatorClass, finalizerName, sharedResourceSelector string
+	var devMode bool
+	var devNamespace string
+	var disableSecrets, disableConfigMaps bool
+	var printAlertRules bool
+	var alertRulesNamespace string
+	targetAnnotations := map[string]string{}
+	var preSyncAllowedURLs []string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -70,22 +115,176 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
-	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
-	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
-	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	webhooks.bindFlags(flag.CommandLine)
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&compactStatusThreshold, "compact-status-threshold", 250,
+		"SharedResources with more targets than this report status in compact mode. Set to 0 to disable.")
+	flag.IntVar(&compactTrackingThreshold, "compact-tracking-threshold", controller.DefaultCompactTrackingThreshold,
+		"Targets with at least this many bytes of data keep their checksum and provenance in labels instead of "+
+			"annotations. Set to 0 to disable.")
+	flag.DurationVar(&sourceRetryInterval, "source-retry-interval", 30*time.Second,
+		"How often to check for a missing source resource. SharedResources can override this via spec.sourceRetryInterval.")
+	flag.DurationVar(&sourcePollInterval, "source-poll-interval", 0,
+		"If set, re-read every source from the API server at this interval, for clusters with unreliable watches. "+
+			"SharedResources can override this via spec.sourcePollInterval.")
+	flag.StringVar(&namespaceTiersPath, "namespace-tiers", "",
+		"Path to a YAML file defining namespace tiers by label, with default resync intervals and keys "+
+			"for targets in them (see README).")
+	flag.StringVar(&targetIdentitiesPath, "target-identities", "",
+		"Path to a YAML file mapping target namespaces by label to identities (kubeconfig or token files) "+
+			"that target writes in them are made with, for per-tenant audit attribution (see README).")
+	flag.BoolVar(&migrateStorage, "migrate-storage", true,
+		"If set, rewrite stored SharedResources in the current storage version and backfill new status fields on startup.")
+	flag.BoolVar(&startupScan, "startup-scan", true,
+		"If set, re-sync the owners of all managed targets and report orphaned targets whenever this replica becomes leader.")
+	flag.BoolVar(&enableDiffAPI, "enable-diff-api", false,
+		"If set, serve what syncing a SharedResource would change at /diff/<namespace>/<name> on the metrics "+
+			"server, for the kubectl plugin's diff, to callers allowed to get the SharedResource. Key names and value "+
+			"lengths only, never values. Requires --metrics-secure.")
+	flag.BoolVar(&enableVerifyJobs, "enable-verify-jobs", false,
+		"If set, run the spec.verify Jobs of SharedResources in their target namespaces. The pods are written by "+
+			"SharedResource authors and restricted to the Pod Security restricted profile and the synced target.")
+	flag.BoolVar(&watchRoleBindings, "watch-rolebindings", false,
+		"If set, watch RoleBindings and ClusterRoleBindings and retry targets whose writes were forbidden as "+
+			"soon as a RoleBinding in their namespace, or any ClusterRoleBinding, changes, instead of on their retry backoff.")
+	flag.IntVar(&maxShareHops, "max-share-hops", 0,
+		"How many shares away from the first source of a chain (a target shared on by another SharedResource) "+
+			"a target may be. SharedResources exceeding it are not synced. Set to 0 for no limit.")
+	flag.DurationVar(&sweepInterval, "sweep-interval", time.Minute,
+		"How often to delete targets left behind by SharedResources with deletionPolicy deleteBackground.")
+	flag.DurationVar(&sweepObservationPeriod, "sweep-observation-period", 24*time.Hour,
+		"How long the sweeper only reports a target it would delete before deleting it. Set to 0 to delete at once.")
+	flag.StringVar(&userAgent, "user-agent", "",
+		"User agent for all API requests, for attribution in audit logs and API server metrics. "+
+			"Defaults to sharedresource-operator/<version>.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 20, "Client-side limit on API requests per second.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 30, "Client-side burst above --kube-api-qps.")
+	flag.StringVar(&fieldManager, "field-manager", controller.FieldManager,
+		"Field manager recorded for every write, e.g. for policy engines to exempt operator writes.")
+	flag.StringVar(&operatorClass, "operator-class", "",
+		"Manage only SharedResources whose spec.operatorClass equals this value, so several instances can share "+
+			"a cluster. Empty manages SharedResources without an operatorClass.")
+	flag.StringVar(&finalizerName, "finalizer-name", "",
+		"Finalizer placed on managed SharedResources. Defaults to "+controller.FinalizerName+
+			", suffixed with -<operator-class> if one is set.")
+	flag.StringVar(&sharedResourceSelector, "sharedresource-selector", "",
+		"Label selector (e.g. team=payments) limiting the SharedResources this instance manages.")
+	flag.BoolVar(&devMode, "dev-mode", false,
+		"For local development and evaluation: manage only --dev-namespace, place no finalizers "+
+			"(deleting a SharedResource leaves its targets) and explain decisions in the debug log.")
+	flag.StringVar(&devNamespace, "dev-namespace", "default", "The only namespace managed with --dev-mode.")
+	flag.BoolVar(&disableSecrets, "disable-secrets", false,
+		"ConfigMap-only mode: never read or write Secrets, and reject SharedResources that need them.")
+	flag.BoolVar(&disableConfigMaps, "disable-configmaps", false,
+		"Secret-only mode: never read or write ConfigMaps, and reject SharedResources that need them.")
+	flag.Func("target-annotation",
+		"Annotation (key=value) added to every object written in target namespaces, "+
+			"e.g. a policy-exemption annotation. May be repeated.",
+		func(v string) error {
+			key, value, ok := strings.Cut(v, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("expected key=value, got %q", v)
+			}
+			targetAnnotations[key] = value
+			return nil
+		})
+	flag.Func("presync-allowed-url",
+		"scheme://host[:port] that spec.preSync HTTP hooks may call, e.g. https://change.example.com. "+
+			"May be repeated; without it no HTTP hook is called.",
+		func(v string) error {
+			u, err := url.Parse(v)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+				return fmt.Errorf("expected scheme://host[:port], got %q", v)
+			}
+			preSyncAllowedURLs = append(preSyncAllowedURLs, v)
+			return nil
+		})
+	flag.BoolVar(&printAlertRules, "print-alert-rules", false,
+		"Print a PrometheusRule with the standard alerts for this version's metrics and exit.")
+	flag.StringVar(&alertRulesNamespace, "alert-rules-namespace", "",
+		"Namespace of the PrometheusRule printed by --print-alert-rules.")
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	if printAlertRules {
+		rule, err := alerts.PrometheusRule(alerts.Options{Namespace: alertRulesNamespace})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Print(string(rule))
+		os.Exit(0)
+	}
+
+	// Dev mode explains its decisions at debug level, unless --zap-log-level says otherwise
+	if devMode && opts.Level == nil {
+		opts.Level = zapcore.DebugLevel
+	}
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if devMode {
+		setupLog.Info("Dev mode: managing a single namespace without finalizers", "namespace", devNamespace)
+	}
+
+	if err := webhooks.validate(); err != nil {
+		setupLog.Error(err, "invalid webhook flags")
+		os.Exit(1)
+	}
+
+	if err := controller.ValidateOperatorClass(operatorClass); err != nil {
+		setupLog.Error(err, "invalid --operator-class", "value", operatorClass)
+		os.Exit(1)
+	}
+	if errs := validation.IsQualifiedName(finalizerName); finalizerName != "" && len(errs) > 0 {
+		setupLog.Error(nil, "invalid --finalizer-name: "+errs[0], "value", finalizerName)
+		os.Exit(1)
+	}
+	var disabledKinds []string
+	if disableSecrets {
+		disabledKinds = append(disabledKinds, controller.KindSecret)
+	}
+	if disableConfigMaps {
+		disabledKinds = append(disabledKinds, controller.KindConfigMap)
+	}
+	if enableDiffAPI && !secureMetrics {
+		setupLog.Error(nil, "--enable-diff-api requires --metrics-secure, which identifies the callers it authorizes")
+		os.Exit(1)
+	}
+	if len(disabledKinds) == 2 {
+		setupLog.Error(nil, "--disable-secrets and --disable-configmaps leave nothing to sync; set at most one")
+		os.Exit(1)
+	}
+	if len(disabledKinds) > 0 {
+		setupLog.Info("Syncing disabled for a kind; SharedResources needing it are rejected", "kinds", disabledKinds)
+	}
+	var crSelector labels.Selector
+	if sharedResourceSelector != "" {
+		var err error
+		if crSelector, err = labels.Parse(sharedResourceSelector); err != nil {
+			setupLog.Error(err, "invalid --sharedresource-selector", "value", sharedResourceSelector)
+			os.Exit(1)
+		}
+	}
+
+	var namespaceTiers []controller.NamespaceTier
+	if namespaceTiersPath != "" {
+		data, err := os.ReadFile(namespaceTiersPath)
+		if err == nil {
+			namespaceTiers, err = controller.ParseNamespaceTiers(data)
+		}
+		if err != nil {
+			setupLog.Error(err, "invalid --namespace-tiers", "path", namespaceTiersPath)
+			os.Exit(1)
+		}
+		setupLog.Info("loaded namespace tiers", "path", namespaceTiersPath, "tiers", len(namespaceTiers))
+	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -108,13 +307,25 @@ func main() {
 		TLSOpts: webhookTLSOpts,
 	}
 
-	if len(webhookCertPath) > 0 {
+	// Without --webhook-cert-path (e.g. no cert-manager), the operator issues and
+	// rotates its own webhook certificate and serves it from memory, as long as
+	// it serves a webhook at all
+	certRotator := webhooks.certRotator(operatorNamespace(webhooks.namespace))
+	if len(webhooks.certPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
-			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
+			"webhook-cert-path", webhooks.certPath, "webhook-cert-name", webhooks.certName,
+			"webhook-cert-key", webhooks.certKey)
 
-		webhookServerOptions.CertDir = webhookCertPath
-		webhookServerOptions.CertName = webhookCertName
-		webhookServerOptions.KeyName = webhookCertKey
+		webhookServerOptions.CertDir = webhooks.certPath
+		webhookServerOptions.CertName = webhooks.certName
+		webhookServerOptions.KeyName = webhooks.certKey
+	} else if certRotator != nil {
+		setupLog.Info("Using built-in webhook certificate rotation",
+			"namespace", certRotator.Namespace, "secret", certRotator.SecretName)
+
+		webhookServerOptions.TLSOpts = append(webhookServerOptions.TLSOpts, func(c *tls.Config) {
+			c.GetCertificate = certRotator.GetCertificate
+		})
 	}
 
 	webhookServer := webhook.NewServer(webhookServerOptions)
@@ -154,13 +365,50 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	// A dedicated user agent makes the operator's traffic easy to single out when
+	// tuning API Priority and Fairness (see config/apf)
+	restConfig := ctrl.GetConfigOrDie()
+	restConfig.UserAgent = userAgent
+	if restConfig.UserAgent == "" {
+		restConfig.UserAgent = "sharedresource-operator/" + version
+	}
+	restConfig.QPS = float32(kubeAPIQPS)
+	restConfig.Burst = kubeAPIBurst
+
+	var targetIdentities []controller.TargetIdentity
+	if targetIdentitiesPath != "" {
+		data, err := os.ReadFile(targetIdentitiesPath)
+		if err == nil {
+			targetIdentities, err = controller.ParseTargetIdentities(data)
+		}
+		for i := range targetIdentities {
+			if err == nil {
+				targetIdentities[i].Client, err = controller.NewIdentityClient(restConfig, targetIdentities[i],
+					client.Options{Scheme: scheme})
+			}
+		}
+		if err != nil {
+			setupLog.Error(err, "invalid --target-identities", "path", targetIdentitiesPath)
+			os.Exit(1)
+		}
+		setupLog.Info("loaded target identities", "path", targetIdentitiesPath, "identities", len(targetIdentities))
+	}
+
+	// Renamed annotations and labels read in both forms (see compat.go);
+	// dev mode caches, and so sees, its namespace only
+	cacheOptions := cache.Options{DefaultTransform: controller.TranslateLegacyMarkers}
+	if devMode {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{devNamespace: {}}
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "405f586f.platform.dev",
+		LeaderElectionID:       leaderElectionID(operatorClass),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -178,15 +426,131 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Misconfigured RBAC shows at startup, not as failing CRs (see capabilities.go)
+	checkCtx, cancelCheck := context.WithTimeout(context.Background(), 30*time.Second)
+	capabilities, err := controller.CheckCapabilities(checkCtx, mgr.GetClient(), disabledKinds)
+	cancelCheck()
+	if err != nil {
+		setupLog.Error(err, "unable to check RBAC permissions")
+		os.Exit(1)
+	}
+	for _, c := range capabilities {
+		if c.Allowed {
+			setupLog.Info("Capability available", "capability", c.Name)
+		} else {
+			setupLog.Info("Capability missing RBAC permissions", "capability", c.Name, "feature", c.Feature,
+				"missing", c.Missing)
+		}
+	}
+	// Installs may grant create/update only on targets (see permissions.go)
+	canDelete := controller.CapabilityAllowed(capabilities, controller.CapabilityTargetDeletion)
+	if !canDelete {
+		setupLog.Info("No delete permission on Secrets and ConfigMaps: deletion policies other than orphan, " +
+			"the target sweeper and expiry revocation are disabled")
+	}
+
 	if err := (&controller.SharedResourceReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		Recorder:               mgr.GetEventRecorderFor("sharedresource-controller"),
+		CompactStatusThreshold: compactStatusThreshold,
+		DiffAPI:                enableDiffAPI,
+		Capabilities:           capabilities,
+		InstanceOptions: controller.InstanceOptions{
+			OperatorClass:          operatorClass,
+			SharedResourceSelector: crSelector,
+			Finalizer:              finalizerName,
+			Namespace:              devNamespaceIf(devMode, devNamespace),
+			DevMode:                devMode,
+			FieldManager:           fieldManager,
+			OperatorVersion:        version,
+			OperatorBuild:          buildInfo(),
+		},
+		SourceOptions: controller.SourceOptions{
+			SourceRetryInterval: sourceRetryInterval,
+			SourcePollInterval:  sourcePollInterval,
+			APIReader:           mgr.GetAPIReader(),
+		},
+		TargetOptions: controller.TargetOptions{
+			NamespaceTiers:           namespaceTiers,
+			TargetIdentities:         targetIdentities,
+			TargetAnnotations:        targetAnnotations,
+			DisabledKinds:            disabledKinds,
+			CompactTrackingThreshold: compactTrackingThreshold,
+			MaxShareHops:             maxShareHops,
+			WatchRoleBindings:        watchRoleBindings,
+			DeletesDisabled:          !canDelete,
+		},
+		HookOptions: controller.HookOptions{
+			PreSyncAllowedURLs: preSyncAllowedURLs,
+			VerifyJobs:         enableVerifyJobs,
+		},
+		CleanupOptions: controller.CleanupOptions{
+			SweepInterval:          sweepInterval,
+			SweepObservationPeriod: sweepObservationPeriod,
+			SweepReportNamespace:   operatorNamespace(webhooks.namespace),
+			StartupScan:            startupScan,
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SharedResource")
 		os.Exit(1)
 	}
+	// Imports and Sets create SharedResources without an operatorClass, so only the default instance serves them
+	if operatorClass == "" {
+		if err := (&controller.SharedResourceImportReconciler{
+			Client:   client.WithFieldOwner(mgr.GetClient(), fieldManager),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("sharedresourceimport-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SharedResourceImport")
+			os.Exit(1)
+		}
+		if err := (&controller.SharedResourceSetReconciler{
+			Client:   client.WithFieldOwner(mgr.GetClient(), fieldManager),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("sharedresourceset-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SharedResourceSet")
+			os.Exit(1)
+		}
+	}
+	if webhooks.protection() != webhookv1.ProtectionOff {
+		if err := webhookv1.SetupNamespaceWebhookWithManager(mgr, webhooks.protection()); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Namespace")
+			os.Exit(1)
+		}
+	}
+	if webhooks.validateSharedResources {
+		if err := webhookv1alpha1.SetupSharedResourceWebhookWithManager(mgr, disabledKinds); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SharedResource")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if migrateStorage {
+		if err := mgr.Add(&controller.StorageMigrator{
+			Client: client.WithFieldOwner(mgr.GetClient(), fieldManager),
+			Reader: mgr.GetAPIReader(),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up storage migration")
+			os.Exit(1)
+		}
+	}
+
+	if certRotator != nil {
+		certRotator.Client = client.WithFieldOwner(mgr.GetClient(), fieldManager)
+		certRotator.Reader = mgr.GetAPIReader()
+		if err := mgr.Add(certRotator); err != nil {
+			setupLog.Error(err, "unable to set up webhook certificate rotation")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("webhook-certs", certRotator.ReadyCheck); err != nil {
+			setupLog.Error(err, "unable to set up webhook certificate ready check")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -196,9 +560,67 @@ func main() {
 		os.Exit(1)
 	}
 
-	setupLog.Info("starting manager")
+	setupLog.Info("starting manager", "version", version, "build", buildInfo(), "userAgent", restConfig.UserAgent)
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// operatorNamespace returns the namespace given by flag, falling back to the
+// POD_NAMESPACE environment variable and then the in-cluster service account
+// namespace. Returns "" when running outside a cluster.
+func operatorNamespace(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns
+	}
+	if data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	return ""
+}
+
+// leaderElectionID returns the leader election lease name. Instances with an
+// operator class elect their own leader, so they can run side by side.
+func leaderElectionID(operatorClass string) string {
+	const id = "405f586f.platform.dev"
+	if operatorClass != "" {
+		return operatorClass + "." + id
+	}
+	return id
+}
+
+// devNamespaceIf returns the namespace dev mode is restricted to, or "".
+func devNamespaceIf(devMode bool, namespace string) string {
+	if devMode {
+		return namespace
+	}
+	return ""
+}
+
+// buildInfo describes the binary: its Go version and, if the build stamped
+// it, the VCS revision.
+func buildInfo() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	settings := make(map[string]string, len(info.Settings))
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+	build := info.GoVersion
+	if revision := settings["vcs.revision"]; revision != "" {
+		if len(revision) > 12 {
+			revision = revision[:12]
+		}
+		if settings["vcs.modified"] == "true" {
+			revision += "-dirty"
+		}
+		build += ", rev " + revision
+	}
+	return build
+}
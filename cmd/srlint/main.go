@@ -0,0 +1,137 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// srlint validates SharedResource manifests offline.
+//
+//	srlint [--crds PATH]... [--namespaces FILE] FILE|DIR|-...
+//
+// Exits 1 if any manifest has findings, 2 if the input cannot be read.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/lint"
+)
+
+// pathList is a repeatable flag of paths.
+type pathList []string
+
+func (p *pathList) String() string { return strings.Join(*p, ",") }
+
+func (p *pathList) Set(v string) error {
+	*p = append(*p, v)
+	return nil
+}
+
+func main() {
+	var crds pathList
+	var namespaces, defaultNamespace string
+	flag.Var(&crds, "crds", "CRD manifest file or directory, e.g. an install.yaml. May be repeated. "+
+		"Defaults to config/crd/bases.")
+	flag.StringVar(&namespaces, "namespaces", "",
+		"File of Namespace objects or a List (kubectl get namespaces -o yaml). "+
+			"If set, target namespaces must exist there and be allowed by SharedResourcePolicies in the manifests.")
+	flag.StringVar(&defaultNamespace, "default-namespace", "default",
+		"Namespace for namespaced objects that do not set one.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] FILE|DIR|-...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if len(crds) == 0 {
+		crds = pathList{filepath.Join("config", "crd", "bases")}
+	}
+
+	linter := lint.New()
+	linter.DefaultNamespace = defaultNamespace
+	for _, path := range crds {
+		if err := readAll(path, func(_ string, data []byte) error { return linter.AddCRDs(data) }); err != nil {
+			fail(err)
+		}
+	}
+	if namespaces != "" {
+		data, err := os.ReadFile(namespaces)
+		if err != nil {
+			fail(err)
+		}
+		if err := linter.AddNamespaces(data); err != nil {
+			fail(err)
+		}
+	}
+	for _, path := range flag.Args() {
+		if err := readAll(path, linter.AddManifests); err != nil {
+			fail(err)
+		}
+	}
+
+	findings := linter.Lint(context.Background())
+	for _, f := range findings {
+		fmt.Println(f)
+	}
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// readAll calls add for path, every .yaml, .yml and .json file under it if it
+// is a directory, or stdin if it is "-".
+func readAll(path string, add func(source string, data []byte) error) error {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		return add("<stdin>", data)
+	}
+	return filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if file != path {
+			switch filepath.Ext(file) {
+			case ".yaml", ".yml", ".json":
+			default:
+				return nil
+			}
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		return add(file, data)
+	})
+}
+
+// fail reports an input error and exits.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "srlint:", err)
+	os.Exit(2)
+}
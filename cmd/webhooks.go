@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+	webhookv1 "github.com/vijay-papanaboina/sharedresource-operator/internal/webhook/v1"
+)
+
+// webhookOptions are the flags deciding which webhooks are served and where
+// their serving certificate comes from.
+type webhookOptions struct {
+	certPath, certName, certKey string
+	namespace, certSecret       string
+	serviceName                 string
+	validatingConfig            string
+	mutatingConfig              string
+	failurePolicy               string
+	certValidity                time.Duration
+	certRotateBefore            time.Duration
+	namespaceProtection         string
+	validateSharedResources     bool
+}
+
+// bindFlags registers the webhook flags on fs.
+func (o *webhookOptions) bindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.certPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
+	fs.StringVar(&o.certName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
+	fs.StringVar(&o.certKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	fs.StringVar(&o.namespace, "webhook-namespace", "",
+		"Namespace of the webhook certificate Secret and Service. Defaults to the operator's namespace.")
+	fs.StringVar(&o.certSecret, "webhook-cert-secret", "sharedresource-operator-webhook-server-cert",
+		"Secret holding the built-in webhook certificates. Unused when --webhook-cert-path is set.")
+	fs.StringVar(&o.serviceName, "webhook-service-name", "sharedresource-operator-webhook-service",
+		"Service the built-in webhook certificate is issued for.")
+	fs.StringVar(&o.validatingConfig, "validating-webhook-configuration",
+		"sharedresource-operator-validating-webhook-configuration",
+		"ValidatingWebhookConfiguration to inject the built-in CA bundle into. Empty to skip.")
+	fs.StringVar(&o.mutatingConfig, "mutating-webhook-configuration",
+		"sharedresource-operator-mutating-webhook-configuration",
+		"MutatingWebhookConfiguration to inject the built-in CA bundle into. Empty to skip.")
+	fs.StringVar(&o.failurePolicy, "webhook-failure-policy", "",
+		"If set (Fail or Ignore), enforced on every webhook in the configurations along with the CA bundle.")
+	fs.DurationVar(&o.certValidity, "webhook-cert-validity", controller.DefaultWebhookCertValidity,
+		"Lifetime of built-in webhook certificates.")
+	fs.DurationVar(&o.certRotateBefore, "webhook-cert-rotate-before", controller.DefaultWebhookCertRotateBefore,
+		"How long before expiry built-in webhook certificates are rotated.")
+	fs.StringVar(&o.namespaceProtection, "source-namespace-protection", string(webhookv1.ProtectionOff),
+		"What the namespace webhook does when a namespace holding shared sources is deleted: off, warn or deny. "+
+			"Requires the webhook manifests (config/webhook).")
+	fs.BoolVar(&o.validateSharedResources, "validate-sharedresources", false,
+		"If set, serve the SharedResource validating webhook. Requires the webhook manifests (config/webhook), "+
+			"whose SharedResource webhook fails closed.")
+}
+
+// validate returns an error for a flag value that is not understood.
+func (o *webhookOptions) validate() error {
+	switch webhookv1.ProtectionMode(o.namespaceProtection) {
+	case webhookv1.ProtectionOff, webhookv1.ProtectionWarn, webhookv1.ProtectionDeny:
+	default:
+		return fmt.Errorf("--source-namespace-protection must be off, warn or deny, got %q", o.namespaceProtection)
+	}
+	switch policy := admissionregistrationv1.FailurePolicyType(o.failurePolicy); policy {
+	case "", admissionregistrationv1.Fail, admissionregistrationv1.Ignore:
+	default:
+		return fmt.Errorf("--webhook-failure-policy must be Fail or Ignore, got %q", o.failurePolicy)
+	}
+	return nil
+}
+
+// protection returns the namespace webhook's mode.
+func (o *webhookOptions) protection() webhookv1.ProtectionMode {
+	return webhookv1.ProtectionMode(o.namespaceProtection)
+}
+
+// served returns true if any webhook is registered with the manager.
+func (o *webhookOptions) served() bool {
+	return o.protection() != webhookv1.ProtectionOff || o.validateSharedResources
+}
+
+// certRotator returns the built-in certificate rotator for the webhooks, or
+// nil if none is needed: no webhook is served, the certificate comes from
+// --webhook-cert-path, or the operator runs outside a cluster (namespace "").
+// Without a webhook there is nothing to serve, so no CA key is written.
+func (o *webhookOptions) certRotator(namespace string) *controller.WebhookCertRotator {
+	if !o.served() || o.certPath != "" || namespace == "" {
+		return nil
+	}
+	rotator := &controller.WebhookCertRotator{
+		Namespace:     namespace,
+		SecretName:    o.certSecret,
+		ServiceName:   o.serviceName,
+		FailurePolicy: admissionregistrationv1.FailurePolicyType(o.failurePolicy),
+		Validity:      o.certValidity,
+		RotateBefore:  o.certRotateBefore,
+	}
+	if o.validatingConfig != "" {
+		rotator.ValidatingWebhooks = []string{o.validatingConfig}
+	}
+	if o.mutatingConfig != "" {
+		rotator.MutatingWebhooks = []string{o.mutatingConfig}
+	}
+	return rotator
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestWebhookCertRotator(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		namespace   string
+		wantRotator bool
+	}{
+		// Default installs serve no webhook, so no CA key may be written
+		{name: "defaults", namespace: "sharedresource-operator-system"},
+		{name: "validating webhook", args: []string{"--validate-sharedresources"},
+			namespace: "sharedresource-operator-system", wantRotator: true},
+		{name: "namespace webhook", args: []string{"--source-namespace-protection=warn"},
+			namespace: "sharedresource-operator-system", wantRotator: true},
+		{name: "certificate files", args: []string{"--validate-sharedresources", "--webhook-cert-path=/certs"},
+			namespace: "sharedresource-operator-system"},
+		{name: "outside a cluster", args: []string{"--validate-sharedresources"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet(tt.name, flag.ContinueOnError)
+			var o webhookOptions
+			o.bindFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.validate(); err != nil {
+				t.Fatal(err)
+			}
+			rotator := o.certRotator(tt.namespace)
+			if got := rotator != nil; got != tt.wantRotator {
+				t.Fatalf("got rotator %v, want %v", got, tt.wantRotator)
+			}
+			if rotator != nil && (rotator.Namespace != tt.namespace || len(rotator.ValidatingWebhooks) != 1) {
+				t.Errorf("got rotator for %s with webhooks %v", rotator.Namespace, rotator.ValidatingWebhooks)
+			}
+		})
+	}
+}
+
+func TestWebhookOptionsValidate(t *testing.T) {
+	for _, args := range [][]string{
+		{"--source-namespace-protection=block"},
+		{"--webhook-failure-policy=Retry"},
+	} {
+		fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+		var o webhookOptions
+		o.bindFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		if err := o.validate(); err == nil {
+			t.Errorf("%v: got no error", args)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alerts generates a PrometheusRule with the operator's standard
+// alerts, written against the metric names of this build:
+//   - SharedResourceNotReady: a CR has not been Ready for 10 minutes
+//   - SharedResourceTargetsStale: a CR has had failed, outdated targets for 15 minutes
+//   - SharedResourceOrphanedTargets: the startup scan found orphaned targets
+//
+// The prometheus-operator types are not a dependency, so the object is built
+// from plain structs that marshal to the same YAML.
+package alerts
+
+import (
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultName is the name of the generated PrometheusRule.
+const DefaultName = "sharedresource-operator-alerts"
+
+// Options configure the generated PrometheusRule.
+type Options struct {
+	// Name of the PrometheusRule. Empty uses DefaultName.
+	Name string
+
+	// Namespace of the PrometheusRule. Empty leaves it unset, for kubectl -n.
+	Namespace string
+}
+
+type prometheusRule struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Metadata   metadata `json:"metadata"`
+	Spec       ruleSpec `json:"spec"`
+}
+
+type metadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type ruleSpec struct {
+	Groups []ruleGroup `json:"groups"`
+}
+
+type ruleGroup struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is one alerting rule of the generated PrometheusRule.
+type Rule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Rules returns the standard alerting rules.
+//
+// Per-CR series are reported by every replica, so they are aggregated per CR
+// before comparing.
+func Rules() []Rule {
+	return []Rule{
+		{
+			Alert: "SharedResourceNotReady",
+			Expr:  "max by (source_namespace, sharedresource) (sharedresource_ready) == 0",
+			For:   "10m",
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary": "SharedResource {{ $labels.source_namespace }}/{{ $labels.sharedresource }} is not Ready",
+				"description": "The Ready condition of SharedResource {{ $labels.source_namespace }}/{{ $labels.sharedresource }} " +
+					"has not been True for 10 minutes. Check its conditions and status.syncedTargets.",
+			},
+		},
+		{
+			Alert: "SharedResourceTargetsStale",
+			Expr:  "min by (source_namespace, sharedresource) (sharedresource_failed_targets) > 0",
+			For:   "15m",
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary": "SharedResource {{ $labels.source_namespace }}/{{ $labels.sharedresource }} has stale targets",
+				"description": "{{ $value }} target(s) of SharedResource {{ $labels.source_namespace }}/{{ $labels.sharedresource }} " +
+					"have failed to sync for 15 minutes and may hold outdated data. See status.syncedTargets[].recentErrors.",
+			},
+		},
+		{
+			Alert: "SharedResourceOrphanedTargets",
+			Expr:  "max by (kind, reason) (sharedresource_orphaned_targets) > 0",
+			Labels: map[string]string{
+				"severity": "info",
+			},
+			Annotations: map[string]string{
+				"summary": "{{ $value }} orphaned {{ $labels.kind }} target(s) ({{ $labels.reason }})",
+				"description": "The startup consistency scan found managed targets no SharedResource accounts for. " +
+					"They are left in place; see the operator log for their names.",
+			},
+		},
+	}
+}
+
+// PrometheusRule returns the standard alerts as a PrometheusRule manifest.
+func PrometheusRule(opts Options) ([]byte, error) {
+	name := opts.Name
+	if name == "" {
+		name = DefaultName
+	}
+	return yaml.Marshal(prometheusRule{
+		APIVersion: "monitoring.coreos.com/v1",
+		Kind:       "PrometheusRule",
+		Metadata: metadata{
+			Name:      name,
+			Namespace: opts.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/name": "sharedresource-operator"},
+		},
+		Spec: ruleSpec{Groups: []ruleGroup{{Name: "sharedresource-operator", Rules: Rules()}}},
+	})
+}
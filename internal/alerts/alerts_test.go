@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestPrometheusRule(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          Options
+		wantName      string
+		wantNamespace string
+	}{
+		{name: "defaults", wantName: DefaultName},
+		{name: "custom", opts: Options{Name: "alerts", Namespace: "monitoring"}, wantName: "alerts", wantNamespace: "monitoring"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := PrometheusRule(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var rule prometheusRule
+			if err := yaml.UnmarshalStrict(data, &rule); err != nil {
+				t.Fatalf("output does not round-trip: %v\n%s", err, data)
+			}
+			if rule.APIVersion != "monitoring.coreos.com/v1" || rule.Kind != "PrometheusRule" {
+				t.Errorf("got %s %s, want monitoring.coreos.com/v1 PrometheusRule", rule.APIVersion, rule.Kind)
+			}
+			if rule.Metadata.Name != tt.wantName || rule.Metadata.Namespace != tt.wantNamespace {
+				t.Errorf("got %s/%s, want %s/%s", rule.Metadata.Namespace, rule.Metadata.Name, tt.wantNamespace, tt.wantName)
+			}
+			if len(rule.Spec.Groups) != 1 || len(rule.Spec.Groups[0].Rules) != len(Rules()) {
+				t.Errorf("got groups %+v, want one group with every rule", rule.Spec.Groups)
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	metrics := map[string]string{
+		"SharedResourceNotReady":        "sharedresource_ready",
+		"SharedResourceTargetsStale":    "sharedresource_failed_targets",
+		"SharedResourceOrphanedTargets": "sharedresource_orphaned_targets",
+	}
+	rules := Rules()
+	if len(rules) != len(metrics) {
+		t.Fatalf("got %d rules, want %d", len(rules), len(metrics))
+	}
+	for _, rule := range rules {
+		metric, ok := metrics[rule.Alert]
+		if !ok {
+			t.Errorf("unexpected alert %s", rule.Alert)
+			continue
+		}
+		if !strings.Contains(rule.Expr, "("+metric+")") {
+			t.Errorf("%s: expr %q does not use %s", rule.Alert, rule.Expr, metric)
+		}
+		if rule.Labels["severity"] == "" || rule.Annotations["summary"] == "" || rule.Annotations["description"] == "" {
+			t.Errorf("%s: missing severity, summary or description", rule.Alert)
+		}
+	}
+}
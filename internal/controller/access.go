@@ -0,0 +1,435 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Access distribution - Role + RoleBinding per target.
+//
+// When spec.access is set, each target namespace receives:
+//   - a Role allowing "get" on exactly the synced resource (resourceNames)
+//   - a RoleBinding granting that Role to the listed ServiceAccounts
+//
+// Both objects carry the same tracking annotations as synced targets, so
+// the operator never modifies RBAC objects another CR, or no CR, created:
+// a second CR granting access to a target of the same name in the same
+// namespace fails that target with a conflict. Every sync
+// brings them in line with spec: the RoleBinding lists exactly
+// spec.access.serviceAccounts, a target without any loses both objects, and
+// the grants of targets the CR no longer has (e.g. removed from
+// spec.targets) are revoked, even where the copy itself is kept.
+// =============================================================================
+
+// accessObjectName returns the name of the Role/RoleBinding for a target.
+func accessObjectName(targetName string) string {
+	return fmt.Sprintf("sharedresource-%s-read", targetName)
+}
+
+// accessResource returns the RBAC resource name for the synced kind.
+func accessResource(kind string) string {
+	if kind == KindConfigMap {
+		return "configmaps"
+	}
+	return "secrets"
+}
+
+// syncAccess applies every access mechanism configured in spec.access for a target.
+func (r *SharedResourceReconciler) syncAccess(ctx context.Context, sr *platformv1alpha1.SharedResource, targetNamespace, targetName string) error {
+	if err := r.syncAccessGrant(ctx, sr, targetNamespace, targetName); err != nil {
+		return err
+	}
+	return r.syncServiceAccountLinks(ctx, sr, targetNamespace, targetName)
+}
+
+// syncAccessGrant creates/updates or removes the Role and RoleBinding for a target.
+func (r *SharedResourceReconciler) syncAccessGrant(ctx context.Context, sr *platformv1alpha1.SharedResource, targetNamespace, targetName string) error {
+	if sr.Spec.Access == nil || len(sr.Spec.Access.ServiceAccounts) == 0 {
+		return r.deleteAccessGrant(ctx, sr, targetNamespace, targetName)
+	}
+
+	log := logf.FromContext(ctx)
+	name := accessObjectName(targetName)
+	labels, annotations := r.targetMetadata(map[string]string{
+		AnnotationManagedBy:       ManagedByValue,
+		AnnotationSourceNamespace: sr.Namespace,
+		AnnotationSourceName:      sourceName(sr),
+		AnnotationSourceCR:        sr.Name,
+	})
+	labels[LabelGrantedBy] = string(sr.UID)
+
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: targetNamespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		if err := claimManaged(role, sr, labels, annotations); err != nil {
+			return err
+		}
+		role.Rules = []rbacv1.PolicyRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{accessResource(targetKind(sr))},
+			ResourceNames: []string{targetName},
+			Verbs:         []string{"get"},
+		}}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync access Role: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Synced access Role", "namespace", targetNamespace, "name", name, "operation", op)
+	}
+
+	binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: targetNamespace}}
+	op, err = controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+		if err := claimManaged(binding, sr, labels, annotations); err != nil {
+			return err
+		}
+		// RoleRef is immutable; a mismatch means someone else's binding
+		if binding.RoleRef.Name != "" && binding.RoleRef.Name != name {
+			return fmt.Errorf("RoleBinding %s/%s references unexpected role %q", targetNamespace, name, binding.RoleRef.Name)
+		}
+		binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name}
+		binding.Subjects = make([]rbacv1.Subject, 0, len(sr.Spec.Access.ServiceAccounts))
+		for _, sa := range sr.Spec.Access.ServiceAccounts {
+			binding.Subjects = append(binding.Subjects, rbacv1.Subject{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      sa,
+				Namespace: targetNamespace,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync access RoleBinding: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Synced access RoleBinding", "namespace", targetNamespace, "name", name, "operation", op)
+	}
+	return nil
+}
+
+// deleteAccessGrant removes the Role and RoleBinding for a target, if we own them.
+func (r *SharedResourceReconciler) deleteAccessGrant(ctx context.Context, sr *platformv1alpha1.SharedResource, targetNamespace, targetName string) error {
+	key := types.NamespacedName{Namespace: targetNamespace, Name: accessObjectName(targetName)}
+	for _, obj := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}} {
+		if err := r.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !ownedByCR(obj, sr) {
+			continue
+		}
+		logf.FromContext(ctx).Info("Removing access grant", "namespace", targetNamespace, "name", key.Name)
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeRemovedAccessGrants deletes the Roles and RoleBindings this CR
+// created for targets it no longer has. Only the CR's own grants are listed,
+// by LabelGrantedBy.
+func (r *SharedResourceReconciler) revokeRemovedAccessGrants(ctx context.Context, sr *platformv1alpha1.SharedResource, targets []platformv1alpha1.TargetSpec) error {
+	current := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		current[targetKey(target.Namespace, accessObjectName(resolvedTargetName(sr, target)))] = true
+	}
+	for _, list := range []client.ObjectList{&rbacv1.RoleBindingList{}, &rbacv1.RoleList{}} {
+		if err := r.List(ctx, list, client.MatchingLabels{LabelGrantedBy: string(sr.UID)}); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || !ownedByCR(obj, sr) || current[targetKey(obj.GetNamespace(), obj.GetName())] {
+				continue
+			}
+			logf.FromContext(ctx).Info("Revoking access grant of a removed target", "namespace", obj.GetNamespace(), "name", obj.GetName())
+			if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// =============================================================================
+// ServiceAccount links - wire the synced Secret into existing ServiceAccounts.
+//
+// Unlike Roles and RoleBindings, ServiceAccounts are owned by the user; the
+// operator only adds (and on deletion removes) a reference to the Secret it
+// manages, using a merge patch so other fields are never rewritten.
+//
+// Each link is also recorded in the ServiceAccount's linked-secrets
+// annotation as "<mode>/<secret>". Every sync removes the recorded links of
+// the target Secret that spec.access.serviceAccountLinks no longer lists,
+// e.g. a ServiceAccount dropped from it or given another mode, so the links
+// follow the spec like the RoleBinding does.
+// =============================================================================
+
+// syncServiceAccountLinks ensures each linked ServiceAccount references the
+// target Secret, and unlinks it from the ServiceAccounts no longer listed.
+func (r *SharedResourceReconciler) syncServiceAccountLinks(ctx context.Context, sr *platformv1alpha1.SharedResource, targetNamespace, targetName string) error {
+	if targetKind(sr) != KindSecret {
+		return nil
+	}
+	log := logf.FromContext(ctx)
+
+	var links []platformv1alpha1.ServiceAccountLink
+	if sr.Spec.Access != nil {
+		links = sr.Spec.Access.ServiceAccountLinks
+	}
+	for _, link := range links {
+		sa := &corev1.ServiceAccount{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: targetNamespace, Name: link.Name}, sa); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("ServiceAccount %s/%s not found", targetNamespace, link.Name)
+			}
+			return err
+		}
+		patch := client.MergeFrom(sa.DeepCopy())
+		linked := linkSecret(sa, link.Mode, targetName)
+		if !recordLink(sa, link.Mode, targetName) && !linked {
+			continue
+		}
+		if err := r.Patch(ctx, sa, patch); err != nil {
+			return fmt.Errorf("failed to link ServiceAccount %s/%s: %w", targetNamespace, link.Name, err)
+		}
+		if linked {
+			log.Info("Linked Secret into ServiceAccount", "namespace", targetNamespace, "serviceAccount", link.Name, "secret", targetName, "mode", linkMode(link.Mode))
+		}
+	}
+	return r.unlinkUnlisted(ctx, targetNamespace, targetName, links)
+}
+
+// deleteServiceAccountLinks removes references to the target Secret from linked ServiceAccounts.
+func (r *SharedResourceReconciler) deleteServiceAccountLinks(ctx context.Context, sr *platformv1alpha1.SharedResource, targetNamespace, targetName string) error {
+	if targetKind(sr) != KindSecret {
+		return nil
+	}
+	// Links made before they were recorded are only known from the spec
+	if sr.Spec.Access != nil {
+		for _, link := range sr.Spec.Access.ServiceAccountLinks {
+			sa := &corev1.ServiceAccount{}
+			if err := r.Get(ctx, types.NamespacedName{Namespace: targetNamespace, Name: link.Name}, sa); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			patch := client.MergeFrom(sa.DeepCopy())
+			if !unlinkSecret(sa, link.Mode, targetName) {
+				continue
+			}
+			logf.FromContext(ctx).Info("Unlinking Secret from ServiceAccount", "namespace", targetNamespace, "serviceAccount", link.Name, "secret", targetName)
+			if err := r.Patch(ctx, sa, patch); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return r.unlinkUnlisted(ctx, targetNamespace, targetName, nil)
+}
+
+// unlinkUnlisted removes the recorded links of the target Secret that are not in links.
+func (r *SharedResourceReconciler) unlinkUnlisted(ctx context.Context, targetNamespace, targetName string, links []platformv1alpha1.ServiceAccountLink) error {
+	listed := make(map[string]bool, len(links))
+	for _, link := range links {
+		listed[link.Name+"/"+linkRecordEntry(link.Mode, targetName)] = true
+	}
+	var accounts corev1.ServiceAccountList
+	if err := r.List(ctx, &accounts, client.InNamespace(targetNamespace)); err != nil {
+		return err
+	}
+	for i := range accounts.Items {
+		sa := &accounts.Items[i]
+		patch := client.MergeFrom(sa.DeepCopy())
+		changed := false
+		for _, entry := range annotationList(sa, AnnotationLinkedSecrets) {
+			mode, secretName, _ := strings.Cut(entry, "/")
+			if secretName != targetName || listed[sa.Name+"/"+entry] {
+				continue
+			}
+			unlinkSecret(sa, platformv1alpha1.ServiceAccountLinkMode(mode), targetName)
+			setAnnotationList(sa, AnnotationLinkedSecrets, slices.DeleteFunc(annotationList(sa, AnnotationLinkedSecrets),
+				func(e string) bool { return e == entry }))
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		logf.FromContext(ctx).Info("Unlinking Secret from ServiceAccount no longer listed", "namespace", targetNamespace, "serviceAccount", sa.Name, "secret", targetName)
+		if err := r.Patch(ctx, sa, patch); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to unlink ServiceAccount %s/%s: %w", targetNamespace, sa.Name, err)
+		}
+	}
+	return nil
+}
+
+// linkRecordEntry returns the linked-secrets annotation entry of a link.
+func linkRecordEntry(mode platformv1alpha1.ServiceAccountLinkMode, secretName string) string {
+	return string(linkMode(mode)) + "/" + secretName
+}
+
+// recordLink adds the link to the ServiceAccount's linked-secrets annotation. Returns true if it changed.
+func recordLink(sa *corev1.ServiceAccount, mode platformv1alpha1.ServiceAccountLinkMode, secretName string) bool {
+	entries := annotationList(sa, AnnotationLinkedSecrets)
+	entry := linkRecordEntry(mode, secretName)
+	if slices.Contains(entries, entry) {
+		return false
+	}
+	setAnnotationList(sa, AnnotationLinkedSecrets, append(entries, entry))
+	return true
+}
+
+// linkMode returns the effective link mode, applying the API default.
+func linkMode(mode platformv1alpha1.ServiceAccountLinkMode) platformv1alpha1.ServiceAccountLinkMode {
+	if mode == "" {
+		return platformv1alpha1.ServiceAccountLinkSecrets
+	}
+	return mode
+}
+
+// linkSecret adds the Secret reference to the ServiceAccount. Returns true if it changed.
+func linkSecret(sa *corev1.ServiceAccount, mode platformv1alpha1.ServiceAccountLinkMode, secretName string) bool {
+	switch linkMode(mode) {
+	case platformv1alpha1.ServiceAccountLinkImagePullSecrets:
+		for _, ref := range sa.ImagePullSecrets {
+			if ref.Name == secretName {
+				return false
+			}
+		}
+		sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: secretName})
+	case platformv1alpha1.ServiceAccountLinkAnnotation:
+		names := annotationSecretNames(sa)
+		if slices.Contains(names, secretName) {
+			return false
+		}
+		setAnnotationSecretNames(sa, append(names, secretName))
+	default:
+		for _, ref := range sa.Secrets {
+			if ref.Name == secretName {
+				return false
+			}
+		}
+		sa.Secrets = append(sa.Secrets, corev1.ObjectReference{Name: secretName})
+	}
+	return true
+}
+
+// unlinkSecret removes the Secret reference from the ServiceAccount. Returns true if it changed.
+func unlinkSecret(sa *corev1.ServiceAccount, mode platformv1alpha1.ServiceAccountLinkMode, secretName string) bool {
+	switch linkMode(mode) {
+	case platformv1alpha1.ServiceAccountLinkImagePullSecrets:
+		before := len(sa.ImagePullSecrets)
+		sa.ImagePullSecrets = slices.DeleteFunc(sa.ImagePullSecrets, func(ref corev1.LocalObjectReference) bool {
+			return ref.Name == secretName
+		})
+		return len(sa.ImagePullSecrets) != before
+	case platformv1alpha1.ServiceAccountLinkAnnotation:
+		names := annotationSecretNames(sa)
+		if !slices.Contains(names, secretName) {
+			return false
+		}
+		setAnnotationSecretNames(sa, slices.DeleteFunc(names, func(n string) bool { return n == secretName }))
+		return true
+	default:
+		before := len(sa.Secrets)
+		sa.Secrets = slices.DeleteFunc(sa.Secrets, func(ref corev1.ObjectReference) bool {
+			return ref.Name == secretName
+		})
+		return len(sa.Secrets) != before
+	}
+}
+
+// annotationSecretNames parses the shared-secrets annotation on a ServiceAccount.
+func annotationSecretNames(sa *corev1.ServiceAccount) []string {
+	return annotationList(sa, AnnotationSharedSecrets)
+}
+
+// setAnnotationSecretNames writes the shared-secrets annotation, removing it when empty.
+func setAnnotationSecretNames(sa *corev1.ServiceAccount, names []string) {
+	setAnnotationList(sa, AnnotationSharedSecrets, names)
+}
+
+// annotationList parses a comma-separated annotation on a ServiceAccount.
+func annotationList(sa *corev1.ServiceAccount, key string) []string {
+	value := sa.Annotations[key]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// setAnnotationList writes a comma-separated annotation, sorted, removing it when empty.
+func setAnnotationList(sa *corev1.ServiceAccount, key string, values []string) {
+	if len(values) == 0 {
+		delete(sa.Annotations, key)
+		return
+	}
+	if sa.Annotations == nil {
+		sa.Annotations = make(map[string]string)
+	}
+	slices.Sort(values)
+	sa.Annotations[key] = strings.Join(values, ",")
+}
+
+// claimManaged stamps tracking annotations on an object, refusing to take over
+// objects that already exist without our managed-by annotation, or that
+// another SharedResource created.
+func claimManaged(obj client.Object, sr *platformv1alpha1.SharedResource, labels, annotations map[string]string) error {
+	existing := obj.GetAnnotations()
+	if obj.GetResourceVersion() != "" && existing[AnnotationManagedBy] != ManagedByValue {
+		return fmt.Errorf("%s/%s exists and is not managed by %s", obj.GetNamespace(), obj.GetName(), ManagedByValue)
+	}
+	if obj.GetResourceVersion() != "" && !ownedByCR(obj, sr) {
+		return fmt.Errorf("%s/%s is managed by SharedResource %s/%s", obj.GetNamespace(), obj.GetName(),
+			existing[AnnotationSourceNamespace], existing[AnnotationSourceCR])
+	}
+	obj.SetAnnotations(mergeInto(existing, annotations))
+	obj.SetLabels(mergeInto(obj.GetLabels(), labels))
+	return nil
+}
+
+// ownedByCR returns true if the object carries tracking annotations for this CR.
+func ownedByCR(obj client.Object, sr *platformv1alpha1.SharedResource) bool {
+	a := obj.GetAnnotations()
+	return a[AnnotationManagedBy] == ManagedByValue &&
+		a[AnnotationSourceNamespace] == sr.Namespace &&
+		a[AnnotationSourceCR] == sr.Name
+}
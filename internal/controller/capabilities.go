@@ -0,0 +1,267 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Capability report - the startup RBAC self-check.
+//
+// A Role or ClusterRole trimmed by hand, or an aggregated install missing a
+// rule, otherwise shows up much later as CRs failing with "forbidden" on some
+// targets. At startup CheckCapabilities asks the API server, with one
+// SelfSubjectAccessReview per verb and resource, whether the operator may do
+// what each feature needs, and the result is published:
+//   - in the startup log, one line per capability
+//   - in the sharedresource_capability gauge (1 allowed, 0 not)
+//   - with a report namespace, in the ConfigMap CapabilityReportName
+//
+// Only targetDeletion changes behaviour (see permissions.go); the others are
+// reported so operators can fix the RBAC before CRs fail.
+// =============================================================================
+
+const (
+	// CapabilityReportName is the ConfigMap holding the capability report,
+	// suffixed with the operator class if one is set
+	CapabilityReportName = "sharedresource-operator-capabilities"
+
+	// capabilityReportKey is the data key of the report
+	capabilityReportKey = "capabilities.json"
+
+	// CapabilityTargetDeletion is the capability to delete targets
+	CapabilityTargetDeletion = "targetDeletion"
+)
+
+// Capability is the result of checking the permissions one feature needs.
+type Capability struct {
+	// Name identifies the capability, e.g. targetDeletion
+	Name string `json:"name"`
+
+	// Feature says what the capability is needed for
+	Feature string `json:"feature"`
+
+	// Allowed is true if every permission the capability needs is granted
+	Allowed bool `json:"allowed"`
+
+	// Missing lists the permissions not granted, as "verb resource"
+	Missing []string `json:"missing,omitempty"`
+}
+
+// permission is a set of verbs on one resource.
+type permission struct {
+	group    string
+	resource string
+	verbs    []string
+}
+
+// capabilityChecks are the permissions each feature needs; they mirror the
+// RBAC markers on the reconciler.
+var capabilityChecks = []struct {
+	name        string
+	feature     string
+	permissions []permission
+}{
+	{"sync", "Reading sources and writing targets", []permission{
+		{"", "secrets", []string{"get", "list", "watch", "create", "update", "patch"}},
+		{"", "configmaps", []string{"get", "list", "watch", "create", "update", "patch"}},
+	}},
+	{CapabilityTargetDeletion, "deletionPolicy other than orphan, the sweeper and expiry revocation", []permission{
+		{"", "secrets", []string{"delete"}},
+		{"", "configmaps", []string{"delete"}},
+	}},
+	{"status", "Reporting SharedResource status", []permission{
+		{platformv1alpha1.GroupVersion.Group, "sharedresources/status", []string{"update"}},
+	}},
+	{"namespaces", "Namespace selectors and tiers", []permission{
+		{"", "namespaces", []string{"get", "list", "watch"}},
+	}},
+	{"createNamespaces", "Creating target namespaces (spec.createTargetNamespaces)", []permission{
+		{"", "namespaces", []string{"create"}},
+	}},
+	{"policies", "SharedResourcePolicy enforcement", []permission{
+		{platformv1alpha1.GroupVersion.Group, "sharedresourcepolicies", []string{"get", "list", "watch"}},
+	}},
+	{"namespaceGroups", "Group targets (targets[].group)", []permission{
+		{platformv1alpha1.GroupVersion.Group, "namespacegroups", []string{"get", "list", "watch"}},
+	}},
+	{"imports", "SharedResourceExport/Import pairs", []permission{
+		{platformv1alpha1.GroupVersion.Group, "sharedresourceexports", []string{"get", "list", "watch"}},
+		{platformv1alpha1.GroupVersion.Group, "sharedresourceimports", []string{"get", "list", "watch"}},
+		{platformv1alpha1.GroupVersion.Group, "sharedresourceimports/status", []string{"update"}},
+	}},
+	{"sets", "SharedResourceSets", []permission{
+		{platformv1alpha1.GroupVersion.Group, "sharedresourcesets", []string{"get", "list", "watch"}},
+		{platformv1alpha1.GroupVersion.Group, "sharedresourcesets/status", []string{"update"}},
+	}},
+	{"statusReports", "statusPolicy.report", []permission{
+		{platformv1alpha1.GroupVersion.Group, "sharedresourcestatusreports", []string{"get", "create", "update", "delete"}},
+	}},
+	{"access", "Granting target access (spec.access)", []permission{
+		{"rbac.authorization.k8s.io", "roles", []string{"get", "create", "update", "delete"}},
+		{"rbac.authorization.k8s.io", "rolebindings", []string{"get", "create", "update", "delete"}},
+		{"", "serviceaccounts", []string{"get", "patch"}},
+	}},
+	{"bindingWatch", "Retrying forbidden targets on binding changes (--watch-rolebindings)", []permission{
+		{"rbac.authorization.k8s.io", "rolebindings", []string{"list", "watch"}},
+		{"rbac.authorization.k8s.io", "clusterrolebindings", []string{"list", "watch"}},
+	}},
+	{"hooks", "Verification and pre-sync Jobs (spec.verify, spec.preSync.job)", []permission{
+		{"batch", "jobs", []string{"get", "create", "delete"}},
+	}},
+	{"events", "Events on SharedResources", []permission{
+		{"", "events", []string{"create", "patch"}},
+	}},
+}
+
+// kindResources are the resources of the kinds that may be disabled.
+var kindResources = map[string]string{KindSecret: "secrets", KindConfigMap: "configmaps"}
+
+// CheckCapabilities checks, cluster-wide, every permission the operator's
+// features need, except those on the disabled kinds (see kinds.go).
+// SelfSubjectAccessReviews may be created by every authenticated identity.
+func CheckCapabilities(ctx context.Context, c client.Client, disabledKinds []string) ([]Capability, error) {
+	skipped := map[string]bool{}
+	for _, kind := range disabledKinds {
+		skipped[kindResources[kind]] = true
+	}
+	capabilities := make([]Capability, 0, len(capabilityChecks))
+	for _, check := range capabilityChecks {
+		capability := Capability{Name: check.name, Feature: check.feature, Allowed: true}
+		for _, p := range check.permissions {
+			if p.group == "" && skipped[p.resource] {
+				continue
+			}
+			for _, verb := range p.verbs {
+				allowed, err := reviewAccess(ctx, c, verb, p)
+				if err != nil {
+					return nil, err
+				}
+				if !allowed {
+					capability.Allowed = false
+					capability.Missing = append(capability.Missing, verb+" "+p.resource)
+				}
+			}
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities, nil
+}
+
+// reviewAccess returns true if the operator may use verb on the resource in
+// every namespace.
+func reviewAccess(ctx context.Context, c client.Client, verb string, p permission) (bool, error) {
+	resource, subresource, _ := strings.Cut(p.resource, "/")
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb: verb, Group: p.group, Resource: resource, Subresource: subresource,
+			},
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to check %s permission on %s: %w", verb, p.resource, err)
+	}
+	return review.Status.Allowed, nil
+}
+
+// CapabilityAllowed returns true if the named capability was checked and allowed.
+func CapabilityAllowed(capabilities []Capability, name string) bool {
+	for _, c := range capabilities {
+		if c.Name == name {
+			return c.Allowed
+		}
+	}
+	return false
+}
+
+// capabilityReportName returns the name of this instance's capability report.
+func (r *SharedResourceReconciler) capabilityReportName() string {
+	if r.OperatorClass != "" {
+		return CapabilityReportName + "-" + r.OperatorClass
+	}
+	return CapabilityReportName
+}
+
+// recordCapabilities sets the capability gauge.
+func (r *SharedResourceReconciler) recordCapabilities() {
+	capabilityAllowed.Reset()
+	for _, c := range r.Capabilities {
+		value := 0.0
+		if c.Allowed {
+			value = 1
+		}
+		capabilityAllowed.WithLabelValues(c.Name).Set(value)
+	}
+}
+
+// capabilityReporter writes the capability report once the cache has started.
+type capabilityReporter struct {
+	r *SharedResourceReconciler
+}
+
+// Start writes the report; a failure is logged, the operator runs on.
+func (c *capabilityReporter) Start(ctx context.Context) error {
+	if err := c.r.saveCapabilityReport(ctx); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to write capability report")
+	}
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader writes the report.
+func (c *capabilityReporter) NeedLeaderElection() bool {
+	return true
+}
+
+// saveCapabilityReport writes the capability report ConfigMap.
+func (r *SharedResourceReconciler) saveCapabilityReport(ctx context.Context) error {
+	value, err := json.MarshalIndent(r.Capabilities, "", "  ")
+	if err != nil {
+		return err
+	}
+	var cm corev1.ConfigMap
+	err = r.Get(ctx, types.NamespacedName{Namespace: r.SweepReportNamespace, Name: r.capabilityReportName()}, &cm)
+	if apierrors.IsNotFound(err) {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: r.SweepReportNamespace, Name: r.capabilityReportName()},
+			Data:       map[string]string{capabilityReportKey: string(value)},
+		}
+		return r.Create(ctx, &cm)
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data[capabilityReportKey] == string(value) {
+		return nil
+	}
+	cm.Data = map[string]string{capabilityReportKey: string(value)}
+	return r.Update(ctx, &cm)
+}
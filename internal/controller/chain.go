@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Chained shares - propagation metadata for targets that are sources again.
+//
+// A source that is itself a managed target is one hop of a chain (loops are
+// rejected, see circular.go). Its targets are stamped with:
+//   - AnnotationOrigin: the first source of the chain, as namespace/name
+//   - AnnotationHops: how many shares away from it they are
+//
+// and the provenance record carries both. Targets of a share whose source is
+// not managed carry neither, so direct shares look as they always did.
+//
+// --max-share-hops bounds the chains: a SharedResource whose targets would be
+// further away is not synced and reports HopLimitExceeded.
+//
+// The operator's own writes do not normally wake it up again (see
+// predicates.go); a write to a target that is another SharedResource's
+// source does, and the target's event enqueues the SharedResources reading it
+// as well as its owner, so a change moves down the chain at once rather than
+// with the next resync.
+// =============================================================================
+
+// chainAnnotations are the tracking annotations only chained targets carry.
+var chainAnnotations = []string{AnnotationOrigin, AnnotationHops}
+
+// sourceChain returns the origin and hop count of a source, from its own
+// tracking annotations. A source no SharedResource manages is hop 0.
+func sourceChain(annotations map[string]string) (string, int) {
+	if annotations[AnnotationManagedBy] != ManagedByValue {
+		return "", 0
+	}
+	origin := annotations[AnnotationOrigin]
+	if origin == "" {
+		origin = annotations[AnnotationSourceNamespace] + "/" + annotations[AnnotationSourceName]
+	}
+	hops, err := strconv.Atoi(annotations[AnnotationHops])
+	if err != nil || hops < 1 {
+		hops = 1
+	}
+	return origin, hops
+}
+
+// chainHops returns how many hops from the origin targets of a chained
+// source are, or 0 if the source is not chained.
+func chainHops(source sourceMeta) int {
+	if source.Hops == 0 {
+		return 0
+	}
+	return source.Hops + 1
+}
+
+// applyChain stamps the desired tracking annotations of a target of a
+// chained source, modified in place, with the origin and hop count.
+func applyChain(annotations map[string]string, source sourceMeta) {
+	if hops := chainHops(source); hops > 0 {
+		annotations[AnnotationOrigin] = source.Origin
+		annotations[AnnotationHops] = strconv.Itoa(hops)
+	}
+}
+
+// hopLimitExceeded returns true if targets of the source would be further
+// than --max-share-hops from the origin.
+func (r *SharedResourceReconciler) hopLimitExceeded(source sourceMeta) bool {
+	return r.MaxShareHops > 0 && chainHops(source) > r.MaxShareHops
+}
+
+// recordHopLimitExceeded reports a CR whose chain is too long and leaves its
+// targets alone.
+func (r *SharedResourceReconciler) recordHopLimitExceeded(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	source sourceMeta,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	// The sync after the chain is shortened must not be skipped
+	r.verified.forget(types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name})
+
+	before := sr.Status.DeepCopy()
+	message := fmt.Sprintf("Targets would be %d hops from %s; at most %d allowed (--max-share-hops)",
+		source.Hops+1, source.Origin, r.MaxShareHops)
+	if c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeHopLimitExceeded); c == nil || c.Message != message {
+		r.recordEvent(sr, corev1.EventTypeWarning, "HopLimitExceeded", "%s", message)
+	}
+	setCondition(sr, ConditionTypeHopLimitExceeded, metav1.ConditionTrue, "ChainTooLong", message)
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "HopLimitExceeded", "Not synced: "+message)
+	sr.Status.ObservedGeneration = sr.Generation
+	r.explainIfRequested(sr, message, "No target is written while the chain is too long")
+
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Share chain too long, not syncing targets", "origin", source.Origin, "hops", source.Hops+1)
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update hop limit status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// clearHopLimitExceeded drops the HopLimitExceeded condition once the chain
+// is short enough. The status is written with the sync that follows.
+func clearHopLimitExceeded(sr *platformv1alpha1.SharedResource) {
+	meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeHopLimitExceeded)
+}
+
+// isChainedSource returns true if another SharedResource reads the object
+// as its source.
+func (r *SharedResourceReconciler) isChainedSource(obj client.Object, kind string) bool {
+	ctx := context.Background()
+	reading, err := r.sharesReading(ctx, shareNode{kind: kind, namespace: obj.GetNamespace(), name: obj.GetName()})
+	if err != nil {
+		// Passing the event on costs one reconcile at most
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources reading a target")
+		return true
+	}
+	return len(reading) > 0
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Circular shares - SharedResources whose targets feed back into their source.
+//
+// A target may be the source of another SharedResource. Such chains are
+// supported: the next SharedResource sees each write to its source through
+// the source watch and forwards it, so a change travels down the chain one
+// hop per reconcile (see chain.go).
+//
+// A chain that leads back to the source it started from (including a target
+// that is its own source) would rewrite its own source forever. Before every
+// sync the operator follows the chain from the CR's targets; if it reaches
+// the CR's source, the CR is not synced and reports CircularReference,
+// naming the SharedResources in the loop. Every member of the loop detects
+// it on its next reconcile, so the loop stops wherever it is looked at.
+// Rejected CRs check again every CircularReferenceRecheckInterval, as the
+// loop is usually broken by editing another CR.
+// =============================================================================
+
+// shareNode is a Secret or ConfigMap a SharedResource reads or writes.
+type shareNode struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// circularReference returns the SharedResources (namespace/name) of a loop
+// leading from the CR's targets back to its source, starting with the CR,
+// or nil if there is none.
+func (r *SharedResourceReconciler) circularReference(ctx context.Context, sr *platformv1alpha1.SharedResource) ([]string, error) {
+	origin := shareNode{kind: sr.Spec.Source.Kind, namespace: sourceNamespace(sr), name: sourceName(sr)}
+	start := types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}
+
+	// Breadth-first over SharedResources; parent leads back to the CR
+	parent := map[types.NamespacedName]types.NamespacedName{start: start}
+	queue := []*platformv1alpha1.SharedResource{sr}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		currentKey := types.NamespacedName{Namespace: current.Namespace, Name: current.Name}
+		targets, err := r.knownTargets(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range targets {
+			node := shareNode{kind: targetKind(current), namespace: target.Namespace, name: resolvedTargetName(current, target)}
+			if node == origin {
+				return sharePath(parent, start, currentKey), nil
+			}
+			next, err := r.sharesReading(ctx, node)
+			if err != nil {
+				return nil, err
+			}
+			for i := range next {
+				key := types.NamespacedName{Namespace: next[i].Namespace, Name: next[i].Name}
+				if _, seen := parent[key]; seen {
+					continue
+				}
+				parent[key] = currentKey
+				queue = append(queue, &next[i])
+			}
+		}
+	}
+	return nil, nil
+}
+
+// sharesReading returns the SharedResources whose source is the node,
+// including those reading it from another namespace.
+func (r *SharedResourceReconciler) sharesReading(ctx context.Context, node shareNode) ([]platformv1alpha1.SharedResource, error) {
+	var list platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	var reading []platformv1alpha1.SharedResource
+	for _, sr := range list.Items {
+		if sr.Spec.Source.Kind == node.kind && sourceNamespace(&sr) == node.namespace && sourceName(&sr) == node.name {
+			reading = append(reading, sr)
+		}
+	}
+	return reading, nil
+}
+
+// sharePath returns the SharedResources from start to last, following parent.
+func sharePath(parent map[types.NamespacedName]types.NamespacedName, start, last types.NamespacedName) []string {
+	var path []string
+	for key := last; ; key = parent[key] {
+		path = append([]string{key.String()}, path...)
+		if key == start {
+			return path
+		}
+	}
+}
+
+// recordCircularReference reports a CR in a loop and leaves its targets alone.
+func (r *SharedResourceReconciler) recordCircularReference(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	loop []string,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	// The sync after the loop is broken must not be skipped
+	r.verified.forget(types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name})
+
+	before := sr.Status.DeepCopy()
+	message := "Targets lead back to the source: " + strings.Join(append(loop, loop[0]), " -> ")
+	if len(loop) == 1 {
+		message = "A target is the source of this SharedResource"
+	}
+	if c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeCircularReference); c == nil || c.Message != message {
+		r.recordEvent(sr, corev1.EventTypeWarning, "CircularReference", "%s", message)
+	}
+	setCondition(sr, ConditionTypeCircularReference, metav1.ConditionTrue, "LoopDetected", message)
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "CircularReference", "Not synced: "+message)
+	sr.Status.ObservedGeneration = sr.Generation
+	r.explainIfRequested(sr, message, "No target is written while the loop exists")
+
+	result := ctrl.Result{RequeueAfter: CircularReferenceRecheckInterval}
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return result, nil
+	}
+	log.Info("Circular share, not syncing targets", "loop", loop)
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update circular reference status")
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// clearCircularReference drops the CircularReference condition once the loop
+// is broken. The status is written with the sync that follows.
+func clearCircularReference(sr *platformv1alpha1.SharedResource) {
+	meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeCircularReference)
+}
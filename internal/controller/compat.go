@@ -0,0 +1,165 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Renamed annotations and labels.
+//
+// Renaming a key the operator reads would strand every object still carrying
+// the old one until it is rewritten, which on a large estate takes far longer
+// than an upgrade. A rename is therefore listed in renamedMarkers for at
+// least one release, during which:
+//   - TranslateLegacyMarkers, the manager's cache transform, gives each cached
+//     object carrying only the legacy key the current one too, so every read
+//     sees the current form whichever release wrote the object
+//   - the next sync of a target writes the current key and drops the legacy
+//     one (see staleTracking); keys users set on sources, SharedResources
+//     and namespaces are read in both forms but left to their owners
+//   - sharedresource_legacy_markers{kind} counts the cached objects still
+//     carrying a legacy key, so the entry can be removed once it reads zero
+//     everywhere
+// =============================================================================
+
+// renamedMarker is an annotation or label key renamed from legacy to current.
+type renamedMarker struct {
+	legacy  string
+	current string
+	label   bool
+}
+
+// renamedMarkers are the renames whose legacy form is still read. Add an
+// entry with a rename, and remove it no sooner than a release later.
+var renamedMarkers []renamedMarker
+
+// legacyMarkersDesc counts objects still carrying a legacy key.
+var legacyMarkersDesc = prometheus.NewDesc(
+	"sharedresource_legacy_markers",
+	"Number of cached objects still carrying a renamed annotation or label under its legacy key, by kind.",
+	[]string{"kind"}, nil,
+)
+
+// TranslateLegacyMarkers is a cache transform setting the current key of
+// every renamed marker an object only carries in its legacy form. The legacy
+// key is kept, so syncs still see and drop it.
+func TranslateLegacyMarkers(in any) (any, error) {
+	obj, err := meta.Accessor(in)
+	if err != nil || len(renamedMarkers) == 0 {
+		return in, nil
+	}
+	annotations, labels := obj.GetAnnotations(), obj.GetLabels()
+	for _, m := range renamedMarkers {
+		markers := annotations
+		if m.label {
+			markers = labels
+		}
+		value, legacy := markers[m.legacy]
+		if _, current := markers[m.current]; legacy && !current {
+			markers[m.current] = value
+		}
+	}
+	return in, nil
+}
+
+// hasLegacyMarkers returns true if the object carries any renamed marker
+// under its legacy key.
+func hasLegacyMarkers(obj metav1.Object) bool {
+	for _, m := range renamedMarkers {
+		markers := obj.GetAnnotations()
+		if m.label {
+			markers = obj.GetLabels()
+		}
+		if _, ok := markers[m.legacy]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dropLegacyMarkers removes the legacy keys of renamed markers from a target
+// about to be written with the current ones.
+func dropLegacyMarkers(obj metav1.Object) {
+	annotations, labels := obj.GetAnnotations(), obj.GetLabels()
+	for _, m := range renamedMarkers {
+		if m.label {
+			delete(labels, m.legacy)
+		} else {
+			delete(annotations, m.legacy)
+		}
+	}
+}
+
+// legacyMarkerCounts returns, by kind, how many cached Secrets, ConfigMaps,
+// SharedResources and Namespaces still carry a legacy key.
+func (r *SharedResourceReconciler) legacyMarkerCounts(ctx context.Context) (map[string]int, error) {
+	lists := map[string]client.ObjectList{
+		"SharedResource": &platformv1alpha1.SharedResourceList{},
+		"Namespace":      &corev1.NamespaceList{},
+	}
+	for _, kind := range r.enabledKinds() {
+		if kind == KindSecret {
+			lists[kind] = &corev1.SecretList{}
+		} else {
+			lists[kind] = &corev1.ConfigMapList{}
+		}
+	}
+
+	counts := make(map[string]int, len(lists))
+	for kind, list := range lists {
+		counts[kind] = 0
+		if len(renamedMarkers) == 0 {
+			continue
+		}
+		if err := r.List(ctx, list); err != nil {
+			return nil, err
+		}
+		if err := meta.EachListItem(list, func(item runtime.Object) error {
+			if obj, err := meta.Accessor(item); err == nil && hasLegacyMarkers(obj) {
+				counts[kind]++
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// collectLegacyMarkers reports sharedresource_legacy_markers with the inventory.
+func (c *inventoryCollector) collectLegacyMarkers(ctx context.Context, ch chan<- prometheus.Metric) {
+	counts, err := c.r.legacyMarkerCounts(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to count legacy markers")
+		return
+	}
+	for kind, count := range counts {
+		ch <- prometheus.MustNewConstMetric(legacyMarkersDesc, prometheus.GaugeValue, float64(count), kind)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Consistency report - how far the source data has propagated.
+//
+// Every target records in status.syncedTargets[].sourceChecksum the source
+// checksum it was last written or verified with; a failing target keeps the
+// one it had. status.consistency counts the targets at status.sourceChecksum
+// and those still at older checksums, so a rollout's convergence shows at a
+// glance rather than only as allTargetsAtChecksum turning true.
+//
+// Compact status mode only lists failing targets, so a target left out of
+// the previous status is taken to be at the previous status.sourceChecksum,
+// which it synced with.
+// =============================================================================
+
+// maxOutdatedChecksums caps how many older checksums are listed in status
+const maxOutdatedChecksums = 10
+
+// previousSourceChecksums returns the sourceChecksum of each target as of the
+// last sync (see previousTargets).
+func previousSourceChecksums(targets []platformv1alpha1.TargetSyncStatus) map[string]string {
+	previous := make(map[string]string, len(targets))
+	for _, t := range targets {
+		if t.SourceChecksum != "" {
+			previous[targetKey(t.Namespace, t.Name)] = t.SourceChecksum
+		}
+	}
+	return previous
+}
+
+// lastSourceChecksum returns the source checksum a target held before this
+// sync, or "" if it is not known.
+func lastSourceChecksum(sr *platformv1alpha1.SharedResource, previous map[string]string, key string) string {
+	if checksum, ok := previous[key]; ok {
+		return checksum
+	}
+	// Omitted by compact status mode and missing from the status report, so
+	// synced by the previous sync
+	if sr.Status.TargetSummary != nil && sr.Status.TargetSummary.Omitted > 0 {
+		return sr.Status.SourceChecksum
+	}
+	return ""
+}
+
+// consistencyOf counts the targets by the source checksum they hold.
+// Released targets no longer follow the source and are not counted.
+func consistencyOf(targets []platformv1alpha1.TargetSyncStatus, checksum string) *platformv1alpha1.ConsistencyStatus {
+	consistency := &platformv1alpha1.ConsistencyStatus{}
+	outdated := map[string]int32{}
+	for _, t := range targets {
+		switch {
+		case t.Released:
+		case t.SourceChecksum == "":
+			consistency.Unknown++
+		case t.SourceChecksum == checksum:
+			consistency.Current++
+		default:
+			consistency.Outdated++
+			outdated[t.SourceChecksum]++
+		}
+	}
+
+	for checksum, count := range outdated {
+		consistency.OutdatedChecksums = append(consistency.OutdatedChecksums,
+			platformv1alpha1.ChecksumTargets{Checksum: checksum, Targets: count})
+	}
+	sort.Slice(consistency.OutdatedChecksums, func(i, j int) bool {
+		a, b := consistency.OutdatedChecksums[i], consistency.OutdatedChecksums[j]
+		if a.Targets != b.Targets {
+			return a.Targets > b.Targets
+		}
+		return a.Checksum < b.Checksum
+	})
+	if len(consistency.OutdatedChecksums) > maxOutdatedChecksums {
+		consistency.OutdatedChecksums = consistency.OutdatedChecksums[:maxOutdatedChecksums]
+	}
+	return consistency
+}
@@ -16,6 +16,8 @@ limitations under the License.
 
 package controller
 
+import "time"
+
 // =============================================================================
 // Constants for the SharedResource operator.
 //
@@ -25,9 +27,19 @@ package controller
 // - Status conditions (health reporting)
 // =============================================================================
 
-// Finalizer name used to ensure cleanup happens before deletion
+// Finalizer name used to ensure cleanup happens before deletion. Instances
+// with an operator class suffix it with the class (see operatorclass.go)
 const FinalizerName = "sharedresource.platform.dev/finalizer"
 
+// TargetFinalizerName is placed on targets of CRs with spec.trackTargetDeletion
+// so their deletion can be observed and classified (see targetguard.go)
+const TargetFinalizerName = "sharedresource.platform.dev/target"
+
+// FieldManager is recorded in managedFields for every write the operator
+// makes, unless overridden with --field-manager. Used to recognize (and ignore)
+// watch events caused by our own writes, and by policy engines to exempt them.
+const FieldManager = "sharedresource-operator"
+
 // =============================================================================
 // Annotations applied to synced target resources.
 // These enable tracking which operator manages the resource,
@@ -49,11 +61,147 @@ const (
 	// AnnotationChecksum stores SHA256 hash of synced data for drift detection
 	AnnotationChecksum = "sharedresource.platform.dev/checksum"
 
+	// AnnotationProvenance holds a JSON provenance record (see provenanceRecord)
+	// so scanners can trace a copy back to its origin
+	AnnotationProvenance = "sharedresource.platform.dev/provenance"
+
+	// AnnotationOrigin records, as namespace/name, the first source of a
+	// chain of shares on targets more than one hop away from it
+	AnnotationOrigin = "sharedresource.platform.dev/origin"
+
+	// AnnotationHops records how many shares away from AnnotationOrigin a
+	// chained target is; targets without it are one hop away
+	AnnotationHops = "sharedresource.platform.dev/hops"
+
+	// AnnotationDeletionPolicy records the deletion policy in effect for the
+	// target, so the sweeper can clean up after deleteBackground CRs
+	AnnotationDeletionPolicy = "sharedresource.platform.dev/deletion-policy"
+
 	// AnnotationLastSynced records when the resource was last synced
 	AnnotationLastSynced = "sharedresource.platform.dev/last-synced"
 
-	// ManagedByValue is the value for AnnotationManagedBy
+	// AnnotationOperatorVersion records the version and build of the operator
+	// that last wrote the resource. Like last-synced, it never forces a write
+	AnnotationOperatorVersion = "sharedresource.platform.dev/operator-version"
+
+	// AnnotationExpired marks a target of an expired share kept by
+	// expiryPolicy "retain" with the expiry time. Cleared by the next sync
+	AnnotationExpired = "sharedresource.platform.dev/expired"
+
+	// AnnotationKeyOwners records, as JSON, the keys each SharedResource wrote
+	// to a merge-mode target, so several can share it (see keyowners.go)
+	AnnotationKeyOwners = "sharedresource.platform.dev/key-owners"
+
+	// AnnotationVerifies names the target a verification Job checks (see verify.go)
+	AnnotationVerifies = "sharedresource.platform.dev/verifies"
+
+	// LabelManagedBy is set to ManagedByValue on every object the operator
+	// creates in a target namespace, so policy engines can select (and exempt) them
+	LabelManagedBy = "app.kubernetes.io/managed-by"
+
+	// ManagedByValue is the value for AnnotationManagedBy and LabelManagedBy
 	ManagedByValue = "sharedresource-operator"
+
+	// LabelChecksum carries the data checksum, base32-encoded, on compactly
+	// tracked targets in place of AnnotationChecksum (see tracking.go)
+	LabelChecksum = "sharedresource.platform.dev/checksum"
+
+	// LabelSourceUID carries the source's UID on compactly tracked targets,
+	// which have no AnnotationProvenance
+	LabelSourceUID = "sharedresource.platform.dev/source-uid"
+
+	// LabelTracking is set to TrackingCompact on compactly tracked targets
+	LabelTracking = "sharedresource.platform.dev/tracking"
+
+	// TrackingCompact is the value of LabelTracking
+	TrackingCompact = "compact"
+
+	// LabelGrantedBy carries the UID of the SharedResource that created an
+	// access Role or RoleBinding, so its grants can be listed (see access.go)
+	LabelGrantedBy = "sharedresource.platform.dev/granted-by"
+)
+
+// =============================================================================
+// Annotations users set on the SharedResource CR itself.
+// =============================================================================
+const (
+	// AnnotationSyncNow requests an immediate sync. Any new value (e.g. a
+	// timestamp) triggers a reconcile; the handled value is echoed in
+	// status.lastHandledSyncRequest.
+	AnnotationSyncNow = "sharedresource.platform.dev/sync-now"
+
+	// AnnotationExplain requests an explanation of the CR's state. Any new
+	// value (e.g. a timestamp) triggers a reconcile that writes it to
+	// status.explanation and an Explained event.
+	AnnotationExplain = "sharedresource.platform.dev/explain"
+
+	// AnnotationApproveChecksum approves a resume held by
+	// spec.requireResumeApproval; it must equal status.pendingSourceChecksum
+	AnnotationApproveChecksum = "sharedresource.platform.dev/approve-checksum"
+
+	// AnnotationRenewedAt renews a share with spec.duration: the duration
+	// counts from this RFC 3339 time instead of the CR's creation
+	AnnotationRenewedAt = "sharedresource.platform.dev/renewed-at"
+
+	// AnnotationRenew extends a time-limited share: the operator moves
+	// spec.expiresAt later by this duration (e.g. "720h") and removes it
+	AnnotationRenew = "sharedresource.platform.dev/renew"
+
+	// AnnotationSharedSecrets lists (comma-separated) the synced Secrets linked
+	// into a ServiceAccount via spec.access.serviceAccountLinks mode "annotation"
+	AnnotationSharedSecrets = "sharedresource.platform.dev/shared-secrets"
+
+	// AnnotationLinkedSecrets records (comma-separated "<mode>/<secret>") the
+	// links the operator made into a ServiceAccount, so unlisted ones are removed
+	AnnotationLinkedSecrets = "sharedresource.platform.dev/linked-secrets"
+
+	// AnnotationShareKeys on a SOURCE resource lists (comma-separated) the only
+	// keys any SharedResource may share, regardless of its syncPolicy
+	AnnotationShareKeys = "sharedresource.platform.dev/share-keys"
+
+	// AnnotationExcludeKeys on a SOURCE resource lists keys that are never shared
+	AnnotationExcludeKeys = "sharedresource.platform.dev/exclude-keys"
+
+	// AnnotationGenerated on a SOURCE Secret records, as JSON, when each
+	// spec.generate key was last generated (see generate.go)
+	AnnotationGenerated = "sharedresource.platform.dev/generated"
+
+	// AnnotationRotation on a SOURCE Secret records, as JSON, the version and
+	// any staged value of each twoPhase spec.generate key (see generate.go)
+	AnnotationRotation = "sharedresource.platform.dev/rotation"
+
+	// AnnotationAllowExport on a SOURCE resource, or its namespace, set to
+	// "true" lets SharedResources in other namespaces use it as their source
+	AnnotationAllowExport = "sharedresource.platform.dev/allow-export"
+)
+
+// =============================================================================
+// Annotations users set on Namespaces.
+// =============================================================================
+const (
+	// AnnotationAllowDeletion on a source namespace set to "true" lets it be
+	// deleted even though SharedResources in it still sync to other namespaces
+	AnnotationAllowDeletion = "sharedresource.platform.dev/allow-deletion"
+)
+
+// =============================================================================
+// Annotations for releasing a target from management (see release.go).
+// =============================================================================
+const (
+	// AnnotationRelease on a TARGET set to "true" asks its SharedResource to
+	// stop managing it and leave it in place
+	AnnotationRelease = "sharedresource.platform.dev/release"
+
+	// AnnotationReleasedFrom records, as namespace/name, the SharedResource a
+	// target was released from
+	AnnotationReleasedFrom = "sharedresource.platform.dev/released-from"
+
+	// AnnotationReleasedAt records when the target was released
+	AnnotationReleasedAt = "sharedresource.platform.dev/released-at"
+
+	// AnnotationCreatedFor records, as namespace/name, the SharedResource a
+	// target namespace was created for (spec.createTargetNamespaces)
+	AnnotationCreatedFor = "sharedresource.platform.dev/created-for"
 )
 
 // =============================================================================
@@ -72,6 +220,178 @@ const (
 	// ConditionTypeDegraded indicates partial sync failure
 	// True = some (but not all) targets failed to sync
 	ConditionTypeDegraded = "Degraded"
+
+	// ConditionTypePolicyDenied indicates a SharedResourcePolicy violation
+	// True = some targets or keys were withheld by policy
+	ConditionTypePolicyDenied = "PolicyDenied"
+
+	// ConditionTypeDataVaries indicates targets received different data
+	// True = per-target rules or templates produced more than one variant
+	ConditionTypeDataVaries = "DataVaries"
+
+	// ConditionTypeExternallyManaged indicates targets also managed by a GitOps tool
+	// True = some targets were backed off from, or force-applied
+	ConditionTypeExternallyManaged = "ExternallyManaged"
+
+	// ConditionTypeSuspended indicates spec.suspend stops target syncs
+	// True = suspended; removed on resume
+	ConditionTypeSuspended = "Suspended"
+
+	// ConditionTypeMutatedByAdmission indicates admission altered written data
+	// True = some targets are not rewritten until their data changes
+	ConditionTypeMutatedByAdmission = "MutatedByAdmission"
+
+	// ConditionTypeExpired indicates the share passed its expiry
+	// True = targets were deleted or are no longer refreshed; removed on renewal
+	ConditionTypeExpired = "Expired"
+
+	// ConditionTypeRenewalDue indicates a time-limited share nears its expiry
+	// True = within spec.renewBefore of expiring, or expired; False = not yet
+	ConditionTypeRenewalDue = "RenewalDue"
+
+	// ConditionTypeThrottled indicates the API server rate limited target writes
+	// True = remaining target writes wait for the resume time; removed after
+	ConditionTypeThrottled = "Throttled"
+
+	// ConditionTypeDeletesDisabled indicates settings that need target deletes
+	// True = the operator may not delete Secrets or ConfigMaps, so they have no effect
+	ConditionTypeDeletesDisabled = "DeletesDisabled"
+
+	// ConditionTypeRejected indicates the CR needs a kind this installation disabled
+	// True = the CR is not synced; removed once it no longer needs the kind
+	ConditionTypeRejected = "Rejected"
+
+	// ConditionTypeVerified indicates the result of spec.verify Jobs
+	// True = every target verified; False = Jobs running or failed (Ready waits)
+	ConditionTypeVerified = "Verified"
+
+	// ConditionTypePreSync indicates the result of the spec.preSync hook
+	// True = the hook succeeded for the current source; False = running or failed (targets wait)
+	ConditionTypePreSync = "PreSync"
+
+	// ConditionTypeCircularReference indicates the CR's targets feed back into its own source
+	// True = the CR is not synced; removed once the loop is broken
+	ConditionTypeCircularReference = "CircularReference"
+
+	// ConditionTypeHopLimitExceeded indicates the CR's targets would be more
+	// than --max-share-hops shares away from the origin of its source
+	// True = the CR is not synced; removed once the chain is short enough
+	ConditionTypeHopLimitExceeded = "HopLimitExceeded"
+
+	// ConditionTypeSourceExportDenied indicates a source in another namespace
+	// that has not consented to the export
+	// True = the CR is not synced; removed once the source consents
+	ConditionTypeSourceExportDenied = "SourceExportDenied"
+
+	// ConditionTypeKindUnavailable indicates the CR needs a kind whose API the
+	// cluster does not serve
+	// True = the CR is not synced; removed once the API is served
+	ConditionTypeKindUnavailable = "KindUnavailable"
+
+	// ConditionTypeSourceNamespaceDeleting indicates the CR's namespace is
+	// terminating while it still syncs to other namespaces
+	// True = the SourceNamespaceDeleting warnings were raised; never removed,
+	// the namespace does not come back
+	ConditionTypeSourceNamespaceDeleting = "SourceNamespaceDeleting"
+)
+
+// =============================================================================
+// Status reporting defaults.
+// =============================================================================
+const (
+	// DefaultCompactTrackingThreshold is the default --compact-tracking-threshold:
+	// half the 1MiB object size limit
+	DefaultCompactTrackingThreshold = 512 * 1024
+
+	// DefaultMaxFailedTargets caps failing targets listed in compact status mode
+	DefaultMaxFailedTargets = 20
+
+	// MaxTargetErrorHistory caps the entries in each target's recentErrors
+	MaxTargetErrorHistory = 5
+)
+
+// =============================================================================
+// Logging verbosity levels (enable with --zap-log-level=<n>).
+// =============================================================================
+const (
+	// LogLevelDataDiff logs which keys changed on each target write.
+	// Only key names and value lengths are logged, never values.
+	LogLevelDataDiff = 2
+)
+
+// =============================================================================
+// Requeue intervals.
+// =============================================================================
+const (
+	// SourceNotFoundRequeueInterval is how long to wait before checking again
+	// for a missing source resource
+	SourceNotFoundRequeueInterval = 30 * time.Second
+
+	// ResyncInterval is the periodic requeue used for drift detection
+	ResyncInterval = 5 * time.Minute
+
+	// CleanupRetryBaseInterval is the first retry delay after a failed target
+	// cleanup; it doubles with each attempt up to ResyncInterval
+	CleanupRetryBaseInterval = 5 * time.Second
+
+	// CleanupCheckpointInterval is after how many cleaned up targets the
+	// progress is written to status.cleanup, so a restart mid-cleanup resumes
+	// where it stopped
+	CleanupCheckpointInterval = 50
+
+	// DefaultSweepInterval is how often the sweeper looks for targets left
+	// behind by deleteBackground CRs
+	DefaultSweepInterval = time.Minute
+
+	// MinSourcePollInterval is the shortest allowed source polling interval;
+	// shorter ones are raised to it
+	MinSourcePollInterval = 5 * time.Second
+
+	// MinResyncInterval is the shortest resync interval a namespace tier may set
+	MinResyncInterval = 30 * time.Second
+
+	// DefaultRenewBefore is how long before a share expires that renewal is
+	// reported due, unless spec.renewBefore says otherwise
+	DefaultRenewBefore = 7 * 24 * time.Hour
+
+	// RotationCheckInterval is how often a staged twoPhase rotation is checked
+	// for full propagation before the primary key is switched
+	RotationCheckInterval = 5 * time.Second
+
+	// DefaultThrottlePause is how long target writes pause after the API
+	// server answers 429 without a Retry-After delay
+	DefaultThrottlePause = 10 * time.Second
+
+	// MaxThrottlePause caps the pause a Retry-After delay asks for
+	MaxThrottlePause = 5 * time.Minute
+
+	// ForbiddenRetryBaseInterval is the first retry delay of a target whose
+	// write was forbidden; it doubles with each attempt up to
+	// ForbiddenRetryMaxInterval
+	ForbiddenRetryBaseInterval = 5 * time.Second
+
+	// ForbiddenRetryMaxInterval caps the retry delay of forbidden targets
+	ForbiddenRetryMaxInterval = 2 * time.Minute
+
+	// HookCheckInterval is how often running verification and pre-sync Jobs
+	// are checked
+	HookCheckInterval = 10 * time.Second
+
+	// DefaultHookJobTimeout is the deadline of verification and pre-sync Jobs
+	// unless the hook's timeout or the template says otherwise
+	DefaultHookJobTimeout = 10 * time.Minute
+
+	// DefaultPreSyncHTTPTimeout is how long a pre-sync HTTP call may take
+	// unless spec.preSync.timeout says otherwise
+	DefaultPreSyncHTTPTimeout = 30 * time.Second
+
+	// PreSyncRetryInterval is how long a failed pre-sync HTTP call waits
+	// before it is retried
+	PreSyncRetryInterval = 30 * time.Second
+
+	// CircularReferenceRecheckInterval is how often a CR rejected for a
+	// circular share checks whether the loop was broken
+	CircularReferenceRecheckInterval = time.Minute
 )
 
 // =============================================================================
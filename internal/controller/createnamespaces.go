@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Creating target namespaces (spec.createTargetNamespaces).
+//
+// By default a target whose namespace does not exist fails until someone
+// creates it. With spec.createTargetNamespaces the operator creates it, with
+// spec.targetNamespaceLabels, right before building the target's data. Dev
+// mode scoping and SharedResourcePolicies are checked first, against the
+// labels the namespace would get, so a CR cannot create a namespace it may
+// not target.
+//
+// Created namespaces carry AnnotationCreatedFor, and status.syncedTargets
+// reports namespaceCreated for them on every sync. The operator never
+// deletes a namespace, including one it created.
+// =============================================================================
+
+// ensureTargetNamespace creates a missing target namespace if the CR asks for
+// it. Returns true if the namespace was created by the operator, now or for
+// an earlier sync.
+func (r *SharedResourceReconciler) ensureTargetNamespace(ctx context.Context, sr *platformv1alpha1.SharedResource, name string) (bool, error) {
+	var ns corev1.Namespace
+	err := r.Get(ctx, types.NamespacedName{Name: name}, &ns)
+	if err == nil {
+		return ns.Annotations[AnnotationCreatedFor] != "", nil
+	}
+	if !apierrors.IsNotFound(err) || !sr.Spec.CreateTargetNamespaces {
+		// Without createTargetNamespaces the write reports the missing namespace
+		return false, client.IgnoreNotFound(err)
+	}
+
+	ns = corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Labels:      maps.Clone(sr.Spec.TargetNamespaceLabels),
+		Annotations: map[string]string{AnnotationCreatedFor: sr.Namespace + "/" + sr.Name},
+	}}
+	if err := r.Create(ctx, &ns); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create target namespace: %w", err)
+		}
+		// Created meanwhile, possibly by another CR; the cache may not have it yet
+		var reader client.Reader = r.Client
+		if r.APIReader != nil {
+			reader = r.APIReader
+		}
+		if err := reader.Get(ctx, types.NamespacedName{Name: name}, &ns); err != nil {
+			return false, err
+		}
+		return ns.Annotations[AnnotationCreatedFor] != "", nil
+	}
+	r.recordEvent(sr, corev1.EventTypeNormal, "NamespaceCreated", "Created target namespace %s", name)
+	return true, nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+)
+
+// =============================================================================
+// Dev mode - --dev-mode, for evaluating the operator and working on it.
+//
+// Dev mode trades safety for a short inner loop against a shared cluster:
+//   - everything happens in one namespace (Namespace): only its CRs are
+//     managed, and targets in other namespaces fail with an explicit error
+//     instead of being written
+//   - no CR finalizer (DevMode), so `kubectl delete` is never blocked. A
+//     deleted CR's targets are left in place; deletionPolicy is not applied
+//   - decisions (skips, suspension, expiry) are explained in the debug log,
+//     which --dev-mode turns on
+// =============================================================================
+
+// targetOutOfScope returns an error for a target outside the namespace the
+// operator is restricted to, if any.
+func (r *SharedResourceReconciler) targetOutOfScope(namespace string) error {
+	if r.Namespace == "" || namespace == r.Namespace {
+		return nil
+	}
+	return fmt.Errorf("target namespace is outside %s, the only namespace managed in dev mode", r.Namespace)
+}
+
+// usesFinalizer returns true if managed CRs get the finalizer of this instance.
+func (r *SharedResourceReconciler) usesFinalizer() bool {
+	return !r.DevMode
+}
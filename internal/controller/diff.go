@@ -0,0 +1,296 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Target diffs - what a sync would change, computed on demand.
+//
+// DiffTargets runs the source, filter, policy, namespace rule, template and
+// merge code of a sync, reads every target and reports what writing it now
+// would change, without writing anything. Like the data diff log, it names
+// keys and value lengths, never values.
+//
+// With --enable-diff-api the metrics server serves it as JSON at
+// /diff/<namespace>/<name>, so the kubectl plugin's diff is the operator's
+// answer rather than a re-implementation of it. The metrics server's
+// authorization only covers the path, so each request is also checked with a
+// SubjectAccessReview: the caller must be allowed to get the SharedResource.
+// That needs the caller's identity, so --enable-diff-api requires
+// --metrics-secure.
+// =============================================================================
+
+// DiffAPIPath is where the metrics server serves target diffs.
+const DiffAPIPath = "/diff/"
+
+// errNotManagedHere is returned for CRs another operator instance syncs.
+var errNotManagedHere = errors.New("not managed by this operator instance")
+
+// TargetDiff is what syncing one target would change.
+type TargetDiff struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Exists is false if syncing would create the target
+	Exists bool `json:"exists"`
+	// Released targets are left alone, so nothing would change
+	Released bool `json:"released,omitempty"`
+	// Diff lists the keys the write would add, remove or change
+	Diff syncengine.DataDiff `json:"diff"`
+	// Error is why the target would not be synced, if it would not
+	Error string `json:"error,omitempty"`
+}
+
+// DiffReport is what syncing a SharedResource now would change.
+type DiffReport struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Checksum of the source data after filters and policies
+	Checksum string `json:"checksum"`
+	// SyncedChecksum is status.sourceChecksum, the checksum last synced
+	SyncedChecksum string       `json:"syncedChecksum,omitempty"`
+	Targets        []TargetDiff `json:"targets"`
+}
+
+// DiffTargets returns what syncing the SharedResource now would change in
+// each of its targets. Nothing is written.
+func (r *SharedResourceReconciler) DiffTargets(ctx context.Context, key types.NamespacedName) (*DiffReport, error) {
+	var sr platformv1alpha1.SharedResource
+	if err := r.Get(ctx, key, &sr); err != nil {
+		return nil, err
+	}
+	if !r.managesCR(&sr) {
+		return nil, errNotManagedHere
+	}
+	if errs := DisabledKindErrors(&sr, r.DisabledKinds); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	denied, err := r.sourceExportDenied(ctx, &sr)
+	if err != nil {
+		return nil, err
+	}
+	if denied != "" {
+		return nil, errors.New(denied)
+	}
+
+	sourceData, _, err := r.fetchSourceResource(ctx, &sr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source: %w", err)
+	}
+	decision, err := r.evaluatePolicies(ctx, &sr)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := r.resolveTargets(ctx, &sr)
+	if err != nil {
+		return nil, err
+	}
+	data := decision.enforceKeys(syncengine.Filter(sourceData, sr.Spec.SyncPolicy))
+	report := &DiffReport{
+		Namespace:      sr.Namespace,
+		Name:           sr.Name,
+		Checksum:       syncengine.Checksum(data),
+		SyncedChecksum: sr.Status.SourceChecksum,
+		Targets:        make([]TargetDiff, 0, len(targets)),
+	}
+
+	secrets, secretsErr := r.fetchTemplateSecrets(ctx, &sr)
+	seen := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		name := resolvedTargetName(&sr, target)
+		if seen[targetKey(target.Namespace, name)] {
+			continue
+		}
+		seen[targetKey(target.Namespace, name)] = true
+
+		td := TargetDiff{Namespace: target.Namespace, Name: name}
+		err := secretsErr
+		if err == nil {
+			err = r.diffTarget(ctx, &sr, decision, target, data, secrets, &td)
+		}
+		if err != nil {
+			td.Error = err.Error()
+		}
+		report.Targets = append(report.Targets, td)
+	}
+	sort.Slice(report.Targets, func(i, j int) bool {
+		return targetKey(report.Targets[i].Namespace, report.Targets[i].Name) <
+			targetKey(report.Targets[j].Namespace, report.Targets[j].Name)
+	})
+	return report, nil
+}
+
+// diffTarget fills in what syncing one target would change, following the
+// steps of syncAllTargets and syncToTarget.
+func (r *SharedResourceReconciler) diffTarget(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	decision *policyDecision,
+	target platformv1alpha1.TargetSpec,
+	data map[string][]byte,
+	secrets map[string]string,
+	td *TargetDiff,
+) error {
+	if err := r.targetOutOfScope(target.Namespace); err != nil {
+		return err
+	}
+	obj, err := newTargetObject(targetKind(sr))
+	if err != nil {
+		return err
+	}
+	var existing map[string][]byte
+	var existingAnnotations map[string]string
+	if err := r.Get(ctx, types.NamespacedName{Namespace: target.Namespace, Name: td.Name}, obj); err == nil {
+		td.Exists = true
+		existing = objectData(obj)
+		existingAnnotations = obj.GetAnnotations()
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// A released target, or one whose release is requested, is not written again
+	if td.Exists && releasedTarget(sr, existingAnnotations) {
+		td.Released = true
+		return nil
+	}
+
+	denied, err := r.targetDenied(ctx, sr, decision, target.Namespace)
+	if err != nil {
+		return err
+	}
+	if denied != "" {
+		return fmt.Errorf("target namespace denied by SharedResourcePolicy %q", denied)
+	}
+	if tool := gitOpsTool(obj); td.Exists && tool != "" && !takesOwnership(sr) {
+		return fmt.Errorf("also managed by %s; not written while spec.externallyManaged is backOff", tool)
+	}
+
+	targetData, err := r.buildTargetData(ctx, sr, target, td.Name, secrets, data)
+	if err != nil {
+		return err
+	}
+
+	// Merge mode needs the key owner this CR would write as
+	annotations := map[string]string{AnnotationSourceNamespace: sr.Namespace, AnnotationSourceCR: sr.Name}
+	merged, conflicts := mergeForTarget(existing, existingAnnotations, targetData, annotations, targetSyncMode(sr))
+	td.Diff = syncengine.Diff(existing, merged)
+	return keyConflictError(conflicts)
+}
+
+// releasedTarget returns true if syncing leaves the target alone because it
+// was, or is about to be, released (see release.go).
+func releasedTarget(sr *platformv1alpha1.SharedResource, annotations map[string]string) bool {
+	if annotations[AnnotationManagedBy] != ManagedByValue {
+		return annotations[AnnotationReleasedFrom] == releaseOwner(sr)
+	}
+	return releaseRequested(sr, annotations)
+}
+
+// authorizeDiff checks that the bearer token of the request may get the
+// SharedResource. It returns the HTTP status to answer with if not, or 0.
+func (r *SharedResourceReconciler) authorizeDiff(ctx context.Context, req *http.Request, key types.NamespacedName) (int, error) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized, errors.New("a bearer token is required")
+	}
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := r.Create(ctx, review); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return http.StatusUnauthorized, errors.New("the bearer token is not valid")
+	}
+
+	user := review.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	access := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: key.Namespace,
+			Verb:      "get",
+			Group:     platformv1alpha1.GroupVersion.Group,
+			Resource:  "sharedresources",
+			Name:      key.Name,
+		},
+	}}
+	if err := r.Create(ctx, access); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review access: %w", err)
+	}
+	if !access.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("%s may not get SharedResource %s", user.Username, key)
+	}
+	return 0, nil
+}
+
+// diffHandler serves DiffTargets at DiffAPIPath<namespace>/<name> to callers
+// allowed to get the SharedResource.
+func (r *SharedResourceReconciler) diffHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		namespace, name, ok := strings.Cut(strings.TrimPrefix(req.URL.Path, DiffAPIPath), "/")
+		if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+			http.Error(w, "expected "+DiffAPIPath+"<namespace>/<name>", http.StatusBadRequest)
+			return
+		}
+		key := types.NamespacedName{Namespace: namespace, Name: name}
+		if status, err := r.authorizeDiff(req.Context(), req, key); status != 0 {
+			if status == http.StatusInternalServerError {
+				logf.FromContext(req.Context()).Error(err, "Failed to authorize diff request", "namespace", namespace, "name", name)
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		report, err := r.DiffTargets(req.Context(), key)
+		switch {
+		case apierrors.IsNotFound(err), errors.Is(err, errNotManagedHere):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			logf.FromContext(req.Context()).Error(err, "Failed to diff targets", "namespace", namespace, "name", name)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Duplicate targets.
+//
+// Two entries of spec.targets may resolve to the same object, e.g. one using
+// the source name and one renaming to it in the same namespace. The CRD schema
+// cannot express this (a CEL rule over an unbounded list exceeds the cost
+// budget), so the webhook and lint reject it. Without the webhook, the
+// controller syncs the first entry only and warns about the others.
+// =============================================================================
+
+// DuplicateTargets returns an error for each spec.targets entry resolving to
+// the same namespace and name as an earlier one, or naming the same group
+// and name. Group members overlapping other entries are not duplicates (see
+// namespacegroups.go).
+func DuplicateTargets(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]bool, len(sr.Spec.Targets))
+	for i, target := range sr.Spec.Targets {
+		key := targetKey(target.Namespace, resolvedTargetName(sr, target))
+		if target.Group != "" {
+			key = "group " + targetKey(target.Group, resolvedTargetName(sr, target))
+		}
+		if seen[key] {
+			errs = append(errs, field.Duplicate(field.NewPath("spec", "targets").Index(i), key))
+		}
+		seen[key] = true
+	}
+	return errs
+}
+
+// resolvedTargetName returns the name of a target object: its rename, or the
+// source name.
+func resolvedTargetName(sr *platformv1alpha1.SharedResource, target platformv1alpha1.TargetSpec) string {
+	if target.Name != "" {
+		return target.Name
+	}
+	return sourceName(sr)
+}
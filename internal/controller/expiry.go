@@ -0,0 +1,305 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Share expiry - spec.expiresAt and spec.duration.
+//
+// A share may be time-limited, so cross-team credential shares end unless
+// someone actively renews them. Once the expiry passes, the CR stops syncing
+// and, per spec.expiryPolicy:
+//   - "delete": deletes its targets (whatever their deletion policy) and their
+//     access grants, revoking the share
+//   - "retain": leaves the targets unrefreshed and marks them expired
+//
+// Within spec.renewBefore of the expiry, the RenewalDue condition turns True
+// and a RenewalDue event is emitted, so owners can renew in time. Renewing
+// means moving spec.expiresAt later, setting the renew annotation to the
+// extension (the operator then moves spec.expiresAt itself), or, for
+// spec.duration, setting the renewed-at annotation to the time of renewal.
+// The next sync then recreates (or refreshes and unmarks) the targets.
+// Expiry wins over suspension; deleting the CR is not affected.
+// =============================================================================
+
+// shareExpiry returns when the share expires, or nil if it does not.
+func (r *SharedResourceReconciler) shareExpiry(sr *platformv1alpha1.SharedResource) *metav1.Time {
+	var expiry *metav1.Time
+	if sr.Spec.ExpiresAt != nil {
+		at := *sr.Spec.ExpiresAt
+		expiry = &at
+	}
+	if sr.Spec.Duration != nil {
+		end := metav1.NewTime(shareStart(sr, r.now()).Add(sr.Spec.Duration.Duration))
+		if expiry == nil || end.Before(expiry) {
+			expiry = &end
+		}
+	}
+	return expiry
+}
+
+// shareStart returns when spec.duration starts counting: at the CR's
+// creation, or at a later renewal. A renewal time that cannot be parsed is
+// ignored, and one in the future is ignored until it is reached.
+func shareStart(sr *platformv1alpha1.SharedResource, now time.Time) time.Time {
+	start := sr.CreationTimestamp.Time
+	if value, ok := sr.Annotations[AnnotationRenewedAt]; ok {
+		renewed, err := time.Parse(time.RFC3339, value)
+		if err == nil && renewed.After(start) && !renewed.After(now) {
+			start = renewed
+		}
+	}
+	return start
+}
+
+// renewBefore returns how long before the expiry renewal is due.
+func renewBefore(sr *platformv1alpha1.SharedResource) time.Duration {
+	if sr.Spec.RenewBefore != nil {
+		return sr.Spec.RenewBefore.Duration
+	}
+	return DefaultRenewBefore
+}
+
+// checkExpiry records the share's expiry and whether renewal is due in
+// status, and drops the expired state of a renewed share. Returns whether the
+// share has expired, and otherwise the time until renewal is due or, once it
+// is, until the expiry (zero if the share never expires).
+func (r *SharedResourceReconciler) checkExpiry(sr *platformv1alpha1.SharedResource) (bool, time.Duration) {
+	expiry := r.shareExpiry(sr)
+	wasDue := conditionIsTrue(sr, ConditionTypeRenewalDue)
+	changed := !equalTimes(expiry, sr.Status.ExpiresAt)
+	sr.Status.ExpiresAt = expiry
+	if expiry == nil {
+		changed = changed || meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeRenewalDue) != nil
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeRenewalDue)
+		r.clearExpired(sr)
+	} else {
+		r.setRenewalDue(sr, expiry)
+		changed = changed || conditionIsTrue(sr, ConditionTypeRenewalDue) != wasDue
+	}
+	if changed {
+		// A renewal may only touch an annotation; make sure it is synced in full
+		r.verified.invalidate(client.ObjectKeyFromObject(sr))
+	}
+	if expiry == nil {
+		return false, 0
+	}
+
+	remaining := expiry.Sub(r.now())
+	if remaining <= 0 {
+		return true, 0
+	}
+	r.clearExpired(sr)
+	if untilDue := remaining - renewBefore(sr); untilDue > 0 {
+		return false, untilDue
+	}
+	return false, remaining
+}
+
+// setRenewalDue sets the RenewalDue condition, with an event when it turns True.
+func (r *SharedResourceReconciler) setRenewalDue(sr *platformv1alpha1.SharedResource, expiry *metav1.Time) {
+	at := expiry.UTC().Format(time.RFC3339)
+	if expiry.Sub(r.now()) > renewBefore(sr) {
+		setCondition(sr, ConditionTypeRenewalDue, metav1.ConditionFalse, "NotDue", fmt.Sprintf("Share expires at %s", at))
+		return
+	}
+	message := fmt.Sprintf("Share expires at %s; renew it with annotation %s=<duration>", at, AnnotationRenew)
+	if !conditionIsTrue(sr, ConditionTypeRenewalDue) {
+		r.recordEvent(sr, corev1.EventTypeWarning, "RenewalDue", "%s", message)
+	}
+	setCondition(sr, ConditionTypeRenewalDue, metav1.ConditionTrue, "ExpiringSoon", message)
+}
+
+// renewRequested returns true if the renew annotation is set.
+func renewRequested(sr *platformv1alpha1.SharedResource) bool {
+	_, ok := sr.Annotations[AnnotationRenew]
+	return ok
+}
+
+// renewShare acts on the renew annotation: spec.expiresAt moves later by its
+// duration, counted from the current expiry (or now, if already expired),
+// and spec.duration restarts. The annotation is removed either way; the
+// update triggers the reconcile that applies the new expiry.
+func (r *SharedResourceReconciler) renewShare(ctx context.Context, sr *platformv1alpha1.SharedResource, log logr.Logger) error {
+	value := sr.Annotations[AnnotationRenew]
+	delete(sr.Annotations, AnnotationRenew)
+	extension, err := time.ParseDuration(value)
+	expiry := r.shareExpiry(sr)
+	switch {
+	case err != nil || extension <= 0:
+		r.recordEvent(sr, corev1.EventTypeWarning, "InvalidRenewal",
+			"Ignoring %s=%q: not a positive duration", AnnotationRenew, value)
+	case expiry == nil:
+		r.recordEvent(sr, corev1.EventTypeWarning, "InvalidRenewal",
+			"Ignoring %s: the share does not expire", AnnotationRenew)
+	default:
+		now := r.now()
+		from := now
+		if expiry.After(now) {
+			from = expiry.Time
+		}
+		until := metav1.NewTime(from.Add(extension).Truncate(time.Second))
+		sr.Spec.ExpiresAt = &until
+		if sr.Spec.Duration != nil {
+			sr.Annotations[AnnotationRenewedAt] = now.UTC().Format(time.RFC3339)
+		}
+		r.recordEvent(sr, corev1.EventTypeNormal, "ExpiryExtended", "Share renewed by %s", extension)
+		log.Info("Renewing share", "extension", extension.String(), "expiresAt", until.UTC().Format(time.RFC3339))
+	}
+	return r.Update(ctx, sr)
+}
+
+// equalTimes returns true if both times are unset, or set and equal.
+func equalTimes(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(b)
+}
+
+// clearExpired drops the expired state of a renewed share. The status is
+// written with the sync that follows.
+func (r *SharedResourceReconciler) clearExpired(sr *platformv1alpha1.SharedResource) {
+	if meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeExpired) == nil {
+		return
+	}
+	r.recordEvent(sr, corev1.EventTypeNormal, "Renewed", "Share renewed; syncing targets again")
+	meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeExpired)
+}
+
+// handleExpired deletes or marks the targets of an expired share and reports
+// the expiry. Failed targets are retried with the usual error backoff.
+func (r *SharedResourceReconciler) handleExpired(ctx context.Context, sr *platformv1alpha1.SharedResource, log logr.Logger) (ctrl.Result, error) {
+	before := sr.Status.DeepCopy()
+	expiry := sr.Status.ExpiresAt.UTC().Format(time.RFC3339)
+
+	var err error
+	reason, message := "Revoked", fmt.Sprintf("Share expired at %s; targets deleted", expiry)
+	// Without delete permission an expired share can only be retained (see permissions.go)
+	if sr.Spec.ExpiryPolicy == platformv1alpha1.ExpiryPolicyRetain || r.DeletesDisabled {
+		reason, message = "Retained", fmt.Sprintf("Share expired at %s; targets kept but no longer refreshed", expiry)
+		err = r.markTargetsExpired(ctx, sr, expiry)
+	} else {
+		err = r.revokeTargets(ctx, sr)
+		if err == nil {
+			sr.Status.SyncedTargets = nil
+			sr.Status.TargetSummary = nil
+			sr.Status.Consistency = nil
+			r.recordManagedBytes(client.ObjectKeyFromObject(sr), 0)
+		}
+	}
+	if err != nil {
+		message = fmt.Sprintf("Share expired at %s; %v", expiry, err)
+	}
+
+	if meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeExpired) == nil {
+		r.recordEvent(sr, corev1.EventTypeNormal, "Expired", "%s", message)
+	}
+	setCondition(sr, ConditionTypeExpired, metav1.ConditionTrue, reason, message)
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "Expired", message)
+	sr.Status.AllTargetsAtChecksum = false
+	sr.Status.ObservedGeneration = sr.Generation
+	clearRetry(sr)
+	r.applyDeletesDisabledCondition(sr)
+	r.explainIfRequested(sr, message, fmt.Sprintf("Targets are not synced again until the share is extended or renewed with %s", AnnotationRenew))
+
+	if !equality.Semantic.DeepEqual(before, &sr.Status) {
+		if statusErr := r.Status().Update(ctx, sr); statusErr != nil {
+			log.Error(statusErr, "Failed to update expiry status")
+			return ctrl.Result{}, statusErr
+		}
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("Share expired, not syncing targets", "expiresAt", expiry, "expiryPolicy", sr.Spec.ExpiryPolicy)
+	return ctrl.Result{}, nil
+}
+
+// revokeTargets deletes every target of an expired share, along with its
+// access grants. Targets other SharedResources merge into only lose our keys.
+func (r *SharedResourceReconciler) revokeTargets(ctx context.Context, sr *platformv1alpha1.SharedResource) error {
+	targets, err := r.knownTargets(ctx, sr)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, target := range targets {
+		name := resolvedTargetName(sr, target)
+		if err := r.deleteTarget(ctx, sr, target.Namespace, name); err != nil {
+			errs = append(errs, fmt.Errorf("target %s/%s: %w", target.Namespace, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// markTargetsExpired annotates every target this CR owns with the expiry.
+func (r *SharedResourceReconciler) markTargetsExpired(ctx context.Context, sr *platformv1alpha1.SharedResource, expiry string) error {
+	kind := targetKind(sr)
+	targets, err := r.knownTargets(ctx, sr)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, target := range targets {
+		key := types.NamespacedName{Namespace: target.Namespace, Name: resolvedTargetName(sr, target)}
+		obj, err := newTargetObject(kind)
+		if err != nil {
+			return err
+		}
+		if err := r.Get(ctx, key, obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("target %s: %w", key, err))
+			}
+			continue
+		}
+		if !ownedByCR(obj, sr) || obj.GetAnnotations()[AnnotationExpired] == expiry {
+			continue
+		}
+		obj.SetAnnotations(mergeInto(obj.GetAnnotations(), map[string]string{AnnotationExpired: expiry}))
+		if err := r.Update(ctx, obj); err != nil {
+			errs = append(errs, fmt.Errorf("target %s: %w", key, err))
+			continue
+		}
+		switch o := obj.(type) {
+		case *corev1.Secret:
+			r.recordWrite(kind, key.Namespace, key.Name, o.Data)
+		case *corev1.ConfigMap:
+			r.recordWrite(kind, key.Namespace, key.Name, syncengine.FromStrings(o.Data))
+		}
+	}
+	return errors.Join(errs...)
+}
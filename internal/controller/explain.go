@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Explain - the sharedresource.platform.dev/explain annotation.
+//
+// Setting the annotation to a new value (`kubectl annotate --overwrite ...
+// explain=$(date +%s)`) makes the next reconcile write, in plain sentences,
+// why the CR's targets are in their state to status.explanation and an
+// Explained event:
+//   - the source keys, and which keys the source annotations, syncPolicy and
+//     SharedResourcePolicies kept back
+//   - per target: synced since when, released, or why it failed
+//   - what the controller does next, and when
+//
+// A pending request bypasses the reconcile gate, like sync-now. Suspended,
+// expired and missing-source CRs explain why nothing is synced instead.
+// =============================================================================
+
+const (
+	// maxExplainEventLength caps the Explained event message; status holds it all
+	maxExplainEventLength = 1024
+)
+
+// explainRequested returns true if the explain annotation holds a value that
+// has not been answered yet.
+func explainRequested(sr *platformv1alpha1.SharedResource) bool {
+	request := sr.Annotations[AnnotationExplain]
+	if request == "" {
+		return false
+	}
+	return sr.Status.Explanation == nil || sr.Status.Explanation.Request != request
+}
+
+// explainIfRequested answers a pending explain request with the given lines.
+// The caller writes the status.
+func (r *SharedResourceReconciler) explainIfRequested(sr *platformv1alpha1.SharedResource, lines ...string) {
+	if !explainRequested(sr) {
+		return
+	}
+	sr.Status.Explanation = &platformv1alpha1.Explanation{
+		Request: sr.Annotations[AnnotationExplain],
+		Time:    metav1.NewTime(r.now()),
+		Lines:   lines,
+	}
+	message := strings.Join(lines, "; ")
+	if len(message) > maxExplainEventLength {
+		message = message[:maxExplainEventLength-3] + "..."
+	}
+	r.recordEvent(sr, corev1.EventTypeNormal, "Explained", "%s", message)
+}
+
+// explainSync explains a reconcile that synced the targets: sourceData is
+// what the source shares, filtered what was sent after syncPolicy and
+// SharedResourcePolicies, and next the line from explainNext.
+func explainSync(
+	sr *platformv1alpha1.SharedResource,
+	sourceData, filtered map[string][]byte,
+	source sourceMeta,
+	decision *policyDecision,
+	targets []platformv1alpha1.TargetSyncStatus,
+	next string,
+) []string {
+	lines := []string{fmt.Sprintf("Source %s %s shares %d key(s)",
+		sr.Spec.Source.Kind, sourceName(sr), len(sourceData))}
+	if len(source.Withheld) > 0 {
+		lines = append(lines, "Withheld by the source's annotations: "+strings.Join(source.Withheld, ", "))
+	}
+
+	var dropped []string
+	for k := range sourceData {
+		if _, ok := filtered[k]; !ok && !slices.Contains(decision.deniedKeys, k) {
+			dropped = append(dropped, k)
+		}
+	}
+	if len(dropped) > 0 {
+		sort.Strings(dropped)
+		lines = append(lines, fmt.Sprintf("Dropped by syncPolicy (%s): %s", describeSyncPolicy(sr), strings.Join(dropped, ", ")))
+	}
+	if len(decision.deniedKeys) > 0 {
+		lines = append(lines, "Stripped by SharedResourcePolicy: "+strings.Join(decision.deniedKeys, ", "))
+	}
+	lines = append(lines, fmt.Sprintf("Sending %d key(s) to %d target(s)", len(filtered), len(targets)))
+
+	for _, t := range targets {
+		name := t.Namespace + "/" + t.Name
+		switch {
+		case t.Released:
+			lines = append(lines, name+": released, no longer synced")
+		case !t.Synced:
+			lines = append(lines, name+": not synced: "+t.Error)
+		case t.ExternallyManagedBy != "":
+			lines = append(lines, fmt.Sprintf("%s: synced, taken over from %s", name, t.ExternallyManagedBy))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: synced, data current since %s", name, t.LastSynced.UTC().Format(time.RFC3339)))
+		}
+	}
+	return append(lines, next)
+}
+
+// explainNext describes the next reconcile; the delays are those the
+// reconcile requeues with (0 when unused).
+func (r *SharedResourceReconciler) explainNext(allSynced bool, resync, rotateAfter, poll, expireAfter time.Duration) string {
+	next, why := resync, "drift check"
+	if !allSynced {
+		why = "retry of failed targets"
+	}
+	for _, c := range []struct {
+		after time.Duration
+		why   string
+	}{
+		{rotateAfter, "rotation of generated keys"},
+		{poll, "source poll"},
+		{expireAfter, "share expiry or renewal"},
+	} {
+		if c.after > 0 && (next == 0 || c.after < next) {
+			next, why = c.after, c.why
+		}
+	}
+	if next == 0 {
+		return "Next sync on the next change to the CR, its source or a target"
+	}
+	return fmt.Sprintf("Next sync at %s (%s), or earlier on a change to the CR, its source or a target",
+		r.now().Add(next).UTC().Format(time.RFC3339), why)
+}
+
+// describeSyncPolicy names the syncPolicy settings that select keys.
+func describeSyncPolicy(sr *platformv1alpha1.SharedResource) string {
+	policy := sr.Spec.SyncPolicy
+	if policy == nil {
+		return "mode " + string(platformv1alpha1.SyncModeCopy)
+	}
+	mode := policy.Mode
+	if mode == "" {
+		mode = platformv1alpha1.SyncModeCopy
+	}
+	parts := []string{"mode " + string(mode)}
+	if policy.Profile != "" {
+		parts = append(parts, "profile "+string(policy.Profile))
+	}
+	return strings.Join(parts, ", ")
+}
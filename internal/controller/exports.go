@@ -0,0 +1,376 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Delegated sharing (SharedResourceExport / SharedResourceImport).
+//
+// The team owning a source offers it with a SharedResourceExport in its
+// namespace, listing the namespaces that may import it; a consuming team asks
+// for it with a SharedResourceImport in its own namespace. Only while both
+// exist, and the Export admits the Import's namespace, does the Import
+// reconciler keep a SharedResource of the Import's name next to it: owned by
+// the Import, reading the source through spec.source.namespace and writing
+// one target into the Import's namespace.
+//
+// That SharedResource is synced like any other. Its source needs consent
+// from the source namespace; an admitting Export is that consent, next to
+// AnnotationAllowExport (see sourcenamespace.go), but only for a
+// SharedResource controlled by an Import and only while the Export admits
+// every namespace it writes to. When the Export is deleted
+// or stops admitting the namespace the SharedResource is deleted, and its
+// deletionPolicy "delete" removes the copy. The Import mirrors the
+// SharedResource's Ready condition, so the consuming team need not read it.
+// =============================================================================
+
+// SharedResourceImportReconciler keeps a SharedResource for every
+// SharedResourceImport its Export admits.
+type SharedResourceImportReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder emits events on SharedResourceImports. Nil disables events.
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourceimports,verbs=get;list;watch
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourceimports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourceexports,verbs=get;list;watch
+
+func (r *SharedResourceImportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var imp platformv1alpha1.SharedResourceImport
+	if err := r.Get(ctx, req.NamespacedName, &imp); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// The SharedResource is owned by the Import, so garbage collection removes it
+	if !imp.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	export, reason, message, err := r.admittingExport(ctx, &imp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if export == nil {
+		if err := r.deleteImported(ctx, &imp, message); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.updateImportStatus(ctx, &imp, "", metav1.ConditionFalse, reason, message)
+	}
+
+	sr := &platformv1alpha1.SharedResource{ObjectMeta: metav1.ObjectMeta{Namespace: imp.Namespace, Name: imp.Name}}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(sr), sr); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	} else if err == nil && !metav1.IsControlledBy(sr, &imp) {
+		message := fmt.Sprintf("SharedResource %s/%s already exists and is not owned by this Import", sr.Namespace, sr.Name)
+		return ctrl.Result{}, r.updateImportStatus(ctx, &imp, "", metav1.ConditionFalse, "Conflict", message)
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, sr, func() error {
+		sr.Spec = platformv1alpha1.SharedResourceSpec{
+			Source: platformv1alpha1.SourceSpec{
+				Kind:      export.Spec.Source.Kind,
+				Name:      export.Spec.Source.Name,
+				Namespace: export.Namespace,
+			},
+			Targets:        []platformv1alpha1.TargetSpec{{Namespace: imp.Namespace, Name: imp.Spec.TargetName}},
+			DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+		}
+		return controllerutil.SetControllerReference(&imp, sr, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to apply imported SharedResource")
+		return ctrl.Result{}, err
+	}
+	if op == controllerutil.OperationResultCreated {
+		log.Info("Import admitted, created SharedResource", "export", export.Namespace+"/"+export.Name)
+		r.recordEvent(&imp, corev1.EventTypeNormal, "Imported", "Importing %s %s/%s through SharedResource %s",
+			export.Spec.Source.Kind, export.Namespace, export.Spec.Source.Name, sr.Name)
+	}
+
+	// The SharedResource is synced by the SharedResource reconciler; report its state
+	ready := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeReady)
+	if ready == nil || sr.Status.ObservedGeneration != sr.Generation {
+		return ctrl.Result{}, r.updateImportStatus(ctx, &imp, sr.Name, metav1.ConditionUnknown, "Pending",
+			"Waiting for SharedResource "+sr.Name+" to sync")
+	}
+	return ctrl.Result{}, r.updateImportStatus(ctx, &imp, sr.Name, ready.Status, ready.Reason, ready.Message)
+}
+
+// admittingExport returns the Export the Import names if it admits the
+// Import's namespace, or else the reason and message why not.
+func (r *SharedResourceImportReconciler) admittingExport(
+	ctx context.Context,
+	imp *platformv1alpha1.SharedResourceImport,
+) (*platformv1alpha1.SharedResourceExport, string, string, error) {
+	ref := imp.Spec.Export
+	var export platformv1alpha1.SharedResourceExport
+	if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &export); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "ExportNotFound", fmt.Sprintf("SharedResourceExport %s/%s not found", ref.Namespace, ref.Name), nil
+		}
+		return nil, "", "", err
+	}
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: imp.Namespace}, &ns); err != nil {
+		return nil, "", "", err
+	}
+	if !exportAdmits(&export, ns.Name, ns.Labels) {
+		return nil, "NotAdmitted", fmt.Sprintf("SharedResourceExport %s/%s does not admit namespace %s",
+			ref.Namespace, ref.Name, imp.Namespace), nil
+	}
+	return &export, "", "", nil
+}
+
+// deleteImported deletes the Import's SharedResource, if it owns one, once
+// the Export no longer admits it.
+func (r *SharedResourceImportReconciler) deleteImported(
+	ctx context.Context,
+	imp *platformv1alpha1.SharedResourceImport,
+	message string,
+) error {
+	sr := &platformv1alpha1.SharedResource{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: imp.Namespace, Name: imp.Name}, sr); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(sr, imp) || !sr.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	logf.FromContext(ctx).Info("Import no longer admitted, deleting SharedResource", "sharedresource", sr.Name)
+	if err := r.Delete(ctx, sr); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	r.recordEvent(imp, corev1.EventTypeWarning, "ImportRevoked", "%s; deleted SharedResource %s", message, sr.Name)
+	return nil
+}
+
+// updateImportStatus sets the Import's Ready condition and SharedResource,
+// writing the status only if it changed.
+func (r *SharedResourceImportReconciler) updateImportStatus(
+	ctx context.Context,
+	imp *platformv1alpha1.SharedResourceImport,
+	sharedResource string,
+	status metav1.ConditionStatus,
+	reason, message string,
+) error {
+	before := imp.Status.DeepCopy()
+	meta.SetStatusCondition(&imp.Status.Conditions, metav1.Condition{
+		Type:               ConditionTypeReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: imp.Generation,
+	})
+	imp.Status.SharedResource = sharedResource
+	imp.Status.ObservedGeneration = imp.Generation
+	if equality.Semantic.DeepEqual(before, &imp.Status) {
+		return nil
+	}
+	return r.Status().Update(ctx, imp)
+}
+
+// recordEvent emits an event on the Import if a recorder is set.
+func (r *SharedResourceImportReconciler) recordEvent(imp *platformv1alpha1.SharedResourceImport, eventType, reason, messageFmt string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(imp, eventType, reason, messageFmt, args...)
+}
+
+// findImportsForExport returns reconcile requests for the Imports naming the Export.
+func (r *SharedResourceImportReconciler) findImportsForExport(ctx context.Context, obj client.Object) []ctrl.Request {
+	var imports platformv1alpha1.SharedResourceImportList
+	if err := r.List(ctx, &imports); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResourceImports")
+		return nil
+	}
+	var requests []ctrl.Request
+	for _, imp := range imports.Items {
+		if imp.Spec.Export.Namespace == obj.GetNamespace() && imp.Spec.Export.Name == obj.GetName() {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&imp)})
+		}
+	}
+	return requests
+}
+
+// findImportsForNamespace returns reconcile requests for the Imports in the
+// namespace, whose labels may decide whether an Export admits them.
+func (r *SharedResourceImportReconciler) findImportsForNamespace(ctx context.Context, obj client.Object) []ctrl.Request {
+	var imports platformv1alpha1.SharedResourceImportList
+	if err := r.List(ctx, &imports, client.InNamespace(obj.GetName())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResourceImports")
+		return nil
+	}
+	requests := make([]ctrl.Request, 0, len(imports.Items))
+	for _, imp := range imports.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&imp)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the Import controller with the Manager.
+func (r *SharedResourceImportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&platformv1alpha1.SharedResourceImport{}).
+		// Mirror the SharedResource's Ready condition as it syncs
+		Owns(&platformv1alpha1.SharedResource{}).
+		Watches(
+			&platformv1alpha1.SharedResourceExport{},
+			handler.EnqueueRequestsFromMapFunc(r.findImportsForExport),
+		).
+		// Relabelling a namespace may admit or revoke its Imports
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findImportsForNamespace),
+			builder.WithPredicates(namespaceChanged()),
+		).
+		Named("sharedresourceimport").
+		Complete(r)
+}
+
+// exportAdmits returns true if the Export lets the namespace, with its
+// labels, import its source. An invalid selector admits no namespace.
+func exportAdmits(export *platformv1alpha1.SharedResourceExport, namespace string, nsLabels map[string]string) bool {
+	if slices.Contains(export.Spec.Namespaces, namespace) {
+		return true
+	}
+	if export.Spec.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(export.Spec.NamespaceSelector)
+	return err == nil && selector.Matches(labels.Set(nsLabels))
+}
+
+// sourceExported returns true if a SharedResourceExport in the source
+// namespace offers the CR's source to the CR's namespace and to every
+// namespace the CR writes to. Only a SharedResource kept by an Import may
+// use an Export as consent: any other CR in an admitted namespace could
+// otherwise target namespaces the Export never admitted.
+func (r *SharedResourceReconciler) sourceExported(ctx context.Context, sr *platformv1alpha1.SharedResource) (bool, error) {
+	if !importedSharedResource(sr) {
+		return false, nil
+	}
+	var exports platformv1alpha1.SharedResourceExportList
+	if err := r.List(ctx, &exports, client.InNamespace(sourceNamespace(sr))); err != nil {
+		return false, err
+	}
+	var namespaces []*corev1.Namespace
+	for i := range exports.Items {
+		export := &exports.Items[i]
+		if export.Spec.Source.Kind != sr.Spec.Source.Kind || export.Spec.Source.Name != sourceName(sr) {
+			continue
+		}
+		if namespaces == nil {
+			var err error
+			if namespaces, err = r.writtenNamespaces(ctx, sr); err != nil || namespaces == nil {
+				return false, err
+			}
+		}
+		if slices.ContainsFunc(namespaces, func(ns *corev1.Namespace) bool {
+			return !exportAdmits(export, ns.Name, ns.Labels)
+		}) {
+			continue
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// importedSharedResource returns true if a SharedResourceImport controls the CR.
+func importedSharedResource(sr *platformv1alpha1.SharedResource) bool {
+	owner := metav1.GetControllerOf(sr)
+	return owner != nil && owner.Kind == "SharedResourceImport" &&
+		owner.APIVersion == platformv1alpha1.GroupVersion.String()
+}
+
+// writtenNamespaces returns the CR's namespace and those of its resolved
+// targets, or nil if one does not exist or the targets cannot be resolved.
+func (r *SharedResourceReconciler) writtenNamespaces(ctx context.Context, sr *platformv1alpha1.SharedResource) ([]*corev1.Namespace, error) {
+	targets, err := r.resolveTargets(ctx, sr)
+	if errors.Is(err, errGroupUnavailable) || errors.Is(err, errInvalidNamespacePattern) ||
+		errors.Is(err, errInvalidTargetSelector) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := []string{sr.Namespace}
+	for _, target := range targets {
+		if !slices.Contains(names, target.Namespace) {
+			names = append(names, target.Namespace)
+		}
+	}
+	namespaces := make([]*corev1.Namespace, 0, len(names))
+	for _, name := range names {
+		ns := &corev1.Namespace{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces, nil
+}
+
+// findSharedResourcesForExport returns reconcile requests for the
+// SharedResources reading the Export's source from another namespace.
+func (r *SharedResourceReconciler) findSharedResourcesForExport(ctx context.Context, obj client.Object) []ctrl.Request {
+	export, ok := obj.(*platformv1alpha1.SharedResourceExport)
+	if !ok {
+		return nil
+	}
+	var exporting platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &exporting, client.MatchingFields{sourceNamespaceIndex: export.Namespace}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources")
+		return nil
+	}
+	var requests []ctrl.Request
+	for _, sr := range exporting.Items {
+		if sr.Spec.Source.Kind == export.Spec.Source.Kind && sourceName(&sr) == export.Spec.Source.Name {
+			key := client.ObjectKey{Namespace: sr.Namespace, Name: sr.Name}
+			r.verified.invalidate(key)
+			requests = append(requests, ctrl.Request{NamespacedName: key})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Fan-in: several sources merged into each target.
+//
+// spec.sources lists further Secrets or ConfigMaps, of spec.source.kind and in
+// the CR's namespace. They are always read from there, never from another
+// namespace spec.source may name, so no export consent is needed for them
+// (admission rejects sources with a spec.source.namespace besides). Their
+// keys are merged in order on top of spec.source's:
+//   - where sources share a key, the later one wins, so spec.source is the
+//     base and the last entry of spec.sources has the final say
+//   - each source's sharing annotations restrict its own keys only
+//   - a missing source stops the sync with SourceNotFound naming it, unless
+//     its entry is optional; an optional one merges nothing until it exists
+//
+// The merged data is filtered and checksummed like a single source's, so a
+// change to any of the sources resyncs the targets. The secret type, UID and
+// chain position written to targets are spec.source's.
+// =============================================================================
+
+// mergeSources merges the sources of spec.sources into data, read from
+// spec.source.
+func (r *SharedResourceReconciler) mergeSources(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	data map[string][]byte,
+	source sourceMeta,
+) (map[string][]byte, sourceMeta, error) {
+	merged := maps.Clone(data)
+	withheld := slices.Clone(source.Withheld)
+	for _, overlay := range sr.Spec.Sources {
+		// Always the CR's own namespace: export consent covers spec.source only
+		key := types.NamespacedName{Namespace: sr.Namespace, Name: overlay.Name}
+		overlayData, overlaySource, err := r.readSource(ctx, sr, key)
+		if apierrors.IsNotFound(err) && overlay.Optional {
+			continue
+		}
+		if err != nil {
+			return nil, sourceMeta{}, err
+		}
+		maps.Copy(merged, overlayData)
+		withheld = append(withheld, overlaySource.Withheld...)
+	}
+
+	// A key one source withholds may still be shared by another
+	withheld = slices.DeleteFunc(withheld, func(key string) bool {
+		_, shared := merged[key]
+		return shared
+	})
+	slices.Sort(withheld)
+	source.Withheld = slices.Compact(withheld)
+	return merged, source, nil
+}
+
+// mergesSource returns true if spec.sources names the source.
+func mergesSource(sr *platformv1alpha1.SharedResource, name string) bool {
+	return slices.ContainsFunc(sr.Spec.Sources, func(overlay platformv1alpha1.SourceOverlay) bool {
+		return overlay.Name == name
+	})
+}
+
+// missingSourceName returns the name of the source a NotFound error is
+// about, which may be one of spec.sources.
+func missingSourceName(sr *platformv1alpha1.SharedResource, err error) string {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		if details := status.Status().Details; details != nil && details.Name != "" {
+			return details.Name
+		}
+	}
+	return sourceName(sr)
+}
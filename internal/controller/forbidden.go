@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Retrying forbidden targets.
+//
+// A target failing with 403 Forbidden usually waits for someone to grant the
+// operator, or the namespace's target identity, access. That grant is not an
+// event the operator sees, so such targets are marked accessDenied and
+// retried on a backoff of their own, ForbiddenRetryBaseInterval doubling with
+// every consecutive failure up to ForbiddenRetryMaxInterval, rather than at
+// the next resync.
+//
+// With --watch-rolebindings a RoleBinding change in a namespace also retries
+// the CRs with an accessDenied target there at once, and a ClusterRoleBinding
+// change, which may grant access anywhere, those with one in any namespace.
+// =============================================================================
+
+// forbiddenRetryAfter returns when to retry the CR's accessDenied targets:
+// the shortest backoff among them, or zero if there are none.
+func forbiddenRetryAfter(targets []platformv1alpha1.TargetSyncStatus) time.Duration {
+	var after time.Duration
+	for _, target := range targets {
+		if !target.AccessDenied {
+			continue
+		}
+		interval := ForbiddenRetryBaseInterval
+		if n := len(target.RecentErrors); n > 0 {
+			for i := int32(1); i < target.RecentErrors[n-1].Count && interval < ForbiddenRetryMaxInterval; i++ {
+				interval *= 2
+			}
+		}
+		after = sooner(after, min(interval, ForbiddenRetryMaxInterval))
+	}
+	return after
+}
+
+// findSharedResourcesForRoleBinding returns reconcile requests for the
+// SharedResources with an accessDenied target in the RoleBinding's namespace.
+func (r *SharedResourceReconciler) findSharedResourcesForRoleBinding(ctx context.Context, obj client.Object) []ctrl.Request {
+	return r.findSharedResourcesDeniedIn(ctx, obj.GetNamespace())
+}
+
+// findSharedResourcesForClusterRoleBinding returns reconcile requests for the
+// SharedResources with an accessDenied target in any namespace.
+func (r *SharedResourceReconciler) findSharedResourcesForClusterRoleBinding(ctx context.Context, _ client.Object) []ctrl.Request {
+	return r.findSharedResourcesDeniedIn(ctx, "")
+}
+
+// findSharedResourcesDeniedIn returns reconcile requests for the
+// SharedResources with an accessDenied target in the namespace, or in any
+// namespace if it is empty.
+func (r *SharedResourceReconciler) findSharedResourcesDeniedIn(ctx context.Context, namespace string) []ctrl.Request {
+	var sharedResourceList platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &sharedResourceList); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources")
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, sr := range sharedResourceList.Items {
+		for _, target := range sr.Status.SyncedTargets {
+			if target.AccessDenied && (namespace == "" || target.Namespace == namespace) {
+				key := client.ObjectKey{Namespace: sr.Namespace, Name: sr.Name}
+				r.verified.invalidate(key)
+				requests = append(requests, ctrl.Request{NamespacedName: key})
+				break
+			}
+		}
+	}
+	return requests
+}
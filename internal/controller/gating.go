@@ -0,0 +1,164 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Reconcile gating - skipping no-op reconciles.
+//
+// Many events that reach Reconcile carry no new information: status writes
+// made by the controller itself, label edits on the CR, resyncs of the
+// informer cache. Iterating every target for those is wasted work (and for
+// large fan-outs, expensive).
+//
+// A reconcile is skipped when ALL of the following hold:
+//   - metadata.generation == status.observedGeneration (spec unchanged)
+//   - the source checksum matches status.sourceChecksum (source unchanged)
+//   - no sync-now request is pending
+//   - the CR was fully reconciled by this process and no source/target
+//     event has invalidated it since (see verificationTracker)
+//   - the next scheduled retry/resync hasn't arrived yet
+//
+// The "verified" set is in-memory only, so every CR gets one full reconcile
+// after the operator starts.
+// =============================================================================
+
+// verificationTracker records which CRs are verified in-sync.
+//
+// Each invalidation bumps a per-CR epoch. A reconcile captures the epoch when
+// it starts and only marks the CR verified if no invalidation happened while it
+// ran - otherwise an event arriving mid-reconcile could be lost.
+type verificationTracker struct {
+	mu       sync.Mutex
+	epochs   map[types.NamespacedName]uint64
+	verified map[types.NamespacedName]uint64
+}
+
+// begin returns the current epoch for the CR; pass it to markVerified.
+func (t *verificationTracker) begin(key types.NamespacedName) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.epochs[key]
+}
+
+// markVerified records that the CR was fully reconciled at the given epoch.
+func (t *verificationTracker) markVerified(key types.NamespacedName, epoch uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.verified == nil {
+		t.verified = make(map[types.NamespacedName]uint64)
+	}
+	t.verified[key] = epoch
+}
+
+// invalidate forces the next reconcile of the CR to run in full.
+// Called by watch mappings when a source or target of the CR changes.
+func (t *verificationTracker) invalidate(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.epochs == nil {
+		t.epochs = make(map[types.NamespacedName]uint64)
+	}
+	t.epochs[key]++
+}
+
+// isVerified returns true if the CR was verified and not invalidated since.
+func (t *verificationTracker) isVerified(key types.NamespacedName) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	epoch, ok := t.verified[key]
+	return ok && epoch == t.epochs[key]
+}
+
+// forget drops all tracking for a deleted CR.
+func (t *verificationTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.epochs, key)
+	delete(t.verified, key)
+}
+
+// skipReconcile decides whether a reconcile can be short-circuited.
+//
+// checksum is the freshly computed source checksum; sourceFound is false when
+// the source could not be fetched; resync is the CR's periodic resync interval.
+// Returns the delay until the next scheduled sync when the reconcile should be skipped.
+func (r *SharedResourceReconciler) skipReconcile(
+	sr *platformv1alpha1.SharedResource,
+	checksum string,
+	sourceFound bool,
+	resync time.Duration,
+) (bool, time.Duration) {
+	key := types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}
+	if !r.verified.isVerified(key) {
+		return false, 0
+	}
+	if sr.Generation != sr.Status.ObservedGeneration {
+		return false, 0
+	}
+	if syncRequestPending(sr) || explainRequested(sr) || verificationRunning(sr) {
+		return false, 0
+	}
+
+	// Source state must match what status already reports
+	if sourceFound {
+		if !syncengine.ChecksumEqual(checksum, sr.Status.SourceChecksum) || !conditionIsTrue(sr, ConditionTypeSourceFound) {
+			return false, 0
+		}
+	} else if conditionIsTrue(sr, ConditionTypeSourceFound) {
+		return false, 0
+	}
+
+	// Never delay a scheduled retry or periodic resync
+	next := nextScheduledSync(sr, resync)
+	if next.IsZero() {
+		return false, 0
+	}
+	remaining := next.Sub(r.now())
+	if remaining <= 0 {
+		return false, 0
+	}
+	return true, remaining
+}
+
+// nextScheduledSync returns when the controller planned to sync the CR next:
+// the pending retry time after a failure, otherwise the periodic resync.
+func nextScheduledSync(sr *platformv1alpha1.SharedResource, resync time.Duration) time.Time {
+	if sr.Status.NextRetryTime != nil {
+		return sr.Status.NextRetryTime.Time
+	}
+	if sr.Status.LastSyncTime != nil {
+		return sr.Status.LastSyncTime.Add(resync)
+	}
+	return time.Time{}
+}
+
+// syncRequestPending returns true if the sync-now annotation holds a value
+// the controller hasn't acted on yet.
+func syncRequestPending(sr *platformv1alpha1.SharedResource) bool {
+	v, ok := sr.Annotations[AnnotationSyncNow]
+	return ok && v != sr.Status.LastHandledSyncRequest
+}
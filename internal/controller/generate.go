@@ -0,0 +1,287 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Generated source values (spec.generate).
+//
+// Generation writes to the source Secret, before it is fetched for syncing,
+// so generated and rotated values reach targets through the normal source
+// path. When each key was generated is stored on the source itself
+// (AnnotationGenerated), which keeps rotation correct across restarts and
+// leader changes and lets a human see when a value was last rotated.
+//
+// twoPhase rotation avoids a torn rotation across namespaces:
+//  1. Stage: the new value is written under <key>.v<N>, next to the current one
+//  2. Wait: every target carrying <key> must also hold <key>.v<N>
+//  3. Flip: <key> takes the new value and <key>.v<N> is removed
+//
+// Versions and staged rotations are tracked in AnnotationRotation.
+// =============================================================================
+
+// DefaultGenerateLength is used when spec.generate[].length is not set.
+const DefaultGenerateLength = 32
+
+// generatedRecord maps a key to when it was last generated.
+type generatedRecord map[string]time.Time
+
+// readGeneratedRecord parses AnnotationGenerated; a missing or corrupt value yields an empty record.
+func readGeneratedRecord(annotations map[string]string) generatedRecord {
+	record := generatedRecord{}
+	if v, ok := annotations[AnnotationGenerated]; ok {
+		_ = json.Unmarshal([]byte(v), &record)
+	}
+	return record
+}
+
+// rotationState tracks a twoPhase key: the version of the primary value and
+// the version currently staged, if any.
+type rotationState struct {
+	Version int `json:"version"`
+	Staged  int `json:"staged,omitempty"`
+}
+
+// readRotationStates parses AnnotationRotation; a missing or corrupt value yields no states.
+func readRotationStates(annotations map[string]string) map[string]rotationState {
+	states := map[string]rotationState{}
+	if v, ok := annotations[AnnotationRotation]; ok {
+		_ = json.Unmarshal([]byte(v), &states)
+	}
+	return states
+}
+
+// stagedKey returns the versioned key a twoPhase rotation stages its value under.
+func stagedKey(key string, version int) string {
+	return fmt.Sprintf("%s.v%d", key, version)
+}
+
+// ensureGenerated creates missing spec.generate keys in the source Secret and
+// rotates those whose rotationPeriod has elapsed. Returns the time until the
+// next rotation is due, or 0 if none is scheduled.
+func (r *SharedResourceReconciler) ensureGenerated(ctx context.Context, sr *platformv1alpha1.SharedResource) (time.Duration, error) {
+	if len(sr.Spec.Generate) == 0 {
+		return 0, nil
+	}
+	log := logf.FromContext(ctx)
+
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: sr.Namespace, Name: sourceName(sr)}
+	err := r.Get(ctx, key, &secret)
+	create := apierrors.IsNotFound(err)
+	if err != nil && !create {
+		return 0, err
+	}
+	if create {
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+		}
+	}
+
+	record := readGeneratedRecord(secret.Annotations)
+	states := readRotationStates(secret.Annotations)
+	now := r.now()
+	var next time.Duration
+	var generated, staged, rotated []string
+	for _, g := range sr.Spec.Generate {
+		at, tracked := record[g.Key]
+		_, present := secret.Data[g.Key]
+		if present && !tracked {
+			continue // Provided by the owner; never overwritten
+		}
+
+		// A staged rotation is finished even if the strategy changed meanwhile
+		if state := states[g.Key]; present && state.Staged > 0 {
+			staging := stagedKey(g.Key, state.Staged)
+			ready, err := r.stagedKeyPropagated(ctx, sr, g.Key, staging, secret.Data[staging])
+			if err != nil {
+				return 0, err
+			}
+			if !ready {
+				next = sooner(next, RotationCheckInterval)
+				continue
+			}
+			secret.Data[g.Key] = secret.Data[staging]
+			delete(secret.Data, staging)
+			record[g.Key] = now
+			states[g.Key] = rotationState{Version: state.Staged}
+			rotated = append(rotated, g.Key)
+			if g.RotationPeriod != nil {
+				next = sooner(next, g.RotationPeriod.Duration)
+			}
+			continue
+		}
+
+		if present && g.RotationPeriod == nil {
+			continue
+		}
+		if present {
+			if due := at.Add(g.RotationPeriod.Duration); now.Before(due) {
+				next = sooner(next, due.Sub(now))
+				continue
+			}
+		}
+
+		length := int(g.Length)
+		if length == 0 {
+			length = DefaultGenerateLength
+		}
+		value, err := syncengine.GenerateValue(length, g.Charset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate key %q: %w", g.Key, err)
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		twoPhase := g.RotationStrategy == platformv1alpha1.RotationStrategyTwoPhase
+		if present && twoPhase {
+			state := states[g.Key]
+			state.Staged = max(state.Version, 1) + 1
+			staging := stagedKey(g.Key, state.Staged)
+			secret.Data[staging] = []byte(value)
+			if err := stagedKeyShared(sr, &secret, g.Key, staging); err != nil {
+				return 0, err
+			}
+			states[g.Key] = state
+			staged = append(staged, staging)
+			next = sooner(next, RotationCheckInterval)
+			continue
+		}
+		secret.Data[g.Key] = []byte(value)
+		record[g.Key] = now
+		if twoPhase {
+			states[g.Key] = rotationState{Version: 1}
+		}
+		if present {
+			rotated = append(rotated, g.Key)
+		} else {
+			generated = append(generated, g.Key)
+		}
+		if g.RotationPeriod != nil {
+			next = sooner(next, g.RotationPeriod.Duration)
+		}
+	}
+	if len(generated) == 0 && len(staged) == 0 && len(rotated) == 0 {
+		return next, nil
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return 0, err
+	}
+	secret.Annotations[AnnotationGenerated] = string(encoded)
+	if len(states) > 0 {
+		if encoded, err = json.Marshal(states); err != nil {
+			return 0, err
+		}
+		secret.Annotations[AnnotationRotation] = string(encoded)
+	}
+
+	if create {
+		log.Info("Creating source Secret with generated keys", "name", key.Name, "keys", generated)
+		err = r.Create(ctx, &secret)
+	} else {
+		log.Info("Writing generated keys to source Secret", "name", key.Name,
+			"generated", generated, "staged", staged, "rotated", rotated)
+		err = r.Update(ctx, &secret)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to write generated keys to Secret %s: %w", key.Name, err)
+	}
+	if len(generated) > 0 {
+		r.recordEvent(sr, corev1.EventTypeNormal, "KeysGenerated", "Generated %s in Secret %s", strings.Join(generated, ", "), key.Name)
+	}
+	if len(staged) > 0 {
+		r.recordEvent(sr, corev1.EventTypeNormal, "KeyRotationStaged",
+			"Staged %s in Secret %s; waiting for all targets", strings.Join(staged, ", "), key.Name)
+	}
+	if len(rotated) > 0 {
+		r.recordEvent(sr, corev1.EventTypeNormal, "KeysRotated", "Rotated %s in Secret %s", strings.Join(rotated, ", "), key.Name)
+	}
+	return next, nil
+}
+
+// stagedKeyShared fails a twoPhase rotation whose staged key would be filtered
+// out while the primary key is shared, since it could never propagate.
+func stagedKeyShared(sr *platformv1alpha1.SharedResource, secret *corev1.Secret, primary, staging string) error {
+	shared := syncengine.Filter(restrictToSharedKeys(secret.Data, secret.Annotations), sr.Spec.SyncPolicy)
+	if _, ok := shared[primary]; !ok {
+		return nil
+	}
+	if _, ok := shared[staging]; !ok {
+		return fmt.Errorf("key %q is shared but its staged rotation key %q is filtered out; allow it to use twoPhase rotation", primary, staging)
+	}
+	return nil
+}
+
+// stagedKeyPropagated returns true once every target that carries the primary
+// key also holds the staged value. Targets that do not receive the primary key
+// (e.g. filtered by namespaceRules) are not waited for.
+func (r *SharedResourceReconciler) stagedKeyPropagated(ctx context.Context, sr *platformv1alpha1.SharedResource, primary, staging string, value []byte) (bool, error) {
+	targets, err := r.resolveTargets(ctx, sr)
+	if err != nil {
+		return false, err
+	}
+	for _, target := range targets {
+		name := target.Name
+		if name == "" {
+			name = sourceName(sr)
+		}
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: target.Namespace, Name: name}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if _, ok := secret.Data[primary]; !ok {
+			continue
+		}
+		if !syncengine.ValueEqual(secret.Data[staging], value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// sooner returns the shorter of two delays, treating 0 as unset.
+func sooner(a, b time.Duration) time.Duration {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
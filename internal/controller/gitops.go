@@ -0,0 +1,185 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// GitOps-managed targets - not fighting Argo CD or Flux.
+//
+// A target that is also in a GitOps tool's desired state would be reverted by
+// both sides forever. Such targets are detected from the tool's tracking
+// labels/annotations and field managers, and spec.externallyManaged decides:
+//   - backOff (default): the target is not written and reported failed
+//   - takeOwnership: the operator's fields are written with server-side apply
+//     and ForceOwnership, so the tool reports the conflict instead
+//
+// The ExternallyManaged condition lists such targets in both modes.
+// =============================================================================
+
+const (
+	// gitOpsArgoCD and gitOpsFlux name the detected tools in status
+	gitOpsArgoCD = "Argo CD"
+	gitOpsFlux   = "Flux"
+
+	// maxExternallyManagedTargets caps how many targets the condition names
+	maxExternallyManagedTargets = 10
+)
+
+// gitOpsMarkers maps tracking labels and annotations to the tool that sets them.
+var gitOpsMarkers = map[string]string{
+	"argocd.argoproj.io/tracking-id":   gitOpsArgoCD,
+	"argocd.argoproj.io/instance":      gitOpsArgoCD,
+	"kustomize.toolkit.fluxcd.io/name": gitOpsFlux,
+	"helm.toolkit.fluxcd.io/name":      gitOpsFlux,
+}
+
+// gitOpsManagers maps field managers to the tool that uses them.
+var gitOpsManagers = map[string]string{
+	"argocd-controller":             gitOpsArgoCD,
+	"argocd-application-controller": gitOpsArgoCD,
+	"kustomize-controller":          gitOpsFlux,
+	"helm-controller":               gitOpsFlux,
+}
+
+// gitOpsTool returns the GitOps tool that also manages obj, or "" if none.
+func gitOpsTool(obj client.Object) string {
+	for _, m := range [...]map[string]string{obj.GetLabels(), obj.GetAnnotations()} {
+		for key := range m {
+			if tool, ok := gitOpsMarkers[key]; ok {
+				return tool
+			}
+		}
+	}
+	for _, mf := range obj.GetManagedFields() {
+		if tool, ok := gitOpsManagers[mf.Manager]; ok {
+			return tool
+		}
+	}
+	return ""
+}
+
+// externalManager returns the GitOps tool that also manages the target, or ""
+// if there is none or the target does not exist yet.
+func (r *SharedResourceReconciler) externalManager(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	namespace, name string,
+) (string, error) {
+	obj, err := newTargetObject(targetKind(sr))
+	if err != nil {
+		return "", err
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	return gitOpsTool(obj), nil
+}
+
+// takesOwnership returns true if externally managed targets are force-applied.
+func takesOwnership(sr *platformv1alpha1.SharedResource) bool {
+	return sr.Spec.ExternallyManaged == platformv1alpha1.ExternalManagementTakeOwnership
+}
+
+// applySecret writes the operator's fields of a Secret with server-side apply,
+// forcing ownership away from other managers.
+func (r *SharedResourceReconciler) applySecret(
+	ctx context.Context,
+	key types.NamespacedName,
+	data map[string][]byte,
+	secretType corev1.SecretType,
+	labels, annotations map[string]string,
+	immutable *bool,
+	finalizer bool,
+) error {
+	ac := corev1ac.Secret(key.Name, key.Namespace).
+		WithLabels(labels).
+		WithAnnotations(annotations).
+		WithType(secretType).
+		WithData(data)
+	if immutable != nil {
+		ac.WithImmutable(*immutable)
+	}
+	if finalizer {
+		ac.WithFinalizers(TargetFinalizerName)
+	}
+	return r.Apply(ctx, ac, client.ForceOwnership, client.FieldOwner(r.fieldManager()))
+}
+
+// applyConfigMap is applySecret for ConfigMaps.
+func (r *SharedResourceReconciler) applyConfigMap(
+	ctx context.Context,
+	key types.NamespacedName,
+	data map[string]string,
+	labels, annotations map[string]string,
+	immutable *bool,
+	finalizer bool,
+) error {
+	ac := corev1ac.ConfigMap(key.Name, key.Namespace).
+		WithLabels(labels).
+		WithAnnotations(annotations).
+		WithData(data)
+	if immutable != nil {
+		ac.WithImmutable(*immutable)
+	}
+	if finalizer {
+		ac.WithFinalizers(TargetFinalizerName)
+	}
+	return r.Apply(ctx, ac, client.ForceOwnership, client.FieldOwner(r.fieldManager()))
+}
+
+// applyExternallyManagedCondition records targets also managed by a GitOps
+// tool. The condition is only present while there are any.
+func applyExternallyManagedCondition(sr *platformv1alpha1.SharedResource, targets []platformv1alpha1.TargetSyncStatus) {
+	var names []string
+	for _, t := range targets {
+		if t.ExternallyManagedBy != "" {
+			names = append(names, fmt.Sprintf("%s/%s (%s)", t.Namespace, t.Name, t.ExternallyManagedBy))
+		}
+	}
+	if len(names) == 0 {
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeExternallyManaged)
+		return
+	}
+	sort.Strings(names)
+	list := strings.Join(names, ", ")
+	if len(names) > maxExternallyManagedTargets {
+		list = fmt.Sprintf("%s and %d more", strings.Join(names[:maxExternallyManagedTargets], ", "),
+			len(names)-maxExternallyManagedTargets)
+	}
+	if takesOwnership(sr) {
+		setCondition(sr, ConditionTypeExternallyManaged, metav1.ConditionTrue, "OwnershipTaken",
+			"Force-applied targets also managed by a GitOps tool: "+list)
+		return
+	}
+	setCondition(sr, ConditionTypeExternallyManaged, metav1.ConditionTrue, "BackedOff",
+		"Not writing targets also managed by a GitOps tool: "+list)
+}
@@ -17,13 +17,17 @@ limitations under the License.
 package controller
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
 )
 
 // =============================================================================
@@ -33,72 +37,73 @@ import (
 // Kubernetes API but provide supporting logic for the reconciler.
 // =============================================================================
 
-// computeChecksum generates a SHA256 hash of the data for drift detection.
+// restrictToSharedKeys applies the source owner's key policy, read from
+// annotations on the source resource itself.
 //
-// Why checksums?
-// - Avoids unnecessary updates when data hasn't changed
-// - Keys are sorted for deterministic hashes regardless of map iteration order
-// - Stored as annotation on target resources for comparison
-func computeChecksum(data map[string][]byte) string {
-	// Sort keys for deterministic ordering
-	keys := make([]string, 0, len(data))
-	for k := range data {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
+// This runs before the CR's syncPolicy, so no SharedResource can share a key
+// the owner has not allowed:
+// - share-keys: only these keys may leave the namespace (present but empty = none)
+// - exclude-keys: these keys never leave the namespace
+func restrictToSharedKeys(data map[string][]byte, annotations map[string]string) map[string][]byte {
+	allowed, restricted := annotations[AnnotationShareKeys]
+	return syncengine.Restrict(data, syncengine.Restriction{
+		Allowed:    splitKeyList(allowed),
+		AllowedSet: restricted,
+		Excluded:   splitKeyList(annotations[AnnotationExcludeKeys]),
+	})
+}
 
-	// Hash key-value pairs
-	h := sha256.New()
-	for _, k := range keys {
-		h.Write([]byte(k))
-		h.Write([]byte("="))
-		h.Write(data[k])
-		h.Write([]byte("\n"))
+// withheldKeys returns the keys of data that restrictToSharedKeys removed, sorted.
+func withheldKeys(data, shared map[string][]byte) []string {
+	var keys []string
+	for key := range data {
+		if _, ok := shared[key]; !ok {
+			keys = append(keys, key)
+		}
 	}
-
-	return hex.EncodeToString(h.Sum(nil))
+	sort.Strings(keys)
+	return keys
 }
 
-// filterData applies the SyncPolicy to filter which keys to sync.
-//
-// Filtering modes:
-// - "copy" (default): All keys are synced
-// - "selective": Only keys matching Include/Exclude rules are synced
-func filterData(data map[string][]byte, policy *platformv1alpha1.SyncPolicySpec) map[string][]byte {
-	// If no policy or copy mode, return all data
-	if policy == nil || policy.Mode == "" || policy.Mode == platformv1alpha1.SyncModeCopy {
-		return data
+// requestedWithheldKeys returns the withheld keys the CR explicitly asks for
+// in syncPolicy.keys.include, syncPolicy.profile or a namespace rule's include
+// list. Those are dropped all the same; this only tells the CR author why.
+func requestedWithheldKeys(sr *platformv1alpha1.SharedResource, withheld []string) []string {
+	if len(withheld) == 0 || sr.Spec.SyncPolicy == nil {
+		return nil
 	}
-
-	// Selective mode - apply key filtering
-	if policy.Keys == nil {
-		// Warning: selective mode without keys specification returns all data
-		// This is likely a user configuration error
-		return data
+	requested := map[string]bool{}
+	for _, key := range syncengine.ProfileKeys(sr.Spec.SyncPolicy.Profile) {
+		requested[key] = true
 	}
-
-	filtered := make(map[string][]byte)
-
-	// If Include is specified, only include those keys
-	if len(policy.Keys.Include) > 0 {
-		for _, key := range policy.Keys.Include {
-			if val, ok := data[key]; ok {
-				filtered[key] = val
-			}
+	if sr.Spec.SyncPolicy.Keys != nil {
+		for _, key := range sr.Spec.SyncPolicy.Keys.Include {
+			requested[key] = true
 		}
-	} else {
-		// No Include list means start with all keys
-		for k, v := range data {
-			filtered[k] = v
+	}
+	for _, rule := range sr.Spec.SyncPolicy.NamespaceRules {
+		for _, key := range rule.Keys.Include {
+			requested[key] = true
 		}
 	}
-
-	// Apply Exclude filter
-	for _, key := range policy.Keys.Exclude {
-		delete(filtered, key)
+	var keys []string
+	for _, key := range withheld {
+		if requested[key] {
+			keys = append(keys, key)
+		}
 	}
+	return keys
+}
 
-	return filtered
+// splitKeyList parses a comma-separated key list, ignoring blanks and whitespace.
+func splitKeyList(value string) []string {
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 // setCondition updates or adds a condition to the SharedResource status.
@@ -132,6 +137,272 @@ func setCondition(sr *platformv1alpha1.SharedResource, condType string, status m
 		}
 	}
 
-	// Condition doesn't exist, append it
+	// Condition doesn't exist, append it (kept sorted so status diffs are stable)
 	sr.Status.Conditions = append(sr.Status.Conditions, condition)
+	sort.Slice(sr.Status.Conditions, func(i, j int) bool {
+		return sr.Status.Conditions[i].Type < sr.Status.Conditions[j].Type
+	})
+}
+
+// conditionIsTrue returns true if the condition type is present with status True.
+func conditionIsTrue(sr *platformv1alpha1.SharedResource, condType string) bool {
+	for _, c := range sr.Status.Conditions {
+		if c.Type == condType {
+			return c.Status == metav1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// statusMode resolves the effective status reporting mode for a CR.
+//
+// Compact mode is used when the CR asks for it, or automatically when the
+// number of targets exceeds the operator-wide threshold (if configured).
+func statusMode(sr *platformv1alpha1.SharedResource, targetCount, compactThreshold int) platformv1alpha1.StatusMode {
+	if sr.Spec.StatusPolicy != nil && sr.Spec.StatusPolicy.Mode == platformv1alpha1.StatusModeCompact {
+		return platformv1alpha1.StatusModeCompact
+	}
+	if compactThreshold > 0 && targetCount > compactThreshold {
+		return platformv1alpha1.StatusModeCompact
+	}
+	return platformv1alpha1.StatusModeFull
+}
+
+// minReadyTargets returns how many of total targets must be synced for Ready
+// to be True. Percentages round up; unset (or more than total) requires all.
+func minReadyTargets(sr *platformv1alpha1.SharedResource, total int) int {
+	if sr.Spec.MinReadyTargets == nil {
+		return total
+	}
+	n, err := intstr.GetScaledValueFromIntOrPercent(sr.Spec.MinReadyTargets, total, true)
+	if err != nil || n > total {
+		return total
+	}
+	return n
+}
+
+// deletionPolicy returns the effective deletion policy, defaulting to orphan.
+func deletionPolicy(sr *platformv1alpha1.SharedResource) platformv1alpha1.DeletionPolicy {
+	if sr.Spec.DeletionPolicy == "" {
+		return platformv1alpha1.DeletionPolicyOrphan
+	}
+	return sr.Spec.DeletionPolicy
+}
+
+// targetDeletionPolicy returns the deletion policy for one target: its own
+// override if set, otherwise the CR's.
+func targetDeletionPolicy(sr *platformv1alpha1.SharedResource, target platformv1alpha1.TargetSpec) platformv1alpha1.DeletionPolicy {
+	if target.DeletionPolicy != "" {
+		return target.DeletionPolicy
+	}
+	return deletionPolicy(sr)
+}
+
+// maxFailedTargets returns how many failing targets compact mode may list.
+func maxFailedTargets(sr *platformv1alpha1.SharedResource) int {
+	if sr.Spec.StatusPolicy != nil && sr.Spec.StatusPolicy.MaxFailedTargets != nil {
+		return int(*sr.Spec.StatusPolicy.MaxFailedTargets)
+	}
+	return DefaultMaxFailedTargets
+}
+
+// compactTargetStatuses keeps only failing targets, capped at limit entries.
+// Successful targets are represented by the aggregate counters instead.
+func compactTargetStatuses(targets []platformv1alpha1.TargetSyncStatus, limit int) []platformv1alpha1.TargetSyncStatus {
+	failed := make([]platformv1alpha1.TargetSyncStatus, 0, limit)
+	for _, t := range targets {
+		if len(failed) >= limit {
+			break
+		}
+		if !t.Synced {
+			failed = append(failed, t)
+		}
+	}
+	return failed
+}
+
+// recordRetry notes a failed sync attempt and when the next retry will
+// happen, and returns how long until then.
+//
+// Only a scheduled retry, or a sync-now request, counts as a new attempt. A
+// reconcile before the retry is due, e.g. one caused by the status write of
+// the last attempt, keeps the count and the retry time, so the status does
+// not change and cannot trigger another reconcile.
+//
+// Any pending sync-now request is also marked handled, since this reconcile
+// is the sync the user asked for.
+func recordRetry(sr *platformv1alpha1.SharedResource, now time.Time, after time.Duration) time.Duration {
+	if previous := sr.Status.NextRetryTime; previous != nil && now.Before(previous.Time) && !syncRequestPending(sr) {
+		return previous.Sub(now)
+	}
+	next := metav1.NewTime(now.Add(after))
+	sr.Status.RetryCount++
+	sr.Status.NextRetryTime = &next
+	markSyncRequestHandled(sr)
+	return after
+}
+
+// clearRetry resets retry tracking after a fully successful sync.
+func clearRetry(sr *platformv1alpha1.SharedResource) {
+	sr.Status.RetryCount = 0
+	sr.Status.NextRetryTime = nil
+	markSyncRequestHandled(sr)
+}
+
+// markSyncRequestHandled echoes the sync-now annotation value into status.
+func markSyncRequestHandled(sr *platformv1alpha1.SharedResource) {
+	if v, ok := sr.Annotations[AnnotationSyncNow]; ok {
+		sr.Status.LastHandledSyncRequest = v
+	}
+}
+
+// targetKey returns the namespace/name key used to match target statuses.
+func targetKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// sortTargetStatuses orders target statuses by namespace, then name.
+func sortTargetStatuses(targets []platformv1alpha1.TargetSyncStatus) {
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Namespace != targets[j].Namespace {
+			return targets[i].Namespace < targets[j].Namespace
+		}
+		return targets[i].Name < targets[j].Name
+	})
+}
+
+// previousTargetSync returns the LastSynced time of each target that was
+// synced successfully as of the last sync (see previousTargets).
+func previousTargetSync(targets []platformv1alpha1.TargetSyncStatus) map[string]metav1.Time {
+	previous := make(map[string]metav1.Time, len(targets))
+	for _, t := range targets {
+		if t.Synced && !t.LastSynced.IsZero() {
+			previous[targetKey(t.Namespace, t.Name)] = t.LastSynced
+		}
+	}
+	return previous
+}
+
+// previousTargetChanges returns the LastChange of each target as of the last sync.
+func previousTargetChanges(targets []platformv1alpha1.TargetSyncStatus) map[string]*platformv1alpha1.TargetChange {
+	previous := make(map[string]*platformv1alpha1.TargetChange, len(targets))
+	for _, t := range targets {
+		if t.LastChange != nil {
+			previous[targetKey(t.Namespace, t.Name)] = t.LastChange
+		}
+	}
+	return previous
+}
+
+// previousTargetErrors returns the LastErrorTime and RecentErrors of each
+// target as of the last sync.
+func previousTargetErrors(targets []platformv1alpha1.TargetSyncStatus) map[string]platformv1alpha1.TargetSyncStatus {
+	previous := make(map[string]platformv1alpha1.TargetSyncStatus, len(targets))
+	for _, t := range targets {
+		if t.LastErrorTime != nil || len(t.RecentErrors) > 0 {
+			previous[targetKey(t.Namespace, t.Name)] = platformv1alpha1.TargetSyncStatus{
+				LastErrorTime: t.LastErrorTime,
+				RecentErrors:  t.RecentErrors,
+			}
+		}
+	}
+	return previous
+}
+
+// recordTargetError adds a sync error to a target's history. A repeat of the
+// latest error bumps its count; otherwise the oldest entries are dropped to
+// keep at most MaxTargetErrorHistory. The history passed in is not modified.
+func recordTargetError(history []platformv1alpha1.TargetError, message string, now metav1.Time) []platformv1alpha1.TargetError {
+	if n := len(history); n > 0 && history[n-1].Message == message {
+		updated := append([]platformv1alpha1.TargetError(nil), history...)
+		updated[n-1].Time = now
+		updated[n-1].Count++
+		return updated
+	}
+	updated := append(append([]platformv1alpha1.TargetError(nil), history...),
+		platformv1alpha1.TargetError{Time: now, Message: message, Count: 1})
+	if len(updated) > MaxTargetErrorHistory {
+		updated = updated[len(updated)-MaxTargetErrorHistory:]
+	}
+	return updated
+}
+
+// lastChange returns the change summary to report for a synced target: the
+// diff just applied, or the previous summary if the data did not change.
+func lastChange(
+	sr *platformv1alpha1.SharedResource,
+	previous *platformv1alpha1.TargetChange,
+	diff syncengine.DataDiff,
+	now metav1.Time,
+) *platformv1alpha1.TargetChange {
+	if sr.Spec.StatusPolicy == nil || !sr.Spec.StatusPolicy.RecordChanges {
+		return nil
+	}
+	if diff.Empty() {
+		return previous
+	}
+	return &platformv1alpha1.TargetChange{
+		Time:    now,
+		Added:   int32(len(diff.Added)),
+		Removed: int32(len(diff.Removed)),
+		Changed: int32(len(diff.Changed)),
+	}
+}
+
+// logDataDiff logs a data-less diff of a target write at LogLevelDataDiff.
+func logDataDiff(log logr.Logger, kind string, key types.NamespacedName, oldData, newData map[string][]byte) {
+	if !log.V(LogLevelDataDiff).Enabled() {
+		return
+	}
+	diff := syncengine.Diff(oldData, newData)
+	log.V(LogLevelDataDiff).Info("Target data diff",
+		"kind", kind, "namespace", key.Namespace, "name", key.Name,
+		"added", diff.Added, "removed", diff.Removed, "changed", diff.Changed)
+}
+
+// fieldManager returns the field manager used for all writes.
+func (r *SharedResourceReconciler) fieldManager() string {
+	if r.FieldManager != "" {
+		return r.FieldManager
+	}
+	return FieldManager
+}
+
+// targetMetadata returns the labels and annotations stamped on every object
+// written in a target namespace, merging the installation's TargetAnnotations
+// under the given tracking annotations.
+func (r *SharedResourceReconciler) targetMetadata(tracking map[string]string) (labels, annotations map[string]string) {
+	annotations = make(map[string]string, len(r.TargetAnnotations)+len(tracking))
+	for k, v := range r.TargetAnnotations {
+		annotations[k] = v
+	}
+	for k, v := range tracking {
+		annotations[k] = v
+	}
+	return map[string]string{LabelManagedBy: ManagedByValue}, annotations
+}
+
+// operatorVersion returns the version and build recorded on targets and in
+// status, e.g. "v0.3.0 (go1.24.1, rev 1a2b3c4d5e6f)". Empty if the version is unknown.
+func (r *SharedResourceReconciler) operatorVersion() string {
+	if r.OperatorVersion == "" || r.OperatorBuild == "" {
+		return r.OperatorVersion
+	}
+	return r.OperatorVersion + " (" + r.OperatorBuild + ")"
+}
+
+// now returns the current time from the reconciler's clock.
+func (r *SharedResourceReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// recordEvent emits an event on the SharedResource if a recorder is configured.
+func (r *SharedResourceReconciler) recordEvent(sr *platformv1alpha1.SharedResource, eventType, reason, messageFmt string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(sr, eventType, reason, messageFmt, args...)
 }
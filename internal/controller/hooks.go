@@ -0,0 +1,381 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Hook Jobs - what verification Jobs (verify.go) and pre-sync Jobs
+// (presync.go) share.
+//
+// A hook Job is named after what it checks and the data checksum, so a Job
+// that already exists (e.g. after a restart) is picked up instead of rerun.
+// Jobs are read straight from the API server, neither watched nor cached, and
+// carry the tracking annotations of targets so they can be told apart from
+// Jobs someone else created under the same name.
+//
+// Hook templates are written by whoever may create a SharedResource, and
+// verification Jobs run in every target namespace (only with
+// --enable-verify-jobs), so a hook pod may not get more than the synced data:
+//   - it runs as hookServiceAccount without a token, and is hardened to the
+//     Pod Security "restricted" profile (see hardenHookPod)
+//   - hookPodErrors only admits the pod and container fields listed below,
+//     rather than rejecting known escapes, and rejects values loosening the
+//     restricted profile
+//   - volumes are emptyDir, downwardAPI and the synced Secret or ConfigMap,
+//     and Secret and ConfigMap references in the environment must name it
+// =============================================================================
+
+const (
+	// hookJobChecksumLength is how many checksum characters a Job name carries
+	hookJobChecksumLength = 10
+
+	// hookServiceAccount is the ServiceAccount every hook pod runs as
+	hookServiceAccount = "default"
+)
+
+// hookJobName returns the name of a hook Job: prefix, suffix and the start of
+// the checksum. Pods carry the Job name in a label, so it is kept to 63
+// characters.
+func hookJobName(prefix, suffix, checksum string) string {
+	short := checksum
+	if len(short) > hookJobChecksumLength {
+		short = short[:hookJobChecksumLength]
+	}
+	if limit := 63 - len(suffix) - len(short); len(prefix) > limit {
+		prefix = strings.TrimRight(prefix[:limit], "-.")
+	}
+	return prefix + suffix + short
+}
+
+// hookTimeout returns a hook's timeout, or def if it sets none.
+func hookTimeout(timeout *metav1.Duration, def time.Duration) time.Duration {
+	if timeout != nil && timeout.Duration > 0 {
+		return timeout.Duration
+	}
+	return def
+}
+
+// hookPodFields are the PodSpec fields a hook template may set. Anything
+// else, e.g. nodeName, tolerations or hostNetwork, is rejected, so fields
+// added to PodSpec later are rejected as well.
+var hookPodFields = []string{
+	"containers", "initContainers", "volumes", "securityContext", "restartPolicy",
+	"activeDeadlineSeconds", "terminationGracePeriodSeconds",
+	"serviceAccountName", "serviceAccount", "automountServiceAccountToken",
+}
+
+// hookContainerFields are the Container fields a hook template may set.
+var hookContainerFields = []string{
+	"name", "image", "imagePullPolicy", "command", "args", "workingDir", "env", "envFrom",
+	"resources", "volumeMounts", "terminationMessagePath", "terminationMessagePolicy", "securityContext",
+}
+
+// hookPodSecurityFields and hookContainerSecurityFields are the security
+// context fields a hook template may set, those of the Pod Security
+// "restricted" profile that cannot loosen it.
+var (
+	hookPodSecurityFields = []string{
+		"runAsNonRoot", "runAsUser", "runAsGroup", "fsGroup", "supplementalGroups", "seccompProfile",
+	}
+	hookContainerSecurityFields = []string{
+		"runAsNonRoot", "runAsUser", "runAsGroup", "readOnlyRootFilesystem", "allowPrivilegeEscalation",
+		"capabilities", "seccompProfile",
+	}
+)
+
+// unlistedFields returns the JSON names of the fields set in obj that are
+// not in allowed, sorted.
+func unlistedFields(obj any, allowed []string) []string {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return []string{err.Error()}
+	}
+	var unlisted []string
+	for name := range fields {
+		if !slices.Contains(allowed, name) {
+			unlisted = append(unlisted, name)
+		}
+	}
+	slices.Sort(unlisted)
+	return unlisted
+}
+
+// hookPodErrors returns why a hook pod may not run as templated: it may only
+// set the fields listed above, must meet the Pod Security "restricted"
+// profile, and may read no Secret or ConfigMap but the one named by kind and
+// name; kind is "" for none.
+func hookPodErrors(spec *corev1.PodSpec, kind, name string) []string {
+	var errs []string
+	forbid := func(format string, args ...any) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+	reads := func(path, readKind, readName string) {
+		if readKind != kind || readName != name {
+			forbid("%s: %s %q is not the synced target", path, readKind, readName)
+		}
+	}
+	// The security context fields shared by pods and containers
+	restricted := func(path string, runAsNonRoot *bool, runAsUser *int64, seccomp *corev1.SeccompProfile) {
+		if runAsNonRoot != nil && !*runAsNonRoot {
+			forbid("%s.runAsNonRoot: hook pods run as non-root", path)
+		}
+		if runAsUser != nil && *runAsUser == 0 {
+			forbid("%s.runAsUser: hook pods run as non-root", path)
+		}
+		if seccomp != nil && seccomp.Type != corev1.SeccompProfileTypeRuntimeDefault &&
+			seccomp.Type != corev1.SeccompProfileTypeLocalhost {
+			forbid("%s.seccompProfile: only RuntimeDefault and Localhost are allowed", path)
+		}
+	}
+	for _, field := range unlistedFields(spec, hookPodFields) {
+		forbid("%s is not allowed", field)
+	}
+	if (spec.ServiceAccountName != "" && spec.ServiceAccountName != hookServiceAccount) ||
+		(spec.DeprecatedServiceAccount != "" && spec.DeprecatedServiceAccount != hookServiceAccount) {
+		forbid("serviceAccountName: hook pods run as ServiceAccount %q", hookServiceAccount)
+	}
+	if sc := spec.SecurityContext; sc != nil {
+		for _, field := range unlistedFields(sc, hookPodSecurityFields) {
+			forbid("securityContext.%s is not allowed", field)
+		}
+		restricted("securityContext", sc.RunAsNonRoot, sc.RunAsUser, sc.SeccompProfile)
+	}
+	for _, volume := range spec.Volumes {
+		path := "volumes[" + volume.Name + "]"
+		switch {
+		case volume.EmptyDir != nil, volume.DownwardAPI != nil:
+		case volume.Secret != nil:
+			reads(path, KindSecret, volume.Secret.SecretName)
+		case volume.ConfigMap != nil:
+			reads(path, KindConfigMap, volume.ConfigMap.Name)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				switch {
+				case source.DownwardAPI != nil:
+				case source.Secret != nil:
+					reads(path, KindSecret, source.Secret.Name)
+				case source.ConfigMap != nil:
+					reads(path, KindConfigMap, source.ConfigMap.Name)
+				default:
+					forbid("%s: only Secret, ConfigMap and downwardAPI projections are allowed", path)
+				}
+			}
+		default:
+			forbid("%s: only emptyDir, downwardAPI, Secret, ConfigMap and projected volumes are allowed", path)
+		}
+	}
+	for _, container := range slices.Concat(spec.InitContainers, spec.Containers) {
+		path := "containers[" + container.Name + "]"
+		for _, field := range unlistedFields(container, hookContainerFields) {
+			forbid("%s: %s is not allowed", path, field)
+		}
+		if sc := container.SecurityContext; sc != nil {
+			for _, field := range unlistedFields(sc, hookContainerSecurityFields) {
+				forbid("%s: securityContext.%s is not allowed", path, field)
+			}
+			if sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation {
+				forbid("%s: securityContext.allowPrivilegeEscalation is not allowed", path)
+			}
+			if sc.Capabilities != nil && len(sc.Capabilities.Add) > 0 {
+				forbid("%s: securityContext.capabilities.add is not allowed", path)
+			}
+			restricted(path+": securityContext", sc.RunAsNonRoot, sc.RunAsUser, sc.SeccompProfile)
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				reads(path, KindSecret, ref.Name)
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				reads(path, KindConfigMap, ref.Name)
+			}
+		}
+		for _, from := range container.EnvFrom {
+			if from.SecretRef != nil {
+				reads(path, KindSecret, from.SecretRef.Name)
+			}
+			if from.ConfigMapRef != nil {
+				reads(path, KindConfigMap, from.ConfigMapRef.Name)
+			}
+		}
+	}
+	return errs
+}
+
+// hardenHookPod makes a hook pod meet the Pod Security "restricted"
+// profile: it runs as a non-root user with the runtime's default seccomp
+// profile, and each container drops every capability and cannot escalate.
+func hardenHookPod(pod *corev1.PodSpec) {
+	if pod.SecurityContext == nil {
+		pod.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	pod.SecurityContext.RunAsNonRoot = ptr.To(true)
+	if pod.SecurityContext.SeccompProfile == nil {
+		pod.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	for _, containers := range [][]corev1.Container{pod.InitContainers, pod.Containers} {
+		for i := range containers {
+			if containers[i].SecurityContext == nil {
+				containers[i].SecurityContext = &corev1.SecurityContext{}
+			}
+			sc := containers[i].SecurityContext
+			sc.AllowPrivilegeEscalation = ptr.To(false)
+			sc.RunAsNonRoot = ptr.To(true)
+			sc.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+		}
+	}
+}
+
+// checkHookPod returns an error naming the field if the template's pod may
+// not run (see hookPodErrors).
+func checkHookPod(field string, tmpl *batchv1.JobTemplateSpec, kind, name string) error {
+	errs := hookPodErrors(&tmpl.Spec.Template.Spec, kind, name)
+	if len(errs) == 0 {
+		return nil
+	}
+	for i := range errs {
+		errs[i] = "spec.template.spec." + errs[i]
+	}
+	return fmt.Errorf("invalid %s: %s", field, strings.Join(errs, "; "))
+}
+
+// getHookJob reads a hook Job from the API server.
+func (r *SharedResourceReconciler) getHookJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+	var job batchv1.Job
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// hookJobOwnedBy returns true if the Job was created for the SharedResource.
+func hookJobOwnedBy(job *batchv1.Job, sr *platformv1alpha1.SharedResource) bool {
+	return job.Annotations[AnnotationSourceNamespace] == sr.Namespace && job.Annotations[AnnotationSourceCR] == sr.Name
+}
+
+// hookJobPhase returns the phase of a hook Job, when it finished and, for a
+// failed Job, why.
+func hookJobPhase(job *batchv1.Job) (platformv1alpha1.HookPhase, *metav1.Time, string) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return platformv1alpha1.HookSucceeded, job.Status.CompletionTime, ""
+		case batchv1.JobFailed:
+			completed := c.LastTransitionTime
+			return platformv1alpha1.HookFailed, &completed, fmt.Sprintf("Job %s failed: %s", job.Name, c.Message)
+		}
+	}
+	return platformv1alpha1.HookRunning, nil, ""
+}
+
+// createHookJob creates a hook Job from a template, stamped with the
+// operator's labels and annotations plus the tracking annotations.
+// restartPolicy defaults to Never and activeDeadlineSeconds to timeout; the
+// pod always runs as hookServiceAccount, without its token, and hardened
+// (see hardenHookPod).
+// With an owner, the Job is garbage collected with it. A Job that already
+// exists is left as it is.
+func (r *SharedResourceReconciler) createHookJob(
+	ctx context.Context,
+	tmpl *batchv1.JobTemplateSpec,
+	namespace, name string,
+	tracking map[string]string,
+	timeout time.Duration,
+	owner metav1.Object,
+) error {
+	labels, annotations := r.targetMetadata(tracking)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace,
+			Labels: tmpl.Labels, Annotations: tmpl.Annotations},
+		Spec: tmpl.Spec,
+	}
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		job.Labels[k] = v
+	}
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		job.Annotations[k] = v
+	}
+	pod := &job.Spec.Template.Spec
+	pod.ServiceAccountName, pod.DeprecatedServiceAccount = hookServiceAccount, ""
+	pod.AutomountServiceAccountToken = ptr.To(false)
+	hardenHookPod(pod)
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil {
+		deadline := int64(timeout.Seconds())
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	if owner != nil {
+		if err := controllerutil.SetControllerReference(owner, job, r.Scheme); err != nil {
+			return err
+		}
+	}
+	if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteHookJob deletes a hook Job and its Pods; a failure is only logged,
+// as the Job no longer decides anything.
+func (r *SharedResourceReconciler) deleteHookJob(ctx context.Context, namespace, name string) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		logf.FromContext(ctx).Error(err, "Failed to delete hook Job", "namespace", namespace, "job", name)
+	}
+}
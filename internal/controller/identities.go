@@ -0,0 +1,209 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// =============================================================================
+// Target identities - the --target-identities file.
+//
+// By default every write is made with the operator's own ServiceAccount, so
+// audit logs attribute all target changes to it. A target identity gives the
+// namespaces matching its selector (e.g. one tenant's) credentials of their
+// own, read from a file mounted from a Secret:
+//   - kubeconfig: a kubeconfig for the identity
+//   - tokenFile: a bearer token for the operator's API server, e.g. of a
+//     ServiceAccount "sharedresource-tenant-a" or a projected token
+//
+// Writes of target objects (Secrets, ConfigMaps, and the Roles, RoleBindings
+// and ServiceAccount links of spec.access) in those namespaces are made with
+// the identity; reads, watches and everything else keep the operator's
+// credentials. A namespace uses the first identity whose selector matches it.
+// =============================================================================
+
+// TargetIdentity is one identity of the --target-identities file.
+type TargetIdentity struct {
+	// Name identifies the identity in logs.
+	Name string
+
+	// Selector matches the labels of the namespaces written with the identity.
+	Selector labels.Selector
+
+	// Kubeconfig is the path of the identity's kubeconfig, if it has one.
+	Kubeconfig string
+
+	// TokenFile is the path of the identity's bearer token, if it has one.
+	TokenFile string
+
+	// Client writes with the identity's credentials (see NewIdentityClient).
+	Client client.Client
+}
+
+// targetIdentitiesFile is the on-disk format of --target-identities.
+type targetIdentitiesFile struct {
+	Identities []targetIdentitySpec `json:"identities"`
+}
+
+type targetIdentitySpec struct {
+	Name              string               `json:"name"`
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	Kubeconfig        string               `json:"kubeconfig,omitempty"`
+	TokenFile         string               `json:"tokenFile,omitempty"`
+}
+
+// ParseTargetIdentities parses and validates a --target-identities file.
+// The identities have no Client yet.
+func ParseTargetIdentities(data []byte) ([]TargetIdentity, error) {
+	var file targetIdentitiesFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(file.Identities))
+	identities := make([]TargetIdentity, 0, len(file.Identities))
+	for i, spec := range file.Identities {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("identities[%d]: name is required", i)
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("identities[%d]: duplicate identity %q", i, spec.Name)
+		}
+		names[spec.Name] = true
+
+		selector, err := metav1.LabelSelectorAsSelector(&spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("identity %q: invalid namespaceSelector: %w", spec.Name, err)
+		}
+		// An empty selector would hand every namespace to the identity by accident
+		if selector.Empty() {
+			return nil, fmt.Errorf("identity %q: namespaceSelector must not be empty", spec.Name)
+		}
+		if (spec.Kubeconfig == "") == (spec.TokenFile == "") {
+			return nil, fmt.Errorf("identity %q: exactly one of kubeconfig and tokenFile is required", spec.Name)
+		}
+		identities = append(identities, TargetIdentity{
+			Name: spec.Name, Selector: selector, Kubeconfig: spec.Kubeconfig, TokenFile: spec.TokenFile,
+		})
+	}
+	return identities, nil
+}
+
+// NewIdentityClient returns a client writing with the identity's
+// credentials. A tokenFile identity talks to the API server of base with the
+// token instead of base's credentials; the file is re-read as it rotates.
+func NewIdentityClient(base *rest.Config, identity TargetIdentity, options client.Options) (client.Client, error) {
+	var cfg *rest.Config
+	if identity.Kubeconfig != "" {
+		var err error
+		if cfg, err = clientcmd.BuildConfigFromFlags("", identity.Kubeconfig); err != nil {
+			return nil, fmt.Errorf("identity %q: %w", identity.Name, err)
+		}
+	} else {
+		cfg = rest.AnonymousClientConfig(base)
+		cfg.BearerTokenFile = identity.TokenFile
+	}
+	cfg.UserAgent = base.UserAgent
+	cfg.QPS, cfg.Burst = base.QPS, base.Burst
+	return client.New(cfg, options)
+}
+
+// identityRouter makes target writes in namespaces with a target identity
+// with that identity's client, and everything else with the embedded one.
+type identityRouter struct {
+	client.Client
+	identities []TargetIdentity
+}
+
+// routeTargetWrites returns c, routing target writes through the identities.
+func routeTargetWrites(c client.Client, identities []TargetIdentity) client.Client {
+	if len(identities) == 0 {
+		return c
+	}
+	return &identityRouter{Client: c, identities: identities}
+}
+
+// writer returns the client to write obj with. Objects in a namespace that
+// does not exist (yet) are written with the embedded client.
+func (r *identityRouter) writer(ctx context.Context, obj client.Object) (client.Client, error) {
+	switch obj.(type) {
+	case *corev1.Secret, *corev1.ConfigMap, *corev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding:
+	default:
+		return r.Client, nil
+	}
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: obj.GetNamespace()}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.Client, nil
+		}
+		return nil, err
+	}
+	for _, identity := range r.identities {
+		if identity.Selector.Matches(labels.Set(ns.Labels)) {
+			return identity.Client, nil
+		}
+	}
+	return r.Client, nil
+}
+
+// Create creates obj with the client for its namespace.
+func (r *identityRouter) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	w, err := r.writer(ctx, obj)
+	if err != nil {
+		return err
+	}
+	return w.Create(ctx, obj, opts...)
+}
+
+// Update updates obj with the client for its namespace.
+func (r *identityRouter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	w, err := r.writer(ctx, obj)
+	if err != nil {
+		return err
+	}
+	return w.Update(ctx, obj, opts...)
+}
+
+// Patch patches obj with the client for its namespace.
+func (r *identityRouter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	w, err := r.writer(ctx, obj)
+	if err != nil {
+		return err
+	}
+	return w.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete deletes obj with the client for its namespace.
+func (r *identityRouter) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	w, err := r.writer(ctx, obj)
+	if err != nil {
+		return err
+	}
+	return w.Delete(ctx, obj, opts...)
+}
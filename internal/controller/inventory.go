@@ -0,0 +1,196 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Inventory metrics - how much the operator manages, for capacity planning.
+//
+// Computed at scrape time from the cached SharedResources, so they can never
+// drift from the cluster:
+//   - SharedResources and managed (successfully synced) targets come from status
+//   - Bytes come from the data size recorded by each CR's latest sync, since
+//     status does not carry data sizes
+//
+// Every series is emitted for both kinds, so dashboards see zeros rather than gaps.
+//
+// The same scrape reports per-CR health (Ready, failed targets) for alerting;
+// see internal/alerts for the rules built on them. Those series are labelled
+// source_namespace rather than namespace, which Prometheus sets to the
+// operator pod's namespace.
+// =============================================================================
+
+// inventoryListTimeout bounds the cache read done on each scrape.
+const inventoryListTimeout = 5 * time.Second
+
+var (
+	inventorySharedResourcesDesc = prometheus.NewDesc(
+		"sharedresource_inventory_sharedresources",
+		"Number of SharedResources.",
+		nil, nil,
+	)
+	inventoryTargetsDesc = prometheus.NewDesc(
+		"sharedresource_inventory_targets",
+		"Number of targets currently synced, across all SharedResources.",
+		nil, nil,
+	)
+	inventoryTargetsByKindDesc = prometheus.NewDesc(
+		"sharedresource_inventory_targets_by_kind",
+		"Number of targets currently synced, by target kind.",
+		[]string{"kind"}, nil,
+	)
+	inventoryBytesDesc = prometheus.NewDesc(
+		"sharedresource_inventory_managed_bytes",
+		"Bytes of data (keys and values) held in synced targets, by target kind.",
+		[]string{"kind"}, nil,
+	)
+	readyDesc = prometheus.NewDesc(
+		"sharedresource_ready",
+		"1 if the SharedResource's Ready condition is True, otherwise 0.",
+		[]string{"source_namespace", "sharedresource"}, nil,
+	)
+	failedTargetsDesc = prometheus.NewDesc(
+		"sharedresource_failed_targets",
+		"Number of the SharedResource's targets whose latest sync failed.",
+		[]string{"source_namespace", "sharedresource"}, nil,
+	)
+)
+
+// inventoryCollector reports the inventory gauges. Implements prometheus.Collector.
+type inventoryCollector struct {
+	r *SharedResourceReconciler
+}
+
+// registerInventoryMetrics adds the inventory collector to the controller-runtime registry.
+// Only the first reconciler set up in a process is reported.
+func (r *SharedResourceReconciler) registerInventoryMetrics() error {
+	err := metrics.Registry.Register(&inventoryCollector{r: r})
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		return nil
+	}
+	return err
+}
+
+// recordManagedBytes remembers the data size written by a CR's latest sync.
+func (r *SharedResourceReconciler) recordManagedBytes(key client.ObjectKey, bytes int64) {
+	r.managedBytes.Store(key, bytes)
+}
+
+// dataSize returns the size of the keys and values of a data map.
+func dataSize(data map[string][]byte) int64 {
+	var size int64
+	for k, v := range data {
+		size += int64(len(k) + len(v))
+	}
+	return size
+}
+
+// Describe implements prometheus.Collector.
+func (c *inventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- inventorySharedResourcesDesc
+	ch <- inventoryTargetsDesc
+	ch <- inventoryTargetsByKindDesc
+	ch <- inventoryBytesDesc
+	ch <- readyDesc
+	ch <- failedTargetsDesc
+	ch <- legacyMarkersDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *inventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), inventoryListTimeout)
+	defer cancel()
+
+	var list platformv1alpha1.SharedResourceList
+	if err := c.r.List(ctx, &list); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources for inventory metrics")
+		return
+	}
+
+	targets := map[string]int{KindSecret: 0, KindConfigMap: 0}
+	bytes := map[string]int64{KindSecret: 0, KindConfigMap: 0}
+	managed := 0
+	for i := range list.Items {
+		sr := &list.Items[i]
+		if !c.r.managesCR(sr) {
+			continue
+		}
+		managed++
+		kind := targetKind(sr)
+		targets[kind] += syncedTargetCount(sr)
+		if size, ok := c.r.managedBytes.Load(client.ObjectKeyFromObject(sr)); ok {
+			bytes[kind] += size.(int64)
+		}
+
+		ready := 0.0
+		if conditionIsTrue(sr, ConditionTypeReady) {
+			ready = 1
+		}
+		ch <- prometheus.MustNewConstMetric(readyDesc, prometheus.GaugeValue, ready, sr.Namespace, sr.Name)
+		ch <- prometheus.MustNewConstMetric(failedTargetsDesc, prometheus.GaugeValue,
+			float64(failedTargetCount(sr)), sr.Namespace, sr.Name)
+	}
+
+	ch <- prometheus.MustNewConstMetric(inventorySharedResourcesDesc, prometheus.GaugeValue, float64(managed))
+	ch <- prometheus.MustNewConstMetric(inventoryTargetsDesc, prometheus.GaugeValue, float64(targets[KindSecret]+targets[KindConfigMap]))
+	for _, kind := range []string{KindSecret, KindConfigMap} {
+		ch <- prometheus.MustNewConstMetric(inventoryTargetsByKindDesc, prometheus.GaugeValue, float64(targets[kind]), kind)
+		ch <- prometheus.MustNewConstMetric(inventoryBytesDesc, prometheus.GaugeValue, float64(bytes[kind]), kind)
+	}
+	c.collectLegacyMarkers(ctx, ch)
+}
+
+// syncedTargetCount returns the number of synced targets reported in status.
+// The summary is preferred, since compact status omits synced targets from the list.
+func syncedTargetCount(sr *platformv1alpha1.SharedResource) int {
+	if sr.Status.TargetSummary != nil {
+		return int(sr.Status.TargetSummary.Synced)
+	}
+	count := 0
+	for _, t := range sr.Status.SyncedTargets {
+		if t.Synced {
+			count++
+		}
+	}
+	return count
+}
+
+// failedTargetCount returns the number of failed targets reported in status.
+func failedTargetCount(sr *platformv1alpha1.SharedResource) int {
+	if sr.Status.TargetSummary != nil {
+		return int(sr.Status.TargetSummary.Failed)
+	}
+	count := 0
+	for _, t := range sr.Status.SyncedTargets {
+		if !t.Synced {
+			count++
+		}
+	}
+	return count
+}
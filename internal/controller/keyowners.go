@@ -0,0 +1,179 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Key ownership - several SharedResources merging into one target.
+//
+// In merge mode every write records which keys each SharedResource wrote in
+// AnnotationKeyOwners. With that record:
+//  1. A key removed from a source is removed from the target, unless another
+//     SharedResource owns it
+//  2. A key already owned by another SharedResource is not overwritten; the
+//     target is reported failed with the conflicting keys
+//  3. Deleting one SharedResource removes (delete policies) or gives up
+//     (orphan) only its own keys while other owners remain
+//
+// The other tracking annotations name whichever SharedResource wrote last and
+// do not, by themselves, make another owner rewrite a shared target.
+// =============================================================================
+
+// identityAnnotations are the tracking annotations describing a single owner.
+var identityAnnotations = []string{
+	AnnotationSourceNamespace, AnnotationSourceName, AnnotationSourceCR,
+	AnnotationChecksum, AnnotationProvenance, AnnotationDeletionPolicy,
+	AnnotationOrigin, AnnotationHops,
+}
+
+// parseKeyOwners reads AnnotationKeyOwners; a missing or invalid value means no owners.
+func parseKeyOwners(annotations map[string]string) syncengine.KeyOwners {
+	owners := syncengine.KeyOwners{}
+	if value := annotations[AnnotationKeyOwners]; value != "" {
+		if err := json.Unmarshal([]byte(value), &owners); err != nil {
+			return syncengine.KeyOwners{}
+		}
+	}
+	return owners
+}
+
+// encodeKeyOwners renders AnnotationKeyOwners. Map keys are sorted by encoding/json.
+func encodeKeyOwners(owners syncengine.KeyOwners) string {
+	encoded, _ := json.Marshal(owners)
+	return string(encoded)
+}
+
+// keyOwnerID identifies the writing SharedResource from its tracking annotations.
+func keyOwnerID(annotations map[string]string) string {
+	return annotations[AnnotationSourceNamespace] + "/" + annotations[AnnotationSourceCR]
+}
+
+// mergeForTarget computes the data to write to an existing target (nil for a
+// new one). In merge mode it records the writer's keys in the desired
+// annotations, which are modified in place, and returns the keys owned by
+// other SharedResources that were not written.
+func mergeForTarget(
+	existing map[string][]byte,
+	existingAnnotations map[string]string,
+	data map[string][]byte,
+	annotations map[string]string,
+	syncMode string,
+) (map[string][]byte, map[string]string) {
+	if platformv1alpha1.SyncMode(syncMode) != platformv1alpha1.SyncModeMerge {
+		return syncengine.Merge(existing, data, platformv1alpha1.SyncMode(syncMode)), nil
+	}
+	self := keyOwnerID(annotations)
+	owners := parseKeyOwners(existingAnnotations)
+	merged, owned, conflicts := syncengine.MergeOwned(existing, data, owners, self)
+	owners[self] = owned
+	annotations[AnnotationKeyOwners] = encodeKeyOwners(owners)
+	return merged, conflicts
+}
+
+// comparableAnnotations returns the desired annotations that decide whether a
+// target needs a write. On a shared target the identity annotations only name
+// the last writer, so they are left out.
+func comparableAnnotations(annotations map[string]string) map[string]string {
+	if len(parseKeyOwners(annotations)) < 2 {
+		return annotations
+	}
+	compared := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		compared[k] = v
+	}
+	for _, k := range identityAnnotations {
+		delete(compared, k)
+	}
+	return compared
+}
+
+// keyConflictError reports keys another SharedResource owns, or nil if there are none.
+func keyConflictError(conflicts map[string]string) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(conflicts))
+	for key := range conflicts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		keys[i] = fmt.Sprintf("%s (owned by %s)", key, conflicts[key])
+	}
+	return fmt.Errorf("keys not written, owned by another SharedResource: %s", strings.Join(keys, ", "))
+}
+
+// releaseKeyOwnership removes this SharedResource from a target shared with
+// other SharedResources, deleting its keys if removeKeys is set. Returns false,
+// leaving the target untouched, unless other owners remain.
+func (r *SharedResourceReconciler) releaseKeyOwnership(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	obj client.Object,
+	removeKeys bool,
+) (bool, error) {
+	owners := parseKeyOwners(obj.GetAnnotations())
+	self := releaseOwner(sr)
+	if _, ok := owners[self]; !ok || len(owners) < 2 {
+		return false, nil
+	}
+
+	if removeKeys {
+		switch o := obj.(type) {
+		case *corev1.Secret:
+			o.Data, _, _ = syncengine.MergeOwned(o.Data, nil, owners, self)
+		case *corev1.ConfigMap:
+			data, _, _ := syncengine.MergeOwned(syncengine.FromStrings(o.Data), nil, owners, self)
+			o.Data = syncengine.ToStrings(data)
+		}
+	}
+	delete(owners, self)
+	annotations := obj.GetAnnotations()
+	annotations[AnnotationKeyOwners] = encodeKeyOwners(owners)
+	obj.SetAnnotations(annotations)
+
+	logf.FromContext(ctx).Info("Releasing keys of shared target", "namespace", obj.GetNamespace(),
+		"name", obj.GetName(), "removeKeys", removeKeys)
+	return true, r.Update(ctx, obj)
+}
+
+// keyOwnerRequests returns the SharedResources recorded in AnnotationKeyOwners.
+func keyOwnerRequests(annotations map[string]string) []client.ObjectKey {
+	var keys []client.ObjectKey
+	for owner := range parseKeyOwners(annotations) {
+		namespace, name, ok := strings.Cut(owner, "/")
+		if ok && namespace != "" && name != "" {
+			keys = append(keys, client.ObjectKey{Namespace: namespace, Name: name})
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
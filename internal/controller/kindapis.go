@@ -0,0 +1,234 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Kinds served by optional APIs.
+//
+// Secrets and ConfigMaps are core and always served, but a kind added later
+// may be served by a CRD or an API group the cluster does not have, or has
+// not enabled yet. Watching or writing such a kind would crash the manager or
+// fail every reconcile, so each one is listed in optionalKindAPIs and probed
+// with discovery instead:
+//   - once at startup, and again while a SharedResource waits for one, at
+//     most every kindAPIRetryInterval
+//   - SharedResources needing a kind that is not served report
+//     KindUnavailable=True and are not synced; they are retried at the
+//     interval and synced once the API appears, without a restart
+//   - a deleted one keeps its finalizer until the API is back, so the
+//     targets it wrote while the API was served are still cleaned up
+//   - sharedresource_kind_api_available{kind} reports each probe's result
+//
+// enabledKinds drops the kinds not served, so nothing watches, lists or
+// sweeps them; the watches are set up once, so a kind whose API appears
+// after startup is watched from the next restart.
+//
+// No kind is optional yet, so the table is empty and the operator passes no
+// Discovery client; whoever adds an optional kind sets both.
+// =============================================================================
+
+// optionalKindAPIs maps each kind served by an API that may be absent to the
+// group and version serving it. Add an entry with such a kind.
+var optionalKindAPIs = map[string]schema.GroupVersion{}
+
+// kindAPIRetryInterval is how long a SharedResource waiting for a kind's API
+// waits before it is probed again.
+const kindAPIRetryInterval = 5 * time.Minute
+
+// kindAPIAvailable reports the result of each probe.
+var kindAPIAvailable = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "sharedresource_kind_api_available",
+		Help: "Whether the API serving an optional kind was found by the latest discovery probe (1) or not (0).",
+	},
+	[]string{"kind"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(kindAPIAvailable)
+}
+
+// kindAPIs remembers which optional kinds the latest probe found unserved.
+type kindAPIs struct {
+	mu sync.Mutex
+
+	// unavailable maps each unserved kind to why
+	unavailable map[string]string
+
+	// probed is when the latest probe ran
+	probed time.Time
+}
+
+// set records the result of a probe.
+func (k *kindAPIs) set(unavailable map[string]string, probed time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.unavailable = unavailable
+	k.probed = probed
+}
+
+// probedBefore returns true if the latest probe ran before t.
+func (k *kindAPIs) probedBefore(t time.Time) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.probed.Before(t)
+}
+
+// reason returns why the kind is not served, or "" if it is or was never probed.
+func (k *kindAPIs) reason(kind string) string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.unavailable[kind]
+}
+
+// probeKindAPIs asks discovery for the API of every optional kind and
+// records the result. A probe that fails other than with NotFound keeps the
+// kind's previous state.
+func (r *SharedResourceReconciler) probeKindAPIs(ctx context.Context) {
+	if r.Discovery == nil || len(optionalKindAPIs) == 0 {
+		return
+	}
+	log := logf.FromContext(ctx)
+	unavailable := map[string]string{}
+	for _, kind := range slices.Sorted(maps.Keys(optionalKindAPIs)) {
+		gv := optionalKindAPIs[kind]
+		served, err := kindServedBy(r.Discovery, gv, kind)
+		if err != nil {
+			log.Error(err, "Failed to probe API, keeping its previous state", "kind", kind, "groupVersion", gv.String())
+			if reason := r.kindAPIs.reason(kind); reason != "" {
+				unavailable[kind] = reason
+			}
+			continue
+		}
+		if !served {
+			unavailable[kind] = fmt.Sprintf("%s is not served by this cluster (%s)", kind, gv.String())
+		}
+		if before := r.kindAPIs.reason(kind); (before == "") != served {
+			log.Info("Optional kind API changed", "kind", kind, "groupVersion", gv.String(), "served", served)
+		}
+		value := 0.0
+		if served {
+			value = 1
+		}
+		kindAPIAvailable.WithLabelValues(kind).Set(value)
+	}
+	r.kindAPIs.set(unavailable, r.now())
+}
+
+// kindServedBy returns true if discovery lists the kind in the group version.
+func kindServedBy(dc discovery.DiscoveryInterface, gv schema.GroupVersion, kind string) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion(gv.String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(resources.APIResources, func(resource metav1.APIResource) bool {
+		return resource.Kind == kind
+	}), nil
+}
+
+// kindServed returns true if the kind is core, or its API was served at the
+// latest probe.
+func (r *SharedResourceReconciler) kindServed(kind string) bool {
+	return r.kindAPIs.reason(kind) == ""
+}
+
+// unavailableKind returns why a kind the SharedResource reads or writes is
+// not served, or "" if all are. A result older than kindAPIRetryInterval is
+// probed again first.
+func (r *SharedResourceReconciler) unavailableKind(ctx context.Context, sr *platformv1alpha1.SharedResource) string {
+	reason := func() string {
+		for _, kind := range []string{sr.Spec.Source.Kind, targetKind(sr)} {
+			if reason := r.kindAPIs.reason(kind); reason != "" {
+				return reason
+			}
+		}
+		return ""
+	}
+	if message := reason(); message == "" || !r.kindAPIs.probedBefore(r.now().Add(-kindAPIRetryInterval)) {
+		return message
+	}
+	r.probeKindAPIs(ctx)
+	return reason()
+}
+
+// recordKindUnavailable handles a CR needing an unserved kind: it is reported
+// and retried at kindAPIRetryInterval. A deleted one keeps its finalizer, as
+// targets written while the API was served may still exist.
+func (r *SharedResourceReconciler) recordKindUnavailable(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	message string,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	retryAfter := kindAPIRetryInterval
+	next := "Checked again at " + r.now().Add(retryAfter).UTC().Format(time.RFC3339)
+	if !sr.DeletionTimestamp.IsZero() {
+		next = "Targets are deleted once the API is served again; " + next
+	}
+
+	before := sr.Status.DeepCopy()
+	if c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeKindUnavailable); c == nil || c.Message != message {
+		r.recordEvent(sr, corev1.EventTypeWarning, "KindUnavailable", "%s", message)
+	}
+	setCondition(sr, ConditionTypeKindUnavailable, metav1.ConditionTrue, "APINotServed", message)
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "KindUnavailable", "Not synced: "+message)
+	sr.Status.ObservedGeneration = sr.Generation
+	sr.Status.AllTargetsAtChecksum = false
+	r.explainIfRequested(sr, message, next)
+
+	if !equality.Semantic.DeepEqual(before, &sr.Status) {
+		log.Info("Kind not served, not syncing targets", "reason", message)
+		if err := r.Status().Update(ctx, sr); err != nil {
+			log.Error(err, "Failed to update kind availability status")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: retryAfter}, nil
+}
+
+// clearKindUnavailable drops the KindUnavailable condition once the kinds
+// are served. The status is written with the sync that follows.
+func clearKindUnavailable(sr *platformv1alpha1.SharedResource) {
+	meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeKindUnavailable)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Disabled kinds - --disable-secrets and --disable-configmaps.
+//
+// Installations with strict policies may allow the operator one kind only,
+// e.g. ConfigMaps but never Secrets. A disabled kind is neither read nor
+// written: it is not watched, swept or scanned, its permissions are not
+// checked at startup, and SharedResources needing it are rejected:
+//   - by the SharedResource webhook, on create and update
+//   - by the controller, for CRs the webhook did not see (created before the
+//     flag was set, or with the webhook off), with the Rejected condition.
+//     Their targets are no longer synced, but deleting such a CR still
+//     cleans them up per deletionPolicy: disabling a kind stops new syncs,
+//     it does not orphan what was written before
+// =============================================================================
+
+// enabledKinds returns the kinds this instance syncs, if served (see kindapis.go).
+func (r *SharedResourceReconciler) enabledKinds() []string {
+	var kinds []string
+	for _, kind := range []string{KindSecret, KindConfigMap} {
+		if !slices.Contains(r.DisabledKinds, kind) && r.kindServed(kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// targetReader returns the reader for the CR's targets: a disabled kind is
+// not cached, so its targets, read only for cleanup, come from the API server.
+func (r *SharedResourceReconciler) targetReader(sr *platformv1alpha1.SharedResource) client.Reader {
+	if r.APIReader != nil && slices.Contains(r.DisabledKinds, targetKind(sr)) {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// DisabledKindErrors returns an error for each field of the SharedResource
+// that needs one of the disabled kinds.
+func DisabledKindErrors(sr *platformv1alpha1.SharedResource, disabled []string) field.ErrorList {
+	var errs field.ErrorList
+	forbid := func(path *field.Path, kind string) {
+		if slices.Contains(disabled, kind) {
+			errs = append(errs, field.Forbidden(path, fmt.Sprintf("%s syncing is disabled in this installation", kind)))
+		}
+	}
+	forbid(field.NewPath("spec", "source", "kind"), sr.Spec.Source.Kind)
+	if t := sr.Spec.Template; t != nil {
+		if t.TargetKind != "" && t.TargetKind != sr.Spec.Source.Kind {
+			forbid(field.NewPath("spec", "template", "targetKind"), t.TargetKind)
+		}
+		if templateValuesSecret(sr) != "" && sr.Spec.Source.Kind != KindSecret {
+			forbid(field.NewPath("spec", "template", "valuesFrom", "secretName"), KindSecret)
+		}
+	}
+	if p := sr.Spec.PreSync; p != nil && p.HTTP != nil && p.HTTP.BearerTokenSecret != nil && sr.Spec.Source.Kind != KindSecret {
+		forbid(field.NewPath("spec", "preSync", "http", "bearerTokenSecret"), KindSecret)
+	}
+	return errs
+}
+
+// rejectDisabledKinds handles a CR needing a disabled kind: it is reported as
+// Rejected and never synced. A deleted one is cleaned up like any other.
+func (r *SharedResourceReconciler) rejectDisabledKinds(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	errs field.ErrorList,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	if !sr.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, sr, log)
+	}
+
+	before := sr.Status.DeepCopy()
+	message := errs.ToAggregate().Error()
+	if c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeRejected); c == nil || c.Message != message {
+		r.recordEvent(sr, corev1.EventTypeWarning, "Rejected", "%s", message)
+	}
+	setCondition(sr, ConditionTypeRejected, metav1.ConditionTrue, "KindDisabled", message)
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "Rejected", "Not synced: "+message)
+	sr.Status.ObservedGeneration = sr.Generation
+	r.explainIfRequested(sr, message, "No target is written while the SharedResource is rejected")
+
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Rejected, not syncing targets", "reason", message)
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update rejected status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// clearRejected drops the Rejected condition of a CR that no longer needs a
+// disabled kind. The status is written with the sync that follows.
+func clearRejected(sr *platformv1alpha1.SharedResource) {
+	meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeRejected)
+}
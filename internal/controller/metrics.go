@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// =============================================================================
+// Prometheus metrics.
+//
+// Registered with the controller-runtime registry, so they are served on the
+// manager's metrics endpoint alongside the built-in controller metrics.
+// =============================================================================
+
+// Target deletion reasons, used as the "reason" label of targetDeletionsTotal.
+const (
+	// DeletionReasonExternal means someone (or something) deleted the target directly
+	DeletionReasonExternal = "external"
+
+	// DeletionReasonNamespace means the target went away with its namespace
+	DeletionReasonNamespace = "namespace"
+)
+
+// Sync triggers, used as the "trigger" label of syncsTotal.
+const (
+	// SyncTriggerEvent means a watch event, spec change, sync-now request or
+	// operator restart caused the sync
+	SyncTriggerEvent = "event"
+
+	// SyncTriggerPoll means a source poll found a change no event reported
+	SyncTriggerPoll = "poll"
+
+	// SyncTriggerResync means the periodic resync or a retry found a change
+	SyncTriggerResync = "resync"
+)
+
+// targetDeletionsTotal counts observed deletions of finalizer-tracked targets.
+var targetDeletionsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "sharedresource_target_deletions_total",
+		Help: "Deletions of tracked target resources not initiated by the operator, by kind and reason.",
+	},
+	[]string{"kind", "reason"},
+)
+
+// targetRecreationSeconds measures how long a deleted target stayed missing.
+var targetRecreationSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "sharedresource_target_recreation_seconds",
+		Help:    "Time from an out-of-band delete of a target to its recreation, by kind.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+	},
+	[]string{"kind"},
+)
+
+// orphanedTargets counts managed targets no SharedResource accounts for, as of
+// the latest startup consistency scan.
+var orphanedTargets = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "sharedresource_orphaned_targets",
+		Help: "Managed targets whose SharedResource is gone or no longer lists them, by kind and reason, as of the startup scan.",
+	},
+	[]string{"kind", "reason"},
+)
+
+// sweepCandidates counts targets the sweeper holds for observation before
+// deleting them.
+var sweepCandidates = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "sharedresource_sweep_candidates",
+		Help: "Targets of deleted deleteBackground SharedResources held for the sweep observation period, by kind.",
+	},
+	[]string{"kind"},
+)
+
+// syncsTotal counts reconciles that synced targets, by what triggered them.
+var syncsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "sharedresource_syncs_total",
+		Help: "Reconciles that synced targets, by trigger (event, poll or resync).",
+	},
+	[]string{"trigger"},
+)
+
+// capabilityAllowed reports the startup capability checks (see capabilities.go).
+var capabilityAllowed = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "sharedresource_capability",
+		Help: "Whether the operator's RBAC allows a capability (1) or not (0), as checked at startup.",
+	},
+	[]string{"capability"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(targetDeletionsTotal, targetRecreationSeconds, orphanedTargets, sweepCandidates, syncsTotal,
+		capabilityAllowed)
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Storage migration - rewrite stored objects after an operator upgrade.
+//
+// When the CRD schema changes, objects already in etcd keep their old encoding
+// (and any stored version they were written in) until something writes them
+// again. The migrator runs once per leader election:
+//  1. Rewrites every SharedResource, SharedResourceStatusReport,
+//     SharedResourcePolicy, NamespaceGroup, SharedResourceExport,
+//     SharedResourceImport and SharedResourceSet with a no-op update, so the API
+//     server re-encodes it in the current storage version and persists new
+//     schema defaults
+//  2. Backfills status fields introduced after the object was last reconciled
+//  3. Trims the CRDs' status.storedVersions to the current storage version,
+//     so old versions can later be removed from the CRD safely
+//
+// The API server skips writes whose encoding is unchanged, so running the
+// migrator on every startup is cheap and does not wake up the reconciler.
+// =============================================================================
+
+// MigrationPageSize is the number of objects listed per page during migration.
+const MigrationPageSize = 100
+
+// StorageMigrator rewrites stored operator objects in the current storage version.
+type StorageMigrator struct {
+	// Client is used for writes
+	Client client.Client
+
+	// Reader is used for reads; pass the manager's API reader to bypass the cache
+	Reader client.Reader
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update;patch
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourcepolicies;namespacegroups,verbs=update
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourceexports;sharedresourceimports;sharedresourcesets,verbs=update
+
+// Start runs the migration once. Implements manager.Runnable.
+func (m *StorageMigrator) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("storage-migration")
+	ctx = logf.IntoContext(ctx, log)
+
+	// A failed migration must not take the operator down; it is retried on the next start
+	if err := m.Migrate(ctx); err != nil {
+		log.Error(err, "Storage migration failed")
+		return nil
+	}
+	log.Info("Storage migration complete")
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader rewrites objects.
+func (m *StorageMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Migrate rewrites all operator objects, then trims storedVersions.
+func (m *StorageMigrator) Migrate(ctx context.Context) error {
+	migrated, err := m.migrateAll(ctx, &platformv1alpha1.SharedResourceList{},
+		func() client.Object { return &platformv1alpha1.SharedResource{} }, backfillStatus)
+	if err != nil {
+		return err
+	}
+	reports, err := m.migrateAll(ctx, &platformv1alpha1.SharedResourceStatusReportList{},
+		func() client.Object { return &platformv1alpha1.SharedResourceStatusReport{} }, nil)
+	if err != nil {
+		return err
+	}
+	policies, err := m.migrateAll(ctx, &platformv1alpha1.SharedResourcePolicyList{},
+		func() client.Object { return &platformv1alpha1.SharedResourcePolicy{} }, nil)
+	if err != nil {
+		return err
+	}
+	groups, err := m.migrateAll(ctx, &platformv1alpha1.NamespaceGroupList{},
+		func() client.Object { return &platformv1alpha1.NamespaceGroup{} }, nil)
+	if err != nil {
+		return err
+	}
+	exports, err := m.migrateAll(ctx, &platformv1alpha1.SharedResourceExportList{},
+		func() client.Object { return &platformv1alpha1.SharedResourceExport{} }, nil)
+	if err != nil {
+		return err
+	}
+	imports, err := m.migrateAll(ctx, &platformv1alpha1.SharedResourceImportList{},
+		func() client.Object { return &platformv1alpha1.SharedResourceImport{} }, nil)
+	if err != nil {
+		return err
+	}
+	sets, err := m.migrateAll(ctx, &platformv1alpha1.SharedResourceSetList{},
+		func() client.Object { return &platformv1alpha1.SharedResourceSet{} }, nil)
+	if err != nil {
+		return err
+	}
+	logf.FromContext(ctx).Info("Rewrote stored objects",
+		"sharedResources", migrated, "statusReports", reports, "policies", policies, "namespaceGroups", groups,
+		"exports", exports, "imports", imports, "sets", sets)
+
+	for _, crd := range []string{
+		"sharedresources." + platformv1alpha1.GroupVersion.Group,
+		"sharedresourcestatusreports." + platformv1alpha1.GroupVersion.Group,
+		"sharedresourcepolicies." + platformv1alpha1.GroupVersion.Group,
+		"namespacegroups." + platformv1alpha1.GroupVersion.Group,
+		"sharedresourceexports." + platformv1alpha1.GroupVersion.Group,
+		"sharedresourceimports." + platformv1alpha1.GroupVersion.Group,
+		"sharedresourcesets." + platformv1alpha1.GroupVersion.Group,
+	} {
+		if err := m.trimStoredVersions(ctx, crd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateAll rewrites every object of one kind, page by page.
+// newObj returns an empty object of the listed kind to read each item into.
+func (m *StorageMigrator) migrateAll(ctx context.Context, list client.ObjectList, newObj func() client.Object, backfill func(client.Object) bool) (int, error) {
+	count := 0
+	opts := []client.ListOption{client.Limit(MigrationPageSize)}
+	for {
+		if err := m.Reader.List(ctx, list, opts...); err != nil {
+			return count, fmt.Errorf("failed to list %T: %w", list, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return count, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+			if err := m.rewrite(ctx, key, newObj(), backfill); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if list.GetContinue() == "" {
+			return count, nil
+		}
+		opts = []client.ListOption{client.Limit(MigrationPageSize), client.Continue(list.GetContinue())}
+	}
+}
+
+// rewrite re-reads and updates a single object, retrying on conflicts.
+// If backfill is set and reports a change, the status subresource is updated too.
+func (m *StorageMigrator) rewrite(ctx context.Context, key types.NamespacedName, obj client.Object, backfill func(client.Object) bool) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.Reader.Get(ctx, key, obj); err != nil {
+			return err
+		}
+		// Empty update: the API server re-encodes the object in the storage version
+		if err := m.Client.Update(ctx, obj); err != nil {
+			return err
+		}
+		if backfill != nil && backfill(obj) {
+			return m.Client.Status().Update(ctx, obj)
+		}
+		return nil
+	})
+	if apierrors.IsNotFound(err) {
+		return nil // Deleted while migrating
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", key, err)
+	}
+	return nil
+}
+
+// backfillStatus fills status fields that older operator versions did not write.
+// Returns true if the status changed.
+//
+// ObservedGeneration is deliberately left alone: a zero value makes the next
+// reconcile run in full instead of being skipped by the gate.
+func backfillStatus(obj client.Object) bool {
+	sr, ok := obj.(*platformv1alpha1.SharedResource)
+	if !ok || sr.Status.TargetSummary != nil || len(sr.Status.SyncedTargets) == 0 {
+		return false
+	}
+	summary := &platformv1alpha1.TargetSummary{Total: int32(len(sr.Status.SyncedTargets))}
+	for _, t := range sr.Status.SyncedTargets {
+		if t.Synced {
+			summary.Synced++
+		} else {
+			summary.Failed++
+		}
+	}
+	sr.Status.TargetSummary = summary
+	return true
+}
+
+// trimStoredVersions sets a CRD's status.storedVersions to its current storage version.
+func (m *StorageMigrator) trimStoredVersions(ctx context.Context, name string) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.Reader.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+			return fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		storage := ""
+		for _, v := range crd.Spec.Versions {
+			if v.Storage {
+				storage = v.Name
+			}
+		}
+		if storage == "" || (len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == storage) {
+			return nil
+		}
+		logf.FromContext(ctx).Info("Trimming CRD storedVersions", "crd", name, "from", crd.Status.StoredVersions, "to", storage)
+		crd.Status.StoredVersions = []string{storage}
+		return m.Client.Status().Update(ctx, crd)
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// =============================================================================
+// Namespace set cache - the active namespaces selectors and patterns match.
+//
+// Resolving spec.targetSelector, a namespace pattern or a NamespaceGroup
+// selector needs the labels of every active namespace. Listing them from the
+// informer copies each Namespace, which on a large cluster costs more than
+// the matching, and every reconcile of every such CR would pay it. Instead
+// the reconciler keeps one snapshot, name to labels, shared by all CRs:
+//   - the Namespace watch drops it on every create, relabel or termination,
+//     before the affected CRs are enqueued, so they re-resolve from a fresh
+//     list; a list racing such an event is not kept
+//   - as a backstop it is listed again once namespaceSetTTL has passed
+//   - a reconciler not running the watch (e.g. one built by hand in tests)
+//     keeps no snapshot and lists on every call
+//
+// A deleted namespace needs no event: it was terminating, and left the
+// snapshot, before it went away.
+// =============================================================================
+
+// namespaceSetTTL is how long a namespace snapshot is used at most.
+const namespaceSetTTL = 30 * time.Second
+
+// namespaceSet holds the latest snapshot of the active namespaces.
+type namespaceSet struct {
+	mu sync.Mutex
+
+	// watched is set once the Namespace watch keeps the snapshot current
+	watched bool
+
+	// generation counts the snapshots dropped
+	generation uint64
+
+	// namespaces maps each active namespace to its labels; nil if dropped.
+	// Shared by all callers, so never modified.
+	namespaces map[string]labels.Set
+
+	// listed is when namespaces was listed
+	listed time.Time
+}
+
+// watch starts keeping snapshots, which the Namespace watch now drops.
+func (s *namespaceSet) watch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watched = true
+}
+
+// invalidate drops the snapshot.
+func (s *namespaceSet) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	s.namespaces = nil
+}
+
+// get returns the snapshot if it is current at now, otherwise the generation
+// a new list is to be stored with.
+func (s *namespaceSet) get(now time.Time) (map[string]labels.Set, uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.namespaces == nil || now.Sub(s.listed) >= namespaceSetTTL {
+		return nil, s.generation, false
+	}
+	return s.namespaces, s.generation, true
+}
+
+// store keeps a list taken at generation, unless it was dropped since.
+func (s *namespaceSet) store(namespaces map[string]labels.Set, generation uint64, listed time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.watched || generation != s.generation {
+		return
+	}
+	s.namespaces = namespaces
+	s.listed = listed
+}
+
+// activeNamespaces returns the labels of every namespace not terminating,
+// from the snapshot while it is current. The result must not be modified.
+func (r *SharedResourceReconciler) activeNamespaces(ctx context.Context) (map[string]labels.Set, error) {
+	now := r.now()
+	namespaces, generation, ok := r.namespaces.get(now)
+	if ok {
+		return namespaces, nil
+	}
+	namespaces, err := ActiveNamespaces(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	r.namespaces.store(namespaces, generation, now)
+	return namespaces, nil
+}
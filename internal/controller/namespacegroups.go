@@ -0,0 +1,337 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Namespace groups (targets[].group).
+//
+// A target naming a cluster-scoped NamespaceGroup stands for one target per
+// member namespace, with the entry's name, values, prefix and deletion
+// policy. Everything acting on targets goes through resolveTargets, so group
+// members are synced, diffed, expired and cleaned up like listed targets. A
+// namespace listed explicitly wins over a group containing it; of two groups
+// containing it, the first entry wins.
+//
+// A group that is missing or has an invalid selector leaves the CR unsynced
+// (Ready=False, NamespaceGroupUnavailable) rather than treating it as empty;
+// cleanup falls back to the namespaces last synced under the entry. Editing
+// a group, or creating or relabelling a namespace, re-syncs the
+// SharedResources targeting it; the CRs naming a group are looked up through
+// a field index on the manager's cache (targetGroupIndex). Namespaces leaving
+// a group keep their copy, like a target removed from spec.targets.
+// =============================================================================
+
+// targetGroupIndex indexes SharedResources by the groups their targets name.
+const targetGroupIndex = "spec.targets.group"
+
+// errGroupUnavailable is returned for a NamespaceGroup that cannot be expanded.
+var errGroupUnavailable = errors.New("target group unavailable")
+
+// GroupNamespaces returns the members of a NamespaceGroup, sorted: its listed
+// namespaces plus those of namespaces, name to labels, matching its selector.
+// Used by offline linting, where groups and namespaces come from manifests.
+func GroupNamespaces(group *platformv1alpha1.NamespaceGroup, namespaces map[string]labels.Set) ([]string, error) {
+	members := make(map[string]bool, len(group.Spec.Namespaces))
+	for _, name := range group.Spec.Namespaces {
+		members[name] = true
+	}
+	if group.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(group.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("NamespaceGroup %q has an invalid selector: %w", group.Name, err)
+		}
+		for name, nsLabels := range namespaces {
+			if selector.Matches(nsLabels) {
+				members[name] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// groupNamespaces returns the members of the named NamespaceGroup. Terminating
+// namespaces do not match a selector.
+func (r *SharedResourceReconciler) groupNamespaces(ctx context.Context, name string) ([]string, error) {
+	var group platformv1alpha1.NamespaceGroup
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &group); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: NamespaceGroup %q not found", errGroupUnavailable, name)
+		}
+		return nil, err
+	}
+	var namespaces map[string]labels.Set
+	if group.Spec.Selector != nil {
+		var err error
+		if namespaces, err = r.activeNamespaces(ctx); err != nil {
+			return nil, err
+		}
+	}
+	members, err := GroupNamespaces(&group, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errGroupUnavailable, err)
+	}
+	return members, nil
+}
+
+// expandsTargets returns true if spec.targets names a group or a namespace
+// pattern, or a spec.targetSelector is set, i.e. the targets depend on other
+// objects.
+func expandsTargets(sr *platformv1alpha1.SharedResource) bool {
+	if sr.Spec.TargetSelector != nil {
+		return true
+	}
+	for _, target := range sr.Spec.Targets {
+		if target.Group != "" || IsNamespacePattern(target.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandedTarget returns true for a target standing for several namespaces,
+// a group entry or a namespace pattern.
+func expandedTarget(target platformv1alpha1.TargetSpec) bool {
+	return target.Group != "" || IsNamespacePattern(target.Namespace)
+}
+
+// resolveTargets returns spec.targets with every group entry and namespace
+// pattern replaced by an entry per member or matching namespace (see
+// namespacepatterns.go), followed by the namespaces spec.targetSelector
+// matches (see targetselector.go). Fails with errGroupUnavailable for a
+// group that cannot be expanded, errInvalidNamespacePattern and
+// errInvalidTargetSelector.
+func (r *SharedResourceReconciler) resolveTargets(ctx context.Context, sr *platformv1alpha1.SharedResource) ([]platformv1alpha1.TargetSpec, error) {
+	return r.expandTargets(ctx, sr, nil)
+}
+
+// knownTargets is resolveTargets for cleanup and bookkeeping: a group,
+// pattern or selector that cannot be expanded stands for the namespaces last synced
+// under its target name.
+func (r *SharedResourceReconciler) knownTargets(ctx context.Context, sr *platformv1alpha1.SharedResource) ([]platformv1alpha1.TargetSpec, error) {
+	var lastTargets []platformv1alpha1.TargetSyncStatus
+	read := false
+	return r.expandTargets(ctx, sr, func(target platformv1alpha1.TargetSpec) []string {
+		if !read {
+			lastTargets, read = r.previousTargets(ctx, sr), true
+		}
+		name := resolvedTargetName(sr, target)
+		var namespaces []string
+		for _, synced := range lastTargets {
+			if synced.Name == name {
+				namespaces = append(namespaces, synced.Namespace)
+			}
+		}
+		return namespaces
+	})
+}
+
+// expandTargets replaces group entries and patterns by their namespaces and
+// adds the selected namespaces. unavailable, if set, supplies the namespaces
+// of a group entry or pattern, or of the selector as an entry without a
+// name, that cannot be expanded.
+func (r *SharedResourceReconciler) expandTargets(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	unavailable func(platformv1alpha1.TargetSpec) []string,
+) ([]platformv1alpha1.TargetSpec, error) {
+	if !expandsTargets(sr) {
+		return sr.Spec.Targets, nil
+	}
+	// Listed targets win over group members, wherever they appear
+	listed := make(map[string]bool, len(sr.Spec.Targets))
+	for _, target := range sr.Spec.Targets {
+		if !expandedTarget(target) {
+			listed[targetKey(target.Namespace, resolvedTargetName(sr, target))] = true
+		}
+	}
+
+	targets := make([]platformv1alpha1.TargetSpec, 0, len(sr.Spec.Targets))
+	members := map[string]bool{}
+	for _, target := range sr.Spec.Targets {
+		if !expandedTarget(target) {
+			targets = append(targets, target)
+			continue
+		}
+		var namespaces []string
+		var err error
+		if target.Group != "" {
+			namespaces, err = r.groupNamespaces(ctx, target.Group)
+		} else {
+			namespaces, err = r.patternNamespaces(ctx, sr, target.Namespace)
+		}
+		if (errors.Is(err, errGroupUnavailable) || errors.Is(err, errInvalidNamespacePattern)) && unavailable != nil {
+			namespaces, err = unavailable(target), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := resolvedTargetName(sr, target)
+		for _, namespace := range namespaces {
+			key := targetKey(namespace, name)
+			if listed[key] || members[key] {
+				continue
+			}
+			members[key] = true
+			member := target
+			member.Namespace, member.Group = namespace, ""
+			targets = append(targets, member)
+		}
+	}
+
+	namespaces, err := r.selectedNamespaces(ctx, sr)
+	if errors.Is(err, errInvalidTargetSelector) && unavailable != nil {
+		namespaces, err = unavailable(platformv1alpha1.TargetSpec{}), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	name := sourceName(sr)
+	for _, namespace := range namespaces {
+		key := targetKey(namespace, name)
+		if listed[key] || members[key] {
+			continue
+		}
+		members[key] = true
+		targets = append(targets, platformv1alpha1.TargetSpec{Namespace: namespace})
+	}
+	return targets, nil
+}
+
+// recordUnresolvedTargets reports targets that cannot be resolved, an
+// unavailable group, a malformed namespace pattern or an invalid
+// targetSelector, and leaves every target alone. Fixing the group or selector reconciles the CR again.
+func (r *SharedResourceReconciler) recordUnresolvedTargets(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	resolveErr error,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	// The sync after the fix must not be skipped
+	r.verified.forget(types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name})
+
+	reason := "NamespaceGroupUnavailable"
+	switch {
+	case errors.Is(resolveErr, errInvalidTargetSelector):
+		reason = "InvalidTargetSelector"
+	case errors.Is(resolveErr, errInvalidNamespacePattern):
+		reason = "InvalidNamespacePattern"
+	}
+	before := sr.Status.DeepCopy()
+	message := "Not synced: " + resolveErr.Error()
+	if c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeReady); c == nil || c.Message != message {
+		r.recordEvent(sr, corev1.EventTypeWarning, reason, "%s", message)
+	}
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, reason, message)
+	sr.Status.ObservedGeneration = sr.Generation
+	r.explainIfRequested(sr, message, "No target is written until the targets can be resolved")
+
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Targets cannot be resolved, not syncing them", "error", resolveErr.Error())
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// targetsNamespace returns true if the CR targets the namespace, listed,
+// through a group, a pattern or by label, or last synced to it.
+func (r *SharedResourceReconciler) targetsNamespace(ctx context.Context, sr *platformv1alpha1.SharedResource, namespace string) bool {
+	targets := sr.Spec.Targets
+	if expandsTargets(sr) {
+		for _, synced := range sr.Status.SyncedTargets {
+			if synced.Namespace == namespace {
+				return true
+			}
+		}
+		known, err := r.knownTargets(ctx, sr)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to resolve targets", "sharedresource", client.ObjectKeyFromObject(sr))
+			return true
+		}
+		targets = known
+	}
+	for _, target := range targets {
+		if target.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// indexTargetGroups returns the groups the targets of a SharedResource name,
+// for targetGroupIndex.
+func indexTargetGroups(obj client.Object) []string {
+	sr, ok := obj.(*platformv1alpha1.SharedResource)
+	if !ok {
+		return nil
+	}
+	var groups []string
+	for _, target := range sr.Spec.Targets {
+		if target.Group != "" && !slices.Contains(groups, target.Group) {
+			groups = append(groups, target.Group)
+		}
+	}
+	return groups
+}
+
+// findSharedResourcesForGroup returns reconcile requests for all
+// SharedResources targeting the changed NamespaceGroup.
+func (r *SharedResourceReconciler) findSharedResourcesForGroup(ctx context.Context, obj client.Object) []ctrl.Request {
+	var sharedResourceList platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &sharedResourceList, client.MatchingFields{targetGroupIndex: obj.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources targeting a NamespaceGroup", "group", obj.GetName())
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(sharedResourceList.Items))
+	for _, sr := range sharedResourceList.Items {
+		key := client.ObjectKey{Namespace: sr.Namespace, Name: sr.Name}
+		r.verified.invalidate(key)
+		requests = append(requests, ctrl.Request{NamespacedName: key})
+	}
+	return requests
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Namespace patterns (targets[].namespace: team-*).
+//
+// A target namespace containing a glob metacharacter (*, ? or [) is a
+// pattern, matched with path.Match against the live namespace list. Like a
+// group entry, it stands for one target per matching namespace with the
+// entry's other settings, and a listed entry for the same namespace and name
+// wins. The CR's own namespace, and that of a source read from another
+// namespace, never match: the copy there would be the source itself.
+//
+// The namespace watch re-syncs the CR when a matching namespace is created or
+// starts terminating. Terminating namespaces do not match. Malformed patterns
+// are rejected by the webhook and lint; otherwise the CR is reported with
+// Ready=False, InvalidNamespacePattern, and not synced.
+// =============================================================================
+
+// errInvalidNamespacePattern is returned for a target namespace pattern that does not parse.
+var errInvalidNamespacePattern = errors.New("invalid namespace pattern")
+
+// IsNamespacePattern returns true if a target namespace is a glob pattern
+// rather than a namespace name.
+func IsNamespacePattern(namespace string) bool {
+	return strings.ContainsAny(namespace, "*?[")
+}
+
+// NamespacePatternErrors returns an error for each malformed namespace pattern
+// in spec.targets.
+func NamespacePatternErrors(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	var errs field.ErrorList
+	for i, target := range sr.Spec.Targets {
+		if !IsNamespacePattern(target.Namespace) {
+			continue
+		}
+		if _, err := path.Match(target.Namespace, ""); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "targets").Index(i).Child("namespace"),
+				target.Namespace, "malformed glob pattern"))
+		}
+	}
+	return errs
+}
+
+// PatternNamespaces returns the namespaces, from namespaces, name to labels,
+// matching a target namespace pattern, sorted. Used by offline linting too.
+func PatternNamespaces(sr *platformv1alpha1.SharedResource, pattern string, namespaces map[string]labels.Set) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("%w %q", errInvalidNamespacePattern, pattern)
+	}
+	var names []string
+	for name := range namespaces {
+		// The pattern was checked above
+		if matched, _ := path.Match(pattern, name); matched && name != sr.Namespace && name != sourceNamespace(sr) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// patternNamespaces returns the namespaces, not terminating, matching a
+// target namespace pattern.
+func (r *SharedResourceReconciler) patternNamespaces(ctx context.Context, sr *platformv1alpha1.SharedResource, pattern string) ([]string, error) {
+	namespaces, err := r.activeNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return PatternNamespaces(sr, pattern, namespaces)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Target namespace classification by labels.
+//
+// Namespace labels drive per-target decisions (syncPolicy.namespaceRules,
+// namespace tiers and SharedResourcePolicy selectors). Namespaces are watched so that creating a
+// namespace or relabelling it re-syncs the SharedResources that target it.
+//
+// Deleting a source namespace silently freezes every copy in other
+// namespaces. When it starts terminating, its SharedResources are reconciled
+// once more to raise a SourceNamespaceDeleting warning on the CR and the
+// Namespace. The optional namespace webhook can refuse the delete instead.
+// =============================================================================
+
+// targetNamespaceLabels returns the labels of a target namespace. A missing
+// namespace the CR would create has spec.targetNamespaceLabels (see
+// createnamespaces.go), any other missing namespace empty labels.
+func (r *SharedResourceReconciler) targetNamespaceLabels(ctx context.Context, sr *platformv1alpha1.SharedResource, name string) (labels.Set, error) {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if sr.Spec.CreateTargetNamespaces {
+			return labels.Set(sr.Spec.TargetNamespaceLabels), nil
+		}
+		return labels.Set{}, nil
+	}
+	return labels.Set(ns.Labels), nil
+}
+
+// dataForTarget applies the first matching syncPolicy.namespaceRules entry for
+// the target namespace, or else the keys of its namespace tier. Returns the
+// data unchanged if neither applies.
+func (r *SharedResourceReconciler) dataForTarget(ctx context.Context, sr *platformv1alpha1.SharedResource, namespace string, data map[string][]byte) (map[string][]byte, error) {
+	var rules []platformv1alpha1.NamespaceKeyRule
+	if sr.Spec.SyncPolicy != nil {
+		rules = sr.Spec.SyncPolicy.NamespaceRules
+	}
+	if len(rules) == 0 && len(r.NamespaceTiers) == 0 {
+		return data, nil
+	}
+
+	nsLabels, err := r.targetNamespaceLabels(ctx, sr, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i, rule := range rules {
+		selector, err := metav1.LabelSelectorAsSelector(&rule.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("syncPolicy.namespaceRules[%d] has an invalid namespaceSelector: %w", i, err)
+		}
+		if selector.Matches(nsLabels) {
+			return syncengine.FilterKeys(data, &rule.Keys), nil
+		}
+	}
+	if tier := r.namespaceTier(nsLabels); tier != nil && tier.Keys != nil {
+		return syncengine.FilterKeys(data, tier.Keys), nil
+	}
+	return data, nil
+}
+
+// namespaceChanged passes namespace creations, label changes, export consent
+// changes and the start of termination.
+func namespaceChanged() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			terminating := e.ObjectOld.GetDeletionTimestamp().IsZero() && !e.ObjectNew.GetDeletionTimestamp().IsZero()
+			return terminating || !labels.Equals(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				exportConsentChanged(e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// findSharedResourcesForNamespace returns reconcile requests for all
+// SharedResources that target the namespace, directly or through a group,
+// or read a source in it from another namespace, plus those in the namespace
+// if it is terminating.
+func (r *SharedResourceReconciler) findSharedResourcesForNamespace(ctx context.Context, obj client.Object) []ctrl.Request {
+	// The event is for a change the snapshot may miss; the CRs below re-resolve from a fresh list
+	r.namespaces.invalidate()
+
+	var sharedResourceList platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &sharedResourceList); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources")
+		return nil
+	}
+
+	terminating := !obj.GetDeletionTimestamp().IsZero()
+	var requests []ctrl.Request
+	for _, sr := range sharedResourceList.Items {
+		if terminating && sr.Namespace == obj.GetName() {
+			key := client.ObjectKey{Namespace: sr.Namespace, Name: sr.Name}
+			r.verified.invalidate(key)
+			requests = append(requests, ctrl.Request{NamespacedName: key})
+			continue
+		}
+		if exportsFrom(&sr, obj.GetName()) || r.targetsNamespace(ctx, &sr, obj.GetName()) {
+			key := client.ObjectKey{Namespace: sr.Namespace, Name: sr.Name}
+			r.verified.invalidate(key)
+			requests = append(requests, ctrl.Request{NamespacedName: key})
+		}
+	}
+	return requests
+}
+
+// remoteTargetCount returns the number of targets outside the CR's own
+// namespace. A group entry or a targetSelector counts as one, wherever its
+// namespaces are.
+func remoteTargetCount(sr *platformv1alpha1.SharedResource) int {
+	count := 0
+	if sr.Spec.TargetSelector != nil {
+		count++
+	}
+	for _, target := range sr.Spec.Targets {
+		if target.Group != "" || target.Namespace != sr.Namespace {
+			count++
+		}
+	}
+	return count
+}
+
+// SharedResourcesWithRemoteTargets returns the names of the SharedResources in
+// the namespace, not already being deleted, that sync to other namespaces.
+// Deleting the namespace would freeze their copies.
+func SharedResourcesWithRemoteTargets(ctx context.Context, c client.Reader, namespace string) ([]string, error) {
+	var list platformv1alpha1.SharedResourceList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list SharedResources: %w", err)
+	}
+	var names []string
+	for i := range list.Items {
+		sr := &list.Items[i]
+		if sr.DeletionTimestamp.IsZero() && remoteTargetCount(sr) > 0 {
+			names = append(names, sr.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// warnIfSourceNamespaceDeleting raises a SourceNamespaceDeleting warning on
+// the CR and its namespace if the namespace is terminating while the CR still
+// syncs to other namespaces. The warnings are raised once: the
+// SourceNamespaceDeleting condition, written with the sync that follows,
+// records that they were.
+func (r *SharedResourceReconciler) warnIfSourceNamespaceDeleting(ctx context.Context, sr *platformv1alpha1.SharedResource) error {
+	remote := remoteTargetCount(sr)
+	if remote == 0 || r.Recorder == nil || conditionIsTrue(sr, ConditionTypeSourceNamespaceDeleting) {
+		return nil
+	}
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: sr.Namespace}, &ns); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if ns.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	message := fmt.Sprintf("Source namespace %s is being deleted: SharedResource %s stops updating its %d target(s) "+
+		"in other namespaces, which are frozen in place", sr.Namespace, sr.Name, remote)
+	r.recordEvent(sr, corev1.EventTypeWarning, "SourceNamespaceDeleting", "%s", message)
+	r.Recorder.Event(&ns, corev1.EventTypeWarning, "SourceNamespaceDeleting", message)
+	setCondition(sr, ConditionTypeSourceNamespaceDeleting, metav1.ConditionTrue, "NamespaceTerminating", message)
+	return nil
+}
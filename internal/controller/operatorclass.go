@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Operator classes - several operator instances in one cluster.
+//
+// Two instances (e.g. the old and new major version during a migration, or
+// one per tenant) split the SharedResources between them:
+//   - spec.operatorClass must equal the instance's --operator-class (both
+//     empty for the default instance), like an IngressClass
+//   - --sharedresource-selector further limits an instance to CRs with
+//     matching labels
+//
+// Each instance uses its own CR finalizer (derived from the class unless set
+// with --finalizer-name), so neither blocks the other's deletions. CRs of
+// other instances are not reconciled; one that carries our finalizer was
+// handed over (its class or labels changed), so the finalizer is removed and
+// its targets are left to the new instance, which adopts them by CR identity.
+// =============================================================================
+
+// MaxOperatorClassLength keeps derived names (finalizer, sweep report) valid.
+const MaxOperatorClassLength = 40
+
+// ValidateOperatorClass checks an --operator-class value against the rules
+// of spec.operatorClass.
+func ValidateOperatorClass(class string) error {
+	if class == "" {
+		return nil
+	}
+	if len(class) > MaxOperatorClassLength {
+		return fmt.Errorf("must be at most %d characters", MaxOperatorClassLength)
+	}
+	if errs := validation.IsDNS1123Label(class); len(errs) > 0 {
+		return fmt.Errorf("%s", errs[0])
+	}
+	return nil
+}
+
+// finalizer returns the CR finalizer of this instance.
+func (r *SharedResourceReconciler) finalizer() string {
+	switch {
+	case r.Finalizer != "":
+		return r.Finalizer
+	case r.OperatorClass != "":
+		return FinalizerName + "-" + r.OperatorClass
+	default:
+		return FinalizerName
+	}
+}
+
+// sweepReportName returns the name of this instance's sweep report, so
+// instances sharing a namespace keep separate candidate lists.
+func (r *SharedResourceReconciler) sweepReportName() string {
+	if r.OperatorClass != "" {
+		return SweepReportName + "-" + r.OperatorClass
+	}
+	return SweepReportName
+}
+
+// managesCR returns true if this instance is responsible for the CR.
+func (r *SharedResourceReconciler) managesCR(sr *platformv1alpha1.SharedResource) bool {
+	if sr.Spec.OperatorClass != r.OperatorClass {
+		return false
+	}
+	if r.Namespace != "" && sr.Namespace != r.Namespace {
+		return false
+	}
+	return r.SharedResourceSelector == nil || r.SharedResourceSelector.Matches(labels.Set(sr.Labels))
+}
+
+// managedCRs returns a predicate passing the CRs this instance manages, and
+// those still carrying its finalizer so they can be handed over.
+func (r *SharedResourceReconciler) managedCRs() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		sr, ok := obj.(*platformv1alpha1.SharedResource)
+		return ok && (r.managesCR(sr) || controllerutil.ContainsFinalizer(sr, r.finalizer()))
+	})
+}
+
+// handOver releases a CR managed by another instance: our finalizer, if any,
+// is removed and the targets are left in place for the new instance.
+func (r *SharedResourceReconciler) handOver(ctx context.Context, sr *platformv1alpha1.SharedResource, log logr.Logger) error {
+	key := client.ObjectKeyFromObject(sr)
+	r.verified.forget(key)
+	r.managedBytes.Delete(key)
+	// Without finalizers of our own, one on the CR belongs to someone else
+	if !r.usesFinalizer() || !controllerutil.ContainsFinalizer(sr, r.finalizer()) {
+		return nil
+	}
+	log.Info("Handing over SharedResource to another operator instance", "operatorClass", sr.Spec.OperatorClass)
+	r.recordEvent(sr, corev1.EventTypeNormal, "HandedOver",
+		"Operator class %q no longer manages this SharedResource; targets are left to its new instance", r.OperatorClass)
+	controllerutil.RemoveFinalizer(sr, r.finalizer())
+	return r.Update(ctx, sr)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// =============================================================================
+// Reconciler options - the installation-wide configuration of a
+// SharedResourceReconciler, mostly set from flags in cmd/main.go.
+//
+// The groups are embedded, so their fields read as fields of the reconciler
+// (r.OperatorClass).
+// =============================================================================
+
+// InstanceOptions decide which SharedResources this instance manages and
+// how it identifies itself on what it writes.
+type InstanceOptions struct {
+	// OperatorClass is the spec.operatorClass of the CRs this instance
+	// manages (see operatorclass.go). Empty manages CRs without a class.
+	OperatorClass string
+
+	// SharedResourceSelector limits the instance to CRs with matching labels.
+	// Nil manages CRs regardless of their labels.
+	SharedResourceSelector labels.Selector
+
+	// Finalizer is placed on every CR this instance manages. Empty uses
+	// FinalizerName, suffixed with the OperatorClass if one is set.
+	Finalizer string
+
+	// Namespace restricts the instance to CRs and targets in one namespace
+	// (see devmode.go). Empty manages all namespaces.
+	Namespace string
+
+	// DevMode skips the CR finalizer, so deletes are never blocked and leave
+	// the targets in place (see devmode.go).
+	DevMode bool
+
+	// FieldManager is recorded in managedFields for every write.
+	// Empty uses FieldManager.
+	FieldManager string
+
+	// OperatorVersion is recorded in the provenance annotation of every target.
+	OperatorVersion string
+
+	// OperatorBuild describes the binary (Go version, VCS revision). It is
+	// recorded with OperatorVersion on targets and in status.
+	OperatorBuild string
+}
+
+// SourceOptions decide how sources are read.
+type SourceOptions struct {
+	// SourceRetryInterval is the default requeue delay when the source resource
+	// is missing. CRs can override it via spec.sourceRetryInterval.
+	// Zero uses SourceNotFoundRequeueInterval.
+	SourceRetryInterval time.Duration
+
+	// SourcePollInterval polls every CR's source at this interval, bypassing
+	// the watch cache. CRs can override it via spec.sourcePollInterval.
+	// Zero disables polling.
+	SourcePollInterval time.Duration
+
+	// APIReader reads polled sources and verified writes straight from the
+	// API server. Nil reads them from the cache like everything else.
+	APIReader client.Reader
+}
+
+// TargetOptions decide how, and which, targets are written.
+type TargetOptions struct {
+	// NamespaceTiers give targets defaults by namespace label (see tiers.go).
+	// Nil puts every namespace in no tier.
+	NamespaceTiers []NamespaceTier
+
+	// TargetIdentities write targets in the namespaces they select with
+	// credentials of their own (see identities.go). Nil writes all targets
+	// with Client.
+	TargetIdentities []TargetIdentity
+
+	// TargetAnnotations are added to every object written in a target
+	// namespace, e.g. a policy-exemption annotation required by the cluster's
+	// admission policies. Tracking annotations take precedence on conflict.
+	TargetAnnotations map[string]string
+
+	// DisabledKinds are the kinds (KindSecret, KindConfigMap) this instance
+	// never reads or writes; CRs needing one are rejected (see kinds.go).
+	DisabledKinds []string
+
+	// Discovery probes the APIs of optional kinds (see kindapis.go). Nil
+	// treats every kind as served.
+	Discovery discovery.DiscoveryInterface
+
+	// CompactTrackingThreshold tracks targets whose data is at least this many
+	// bytes with labels instead of the checksum and provenance annotations
+	// (see tracking.go). Zero tracks every target with annotations.
+	CompactTrackingThreshold int
+
+	// MaxShareHops is how many shares away from the first source of a chain
+	// a target may be (see chain.go). Zero allows chains of any length.
+	MaxShareHops int
+
+	// WatchRoleBindings retries forbidden targets as soon as a RoleBinding in
+	// their namespace, or a ClusterRoleBinding, changes (see forbidden.go).
+	WatchRoleBindings bool
+
+	// DeletesDisabled turns off everything that deletes targets, for an
+	// operator without delete permission on Secrets and ConfigMaps (see
+	// permissions.go).
+	DeletesDisabled bool
+}
+
+// HookOptions decide which spec.preSync and spec.verify hooks may run.
+type HookOptions struct {
+	// HTTPClient makes the calls of spec.preSync HTTP hooks (see presync.go);
+	// redirects are never followed. Nil uses a default client.
+	HTTPClient *http.Client
+
+	// PreSyncAllowedURLs are the scheme://host[:port] spec.preSync HTTP hooks
+	// may call. Empty allows none.
+	PreSyncAllowedURLs []string
+
+	// VerifyJobs runs the spec.verify Jobs of CRs in their target namespaces
+	// (see verify.go). Off, such CRs are synced but never Verified or Ready.
+	VerifyJobs bool
+}
+
+// CleanupOptions configure the background sweeper and the startup scan.
+type CleanupOptions struct {
+	// SweepInterval is how often targets left behind by deleteBackground CRs
+	// are cleaned up. Zero uses DefaultSweepInterval.
+	SweepInterval time.Duration
+
+	// SweepObservationPeriod holds targets the sweeper would delete, only
+	// reporting them, until they have been candidates this long. Zero deletes
+	// them on the first sweep.
+	SweepObservationPeriod time.Duration
+
+	// SweepReportNamespace is where the sweep report ConfigMap is kept.
+	// Empty keeps candidates in memory, so a restart starts their period over.
+	SweepReportNamespace string
+
+	// StartupScan enqueues the owners of every labelled target once per leader
+	// election and reports orphaned targets (see startupscan.go).
+	StartupScan bool
+}
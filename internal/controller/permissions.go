@@ -0,0 +1,84 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Reduced RBAC - running without delete on Secrets and ConfigMaps.
+//
+// Security-conscious installs may grant the operator create/update only, so
+// it can never remove a Secret. The permission is checked once at startup,
+// as the targetDeletion capability (see capabilities.go); without it
+// (DeletesDisabled) everything that deletes targets is turned off instead of
+// failing on every attempt:
+//   - deletionPolicy delete, deleteForeground and deleteBackground act as
+//     orphan, and targets are annotated as orphaned so that a later instance
+//     with delete permission does not sweep them
+//   - the background sweeper does not run
+//   - expired shares keep their targets, as with expiryPolicy retain
+//
+// CRs asking for any of these get the DeletesDisabled condition.
+// =============================================================================
+
+// effectiveDeletionPolicy returns the deletion policy applied to a target,
+// which is orphan whenever deletes are disabled.
+func (r *SharedResourceReconciler) effectiveDeletionPolicy(sr *platformv1alpha1.SharedResource, target platformv1alpha1.TargetSpec) platformv1alpha1.DeletionPolicy {
+	if r.DeletesDisabled {
+		return platformv1alpha1.DeletionPolicyOrphan
+	}
+	return targetDeletionPolicy(sr, target)
+}
+
+// deletingFeatures lists the settings of the CR that need target deletes.
+func deletingFeatures(sr *platformv1alpha1.SharedResource) []string {
+	var features []string
+	if deletionPolicy(sr) != platformv1alpha1.DeletionPolicyOrphan {
+		features = append(features, "deletionPolicy "+string(deletionPolicy(sr)))
+	}
+	for _, target := range sr.Spec.Targets {
+		if target.DeletionPolicy != "" && target.DeletionPolicy != platformv1alpha1.DeletionPolicyOrphan {
+			features = append(features, "target deletionPolicy "+string(target.DeletionPolicy))
+			break
+		}
+	}
+	if (sr.Spec.ExpiresAt != nil || sr.Spec.Duration != nil) && sr.Spec.ExpiryPolicy != platformv1alpha1.ExpiryPolicyRetain {
+		features = append(features, "expiryPolicy delete")
+	}
+	return features
+}
+
+// applyDeletesDisabledCondition reports settings of the CR that have no effect
+// without delete permission. The condition is only present while there are any.
+func (r *SharedResourceReconciler) applyDeletesDisabledCondition(sr *platformv1alpha1.SharedResource) {
+	features := deletingFeatures(sr)
+	if !r.DeletesDisabled || len(features) == 0 {
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeDeletesDisabled)
+		return
+	}
+	setCondition(sr, ConditionTypeDeletesDisabled, metav1.ConditionTrue, "NoDeletePermission",
+		fmt.Sprintf("The operator may not delete Secrets or ConfigMaps; %s not applied, targets are left in place",
+			strings.Join(features, ", ")))
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Source-owner policy enforcement (SharedResourcePolicy).
+//
+// Policies live in the source namespace and constrain every SharedResource
+// whose source they select, including those reading the source from another
+// namespace (see sourcenamespace.go). They are evaluated once per reconcile:
+//   - enforceKeys strips disallowed keys before the checksum is computed
+//   - targetDenied is checked per target before anything is written
+//
+// Violations do not stop allowed targets from syncing; they are surfaced via
+// the PolicyDenied condition and per-target errors.
+// =============================================================================
+
+// policyDecision holds the policies that apply to a SharedResource and the
+// violations found while enforcing them during one reconcile.
+type policyDecision struct {
+	policies      []platformv1alpha1.SharedResourcePolicy
+	deniedKeys    []string
+	deniedTargets []string
+}
+
+// evaluatePolicies returns the policies in the source namespace that select the CR's source.
+func (r *SharedResourceReconciler) evaluatePolicies(ctx context.Context, sr *platformv1alpha1.SharedResource) (*policyDecision, error) {
+	var list platformv1alpha1.SharedResourcePolicyList
+	if err := r.List(ctx, &list, client.InNamespace(sourceNamespace(sr))); err != nil {
+		return nil, fmt.Errorf("failed to list SharedResourcePolicies: %w", err)
+	}
+
+	decision := &policyDecision{}
+	for _, policy := range list.Items {
+		if policySelectsSource(&policy, sr.Spec.Source.Kind, sourceName(sr)) {
+			decision.policies = append(decision.policies, policy)
+		}
+	}
+	return decision, nil
+}
+
+// policySelectsSource returns true if the policy applies to the given source.
+func policySelectsSource(policy *platformv1alpha1.SharedResourcePolicy, kind, name string) bool {
+	if len(policy.Spec.Sources) == 0 {
+		return true
+	}
+	for _, s := range policy.Spec.Sources {
+		if s.Kind == kind && s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// enforceKeys removes keys that any applicable policy does not allow,
+// recording them as denied.
+func (d *policyDecision) enforceKeys(data map[string][]byte) map[string][]byte {
+	filtered := data
+	for _, policy := range d.policies {
+		if policy.Spec.AllowedKeys == nil {
+			continue
+		}
+		next := make(map[string][]byte, len(filtered))
+		for k, v := range filtered {
+			if slices.Contains(policy.Spec.AllowedKeys, k) {
+				next[k] = v
+			} else {
+				d.deniedKeys = append(d.deniedKeys, k)
+			}
+		}
+		filtered = next
+	}
+	sort.Strings(d.deniedKeys)
+	return filtered
+}
+
+// targetDenied returns the name of the first policy that does not allow the
+// namespace as a target, or "" if every applicable policy allows it.
+func (r *SharedResourceReconciler) targetDenied(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	d *policyDecision,
+	namespace string,
+) (string, error) {
+	return deniedBy(d.policies, namespace, func() (labels.Set, error) {
+		return r.targetNamespaceLabels(ctx, sr, namespace)
+	})
+}
+
+// DeniedTargetNamespace returns the name of the first policy that applies to
+// the SharedResource and does not allow the namespace as a target, or "".
+// namespaceLabels is only called if a policy has a targetNamespaceSelector.
+// Used by offline linting, where policies and namespaces come from manifests.
+func DeniedTargetNamespace(
+	policies []platformv1alpha1.SharedResourcePolicy,
+	sr *platformv1alpha1.SharedResource,
+	namespace string,
+	namespaceLabels func() (labels.Set, error),
+) (string, error) {
+	var applicable []platformv1alpha1.SharedResourcePolicy
+	for _, policy := range policies {
+		if policy.Namespace == sourceNamespace(sr) && policySelectsSource(&policy, sr.Spec.Source.Kind, sourceName(sr)) {
+			applicable = append(applicable, policy)
+		}
+	}
+	return deniedBy(applicable, namespace, namespaceLabels)
+}
+
+// deniedBy returns the name of the first of policies that does not allow the
+// namespace as a target, reading its labels at most once.
+func deniedBy(
+	policies []platformv1alpha1.SharedResourcePolicy,
+	namespace string,
+	namespaceLabels func() (labels.Set, error),
+) (string, error) {
+	var nsLabels labels.Set
+	for _, policy := range policies {
+		spec := policy.Spec
+		if len(spec.AllowedTargetNamespaces) == 0 && spec.TargetNamespaceSelector == nil {
+			continue
+		}
+		if namespaceNameAllowed(spec.AllowedTargetNamespaces, namespace) {
+			continue
+		}
+		if spec.TargetNamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(spec.TargetNamespaceSelector)
+			if err != nil {
+				return "", fmt.Errorf("SharedResourcePolicy %q has an invalid targetNamespaceSelector: %w", policy.Name, err)
+			}
+			if nsLabels == nil {
+				if nsLabels, err = namespaceLabels(); err != nil {
+					return "", err
+				}
+			}
+			if selector.Matches(nsLabels) {
+				continue
+			}
+		}
+		return policy.Name, nil
+	}
+	return "", nil
+}
+
+// namespaceNameAllowed matches a namespace against exact names or globs.
+func namespaceNameAllowed(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPolicyCondition records the outcome of policy enforcement on the CR.
+// The condition is only present while at least one policy applies.
+func applyPolicyCondition(sr *platformv1alpha1.SharedResource, d *policyDecision) {
+	if len(d.policies) == 0 {
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypePolicyDenied)
+		return
+	}
+
+	var violations []string
+	if len(d.deniedTargets) > 0 {
+		violations = append(violations, "targets not allowed: "+strings.Join(d.deniedTargets, ", "))
+	}
+	if len(d.deniedKeys) > 0 {
+		violations = append(violations, "keys not allowed: "+strings.Join(d.deniedKeys, ", "))
+	}
+	if len(violations) == 0 {
+		setCondition(sr, ConditionTypePolicyDenied, metav1.ConditionFalse, "PolicyAllowed", "All targets and keys are allowed by SharedResourcePolicy")
+		return
+	}
+	setCondition(sr, ConditionTypePolicyDenied, metav1.ConditionTrue, "PolicyViolation", strings.Join(violations, "; "))
+}
+
+// findSharedResourcesForPolicy returns reconcile requests for all SharedResources
+// in the namespace of the changed SharedResourcePolicy, plus those reading a
+// source there from another namespace.
+func (r *SharedResourceReconciler) findSharedResourcesForPolicy(ctx context.Context, obj client.Object) []ctrl.Request {
+	var sharedResourceList, exporting platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &sharedResourceList, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources")
+		return nil
+	}
+	if err := r.List(ctx, &exporting, client.MatchingFields{sourceNamespaceIndex: obj.GetNamespace()}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SharedResources")
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(sharedResourceList.Items)+len(exporting.Items))
+	for _, sr := range append(sharedResourceList.Items, exporting.Items...) {
+		key := client.ObjectKey{Namespace: sr.Namespace, Name: sr.Name}
+		r.verified.invalidate(key)
+		requests = append(requests, ctrl.Request{NamespacedName: key})
+	}
+	return requests
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Source polling - a fallback for clusters with unreliable watches.
+//
+// Some proxies and aggregated API layers silently drop watch events, so a
+// source change never reaches the controller and the informer cache goes
+// stale. With polling enabled (spec.sourcePollInterval or the operator's
+// --source-poll-interval), a CR is requeued at the poll interval and its
+// source is read straight from the API server (APIReader) instead of the
+// cache. A poll that finds nothing new is skipped by the usual gating (see
+// gating.go); one that does find a change is counted as a "poll" sync in
+// sharedresource_syncs_total, which is the signal that watches are broken.
+// =============================================================================
+
+// sourcePollInterval resolves how often the CR's source is polled, or zero if
+// it is not. Precedence: spec.sourcePollInterval > operator flag; an explicit
+// zero in spec turns polling off.
+func (r *SharedResourceReconciler) sourcePollInterval(sr *platformv1alpha1.SharedResource) time.Duration {
+	interval := r.SourcePollInterval
+	if sr.Spec.SourcePollInterval != nil {
+		interval = sr.Spec.SourcePollInterval.Duration
+	}
+	if interval <= 0 {
+		return 0
+	}
+	return max(interval, MinSourcePollInterval)
+}
+
+// sourceReader returns the reader for the CR's source: the API server for
+// polled CRs (when an APIReader is configured), otherwise the cache.
+func (r *SharedResourceReconciler) sourceReader(sr *platformv1alpha1.SharedResource) client.Reader {
+	if r.APIReader != nil && r.sourcePollInterval(sr) > 0 {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// syncTrigger classifies what caused the current reconcile, for syncsTotal.
+// Must be called before the CR is marked verified again.
+func (r *SharedResourceReconciler) syncTrigger(sr *platformv1alpha1.SharedResource) string {
+	if !r.verified.isVerified(client.ObjectKeyFromObject(sr)) ||
+		sr.Generation != sr.Status.ObservedGeneration || syncRequestPending(sr) {
+		return SyncTriggerEvent
+	}
+	if r.sourcePollInterval(sr) > 0 {
+		return SyncTriggerPoll
+	}
+	return SyncTriggerResync
+}
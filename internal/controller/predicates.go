@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Watch predicates for Secret/ConfigMap events.
+//
+// Every write the operator makes to a target produces a watch event that maps
+// straight back to the owning SharedResource. Without filtering, each sync
+// causes a second, pointless reconcile. The predicate below drops events that
+// were caused by the operator itself:
+//   - the object is a managed target
+//   - the most recent managedFields entry belongs to our field manager
+//   - the object's data matches exactly what we last wrote
+//   - the object is not the source of another SharedResource
+//
+// A recorded write is forgotten once its event is dropped, and when the
+// target is deleted, so the record only holds writes whose event is pending.
+//
+// Anything else (a human edit, another controller, a deletion) passes through
+// and triggers drift correction as before.
+// =============================================================================
+
+// ignoreSelfInflicted returns a predicate that filters out our own target writes.
+func (r *SharedResourceReconciler) ignoreSelfInflicted() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return !r.isSelfInflicted(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool { return !r.isSelfInflicted(e.ObjectNew) },
+	}
+}
+
+// isSelfInflicted reports whether the object's current state was written by us.
+func (r *SharedResourceReconciler) isSelfInflicted(obj client.Object) bool {
+	if obj.GetAnnotations()[AnnotationManagedBy] != ManagedByValue {
+		return false
+	}
+	// A delete held by the target finalizer needs handling (see targetguard.go)
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+	// A release request only changes an annotation (see release.go)
+	if obj.GetAnnotations()[AnnotationRelease] == "true" {
+		return false
+	}
+	if lastFieldManager(obj) != r.fieldManager() {
+		return false
+	}
+
+	var kind string
+	var data map[string][]byte
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		kind, data = KindSecret, o.Data
+	case *corev1.ConfigMap:
+		kind, data = KindConfigMap, syncengine.FromStrings(o.Data)
+	default:
+		return false
+	}
+
+	// The next share of a chain must see what we wrote (see chain.go)
+	if r.isChainedSource(obj, kind) {
+		return false
+	}
+
+	key := writeKey(kind, obj.GetNamespace(), obj.GetName())
+	expected, ok := r.writes.Load(key)
+	if !ok || !syncengine.ChecksumEqual(expected.(string), syncengine.Checksum(data)) {
+		return false
+	}
+	// One write causes one event: forget the write once its event is seen,
+	// unless a newer one replaced it meanwhile
+	r.writes.CompareAndDelete(key, expected)
+	return true
+}
+
+// recordWrite remembers the checksum of the data we just wrote to a target,
+// so the resulting watch event can be recognized as self-inflicted.
+func (r *SharedResourceReconciler) recordWrite(kind, namespace, name string, data map[string][]byte) {
+	r.writes.Store(writeKey(kind, namespace, name), syncengine.Checksum(data))
+}
+
+// writeKey builds the lookup key for recorded writes.
+func writeKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// lastFieldManager returns the manager of the most recent managedFields entry.
+func lastFieldManager(obj client.Object) string {
+	var manager string
+	var latest time.Time
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Time == nil {
+			continue
+		}
+		if manager == "" || !mf.Time.Time.Before(latest) {
+			manager = mf.Manager
+			latest = mf.Time.Time
+		}
+	}
+	return manager
+}
@@ -0,0 +1,398 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Pre-sync hooks - spec.preSync.
+//
+// Some changes must be coordinated with systems outside the cluster: a
+// distributed lock taken, or a change-management ticket opened, before new
+// credentials reach the consumers. With spec.preSync, source data that
+// differs from what the targets hold (including the first sync) is only
+// propagated once a hook succeeded for it:
+//   - http: a POST describing the change (no data) must answer 2xx. Failed
+//     calls are retried every PreSyncRetryInterval, or at once on sync-now.
+//     Only URLs whose scheme and host the operator allows are called
+//     (--presync-allowed-url), redirects are not followed and only the
+//     status code of an answer is reported, so CR authors cannot use the
+//     operator to reach, and read, in-cluster or metadata endpoints
+//   - job: a Job in the SharedResource's namespace, owned by it, must
+//     complete. Running Jobs are checked every HookCheckInterval; a failed
+//     Job is not rerun until the source changes or the Job is deleted. Its
+//     pod is restricted like a verification pod (see hooks.go)
+//
+// Until then no target is written, and the PreSync condition and
+// status.preSync say why; a failed hook sets Ready to False as well. The
+// result is kept per source checksum, so an unchanged source never calls the
+// hook twice.
+// =============================================================================
+
+// preSyncJobSuffix separates the CR name from the checksum in Job names
+const preSyncJobSuffix = "-presync-"
+
+// preSyncRequest is the body POSTed to spec.preSync.http.url.
+type preSyncRequest struct {
+	SharedResource   preSyncObject `json:"sharedResource"`
+	Source           preSyncSource `json:"source"`
+	Checksum         string        `json:"checksum"`
+	PreviousChecksum string        `json:"previousChecksum,omitempty"`
+	TargetNamespaces []string      `json:"targetNamespaces"`
+}
+
+type preSyncObject struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type preSyncSource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// gatePreSync runs spec.preSync for source data the targets do not hold yet.
+// It returns true, with status written, while the sync has to wait for the
+// hook, and when to check again.
+func (r *SharedResourceReconciler) gatePreSync(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	checksum string,
+	log logr.Logger,
+) (bool, time.Duration, error) {
+	if sr.Spec.PreSync == nil {
+		sr.Status.PreSync = nil
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypePreSync)
+		return false, 0, nil
+	}
+	if syncengine.ChecksumEqual(checksum, sr.Status.SourceChecksum) {
+		return false, 0, nil
+	}
+
+	before := sr.Status.DeepCopy()
+	var after time.Duration
+	status := sr.Status.PreSync
+	if wait := r.preSyncRetryPending(sr, checksum); wait > 0 {
+		after = wait
+	} else {
+		status = r.runPreSync(ctx, sr, checksum)
+		sr.Status.PreSync = status
+		markSyncRequestHandled(sr)
+	}
+
+	changed := before.PreSync == nil || before.PreSync.Checksum != status.Checksum || before.PreSync.Phase != status.Phase
+	switch status.Phase {
+	case platformv1alpha1.HookSucceeded:
+		setCondition(sr, ConditionTypePreSync, metav1.ConditionTrue, "PreSyncSucceeded",
+			fmt.Sprintf("Pre-sync hook succeeded for source checksum %s", checksum))
+		if changed {
+			r.recordEvent(sr, corev1.EventTypeNormal, "PreSyncSucceeded",
+				"Pre-sync hook succeeded; propagating the source change")
+		}
+		return false, 0, nil
+	case platformv1alpha1.HookRunning:
+		setCondition(sr, ConditionTypePreSync, metav1.ConditionFalse, "PreSyncRunning",
+			fmt.Sprintf("Waiting for pre-sync Job %s before propagating the source change", status.Job))
+		after = HookCheckInterval
+	default:
+		message := "Pre-sync hook failed, the source change is not propagated: " + status.Message
+		setCondition(sr, ConditionTypePreSync, metav1.ConditionFalse, "PreSyncFailed", message)
+		setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "PreSyncFailed", message)
+		if changed {
+			r.recordEvent(sr, corev1.EventTypeWarning, "PreSyncFailed", "%s", message)
+		}
+		if after == 0 {
+			after = PreSyncRetryInterval
+		}
+	}
+	sr.Status.ObservedGeneration = sr.Generation
+	r.explainIfRequested(sr, meta.FindStatusCondition(sr.Status.Conditions, ConditionTypePreSync).Message,
+		"No target is written until the pre-sync hook succeeds")
+
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return true, after, nil
+	}
+	log.Info("Source change held by pre-sync hook", "phase", status.Phase, "checksum", checksum)
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update pre-sync status")
+		return true, 0, err
+	}
+	return true, after, nil
+}
+
+// preSyncRetryPending returns how long a failed HTTP hook for the checksum
+// waits before it is called again, or zero if it should be called now. A
+// sync-now request skips the wait.
+func (r *SharedResourceReconciler) preSyncRetryPending(sr *platformv1alpha1.SharedResource, checksum string) time.Duration {
+	previous := sr.Status.PreSync
+	if sr.Spec.PreSync.HTTP == nil || previous == nil || previous.Checksum != checksum ||
+		previous.Phase != platformv1alpha1.HookFailed || previous.LastAttemptTime == nil || syncRequestPending(sr) {
+		return 0
+	}
+	return max(previous.LastAttemptTime.Add(PreSyncRetryInterval).Sub(r.now()), 0)
+}
+
+// runPreSync calls or checks the hook for the checksum and returns its result.
+func (r *SharedResourceReconciler) runPreSync(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	checksum string,
+) *platformv1alpha1.PreSyncStatus {
+	previous := sr.Status.PreSync
+	if previous != nil && previous.Checksum == checksum && previous.Phase == platformv1alpha1.HookSucceeded {
+		return previous
+	}
+	now := metav1.NewTime(r.now())
+	status := &platformv1alpha1.PreSyncStatus{Checksum: checksum, Phase: platformv1alpha1.HookRunning, LastAttemptTime: &now}
+	if sr.Spec.PreSync.HTTP != nil {
+		if err := r.callPreSyncHTTP(ctx, sr, checksum); err != nil {
+			status.Phase = platformv1alpha1.HookFailed
+			status.Message = err.Error()
+		} else {
+			status.Phase = platformv1alpha1.HookSucceeded
+		}
+		return status
+	}
+	return r.runPreSyncJob(ctx, sr, checksum, previous, status)
+}
+
+// PreSyncURLAllowed returns an error unless an allowed URL has the scheme and
+// host of the hook URL. Allowed URLs are scheme://host[:port].
+func PreSyncURLAllowed(allowed []string, hookURL string) error {
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return err
+	}
+	for _, entry := range allowed {
+		a, err := url.Parse(entry)
+		if err == nil && a.Scheme == u.Scheme && strings.EqualFold(a.Host, u.Host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s://%s is not an allowed pre-sync URL (--presync-allowed-url)", u.Scheme, u.Host)
+}
+
+// preSyncHTTPClient returns the client for hook calls, which never follows
+// redirects: a hook URL may only reach the host the operator allowed.
+func (r *SharedResourceReconciler) preSyncHTTPClient() *http.Client {
+	c := http.Client{}
+	if r.HTTPClient != nil {
+		c = *r.HTTPClient
+	}
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &c
+}
+
+// callPreSyncHTTP POSTs the pending change to the hook URL.
+func (r *SharedResourceReconciler) callPreSyncHTTP(ctx context.Context, sr *platformv1alpha1.SharedResource, checksum string) error {
+	hook := sr.Spec.PreSync.HTTP
+	if err := PreSyncURLAllowed(r.PreSyncAllowedURLs, hook.URL); err != nil {
+		return err
+	}
+	body := preSyncRequest{
+		SharedResource:   preSyncObject{Namespace: sr.Namespace, Name: sr.Name},
+		Source:           preSyncSource{Kind: sr.Spec.Source.Kind, Namespace: sourceNamespace(sr), Name: sourceName(sr)},
+		Checksum:         checksum,
+		PreviousChecksum: sr.Status.SourceChecksum,
+		TargetNamespaces: []string{},
+	}
+	targets, err := r.resolveTargets(ctx, sr)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if !slices.Contains(body.TargetNamespaces, target.Namespace) {
+			body.TargetNamespaces = append(body.TargetNamespaces, target.Namespace)
+		}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout(sr.Spec.PreSync.Timeout, DefaultPreSyncHTTPTimeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if ref := hook.BearerTokenSecret; ref != nil {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: sr.Namespace, Name: ref.Name}, &secret); err != nil {
+			return fmt.Errorf("failed to read bearer token Secret %s: %w", ref.Name, err)
+		}
+		token, ok := secret.Data[ref.Key]
+		if !ok {
+			return fmt.Errorf("bearer token Secret %s has no key %q", ref.Name, ref.Key)
+		}
+		req.Header.Set("Authorization", "Bearer "+string(bytes.TrimSpace(token)))
+	}
+
+	resp, err := r.preSyncHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	// The answer is not reported: status is readable by the CR's author
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %d", hook.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// preSyncJobName returns the name of the pre-sync Job for the checksum.
+func preSyncJobName(sr *platformv1alpha1.SharedResource, checksum string) string {
+	return hookJobName(sr.Name, preSyncJobSuffix, checksum)
+}
+
+// preSyncJobTemplate decodes spec.preSync.job.
+func preSyncJobTemplate(sr *platformv1alpha1.SharedResource) (*batchv1.JobTemplateSpec, error) {
+	var tmpl batchv1.JobTemplateSpec
+	if err := json.Unmarshal(sr.Spec.PreSync.Job.Raw, &tmpl); err != nil {
+		return nil, fmt.Errorf("invalid preSync.job: %w", err)
+	}
+	if len(tmpl.Spec.Template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("invalid preSync.job: spec.template.spec.containers is required")
+	}
+	// The pod may read the source if it is in its namespace, and nothing else
+	kind, name := "", ""
+	if !exportsSource(sr) {
+		kind, name = sr.Spec.Source.Kind, sourceName(sr)
+	}
+	if err := checkHookPod("preSync.job", &tmpl, kind, name); err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// PreSyncErrors returns an error if spec.preSync.job is not a
+// JobTemplateSpec with containers, or its pod would get more than the source
+// (see hookPodErrors).
+func PreSyncErrors(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	if sr.Spec.PreSync == nil || sr.Spec.PreSync.Job == nil {
+		return nil
+	}
+	if _, err := preSyncJobTemplate(sr); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "preSync", "job"), "", err.Error())}
+	}
+	return nil
+}
+
+// runPreSyncJob starts or checks the pre-sync Job for the checksum. status
+// is the result to fill in; previous is the result status reports, if any.
+func (r *SharedResourceReconciler) runPreSyncJob(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	checksum string,
+	previous, status *platformv1alpha1.PreSyncStatus,
+) *platformv1alpha1.PreSyncStatus {
+	name := preSyncJobName(sr, checksum)
+	status.Job = name
+	if previous != nil && previous.Job == name {
+		status.LastAttemptTime = previous.LastAttemptTime
+	}
+	failed := func(format string, args ...any) *platformv1alpha1.PreSyncStatus {
+		status.Phase = platformv1alpha1.HookFailed
+		status.Message = fmt.Sprintf(format, args...)
+		return status
+	}
+
+	job, err := r.getHookJob(ctx, sr.Namespace, name)
+	if apierrors.IsNotFound(err) {
+		if previous != nil && previous.Job != "" && previous.Job != name {
+			r.deleteHookJob(ctx, sr.Namespace, previous.Job)
+		}
+		if err := r.createPreSyncJob(ctx, sr, name, checksum); err != nil {
+			return failed("failed to create pre-sync Job: %v", err)
+		}
+		return status
+	}
+	if err != nil {
+		return failed("failed to read pre-sync Job: %v", err)
+	}
+	if !hookJobOwnedBy(job, sr) {
+		return failed("Job %s exists and is not managed by this SharedResource", name)
+	}
+	phase, _, message := hookJobPhase(job)
+	if phase == platformv1alpha1.HookFailed {
+		return failed("%s", message)
+	}
+	status.Phase = phase
+	return status
+}
+
+// createPreSyncJob creates the pre-sync Job, telling its containers which
+// change it is for.
+func (r *SharedResourceReconciler) createPreSyncJob(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	name, checksum string,
+) error {
+	tmpl, err := preSyncJobTemplate(sr)
+	if err != nil {
+		return err
+	}
+	env := []corev1.EnvVar{
+		{Name: "SHAREDRESOURCE_NAME", Value: sr.Name},
+		{Name: "SHAREDRESOURCE_CHECKSUM", Value: checksum},
+		{Name: "SHAREDRESOURCE_PREVIOUS_CHECKSUM", Value: sr.Status.SourceChecksum},
+	}
+	containers := tmpl.Spec.Template.Spec.Containers
+	for i := range containers {
+		containers[i].Env = append(containers[i].Env, env...)
+	}
+	tracking := map[string]string{
+		AnnotationManagedBy:       ManagedByValue,
+		AnnotationSourceNamespace: sr.Namespace,
+		AnnotationSourceCR:        sr.Name,
+		AnnotationChecksum:        checksum,
+	}
+	timeout := hookTimeout(sr.Spec.PreSync.Timeout, DefaultHookJobTimeout)
+	if err := r.createHookJob(ctx, tmpl, sr.Namespace, name, tracking, timeout, sr); err != nil {
+		return err
+	}
+	r.recordEvent(sr, corev1.EventTypeNormal, "PreSyncStarted",
+		"Started pre-sync Job %s; the source change waits for it", name)
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// =============================================================================
+// Recreate-on-delete - explicit handling of target Delete events.
+//
+// A deleted target must come back within one reconcile. Delete events for
+// managed targets are handled explicitly rather than through the generic
+// mapping: the deletion time is recorded, the owning CR is enqueued past the
+// gate, and the next create of that target observes the elapsed time in
+// sharedresource_target_recreation_seconds.
+//
+// Deletes the operator issues itself (deletion policies, sweeper) are marked
+// in r.writes beforehand and are neither timed nor recreated.
+// =============================================================================
+
+// selfDeleted marks a target the operator deleted itself in r.writes.
+// Checksums are never empty, so it cannot collide with a recorded write.
+const selfDeleted = ""
+
+// targetEventHandler enqueues the SharedResources for a target or source
+// event, recording deletions of managed targets for the recreation metric.
+func (r *SharedResourceReconciler) targetEventHandler(kind string, mapFn handler.MapFunc) handler.EventHandler {
+	mapped := handler.EnqueueRequestsFromMapFunc(mapFn)
+	return handler.Funcs{
+		CreateFunc:  mapped.Create,
+		UpdateFunc:  mapped.Update,
+		GenericFunc: mapped.Generic,
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.recordTargetDeleted(kind, e.Object)
+			mapped.Delete(ctx, e, q)
+		},
+	}
+}
+
+// recordTargetDeleted remembers when a managed target was deleted out-of-band.
+func (r *SharedResourceReconciler) recordTargetDeleted(kind string, obj client.Object) {
+	// A deleted object no longer needs its recorded write, managed or not
+	key := writeKey(kind, obj.GetNamespace(), obj.GetName())
+	last, ok := r.writes.LoadAndDelete(key)
+	if obj.GetAnnotations()[AnnotationManagedBy] != ManagedByValue {
+		return
+	}
+	if ok && last.(string) == selfDeleted {
+		return
+	}
+
+	// A delete held by the target finalizer started at its deletion timestamp
+	deletedAt := time.Now()
+	if ts := obj.GetDeletionTimestamp(); !ts.IsZero() {
+		deletedAt = ts.Time
+	}
+	r.deletions.Store(key, deletedAt)
+}
+
+// recordTargetCreated observes the recreation latency if the target was deleted earlier.
+func (r *SharedResourceReconciler) recordTargetCreated(kind, namespace, name string) {
+	if deletedAt, ok := r.deletions.LoadAndDelete(writeKey(kind, namespace, name)); ok {
+		targetRecreationSeconds.WithLabelValues(kind).Observe(time.Since(deletedAt.(time.Time)).Seconds())
+	}
+}
+
+// recordSelfDelete marks a target we are about to delete, so its Delete event is ignored.
+func (r *SharedResourceReconciler) recordSelfDelete(kind, namespace, name string) {
+	r.writes.Store(writeKey(kind, namespace, name), selfDeleted)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Target release - hand a copy over to the destination team.
+//
+// Annotating a target sharedresource.platform.dev/release=true asks its
+// SharedResource to let go of it. On the next reconcile the operator:
+//  1. Strips its tracking annotations, the installation's target annotations,
+//     the managed-by label and the target finalizer
+//  2. Stamps released-from and released-at, so the hand-over is auditable
+//  3. Emits a TargetReleased event on the SharedResource and on the target
+//
+// A released target is never written or deleted again; it is reported as
+// released in status until it is removed from spec.targets. Removing the
+// released-from annotation lets the SharedResource adopt it again.
+// =============================================================================
+
+// releaseOwner is the AnnotationReleasedFrom value for targets of this SharedResource.
+func releaseOwner(sr *platformv1alpha1.SharedResource) string {
+	return sr.Namespace + "/" + sr.Name
+}
+
+// releaseRequested returns true if a managed target's annotations ask for its
+// release from this SharedResource.
+func releaseRequested(sr *platformv1alpha1.SharedResource, annotations map[string]string) bool {
+	return annotations[AnnotationRelease] == "true" &&
+		annotations[AnnotationSourceNamespace] == sr.Namespace && annotations[AnnotationSourceCR] == sr.Name
+}
+
+// releaseIfRequested returns true if the target has been released from this
+// SharedResource, releasing it first if its annotation asks for it.
+func (r *SharedResourceReconciler) releaseIfRequested(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	namespace, name string,
+) (bool, error) {
+	kind := targetKind(sr)
+	obj, err := newTargetObject(kind)
+	if err != nil {
+		return false, err
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations[AnnotationManagedBy] != ManagedByValue {
+		return annotations[AnnotationReleasedFrom] == releaseOwner(sr), nil
+	}
+	if !releaseRequested(sr, annotations) {
+		return false, nil
+	}
+
+	for _, key := range []string{
+		AnnotationManagedBy, AnnotationSourceNamespace, AnnotationSourceName, AnnotationSourceCR,
+		AnnotationChecksum, AnnotationProvenance, AnnotationDeletionPolicy, AnnotationLastSynced,
+		AnnotationOperatorVersion, AnnotationKeyOwners, AnnotationExpired, AnnotationRelease,
+		AnnotationOrigin, AnnotationHops,
+	} {
+		delete(annotations, key)
+	}
+	for key := range r.TargetAnnotations {
+		delete(annotations, key)
+	}
+	annotations[AnnotationReleasedFrom] = releaseOwner(sr)
+	annotations[AnnotationReleasedAt] = r.now().UTC().Format(time.RFC3339)
+	obj.SetAnnotations(annotations)
+
+	labels := obj.GetLabels()
+	if labels[LabelManagedBy] == ManagedByValue {
+		delete(labels, LabelManagedBy)
+	}
+	for _, key := range compactTrackingLabels {
+		delete(labels, key)
+	}
+	obj.SetLabels(labels)
+	controllerutil.RemoveFinalizer(obj, TargetFinalizerName)
+
+	if err := r.Update(ctx, obj); err != nil {
+		return false, err
+	}
+	r.writes.Delete(writeKey(kind, namespace, name))
+
+	logf.FromContext(ctx).Info("Released target", "kind", kind, "namespace", namespace, "name", name)
+	r.recordEvent(sr, corev1.EventTypeNormal, "TargetReleased",
+		"Released %s %s/%s; it is no longer managed and can be removed from spec.targets", kind, namespace, name)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(obj, corev1.EventTypeNormal, "TargetReleased",
+			"Released from SharedResource %s; this %s is no longer managed", releaseOwner(sr), kind)
+	}
+	return true, nil
+}
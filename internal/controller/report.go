@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Companion status report management.
+//
+// When spec.statusPolicy.report is enabled, the full per-target state is
+// written into a SharedResourceStatusReport with the same name/namespace as
+// the CR. The report is owned by the CR (ownerReference), so Kubernetes
+// garbage collection removes it when the CR is deleted.
+//
+// A CR whose status is compacted always gets a report: compaction drops the
+// successful targets from status, and the next reconcile still needs their
+// LastSynced, LastChange, error history, source checksum and verification.
+// previousTargets reads them back from the report.
+// =============================================================================
+
+// reportEnabled returns true if the CR asks for a companion status report.
+func reportEnabled(sr *platformv1alpha1.SharedResource) bool {
+	return sr.Spec.StatusPolicy != nil && sr.Spec.StatusPolicy.Report
+}
+
+// syncStatusReport creates, updates, or removes the companion status report.
+// compact is true if the status is about to be compacted, which requires a
+// report even when the CR didn't ask for one.
+//
+// The report is only written when its content changes, so steady-state
+// reconciles don't generate extra API writes.
+func (r *SharedResourceReconciler) syncStatusReport(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	targets []platformv1alpha1.TargetSyncStatus,
+	summary platformv1alpha1.TargetSummary,
+	checksum string,
+	compact bool,
+) error {
+	if !reportEnabled(sr) && !compact {
+		return r.deleteStatusReport(ctx, sr)
+	}
+
+	report := &platformv1alpha1.SharedResourceStatusReport{
+		ObjectMeta: metav1.ObjectMeta{Name: sr.Name, Namespace: sr.Namespace},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, report, func() error {
+		report.Report = platformv1alpha1.StatusReport{
+			ObservedGeneration: sr.Generation,
+			SourceChecksum:     checksum,
+			Summary:            summary,
+			Targets:            targets,
+		}
+		return controllerutil.SetControllerReference(sr, report, r.Scheme)
+	})
+	return err
+}
+
+// deleteStatusReport removes a previously written report once reporting is disabled.
+//
+// Safety check: only reports controlled by this CR are deleted.
+func (r *SharedResourceReconciler) deleteStatusReport(ctx context.Context, sr *platformv1alpha1.SharedResource) error {
+	var report platformv1alpha1.SharedResourceStatusReport
+	if err := r.Get(ctx, types.NamespacedName{Name: sr.Name, Namespace: sr.Namespace}, &report); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(&report, sr) {
+		return nil
+	}
+	if err := r.Delete(ctx, &report); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// previousTargets returns the per-target status of the last sync. It is the
+// CR's status unless that was compacted, in which case the full list is read
+// from the companion report. If the report can't be read, the compacted
+// status is used: omitted targets then start over as if newly added.
+func (r *SharedResourceReconciler) previousTargets(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+) []platformv1alpha1.TargetSyncStatus {
+	if sr.Status.TargetSummary == nil || sr.Status.TargetSummary.Omitted == 0 {
+		return sr.Status.SyncedTargets
+	}
+	var report platformv1alpha1.SharedResourceStatusReport
+	if err := r.Get(ctx, types.NamespacedName{Name: sr.Name, Namespace: sr.Namespace}, &report); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read SharedResourceStatusReport of compacted status")
+		return sr.Status.SyncedTargets
+	}
+	if !metav1.IsControlledBy(&report, sr) {
+		return sr.Status.SyncedTargets
+	}
+	return report.Report.Targets
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Bundled sources (SharedResourceSet).
+//
+// A SharedResourceSet lists several sources in its namespace with one target
+// specification. The Set reconciler expands it into a SharedResource per
+// source, named "<set>-<source>" and owned by the Set, with the Set's
+// targets, targetSelector, syncPolicy and deletionPolicy; those are synced
+// like any other. Then:
+//   - a SharedResource of that name the Set does not own is left alone and
+//     its source reported as a Conflict
+//   - the SharedResource of a source removed from the list is deleted, so
+//     its deletionPolicy decides what happens to the copies; deleting the Set
+//     deletes them all through garbage collection
+//   - the Set's status lists each member's Ready condition, and its own Ready
+//     is True once every member is
+// =============================================================================
+
+// SharedResourceSetReconciler keeps a SharedResource for every source of a
+// SharedResourceSet.
+type SharedResourceSetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder emits events on SharedResourceSets. Nil disables events.
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourcesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourcesets/status,verbs=get;update;patch
+
+func (r *SharedResourceSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var set platformv1alpha1.SharedResourceSet
+	if err := r.Get(ctx, req.NamespacedName, &set); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// The SharedResources are owned by the Set, so garbage collection removes them
+	if !set.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	var errs []error
+	members := make([]platformv1alpha1.SetMemberStatus, 0, len(set.Spec.Sources))
+	for _, source := range set.Spec.Sources {
+		member, err := r.applyMember(ctx, &set, source)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		members = append(members, member)
+	}
+	if err := r.deleteRemovedMembers(ctx, &set); err != nil {
+		errs = append(errs, err)
+	}
+	if err := r.updateSetStatus(ctx, &set, members); err != nil {
+		errs = append(errs, err)
+	}
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// setMemberName returns the name of the SharedResource syncing a source of the Set.
+func setMemberName(set *platformv1alpha1.SharedResourceSet, source string) string {
+	return set.Name + "-" + source
+}
+
+// applyMember creates or updates the SharedResource of one source and
+// returns its state.
+func (r *SharedResourceSetReconciler) applyMember(
+	ctx context.Context,
+	set *platformv1alpha1.SharedResourceSet,
+	source platformv1alpha1.SetSource,
+) (platformv1alpha1.SetMemberStatus, error) {
+	member := platformv1alpha1.SetMemberStatus{
+		Kind: source.Kind, Name: source.Name, SharedResource: setMemberName(set, source.Name),
+		Ready: metav1.ConditionUnknown,
+	}
+
+	sr := &platformv1alpha1.SharedResource{ObjectMeta: metav1.ObjectMeta{Namespace: set.Namespace, Name: member.SharedResource}}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(sr), sr); client.IgnoreNotFound(err) != nil {
+		return member, err
+	} else if err == nil && !metav1.IsControlledBy(sr, set) {
+		member.Ready, member.Reason = metav1.ConditionFalse, "Conflict"
+		member.Message = fmt.Sprintf("SharedResource %s already exists and is not owned by this Set", sr.Name)
+		return member, nil
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, sr, func() error {
+		sr.Spec = platformv1alpha1.SharedResourceSpec{
+			Source:         platformv1alpha1.SourceSpec{Kind: source.Kind, Name: source.Name},
+			Targets:        set.Spec.Targets,
+			TargetSelector: set.Spec.TargetSelector.DeepCopy(),
+			SyncPolicy:     set.Spec.SyncPolicy.DeepCopy(),
+			DeletionPolicy: set.Spec.DeletionPolicy,
+		}
+		return controllerutil.SetControllerReference(set, sr, r.Scheme)
+	})
+	if err != nil {
+		member.Ready, member.Reason, member.Message = metav1.ConditionFalse, "ApplyFailed", err.Error()
+		return member, fmt.Errorf("failed to apply SharedResource %s: %w", sr.Name, err)
+	}
+	if op == controllerutil.OperationResultCreated {
+		logf.FromContext(ctx).Info("Created SharedResource for Set source", "source", source.Kind+"/"+source.Name,
+			"sharedresource", sr.Name)
+	}
+
+	// The SharedResource is synced by the SharedResource reconciler; report its state
+	ready := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeReady)
+	if ready == nil || sr.Status.ObservedGeneration != sr.Generation {
+		member.Reason, member.Message = "Pending", "Waiting for SharedResource "+sr.Name+" to sync"
+		return member, nil
+	}
+	member.Ready = ready.Status
+	if ready.Status != metav1.ConditionTrue {
+		member.Reason, member.Message = ready.Reason, ready.Message
+	}
+	return member, nil
+}
+
+// deleteRemovedMembers deletes the Set's SharedResources whose source is no
+// longer listed.
+func (r *SharedResourceSetReconciler) deleteRemovedMembers(ctx context.Context, set *platformv1alpha1.SharedResourceSet) error {
+	listed := make(map[string]bool, len(set.Spec.Sources))
+	for _, source := range set.Spec.Sources {
+		listed[setMemberName(set, source.Name)] = true
+	}
+	var owned platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &owned, client.InNamespace(set.Namespace)); err != nil {
+		return err
+	}
+	for i := range owned.Items {
+		sr := &owned.Items[i]
+		if listed[sr.Name] || !metav1.IsControlledBy(sr, set) || !sr.DeletionTimestamp.IsZero() {
+			continue
+		}
+		logf.FromContext(ctx).Info("Source removed from Set, deleting SharedResource", "sharedresource", sr.Name)
+		if err := r.Delete(ctx, sr); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		r.recordEvent(set, corev1.EventTypeNormal, "MemberRemoved",
+			"Source %s/%s is no longer listed; deleted SharedResource %s", sr.Spec.Source.Kind, sr.Spec.Source.Name, sr.Name)
+	}
+	return nil
+}
+
+// updateSetStatus sets the Set's members and Ready condition, writing the
+// status only if it changed.
+func (r *SharedResourceSetReconciler) updateSetStatus(
+	ctx context.Context,
+	set *platformv1alpha1.SharedResourceSet,
+	members []platformv1alpha1.SetMemberStatus,
+) error {
+	var ready int32
+	var notReady, pending []string
+	for _, m := range members {
+		switch m.Ready {
+		case metav1.ConditionTrue:
+			ready++
+		case metav1.ConditionFalse:
+			notReady = append(notReady, fmt.Sprintf("%s (%s)", m.SharedResource, m.Reason))
+		default:
+			pending = append(pending, m.SharedResource)
+		}
+	}
+	condition := metav1.Condition{
+		Type:               ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AllReady",
+		Message:            fmt.Sprintf("All %d SharedResources are Ready", len(members)),
+		ObservedGeneration: set.Generation,
+	}
+	switch {
+	case len(notReady) > 0:
+		condition.Status, condition.Reason = metav1.ConditionFalse, "MembersNotReady"
+		condition.Message = fmt.Sprintf("%d of %d SharedResources are Ready; not ready: %s",
+			ready, len(members), strings.Join(notReady, ", "))
+	case len(pending) > 0:
+		condition.Status, condition.Reason = metav1.ConditionUnknown, "Pending"
+		condition.Message = "Waiting for SharedResources to sync: " + strings.Join(pending, ", ")
+	}
+
+	before := set.Status.DeepCopy()
+	meta.SetStatusCondition(&set.Status.Conditions, condition)
+	set.Status.Members = members
+	set.Status.ReadyMembers = ready
+	set.Status.ObservedGeneration = set.Generation
+	if equality.Semantic.DeepEqual(before, &set.Status) {
+		return nil
+	}
+	return r.Status().Update(ctx, set)
+}
+
+// recordEvent emits an event on the Set if a recorder is set.
+func (r *SharedResourceSetReconciler) recordEvent(set *platformv1alpha1.SharedResourceSet, eventType, reason, messageFmt string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(set, eventType, reason, messageFmt, args...)
+}
+
+// SetupWithManager sets up the Set controller with the Manager.
+func (r *SharedResourceSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&platformv1alpha1.SharedResourceSet{}).
+		// Aggregate the members' Ready conditions as they sync
+		Owns(&platformv1alpha1.SharedResource{}).
+		Named("sharedresourceset").
+		Complete(r)
+}
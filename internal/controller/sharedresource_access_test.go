@@ -0,0 +1,304 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Access Distribution", func() {
+	ctx := context.Background()
+
+	It("should grant named ServiceAccounts read access to the synced Secret", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("access-src-%d", suffix)
+		targetNSName := fmt.Sprintf("access-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		removedNSName := fmt.Sprintf("access-removed-%d", suffix)
+		removedNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: removedNSName}}
+		Expect(k8sClient.Create(ctx, removedNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, removedNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "access-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource with an access grant and delete policy
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-access", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "access-secret"},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName}, {Namespace: removedNSName}},
+				Access:         &platformv1alpha1.AccessSpec{ServiceAccounts: []string{"api-server"}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Role grants get on exactly the synced Secret
+		grantKey := types.NamespacedName{Name: "sharedresource-access-secret-read", Namespace: targetNSName}
+		role := &rbacv1.Role{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, grantKey, role)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(role.Rules).To(HaveLen(1))
+		Expect(role.Rules[0].Resources).To(Equal([]string{"secrets"}))
+		Expect(role.Rules[0].ResourceNames).To(Equal([]string{"access-secret"}))
+		Expect(role.Rules[0].Verbs).To(Equal([]string{"get"}))
+
+		// RoleBinding grants the Role to the ServiceAccount
+		binding := &rbacv1.RoleBinding{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, grantKey, binding)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(binding.RoleRef.Name).To(Equal(grantKey.Name))
+		Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{
+			Kind: rbacv1.ServiceAccountKind, Name: "api-server", Namespace: targetNSName,
+		}))
+
+		// Removing a target revokes its grant
+		removedKey := types.NamespacedName{Name: grantKey.Name, Namespace: removedNSName}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, removedKey, &rbacv1.RoleBinding{})
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Eventually(func() error {
+			current := &platformv1alpha1.SharedResource{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-access", Namespace: sourceNSName}, current); err != nil {
+				return err
+			}
+			current.Spec.Targets = []platformv1alpha1.TargetSpec{{Namespace: targetNSName}}
+			return k8sClient.Update(ctx, current)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, removedKey, &rbacv1.Role{})) &&
+				apierrors.IsNotFound(k8sClient.Get(ctx, removedKey, &rbacv1.RoleBinding{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Expect(k8sClient.Get(ctx, grantKey, &rbacv1.RoleBinding{})).To(Succeed())
+
+		// Removing spec.access revokes the grant
+		Eventually(func() error {
+			current := &platformv1alpha1.SharedResource{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-access", Namespace: sourceNSName}, current); err != nil {
+				return err
+			}
+			current.Spec.Access = nil
+			return k8sClient.Update(ctx, current)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, grantKey, &rbacv1.Role{})) &&
+				apierrors.IsNotFound(k8sClient.Get(ctx, grantKey, &rbacv1.RoleBinding{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// Deleting the CR removes the grant along with the data
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, grantKey, &rbacv1.Role{})) &&
+				apierrors.IsNotFound(k8sClient.Get(ctx, grantKey, &rbacv1.RoleBinding{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+	})
+
+	It("should not take over another SharedResource's access grant", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSNames := []string{fmt.Sprintf("grant-src-a-%d", suffix), fmt.Sprintf("grant-src-b-%d", suffix)}
+		targetNSName := fmt.Sprintf("grant-tgt-%d", suffix)
+		for _, name := range append([]string{targetNSName}, sourceNSNames...) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+
+		// Both CRs merge their own key into one target, so only the grant is shared
+		grantKey := types.NamespacedName{Name: "sharedresource-grant-secret-read", Namespace: targetNSName}
+		for i, sourceNSName := range sourceNSNames {
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant-secret", Namespace: sourceNSName},
+				Data:       map[string][]byte{fmt.Sprintf("key-%d", i): []byte("value")},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &platformv1alpha1.SharedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "sync-grant", Namespace: sourceNSName},
+				Spec: platformv1alpha1.SharedResourceSpec{
+					Source:     platformv1alpha1.SourceSpec{Kind: "Secret", Name: "grant-secret"},
+					Targets:    []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+					SyncPolicy: &platformv1alpha1.SyncPolicySpec{Mode: platformv1alpha1.SyncModeMerge},
+					Access:     &platformv1alpha1.AccessSpec{ServiceAccounts: []string{fmt.Sprintf("reader-%d", i)}},
+				},
+			})).To(Succeed())
+			if i == 0 {
+				Eventually(func() error {
+					return k8sClient.Get(ctx, grantKey, &rbacv1.RoleBinding{})
+				}, time.Second*10, time.Millisecond*250).Should(Succeed())
+			}
+		}
+
+		// The first CR's grant is labeled with its UID
+		first := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "sync-grant", Namespace: sourceNSNames[0]}, first)).To(Succeed())
+		role := &rbacv1.Role{}
+		Expect(k8sClient.Get(ctx, grantKey, role)).To(Succeed())
+		Expect(role.Labels).To(HaveKeyWithValue(LabelGrantedBy, string(first.UID)))
+
+		// The second CR's target fails with a conflict and the grant is left alone
+		Eventually(func() string {
+			current := &platformv1alpha1.SharedResource{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-grant", Namespace: sourceNSNames[1]}, current); err != nil ||
+				len(current.Status.SyncedTargets) == 0 {
+				return ""
+			}
+			return current.Status.SyncedTargets[0].Error
+		}, time.Second*10, time.Millisecond*250).Should(ContainSubstring("is managed by SharedResource " + sourceNSNames[0] + "/sync-grant"))
+		binding := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, grantKey, binding)).To(Succeed())
+		Expect(binding.Subjects).To(HaveLen(1))
+		Expect(binding.Subjects[0].Name).To(Equal("reader-0"))
+	})
+
+	It("should link the synced Secret into ServiceAccounts", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("link-src-%d", suffix)
+		targetNSName := fmt.Sprintf("link-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Pre-existing ServiceAccounts (no ServiceAccount controller in envtest)
+		for _, name := range []string{"puller", "legacy", "tooling"} {
+			sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: targetNSName}}
+			Expect(k8sClient.Create(ctx, sa)).To(Succeed())
+		}
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "link-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource linking the Secret in every mode
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-link", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "link-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				Access: &platformv1alpha1.AccessSpec{ServiceAccountLinks: []platformv1alpha1.ServiceAccountLink{
+					{Name: "puller", Mode: platformv1alpha1.ServiceAccountLinkImagePullSecrets},
+					{Name: "legacy", Mode: platformv1alpha1.ServiceAccountLinkSecrets},
+					{Name: "tooling", Mode: platformv1alpha1.ServiceAccountLinkAnnotation},
+				}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Each ServiceAccount references the Secret in the requested way
+		puller, legacy, tooling := &corev1.ServiceAccount{}, &corev1.ServiceAccount{}, &corev1.ServiceAccount{}
+		Eventually(func() bool {
+			if k8sClient.Get(ctx, types.NamespacedName{Name: "puller", Namespace: targetNSName}, puller) != nil ||
+				k8sClient.Get(ctx, types.NamespacedName{Name: "legacy", Namespace: targetNSName}, legacy) != nil ||
+				k8sClient.Get(ctx, types.NamespacedName{Name: "tooling", Namespace: targetNSName}, tooling) != nil {
+				return false
+			}
+			return len(puller.ImagePullSecrets) == 1 && len(legacy.Secrets) == 1 &&
+				tooling.Annotations[AnnotationSharedSecrets] != ""
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Expect(puller.ImagePullSecrets[0].Name).To(Equal("link-secret"))
+		Expect(legacy.Secrets[0].Name).To(Equal("link-secret"))
+		Expect(tooling.Annotations[AnnotationSharedSecrets]).To(Equal("link-secret"))
+
+		Expect(puller.Annotations[AnnotationLinkedSecrets]).To(Equal("imagePullSecrets/link-secret"))
+
+		// Dropping a ServiceAccount from the spec unlinks it
+		Eventually(func() error {
+			current := &platformv1alpha1.SharedResource{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-link", Namespace: sourceNSName}, current); err != nil {
+				return err
+			}
+			current.Spec.Access.ServiceAccountLinks = current.Spec.Access.ServiceAccountLinks[:1]
+			return k8sClient.Update(ctx, current)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func() bool {
+			if k8sClient.Get(ctx, types.NamespacedName{Name: "legacy", Namespace: targetNSName}, legacy) != nil ||
+				k8sClient.Get(ctx, types.NamespacedName{Name: "tooling", Namespace: targetNSName}, tooling) != nil {
+				return false
+			}
+			_, annotated := tooling.Annotations[AnnotationSharedSecrets]
+			_, recorded := legacy.Annotations[AnnotationLinkedSecrets]
+			return len(legacy.Secrets) == 0 && !annotated && !recorded
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "puller", Namespace: targetNSName}, puller)).To(Succeed())
+		Expect(puller.ImagePullSecrets).To(HaveLen(1))
+
+		// No RBAC objects are created when only links are requested
+		grantKey := types.NamespacedName{Name: "sharedresource-link-secret-read", Namespace: targetNSName}
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, grantKey, &rbacv1.Role{}))).To(BeTrue())
+
+		// Deleting the CR unlinks the Secret but keeps the ServiceAccounts
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func() bool {
+			if k8sClient.Get(ctx, types.NamespacedName{Name: "puller", Namespace: targetNSName}, puller) != nil {
+				return false
+			}
+			_, recorded := puller.Annotations[AnnotationLinkedSecrets]
+			return len(puller.ImagePullSecrets) == 0 && !recorded
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+	})
+
+	It("should reject ServiceAccount links for ConfigMap sources", func() {
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "link-configmap", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "some-config"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: "elsewhere"}},
+				Access: &platformv1alpha1.AccessSpec{ServiceAccountLinks: []platformv1alpha1.ServiceAccountLink{
+					{Name: "default"},
+				}},
+			},
+		}
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("only supported for Secret sources"))
+	})
+})
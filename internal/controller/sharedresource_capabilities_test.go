@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Capability Report", func() {
+	ctx := context.Background()
+
+	It("should check every capability and publish the report", func() {
+		capabilities, err := CheckCapabilities(ctx, k8sClient, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capabilities).To(HaveLen(len(capabilityChecks)))
+		for _, c := range capabilities {
+			// envtest runs as cluster admin
+			Expect(c.Allowed).To(BeTrue(), c.Name)
+			Expect(c.Missing).To(BeEmpty())
+		}
+		Expect(CapabilityAllowed(capabilities, "no-such-capability")).To(BeFalse())
+
+		nsName := fmt.Sprintf("capabilities-%d", time.Now().UnixNano()%100000)
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+
+		capabilities[1].Allowed = false
+		capabilities[1].Missing = []string{"delete secrets"}
+		r := &SharedResourceReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			CleanupOptions:  CleanupOptions{SweepReportNamespace: nsName},
+			InstanceOptions: InstanceOptions{OperatorClass: "caps"},
+			Capabilities:    capabilities,
+		}
+		Expect((&capabilityReporter{r: r}).Start(ctx)).To(Succeed())
+		Expect(r.saveCapabilityReport(ctx)).To(Succeed())
+
+		var cm corev1.ConfigMap
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: nsName, Name: CapabilityReportName + "-caps"}, &cm)).To(Succeed())
+		var report []Capability
+		Expect(json.Unmarshal([]byte(cm.Data[capabilityReportKey]), &report)).To(Succeed())
+		Expect(report).To(Equal(capabilities))
+	})
+})
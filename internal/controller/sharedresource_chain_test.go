@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Chained Shares", func() {
+	ctx := context.Background()
+
+	It("should stamp chained targets with origin and hops and bound the chain", func() {
+		suffix := time.Now().UnixNano() % 100000
+		firstNSName := fmt.Sprintf("chain-a-%d", suffix)
+		secondNSName := fmt.Sprintf("chain-b-%d", suffix)
+		thirdNSName := fmt.Sprintf("chain-c-%d", suffix)
+		for _, name := range []string{firstNSName, secondNSName, thirdNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "relay", Namespace: firstNSName},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CRs
+		first := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "share-first", Namespace: firstNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "relay"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: secondNSName}},
+				OperatorClass: "chain",
+			},
+		}
+		second := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "share-second", Namespace: secondNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "relay"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: thirdNSName}},
+				OperatorClass: "chain",
+			},
+		}
+		Expect(k8sClient.Create(ctx, first)).To(Succeed())
+		Expect(k8sClient.Create(ctx, second)).To(Succeed())
+		firstKey := types.NamespacedName{Name: "share-first", Namespace: firstNSName}
+		secondKey := types.NamespacedName{Name: "share-second", Namespace: secondNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "chain"}, TargetOptions: TargetOptions{MaxShareHops: 1}}
+		reconcile := func(key types.NamespacedName) {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		// The first reconciles only add the finalizers
+		reconcile(firstKey)
+		reconcile(secondKey)
+
+		By("leaving the targets of a direct share unstamped")
+		reconcile(firstKey)
+		relay := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "relay", Namespace: secondNSName}, relay)).To(Succeed())
+		Expect(relay.Annotations).NotTo(HaveKey(AnnotationOrigin))
+		Expect(relay.Annotations).NotTo(HaveKey(AnnotationHops))
+
+		By("refusing to sync a chain longer than --max-share-hops")
+		reconcile(secondKey)
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, secondKey, current)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeHopLimitExceeded)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+		thirdKey := types.NamespacedName{Name: "relay", Namespace: thirdNSName}
+		err := k8sClient.Get(ctx, thirdKey, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		By("stamping the second hop with the origin once the limit allows it")
+		r.MaxShareHops = 2
+		reconcile(secondKey)
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, thirdKey, target)).To(Succeed())
+		Expect(target.Data).To(Equal(source.Data))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationOrigin, firstNSName+"/relay"))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationHops, "2"))
+		Expect(target.Annotations[AnnotationProvenance]).To(ContainSubstring(`"hops":2`))
+		Expect(k8sClient.Get(ctx, secondKey, current)).To(Succeed())
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeHopLimitExceeded)).To(BeNil())
+
+		By("cleaning up")
+		for _, key := range []types.NamespacedName{secondKey, firstKey} {
+			sr := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, sr)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+			reconcile(key)
+		}
+	})
+
+	It("should carry a source change down the chain without waiting for a resync", func() {
+		suffix := time.Now().UnixNano() % 100000
+		firstNSName := fmt.Sprintf("chain-wake-a-%d", suffix)
+		secondNSName := fmt.Sprintf("chain-wake-b-%d", suffix)
+		thirdNSName := fmt.Sprintf("chain-wake-c-%d", suffix)
+		for _, name := range []string{firstNSName, secondNSName, thirdNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "relay", Namespace: firstNSName},
+			Data:       map[string][]byte{"token": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The manager's reconciler syncs both hops
+		for _, sr := range []*platformv1alpha1.SharedResource{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "wake-first", Namespace: firstNSName},
+				Spec: platformv1alpha1.SharedResourceSpec{
+					Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "relay"},
+					Targets: []platformv1alpha1.TargetSpec{{Namespace: secondNSName}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "wake-second", Namespace: secondNSName},
+				Spec: platformv1alpha1.SharedResourceSpec{
+					Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "relay"},
+					Targets: []platformv1alpha1.TargetSpec{{Namespace: thirdNSName}},
+				},
+			},
+		} {
+			Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, sr) })
+		}
+		thirdKey := types.NamespacedName{Name: "relay", Namespace: thirdNSName}
+		targetToken := func(g Gomega) string {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, thirdKey, target)).To(Succeed())
+			return string(target.Data["token"])
+		}
+		Eventually(targetToken, 10*time.Second).Should(Equal("v1"))
+
+		By("updating the last hop once the first hop's target changes")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "relay", Namespace: firstNSName}, source)).To(Succeed())
+		source.Data["token"] = []byte("v2")
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Eventually(targetToken, 10*time.Second).Should(Equal("v2"))
+	})
+})
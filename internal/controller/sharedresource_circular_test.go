@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Circular Shares", func() {
+	ctx := context.Background()
+
+	It("should sync chained shares and reject loops", func() {
+		suffix := time.Now().UnixNano() % 100000
+		firstNS := fmt.Sprintf("chain-a-%d", suffix)
+		secondNS := fmt.Sprintf("chain-b-%d", suffix)
+		thirdNS := fmt.Sprintf("chain-c-%d", suffix)
+		for _, name := range []string{firstNS, secondNS, thirdNS} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "chained", Namespace: firstNS},
+			Data:       map[string][]byte{"token": []byte("t0")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CRs
+		share := func(namespace, target string) types.NamespacedName {
+			GinkgoHelper()
+			sr := &platformv1alpha1.SharedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "forward", Namespace: namespace},
+				Spec: platformv1alpha1.SharedResourceSpec{
+					Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "chained"},
+					Targets:       []platformv1alpha1.TargetSpec{{Namespace: target}},
+					OperatorClass: "circular",
+				},
+			}
+			Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+			return types.NamespacedName{Namespace: namespace, Name: "forward"}
+		}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "circular"}}
+		reconcile := func(key types.NamespacedName) *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		setTarget := func(key types.NamespacedName, target string) {
+			GinkgoHelper()
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			current.Spec.Targets = []platformv1alpha1.TargetSpec{{Namespace: target}}
+			Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		}
+
+		By("forwarding a target of one share through the next")
+		first := share(firstNS, secondNS)
+		second := share(secondNS, thirdNS)
+		for _, key := range []types.NamespacedName{first, first, second, second} {
+			reconcile(key)
+		}
+		Eventually(func(g Gomega) {
+			forwarded := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "chained", Namespace: thirdNS}, forwarded)).To(Succeed())
+			g.Expect(forwarded.Data).To(HaveKeyWithValue("token", []byte("t0")))
+		}).Should(Succeed())
+		Expect(meta.FindStatusCondition(reconcile(first).Status.Conditions, ConditionTypeCircularReference)).To(BeNil())
+
+		By("rejecting every share of a loop")
+		setTarget(second, firstNS)
+		for _, key := range []types.NamespacedName{second, first} {
+			current := reconcile(key)
+			Expect(conditionIsTrue(current, ConditionTypeCircularReference)).To(BeTrue(), key.String())
+			Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeFalse())
+			c := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeCircularReference)
+			Expect(c.Message).To(ContainSubstring(first.String()))
+			Expect(c.Message).To(ContainSubstring(second.String()))
+		}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "chained", Namespace: firstNS}, source)).To(Succeed())
+		Expect(source.Annotations).NotTo(HaveKey(AnnotationManagedBy))
+
+		By("syncing again once the loop is broken")
+		setTarget(second, thirdNS)
+		for _, key := range []types.NamespacedName{second, first} {
+			current := reconcile(key)
+			Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeCircularReference)).To(BeNil())
+			Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue(), key.String())
+		}
+
+		By("rejecting a share whose target is its own source")
+		setTarget(second, secondNS)
+		current := reconcile(second)
+		c := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeCircularReference)
+		Expect(c).NotTo(BeNil())
+		Expect(c.Message).To(Equal("A target is the source of this SharedResource"))
+
+		By("cleaning up")
+		for _, key := range []types.NamespacedName{second, first} {
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// legacyDeletionPolicy is the legacy key of a rename made up for the tests
+const legacyDeletionPolicy = "sharedresource.platform.dev/on-delete"
+
+var _ = Describe("Renamed Markers", func() {
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		renamed := renamedMarkers
+		renamedMarkers = []renamedMarker{{legacy: legacyDeletionPolicy, current: AnnotationDeletionPolicy}}
+		DeferCleanup(func() { renamedMarkers = renamed })
+	})
+
+	It("should read the legacy form of a renamed marker as the current one", func() {
+		legacy := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{legacyDeletionPolicy: "delete"}}}
+		_, err := TranslateLegacyMarkers(legacy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(legacy.Annotations).To(Equal(map[string]string{
+			legacyDeletionPolicy: "delete", AnnotationDeletionPolicy: "delete",
+		}))
+
+		// The current form wins over a leftover legacy one
+		both := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			legacyDeletionPolicy: "delete", AnnotationDeletionPolicy: "orphan",
+		}}}
+		_, err = TranslateLegacyMarkers(both)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(both.Annotations).To(HaveKeyWithValue(AnnotationDeletionPolicy, "orphan"))
+	})
+
+	It("should rewrite a target to the current form on its next sync", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("compat-src-%d", suffix)
+		targetNSName := fmt.Sprintf("compat-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "compat-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		})).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-compat", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "compat-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "compat",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-compat", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "compat-secret", Namespace: targetNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "compat"}}
+		reconcile := func() {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+		reconcile()
+
+		By("counting a target an earlier release wrote with the legacy key")
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		target.Annotations[legacyDeletionPolicy] = target.Annotations[AnnotationDeletionPolicy]
+		delete(target.Annotations, AnnotationDeletionPolicy)
+		Expect(k8sClient.Update(ctx, target)).To(Succeed())
+		counts, err := r.legacyMarkerCounts(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counts).To(HaveKeyWithValue(KindSecret, 1))
+
+		By("writing the current key and dropping the legacy one")
+		// As the target watch would
+		r.verified.invalidate(key)
+		reconcile()
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationDeletionPolicy, "orphan"))
+		Expect(target.Annotations).NotTo(HaveKey(legacyDeletionPolicy))
+		Expect(target.Data).To(Equal(map[string][]byte{"password": []byte("v1")}))
+		counts, err = r.legacyMarkerCounts(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counts).To(HaveKeyWithValue(KindSecret, 0))
+
+		By("cleaning up")
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		reconcile()
+	})
+})
@@ -0,0 +1,144 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Consistency Report", func() {
+	ctx := context.Background()
+
+	It("should count the targets per source checksum during a rollout", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("consistency-src-%d", suffix)
+		current := fmt.Sprintf("consistency-current-%d", suffix)
+		outdated := fmt.Sprintf("consistency-outdated-%d", suffix)
+		unknown := fmt.Sprintf("consistency-unknown-%d", suffix)
+		for name, nsLabels := range map[string]map[string]string{
+			sourceNSName: nil, current: {"rollout": "first"}, outdated: nil, unknown: nil,
+		} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "consistency-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-consistency", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "consistency-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: current}, {Namespace: outdated}},
+				OperatorClass: "consistency",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-consistency", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "consistency"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("reporting every target current once synced")
+		cr := reconcile()
+		first := cr.Status.SourceChecksum
+		Expect(cr.Status.Consistency).To(Equal(&platformv1alpha1.ConsistencyStatus{Current: 2}))
+
+		By("reporting the targets a new source value has not reached yet")
+		policy := &platformv1alpha1.SharedResourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "consistency-policy", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourcePolicySpec{
+				Sources:                 []platformv1alpha1.PolicySource{{Kind: "Secret", Name: "consistency-secret"}},
+				TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"rollout": "first"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		source.Data = map[string][]byte{"password": []byte("v2")}
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		cr.Spec.Targets = append(cr.Spec.Targets, platformv1alpha1.TargetSpec{Namespace: unknown})
+		Expect(k8sClient.Update(ctx, cr)).To(Succeed())
+		cr = reconcile()
+		Expect(cr.Status.SourceChecksum).NotTo(Equal(first))
+		Expect(cr.Status.Consistency).To(Equal(&platformv1alpha1.ConsistencyStatus{
+			Current:           1,
+			Outdated:          1,
+			Unknown:           1,
+			OutdatedChecksums: []platformv1alpha1.ChecksumTargets{{Checksum: first, Targets: 1}},
+		}))
+
+		By("converging once the remaining targets sync")
+		Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+		// As the policy watch would
+		r.verified.invalidate(key)
+		cr = reconcile()
+		Expect(cr.Status.Consistency).To(Equal(&platformv1alpha1.ConsistencyStatus{Current: 3}))
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, cr)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should take targets left out of a compact status to be at its checksum", func() {
+		sr := &platformv1alpha1.SharedResource{Status: platformv1alpha1.SharedResourceStatus{
+			SourceChecksum: "new",
+			SyncedTargets:  []platformv1alpha1.TargetSyncStatus{{Namespace: "failing", Name: "s", SourceChecksum: "old"}},
+		}}
+		previous := previousSourceChecksums(sr.Status.SyncedTargets)
+		Expect(lastSourceChecksum(sr, previous, targetKey("failing", "s"))).To(Equal("old"))
+		Expect(lastSourceChecksum(sr, previous, targetKey("listed-later", "s"))).To(BeEmpty())
+
+		sr.Status.TargetSummary = &platformv1alpha1.TargetSummary{Total: 3, Synced: 2, Failed: 1, Omitted: 2}
+		Expect(lastSourceChecksum(sr, previous, targetKey("omitted", "s"))).To(Equal("new"))
+
+		// Released targets are not counted
+		Expect(consistencyOf([]platformv1alpha1.TargetSyncStatus{
+			{SourceChecksum: "new"}, {SourceChecksum: "old"}, {SourceChecksum: "older"}, {SourceChecksum: "old"},
+			{Released: true, SourceChecksum: "old"},
+		}, "new")).To(Equal(&platformv1alpha1.ConsistencyStatus{
+			Current:  1,
+			Outdated: 3,
+			OutdatedChecksums: []platformv1alpha1.ChecksumTargets{
+				{Checksum: "old", Targets: 2}, {Checksum: "older", Targets: 1},
+			},
+		}))
+	})
+})
@@ -18,21 +18,32 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
 )
 
 // =============================================================================
@@ -43,12 +54,71 @@ import (
 //
 // Related files:
 // - constants.go: Annotation keys, finalizer name, condition types
-// - helpers.go: Utility functions (checksum, filtering, conditions)
+// - options.go: Installation-wide options, grouped by concern
+// - helpers.go: Utility functions (conditions, status, source key annotations)
 // - sync.go: Secret/ConfigMap sync operations
 // =============================================================================
 type SharedResourceReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	InstanceOptions
+	SourceOptions
+	TargetOptions
+	HookOptions
+	CleanupOptions
+
+	// Recorder emits events on SharedResources. Nil disables events.
+	Recorder record.EventRecorder
+
+	// Clock decides retry, resync and rotation times and the timestamps
+	// recorded in status and on targets. Nil uses the real clock.
+	Clock clock.PassiveClock
+
+	// CompactStatusThreshold switches a CR to compact status reporting when it
+	// resolves to more targets than this, even if spec.statusPolicy asks for full
+	// mode. Zero disables the automatic switch.
+	CompactStatusThreshold int
+
+	// DiffAPI serves target diffs on the metrics server (see diff.go).
+	DiffAPI bool
+
+	// Capabilities is the startup RBAC self-check, published as a gauge and,
+	// with a SweepReportNamespace, a ConfigMap (see capabilities.go). Nil
+	// publishes nothing.
+	Capabilities []Capability
+
+	// verified tracks CRs fully reconciled by this process since their last
+	// source/target event. Used to skip no-op reconciles (see gating.go).
+	verified verificationTracker
+
+	// writes remembers the data checksum of our latest write to each target
+	// until its watch event is ignored or the target is deleted (see predicates.go).
+	writes sync.Map
+
+	// mutations remembers targets whose last write admission altered, so
+	// they are not rewritten in vain (see writeverify.go).
+	mutations sync.Map
+
+	// deletions remembers when each managed target was deleted out-of-band,
+	// until it is recreated (see recreate.go).
+	deletions sync.Map
+
+	// managedBytes remembers the data size held in each CR's synced targets
+	// as of its latest sync (see inventory.go).
+	managedBytes sync.Map
+
+	// throttle pauses target writes after the API server answered 429
+	// (see throttle.go).
+	throttle apiThrottle
+
+	// kindAPIs remembers the optional kinds whose API is not served
+	// (see kindapis.go).
+	kindAPIs kindAPIs
+
+	// namespaces holds the active namespaces selectors and patterns match
+	// (see namespacecache.go).
+	namespaces namespaceSet
 }
 
 // =============================================================================
@@ -60,10 +130,20 @@ type SharedResourceReconciler struct {
 // +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresources,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresources/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresources/finalizers,verbs=update
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourcestatusreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=sharedresourcepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=platform.platform.dev,resources=namespacegroups,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=list;watch
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // =============================================================================
 // Reconcile is the core reconciliation loop.
@@ -82,16 +162,42 @@ func (r *SharedResourceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// -------------------------------------------------------------------------
 	// Step 1: Fetch the SharedResource CR
 	// -------------------------------------------------------------------------
+	// Capture the verification epoch before reading anything, so events that
+	// arrive while this reconcile runs are not lost (see gating.go)
+	epoch := r.verified.begin(req.NamespacedName)
+
 	var sharedResource platformv1alpha1.SharedResource
 	if err := r.Get(ctx, req.NamespacedName, &sharedResource); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("SharedResource not found, likely deleted")
+			if r.DevMode {
+				log.V(1).Info("Dev mode runs no finalizer; the targets of a deleted SharedResource are left in place")
+			}
+			r.verified.forget(req.NamespacedName)
+			r.managedBytes.Delete(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to fetch SharedResource")
 		return ctrl.Result{}, err
 	}
 
+	// CRs of another operator instance are left to it (see operatorclass.go)
+	if !r.managesCR(&sharedResource) {
+		return ctrl.Result{}, r.handOver(ctx, &sharedResource, log)
+	}
+
+	// CRs needing a disabled kind are rejected, not synced (see kinds.go)
+	if errs := DisabledKindErrors(&sharedResource, r.DisabledKinds); len(errs) > 0 {
+		return r.rejectDisabledKinds(ctx, &sharedResource, errs, log)
+	}
+	clearRejected(&sharedResource)
+
+	// CRs needing a kind whose API is not served wait for it (see kindapis.go)
+	if message := r.unavailableKind(ctx, &sharedResource); message != "" {
+		return r.recordKindUnavailable(ctx, &sharedResource, message, log)
+	}
+	clearKindUnavailable(&sharedResource)
+
 	// -------------------------------------------------------------------------
 	// Step 2: Handle deletion with finalizer
 	// -------------------------------------------------------------------------
@@ -102,62 +208,222 @@ func (r *SharedResourceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// -------------------------------------------------------------------------
 	// Step 3: Add finalizer if not present
 	// -------------------------------------------------------------------------
-	if !controllerutil.ContainsFinalizer(&sharedResource, FinalizerName) {
+	if r.usesFinalizer() && !controllerutil.ContainsFinalizer(&sharedResource, r.finalizer()) {
 		log.Info("Adding finalizer")
-		controllerutil.AddFinalizer(&sharedResource, FinalizerName)
+		controllerutil.AddFinalizer(&sharedResource, r.finalizer())
 		if err := r.Update(ctx, &sharedResource); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// A terminating source namespace freezes every copy; say so loudly
+	if err := r.warnIfSourceNamespaceDeleting(ctx, &sharedResource); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A source.nameTemplate that does not render names no source (see sourcename.go)
+	if _, err := ResolveSourceName(&sharedResource); err != nil {
+		return r.recordInvalidSourceName(ctx, &sharedResource, err, log)
+	}
+
+	// A source in another namespace needs its consent (see sourcenamespace.go)
+	denied, err := r.sourceExportDenied(ctx, &sharedResource)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if denied != "" {
+		return r.recordSourceExportDenied(ctx, &sharedResource, denied, log)
+	}
+	clearSourceExportDenied(&sharedResource)
+
+	// Group, pattern and selector targets are resolved now (see namespacegroups.go,
+	// namespacepatterns.go, targetselector.go)
+	targets, err := r.resolveTargets(ctx, &sharedResource)
+	if errors.Is(err, errGroupUnavailable) || errors.Is(err, errInvalidNamespacePattern) ||
+		errors.Is(err, errInvalidTargetSelector) {
+		return r.recordUnresolvedTargets(ctx, &sharedResource, err, log)
+	}
+	if err != nil {
+		log.Error(err, "Failed to resolve targets")
+		return ctrl.Result{}, err
+	}
+
+	// Targets feeding back into the source would sync forever (see circular.go)
+	loop, err := r.circularReference(ctx, &sharedResource)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(loop) > 0 {
+		return r.recordCircularReference(ctx, &sharedResource, loop, log)
+	}
+	clearCircularReference(&sharedResource)
+
+	// A renew request rewrites the expiry first; the update reconciles again
+	if renewRequested(&sharedResource) {
+		return ctrl.Result{}, r.renewShare(ctx, &sharedResource, log)
+	}
+
+	// An expired share is revoked (or left unrefreshed) instead of synced
+	expired, expireAfter := r.checkExpiry(&sharedResource)
+	if expired {
+		log.V(1).Info("Share expired, not syncing targets", "expiresAt", sharedResource.Status.ExpiresAt,
+			"expiryPolicy", sharedResource.Spec.ExpiryPolicy)
+		return r.handleExpired(ctx, &sharedResource, log)
+	}
+
 	// -------------------------------------------------------------------------
-	// Step 4: Fetch the source resource
+	// Step 4: Generate declared keys, then fetch the source resource
 	// -------------------------------------------------------------------------
-	sourceData, sourceType, err := r.fetchSourceResource(ctx, &sharedResource)
+	// A suspended CR writes nothing, so generated keys wait as well
+	suspended := suspensionHeld(&sharedResource)
+	var rotateAfter time.Duration
+	if !suspended {
+		r.clearSuspended(&sharedResource)
+		var err error
+		if rotateAfter, err = r.ensureGenerated(ctx, &sharedResource); err != nil {
+			log.Error(err, "Failed to generate source keys")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Polled CRs come back at the poll interval even when nothing else is due;
+	// the trigger is decided before this reconcile marks the CR verified again
+	poll := r.sourcePollInterval(&sharedResource)
+	trigger := r.syncTrigger(&sharedResource)
+	resync := r.resyncInterval(ctx, &sharedResource)
+
+	sourceData, source, err := r.fetchSourceResource(ctx, &sharedResource)
 	if err != nil {
-		return r.handleSourceError(ctx, &sharedResource, err, log)
+		if apierrors.IsNotFound(err) {
+			if skip, after := r.skipReconcile(&sharedResource, "", false, resync); skip {
+				after = sooner(sooner(after, poll), expireAfter)
+				log.V(1).Info("Source still missing, skipping until next retry", "requeueAfter", after)
+				return ctrl.Result{RequeueAfter: after}, nil
+			}
+		}
+		result, err := r.handleSourceError(ctx, &sharedResource, err, log)
+		if err == nil {
+			r.verified.markVerified(req.NamespacedName, epoch)
+			result.RequeueAfter = sooner(sooner(result.RequeueAfter, poll), expireAfter)
+		}
+		return result, err
 	}
 
 	// Source found - update condition
 	setCondition(&sharedResource, ConditionTypeSourceFound, metav1.ConditionTrue, "SourceExists", "Source resource found")
 
+	// Chains longer than --max-share-hops are not forwarded (see chain.go)
+	if r.hopLimitExceeded(source) {
+		return r.recordHopLimitExceeded(ctx, &sharedResource, source, log)
+	}
+	clearHopLimitExceeded(&sharedResource)
+
 	// -------------------------------------------------------------------------
 	// Step 5: Compute checksum for drift detection
 	// -------------------------------------------------------------------------
-	filteredData := filterData(sourceData, sharedResource.Spec.SyncPolicy)
-	checksum := computeChecksum(filteredData)
+	filteredData := syncengine.Filter(sourceData, sharedResource.Spec.SyncPolicy)
+
+	// Source-owner policies may strip keys, so enforce them before checksumming
+	decision, err := r.evaluatePolicies(ctx, &sharedResource)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	filteredData = decision.enforceKeys(filteredData)
+	checksum := syncengine.Checksum(filteredData)
 	log.Info("Computed source checksum", "checksum", checksum)
 
+	// Suspended CRs, and resumes awaiting approval, only report what resuming would propagate
+	if suspended {
+		if sharedResource.Spec.Suspend || resumeAwaitsApproval(&sharedResource, checksum) {
+			log.V(1).Info("Suspended, only reporting what resuming would propagate",
+				"suspend", sharedResource.Spec.Suspend, "awaitingApproval", !sharedResource.Spec.Suspend)
+			return r.recordSuspended(ctx, &sharedResource, checksum, log)
+		}
+		r.clearSuspended(&sharedResource)
+	}
+
+	// Nothing changed since the last full sync - skip target iteration
+	if skip, after := r.skipReconcile(&sharedResource, checksum, true, resync); skip {
+		log.V(1).Info("No changes since last sync, skipping", "requeueAfter", after)
+		return ctrl.Result{RequeueAfter: sooner(sooner(sooner(after, rotateAfter), poll), expireAfter)}, nil
+	}
+
+	// A changed source waits for spec.preSync to succeed (see presync.go)
+	if blocked, after, err := r.gatePreSync(ctx, &sharedResource, checksum, log); blocked || err != nil {
+		return ctrl.Result{RequeueAfter: sooner(sooner(after, poll), expireAfter)}, err
+	}
+	syncsTotal.WithLabelValues(trigger).Inc()
+	log.Info("Syncing targets", "trigger", trigger)
+
 	// -------------------------------------------------------------------------
 	// Step 6: Sync to each target namespace
 	// -------------------------------------------------------------------------
-	syncedTargets, allSynced := r.syncAllTargets(ctx, &sharedResource, filteredData, sourceType, checksum, log)
+	syncedTargets, variants, allSynced, resume := r.syncAllTargets(ctx, &sharedResource, targets, decision, filteredData, source, checksum, log)
+	if !resume.IsZero() {
+		// Retry the deferred targets once writes resume
+		resync = sooner(resync, max(resume.Sub(r.now()), time.Second))
+	}
+	// Forbidden targets are retried until access is granted (see forbidden.go)
+	resync = sooner(resync, forbiddenRetryAfter(syncedTargets))
+	r.applyVerifiedCondition(&sharedResource, syncedTargets)
+	if verificationRunning(&sharedResource) {
+		// Check the running verification Jobs again (see verify.go)
+		resync = sooner(resync, HookCheckInterval)
+	}
+	applyPolicyCondition(&sharedResource, decision)
+	applyVariants(&sharedResource, variants)
+	applyExternallyManagedCondition(&sharedResource, syncedTargets)
+	applyMutatedCondition(&sharedResource, syncedTargets)
+	r.applyDeletesDisabledCondition(&sharedResource)
+	if withheld := requestedWithheldKeys(&sharedResource, source.Withheld); len(withheld) > 0 {
+		r.recordEvent(&sharedResource, corev1.EventTypeWarning, "KeysWithheldBySource",
+			"Source %s does not allow sharing requested key(s) %s; they were not synced",
+			sourceName(&sharedResource), strings.Join(withheld, ", "))
+	}
+
+	r.explainIfRequested(&sharedResource, explainSync(&sharedResource, sourceData, filteredData, source, decision,
+		syncedTargets, r.explainNext(allSynced, resync, rotateAfter, poll, expireAfter))...)
 
 	// -------------------------------------------------------------------------
 	// Step 7: Update status
 	// -------------------------------------------------------------------------
-	return r.updateStatus(ctx, &sharedResource, syncedTargets, checksum, allSynced, log)
+	result, err := r.updateStatus(ctx, &sharedResource, syncedTargets, checksum, allSynced, resync, log)
+	if err == nil {
+		r.verified.markVerified(req.NamespacedName, epoch)
+		result.RequeueAfter = sooner(sooner(sooner(result.RequeueAfter, rotateAfter), poll), expireAfter)
+	}
+	return result, err
 }
 
 // handleDeletion processes the SharedResource deletion with finalizer cleanup.
+//
+// If some targets cannot be deleted, the finalizer stays and cleanup progress
+// is written to status.cleanup, so a Terminating CR explains what it waits for.
+//
+// Deletion policies (per target, see targetDeletionPolicy):
+// - "orphan": the target is left in place
+// - "delete": the finalizer waits until the target delete was accepted
+// - "deleteForeground": the finalizer waits until the target is gone
+// - "deleteBackground": the target is left to the sweeper (see sweeper.go)
 func (r *SharedResourceReconciler) handleDeletion(ctx context.Context, sr *platformv1alpha1.SharedResource, log logr.Logger) (ctrl.Result, error) {
-	if controllerutil.ContainsFinalizer(sr, FinalizerName) {
+	if controllerutil.ContainsFinalizer(sr, r.finalizer()) {
 		log.Info("Processing finalizer for deletion")
 
-		// Only delete targets if DeletionPolicy is "delete"
-		if sr.Spec.DeletionPolicy == platformv1alpha1.DeletionPolicyDelete {
-			if err := r.deleteTargetResources(ctx, sr); err != nil {
-				log.Error(err, "Failed to delete target resources")
-				return ctrl.Result{}, err
-			}
-			log.Info("Deleted target resources per DeletionPolicy")
-		} else {
-			log.Info("Orphaning target resources per DeletionPolicy")
+		// Our own cleanup status writes trigger reconciles too; wait out the backoff
+		if wait := r.cleanupRetryPending(sr); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		total, remaining, err := r.deleteTargetResources(ctx, sr)
+		if err != nil {
+			log.Error(err, "Failed to delete target resources", "remaining", remaining)
+			return r.recordCleanupFailure(ctx, sr, total, remaining, err, log)
 		}
+		log.Info("Cleaned up target resources per DeletionPolicy", "deleted", total)
+		r.deleteVerifyJobs(ctx, sr)
 
 		// Remove finalizer to allow CR deletion to proceed
-		controllerutil.RemoveFinalizer(sr, FinalizerName)
+		controllerutil.RemoveFinalizer(sr, r.finalizer())
 		if err := r.Update(ctx, sr); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -165,67 +431,292 @@ func (r *SharedResourceReconciler) handleDeletion(ctx context.Context, sr *platf
 	return ctrl.Result{}, nil
 }
 
+// recordCleanupFailure writes cleanup progress to status and schedules a retry
+// with exponential backoff.
+func (r *SharedResourceReconciler) recordCleanupFailure(ctx context.Context, sr *platformv1alpha1.SharedResource, total, remaining int, cleanupErr error, log logr.Logger) (ctrl.Result, error) {
+	now := metav1.NewTime(r.now())
+	var cleanedUp []string
+	if sr.Status.Cleanup != nil {
+		cleanedUp = sr.Status.Cleanup.CleanedUp
+	}
+	sr.Status.Cleanup = &platformv1alpha1.CleanupStatus{
+		TargetsTotal:     int32(total),
+		TargetsRemaining: int32(remaining),
+		LastAttemptTime:  &now,
+		LastError:        cleanupErr.Error(),
+		CleanedUp:        cleanedUp,
+	}
+	retryAfter := recordRetry(sr, r.now(), cleanupRetryInterval(sr.Status.RetryCount))
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "CleanupFailed",
+		fmt.Sprintf("Waiting to clean up %d of %d targets", remaining, total))
+
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update cleanup status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: retryAfter}, nil
+}
+
+// cleanupRetryPending returns how long until the next cleanup retry is due,
+// or zero if cleanup should run now. A sync-now request skips the wait.
+func (r *SharedResourceReconciler) cleanupRetryPending(sr *platformv1alpha1.SharedResource) time.Duration {
+	if sr.Status.Cleanup == nil || sr.Status.NextRetryTime == nil || syncRequestPending(sr) {
+		return 0
+	}
+	return max(sr.Status.NextRetryTime.Sub(r.now()), 0)
+}
+
+// cleanupRetryInterval doubles CleanupRetryBaseInterval per failed attempt, capped at ResyncInterval.
+func cleanupRetryInterval(attempts int32) time.Duration {
+	interval := CleanupRetryBaseInterval
+	for i := int32(0); i < attempts && interval < ResyncInterval; i++ {
+		interval *= 2
+	}
+	return min(interval, ResyncInterval)
+}
+
 // handleSourceError updates status when source resource is not found.
 func (r *SharedResourceReconciler) handleSourceError(ctx context.Context, sr *platformv1alpha1.SharedResource, err error, log logr.Logger) (ctrl.Result, error) {
 	if apierrors.IsNotFound(err) {
-		log.Info("Source resource not found", "kind", sr.Spec.Source.Kind, "name", sr.Spec.Source.Name)
+		// The missing source may be one of spec.sources (see fanin.go)
+		name := missingSourceName(sr, err)
+		log.Info("Source resource not found", "kind", sr.Spec.Source.Kind, "name", name)
 
 		setCondition(sr, ConditionTypeSourceFound, metav1.ConditionFalse, "SourceNotFound",
-			fmt.Sprintf("Source %s/%s not found", sr.Spec.Source.Kind, sr.Spec.Source.Name))
+			fmt.Sprintf("Source %s/%s not found", sr.Spec.Source.Kind, name))
 		setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "SourceNotFound", "Cannot sync: source resource not found")
+		retryAfter := recordRetry(sr, r.now(), r.sourceRetryInterval(sr))
+		sr.Status.ObservedGeneration = sr.Generation
+		sr.Status.AllTargetsAtChecksum = false
+		r.explainIfRequested(sr, fmt.Sprintf("Source %s %s not found; no target is written", sr.Spec.Source.Kind, name),
+			fmt.Sprintf("Next check at %s, or earlier when the source is created",
+				r.now().Add(retryAfter).UTC().Format(time.RFC3339)))
 
 		if statusErr := r.Status().Update(ctx, sr); statusErr != nil {
 			log.Error(statusErr, "Failed to update status")
 		}
 		// Requeue after delay to check if source appears
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
 	}
 	log.Error(err, "Failed to fetch source resource")
 	return ctrl.Result{}, err
 }
 
+// sourceRetryInterval resolves the requeue delay for a missing source.
+//
+// Precedence: spec.sourceRetryInterval > operator flag > built-in default.
+func (r *SharedResourceReconciler) sourceRetryInterval(sr *platformv1alpha1.SharedResource) time.Duration {
+	if sr.Spec.SourceRetryInterval != nil && sr.Spec.SourceRetryInterval.Duration > 0 {
+		return sr.Spec.SourceRetryInterval.Duration
+	}
+	if r.SourceRetryInterval > 0 {
+		return r.SourceRetryInterval
+	}
+	return SourceNotFoundRequeueInterval
+}
+
 // syncAllTargets syncs the source data to all target namespaces.
+//
+// The result is sorted by namespace/name, and targets that were already up to
+// date keep their previous LastSynced, so unchanged syncs produce identical status.
+// While the API server throttles writes, the remaining targets are left as
+// they are and the returned time says when writes resume (see throttle.go).
 func (r *SharedResourceReconciler) syncAllTargets(
 	ctx context.Context,
 	sr *platformv1alpha1.SharedResource,
+	targets []platformv1alpha1.TargetSpec,
+	decision *policyDecision,
 	data map[string][]byte,
-	sourceType corev1.SecretType,
+	source sourceMeta,
 	checksum string,
 	log logr.Logger,
-) ([]platformv1alpha1.TargetSyncStatus, bool) {
-	syncedTargets := make([]platformv1alpha1.TargetSyncStatus, 0, len(sr.Spec.Targets))
+) ([]platformv1alpha1.TargetSyncStatus, []platformv1alpha1.DataVariant, bool, time.Time) {
+	syncedTargets := make([]platformv1alpha1.TargetSyncStatus, 0, len(targets))
+	lastTargets := r.previousTargets(ctx, sr)
+	previous := previousTargetSync(lastTargets)
+	changes := previousTargetChanges(lastTargets)
+	errorHistory := previousTargetErrors(lastTargets)
+	verifications := previousVerifications(lastTargets)
+	sourceChecksums := previousSourceChecksums(lastTargets)
+	variants := newVariantSet()
 	allSynced := true
-	now := metav1.Now()
+	var managedBytes int64
+	now := metav1.NewTime(r.now())
+	var resume time.Time
+	deferred := 0
 
-	for _, target := range sr.Spec.Targets {
+	// Template values are shared by all targets; if they cannot be read, every target fails
+	secrets, secretsErr := r.fetchTemplateSecrets(ctx, sr)
+
+	seen := make(map[string]bool, len(targets))
+	for _, target := range targets {
 		// Determine target resource name
-		targetName := target.Name
-		if targetName == "" {
-			targetName = sr.Spec.Source.Name
+		targetName := resolvedTargetName(sr, target)
+
+		// A target listed twice is synced once
+		if seen[targetKey(target.Namespace, targetName)] {
+			r.recordEvent(sr, corev1.EventTypeWarning, "DuplicateTarget",
+				"Target %s/%s is listed more than once in spec.targets; syncing it once", target.Namespace, targetName)
+			continue
 		}
+		seen[targetKey(target.Namespace, targetName)] = true
 
+		// Error history outlives recovery, so flapping targets show a pattern
+		history := errorHistory[targetKey(target.Namespace, targetName)]
 		targetStatus := platformv1alpha1.TargetSyncStatus{
-			Namespace: target.Namespace,
-			Name:      targetName,
+			Namespace:     target.Namespace,
+			Name:          targetName,
+			LastErrorTime: history.LastErrorTime,
+			RecentErrors:  history.RecentErrors,
+			// A failing target keeps the source data it had (see consistency.go)
+			SourceChecksum: lastSourceChecksum(sr, sourceChecksums, targetKey(target.Namespace, targetName)),
+		}
+
+		// Writes paused by a 429 are not attempted (see throttle.go)
+		if until := r.throttle.resumeAt(now.Time); !until.IsZero() {
+			if deferred == 0 {
+				log.Info("API server is throttling requests, deferring remaining targets", "resumeAt", until)
+			}
+			resume = until
+			deferred++
+			syncedTargets = append(syncedTargets, deferredTarget(lastTargets, targetStatus, until))
+			allSynced = false
+			continue
 		}
 
-		// Sync to this target
-		err := r.syncToTarget(ctx, sr, target.Namespace, targetName, data, sourceType, checksum)
+		// Dev mode only reaches its own namespace (see devmode.go)
+		err := r.targetOutOfScope(target.Namespace)
+
+		// A released target is left alone for good
+		var released bool
+		if err == nil {
+			released, err = r.releaseIfRequested(ctx, sr, target.Namespace, targetName)
+		}
+		if err == nil && released {
+			targetStatus.Synced = true
+			targetStatus.Released = true
+			targetStatus.LastSynced = previous[targetKey(target.Namespace, targetName)]
+			syncedTargets = append(syncedTargets, targetStatus)
+			continue
+		}
+
+		// Check source-owner policy, sync to this target, then distribute access if requested
+		changed := false
+		var diff syncengine.DataDiff
+		var targetData map[string][]byte
+		var size int64
+		var denied, tool, targetChecksum string
+		if err == nil {
+			denied, err = r.targetDenied(ctx, sr, decision, target.Namespace)
+		}
+		if err == nil {
+			tool, err = r.externalManager(ctx, sr, target.Namespace, targetName)
+			targetStatus.ExternallyManagedBy = tool
+		}
+		if err == nil && tool != "" && !takesOwnership(sr) {
+			err = fmt.Errorf("also managed by %s; not written while spec.externallyManaged is backOff", tool)
+		}
+		if err == nil && denied != "" {
+			decision.deniedTargets = append(decision.deniedTargets, target.Namespace)
+			err = fmt.Errorf("target namespace denied by SharedResourcePolicy %q", denied)
+		}
+		// A missing namespace is created once the target is allowed (see createnamespaces.go)
+		if err == nil {
+			targetStatus.NamespaceCreated, err = r.ensureTargetNamespace(ctx, sr, target.Namespace)
+		}
+		if err == nil {
+			err = secretsErr
+		}
+		if err == nil {
+			targetData, err = r.buildTargetData(ctx, sr, target, targetName, secrets, data)
+			if err == nil {
+				size = dataSize(targetData)
+				targetChecksum = syncengine.Checksum(targetData)
+				changed, diff, err = r.syncToTarget(ctx, sr, target.Namespace, targetName, r.effectiveDeletionPolicy(sr, target),
+					targetData, source, targetChecksum, tool != "")
+			}
+			if err == nil && changed && tool != "" {
+				r.recordEvent(sr, corev1.EventTypeNormal, "TargetOwnershipTaken",
+					"Force-applied %s %s/%s, also managed by %s", targetKind(sr), target.Namespace, targetName, tool)
+			}
+		}
+		if err == nil {
+			err = r.syncAccess(ctx, sr, target.Namespace, targetName)
+		}
+		// A failed write keeps the last verification; the data it checked is still there
+		targetStatus.Verification = verifications[targetKey(target.Namespace, targetName)]
+		if err == nil && sr.Spec.Verify != nil && r.VerifyJobs {
+			targetStatus.Verification = r.verifyTarget(ctx, sr, target.Namespace, targetName, targetChecksum,
+				targetStatus.Verification)
+		}
 		if err != nil {
 			log.Error(err, "Failed to sync to target", "namespace", target.Namespace, "name", targetName)
 			targetStatus.Synced = false
 			targetStatus.Error = err.Error()
+			targetStatus.MutatedByAdmission = errors.Is(err, errMutatedByAdmission)
+			targetStatus.AccessDenied = apierrors.IsForbidden(err)
+			if pause, ok := throttlePause(err); ok {
+				r.throttle.pause(now.Add(pause))
+			}
+			targetStatus.LastErrorTime = &now
+			targetStatus.RecentErrors = recordTargetError(history.RecentErrors, err.Error(), now)
 			allSynced = false
 		} else {
 			log.Info("Successfully synced to target", "namespace", target.Namespace, "name", targetName)
 			targetStatus.Synced = true
 			targetStatus.LastSynced = now
+			targetStatus.SourceChecksum = checksum
+			managedBytes += size
+			variants.add(targetData, target.Namespace)
+			if last, ok := previous[targetKey(target.Namespace, targetName)]; ok && !changed {
+				targetStatus.LastSynced = last
+			}
 		}
+		targetStatus.LastChange = lastChange(sr, changes[targetKey(target.Namespace, targetName)], diff, now)
 
 		syncedTargets = append(syncedTargets, targetStatus)
 	}
 
-	return syncedTargets, allSynced
+	// Grants of targets the CR no longer has are revoked; a failure is retried on the next sync
+	if deferred == 0 {
+		if err := r.revokeRemovedAccessGrants(ctx, sr, targets); err != nil {
+			log.Error(err, "Failed to revoke access grants of removed targets")
+		}
+	}
+
+	r.recordManagedBytes(client.ObjectKeyFromObject(sr), managedBytes)
+	sortTargetStatuses(syncedTargets)
+	applyThrottledCondition(sr, resume, deferred)
+	return syncedTargets, variants.list(), allSynced, resume
+}
+
+// buildTargetData returns the data a target receives: the filtered source
+// data narrowed by namespace rules or tiers, split, substituted, rendered and
+// prefixed for the target.
+func (r *SharedResourceReconciler) buildTargetData(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	target platformv1alpha1.TargetSpec,
+	targetName string,
+	secrets map[string]string,
+	data map[string][]byte,
+) (map[string][]byte, error) {
+	targetData, err := r.dataForTarget(ctx, sr, target.Namespace, data)
+	if err != nil {
+		return nil, err
+	}
+	if targetData, err = syncengine.Split(targetData, splitSpecs(sr)); err != nil {
+		return nil, err
+	}
+	replacements, err := r.substitutionsFor(ctx, sr, target.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	targetData = syncengine.Substitute(targetData, replacements)
+	if targetData, err = renderForTarget(sr, target, targetName, secrets, targetData); err != nil {
+		return nil, err
+	}
+	return syncengine.Prefix(targetData, target.KeyPrefix), nil
 }
 
 // updateStatus updates the SharedResource status with sync results.
@@ -235,12 +726,15 @@ func (r *SharedResourceReconciler) updateStatus(
 	syncedTargets []platformv1alpha1.TargetSyncStatus,
 	checksum string,
 	allSynced bool,
+	resync time.Duration,
 	log logr.Logger,
 ) (ctrl.Result, error) {
-	now := metav1.Now()
+	now := metav1.NewTime(r.now())
 
-	sr.Status.SyncedTargets = syncedTargets
 	sr.Status.SourceChecksum = checksum
+	sr.Status.ObservedGeneration = sr.Generation
+	sr.Status.AllTargetsAtChecksum = allSynced
+	sr.Status.OperatorVersion = r.operatorVersion()
 
 	// Count failed targets for Degraded condition
 	failedCount := 0
@@ -250,21 +744,59 @@ func (r *SharedResourceReconciler) updateStatus(
 		}
 	}
 
+	// Large fan-outs only list failing targets to keep the object small
+	summary := &platformv1alpha1.TargetSummary{
+		Total:  int32(len(syncedTargets)),
+		Synced: int32(len(syncedTargets) - failedCount),
+		Failed: int32(failedCount),
+	}
+	allTargets := syncedTargets
+	listed := syncedTargets
+	if statusMode(sr, len(syncedTargets), r.CompactStatusThreshold) == platformv1alpha1.StatusModeCompact {
+		listed = compactTargetStatuses(syncedTargets, maxFailedTargets(sr))
+	}
+
+	// The companion report always carries the full list. Compacted CRs need it
+	// to remember the omitted targets, so it is written before compacting.
+	if err := r.syncStatusReport(ctx, sr, syncedTargets, *summary, checksum, len(listed) < len(syncedTargets)); err != nil {
+		log.Error(err, "Failed to write SharedResourceStatusReport")
+	}
+	summary.Omitted = int32(len(syncedTargets) - len(listed))
+	syncedTargets = listed
+	sr.Status.SyncedTargets = syncedTargets
+	sr.Status.TargetSummary = summary
+	sr.Status.Consistency = consistencyOf(allTargets, checksum)
+
+	if allSynced {
+		clearRetry(sr)
+	} else {
+		resync = recordRetry(sr, r.now(), resync)
+	}
+
 	if allSynced {
 		sr.Status.LastSyncTime = &now
 		setCondition(sr, ConditionTypeReady, metav1.ConditionTrue, "SyncSuccessful", "All targets synced successfully")
 		setCondition(sr, ConditionTypeDegraded, metav1.ConditionFalse, "AllTargetsSynced", "No targets failed")
-	} else if failedCount < len(syncedTargets) {
+	} else if required := minReadyTargets(sr, int(summary.Total)); int(summary.Synced) >= required {
+		// Enough targets synced for spec.minReadyTargets; stragglers are still retried
+		setCondition(sr, ConditionTypeReady, metav1.ConditionTrue, "MinReadyTargetsSynced",
+			fmt.Sprintf("%d of %d targets synced, %d required", summary.Synced, summary.Total, required))
+		setCondition(sr, ConditionTypeDegraded, metav1.ConditionTrue, "PartialFailure",
+			fmt.Sprintf("%d of %d targets failed to sync", failedCount, summary.Total))
+	} else if int32(failedCount) < summary.Total {
 		// Partial failure - some targets synced, some failed
 		setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "PartialSync", "Some targets failed to sync")
 		setCondition(sr, ConditionTypeDegraded, metav1.ConditionTrue, "PartialFailure",
-			fmt.Sprintf("%d of %d targets failed to sync", failedCount, len(syncedTargets)))
+			fmt.Sprintf("%d of %d targets failed to sync", failedCount, summary.Total))
 	} else {
 		// All targets failed
 		setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "SyncFailed", "All targets failed to sync")
 		setCondition(sr, ConditionTypeDegraded, metav1.ConditionFalse, "AllTargetsFailed", "All targets failed, not degraded")
 	}
 
+	// Verification Jobs hold Ready back until enough targets are verified
+	r.holdReadyForVerification(sr, allTargets)
+
 	if err := r.Status().Update(ctx, sr); err != nil {
 		log.Error(err, "Failed to update SharedResource status")
 		return ctrl.Result{}, err
@@ -272,8 +804,8 @@ func (r *SharedResourceReconciler) updateStatus(
 
 	log.Info("Reconciliation complete", "allSynced", allSynced)
 
-	// Requeue periodically for drift detection (every 5 minutes)
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	// Requeue periodically for drift detection (every 5 minutes, or per namespace tier)
+	return ctrl.Result{RequeueAfter: resync}, nil
 }
 
 // =============================================================================
@@ -281,24 +813,121 @@ func (r *SharedResourceReconciler) updateStatus(
 //
 // We watch:
 // 1. SharedResource CRs - primary resource
-// 2. Secrets - to trigger sync when source secrets change
-// 3. ConfigMaps - to trigger sync when source configmaps change
+// 2. Secrets - to trigger sync when source secrets change, and recreate deleted targets
+// 3. ConfigMaps - to trigger sync when source configmaps change, and recreate deleted targets
+// (a kind disabled with --disable-secrets or --disable-configmaps is not watched)
+// 4. Namespaces - to re-sync targets when a namespace is created or relabelled
+// 5. SharedResourcePolicies - to re-check CRs when source-owner policy changes
+// 6. NamespaceGroups - to re-sync CRs targeting a group when it changes
+// 7. RoleBindings and ClusterRoleBindings (--watch-rolebindings) - to retry forbidden targets once access may be granted
 // =============================================================================
 func (r *SharedResourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&platformv1alpha1.SharedResource{}).
-		// Watch Secrets and map back to SharedResources that reference them
-		Watches(
+	// Stamp every write with our field manager so our own watch events can be recognized
+	r.Client = client.WithFieldOwner(r.Client, r.fieldManager())
+	for i := range r.TargetIdentities {
+		r.TargetIdentities[i].Client = client.WithFieldOwner(r.TargetIdentities[i].Client, r.fieldManager())
+	}
+	r.Client = routeTargetWrites(r.Client, r.TargetIdentities)
+
+	r.recordCapabilities()
+
+	// Kinds whose API is not served are neither watched nor synced (see kindapis.go)
+	r.probeKindAPIs(context.Background())
+	if r.Capabilities != nil && r.SweepReportNamespace != "" {
+		if err := mgr.Add(&capabilityReporter{r: r}); err != nil {
+			return err
+		}
+	}
+
+	// Without delete permission there is nothing the sweeper could do
+	if !r.DeletesDisabled {
+		if err := mgr.Add(&targetSweeper{r: r}); err != nil {
+			return err
+		}
+	}
+	if err := r.registerInventoryMetrics(); err != nil {
+		return err
+	}
+	if r.DiffAPI {
+		if err := mgr.AddMetricsServerExtraHandler(DiffAPIPath, r.diffHandler()); err != nil {
+			return err
+		}
+	}
+	// Group changes look up the CRs naming the group (see namespacegroups.go)
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &platformv1alpha1.SharedResource{},
+		targetGroupIndex, indexTargetGroups); err != nil {
+		return err
+	}
+	// Source and policy changes look up the CRs reading from other namespaces (see sourcenamespace.go)
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &platformv1alpha1.SharedResource{},
+		sourceNamespaceIndex, indexSourceNamespace); err != nil {
+		return err
+	}
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&platformv1alpha1.SharedResource{}, builder.WithPredicates(r.managedCRs()))
+	// Watch Secrets and map back to SharedResources that reference them
+	if slices.Contains(r.enabledKinds(), KindSecret) {
+		bldr = bldr.Watches(
 			&corev1.Secret{},
-			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForSecret),
+			r.targetEventHandler(KindSecret, r.findSharedResourcesForSecret),
+			builder.WithPredicates(r.ignoreSelfInflicted()),
+		)
+	}
+	// Watch ConfigMaps and map back to SharedResources that reference them
+	if slices.Contains(r.enabledKinds(), KindConfigMap) {
+		bldr = bldr.Watches(
+			&corev1.ConfigMap{},
+			r.targetEventHandler(KindConfigMap, r.findSharedResourcesForConfigMap),
+			builder.WithPredicates(r.ignoreSelfInflicted()),
+		)
+	}
+	// The namespace watch drops the namespace snapshot (see namespacecache.go)
+	r.namespaces.watch()
+	bldr = bldr.
+		// Re-sync SharedResources targeting a namespace when it appears or is relabelled
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForNamespace),
+			builder.WithPredicates(namespaceChanged()),
 		).
-		// Watch ConfigMaps and map back to SharedResources that reference them
+		// Re-check every SharedResource in the namespace when its policies change
 		Watches(
-			&corev1.ConfigMap{},
-			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForConfigMap),
+			&platformv1alpha1.SharedResourcePolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForPolicy),
+		).
+		// Re-sync SharedResources targeting a group when its members change
+		Watches(
+			&platformv1alpha1.NamespaceGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForGroup),
 		).
-		Named("sharedresource").
-		Complete(r)
+		// Re-check the consent of SharedResources reading an exported source
+		Watches(
+			&platformv1alpha1.SharedResourceExport{},
+			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForExport),
+		)
+
+	// Retry forbidden targets once a binding may have granted access
+	if r.WatchRoleBindings {
+		bldr = bldr.Watches(
+			&rbacv1.RoleBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForRoleBinding),
+		).Watches(
+			&rbacv1.ClusterRoleBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.findSharedResourcesForClusterRoleBinding),
+		)
+	}
+
+	// The startup scan enqueues the CRs it finds through a channel source
+	if r.StartupScan {
+		events := make(chan event.GenericEvent)
+		if err := mgr.Add(&consistencyScanner{r: r, events: events}); err != nil {
+			return err
+		}
+		bldr = bldr.WatchesRawSource(source.Channel(events, &handler.EnqueueRequestForObject{}))
+	}
+
+	return bldr.Named("sharedresource").Complete(r)
 }
 
 // findSharedResourcesForSecret returns reconcile requests for all SharedResources
@@ -308,7 +937,10 @@ func (r *SharedResourceReconciler) findSharedResourcesForSecret(ctx context.Cont
 
 	// Check if this is a managed target resource
 	if managedBy, ok := secret.Annotations[AnnotationManagedBy]; ok && managedBy == ManagedByValue {
-		return r.findSharedResourceForManagedResource(ctx, secret.Annotations, "Secret")
+		// A target that is another SharedResource's source wakes that one too
+		// (see chain.go)
+		requests := r.findSharedResourceForManagedResource(ctx, secret.Annotations, "Secret")
+		return append(requests, r.findSharedResourcesForSource(ctx, secret.Namespace, secret.Name, "Secret")...)
 	}
 
 	// Otherwise, check if it's a source resource
@@ -322,7 +954,10 @@ func (r *SharedResourceReconciler) findSharedResourcesForConfigMap(ctx context.C
 
 	// Check if this is a managed target resource
 	if managedBy, ok := cm.Annotations[AnnotationManagedBy]; ok && managedBy == ManagedByValue {
-		return r.findSharedResourceForManagedResource(ctx, cm.Annotations, "ConfigMap")
+		// A target that is another SharedResource's source wakes that one too
+		// (see chain.go)
+		requests := r.findSharedResourceForManagedResource(ctx, cm.Annotations, "ConfigMap")
+		return append(requests, r.findSharedResourcesForSource(ctx, cm.Namespace, cm.Name, "ConfigMap")...)
 	}
 
 	// Otherwise, check if it's a source resource
@@ -340,21 +975,27 @@ func (r *SharedResourceReconciler) findSharedResourceForManagedResource(ctx cont
 	if sourceNamespace == "" || sourceCR == "" {
 		return nil
 	}
+	key := client.ObjectKey{Namespace: sourceNamespace, Name: sourceCR}
+	r.verified.invalidate(key)
 
 	log.Info("Managed target resource changed, triggering reconcile",
 		"kind", kind,
 		"sharedresource", sourceCR)
 
-	return []ctrl.Request{{
-		NamespacedName: client.ObjectKey{
-			Namespace: sourceNamespace,
-			Name:      sourceCR,
-		},
-	}}
+	// Every SharedResource merging into a shared target must re-check it
+	requests := []ctrl.Request{{NamespacedName: key}}
+	for _, owner := range keyOwnerRequests(annotations) {
+		if owner != key {
+			r.verified.invalidate(owner)
+			requests = append(requests, ctrl.Request{NamespacedName: owner})
+		}
+	}
+	return requests
 }
 
-// findSharedResourcesForSource finds all SharedResources in the given namespace
-// that reference the specified source resource.
+// findSharedResourcesForSource finds all SharedResources that reference the
+// specified source resource: those in its namespace, and those reading it
+// from another namespace.
 func (r *SharedResourceReconciler) findSharedResourcesForSource(ctx context.Context, namespace, name, kind string) []ctrl.Request {
 	log := logf.FromContext(ctx)
 
@@ -364,20 +1005,24 @@ func (r *SharedResourceReconciler) findSharedResourcesForSource(ctx context.Cont
 		log.Error(err, "Failed to list SharedResources")
 		return nil
 	}
+	var exporting platformv1alpha1.SharedResourceList
+	if err := r.List(ctx, &exporting, client.MatchingFields{sourceNamespaceIndex: namespace}); err != nil {
+		log.Error(err, "Failed to list SharedResources")
+		return nil
+	}
 
 	var requests []ctrl.Request
-	for _, sr := range sharedResourceList.Items {
+	for _, sr := range append(sharedResourceList.Items, exporting.Items...) {
 		// Check if this SharedResource references the changed resource
-		if sr.Spec.Source.Kind == kind && sr.Spec.Source.Name == name {
+		if (sr.Spec.Source.Kind == kind && sourceNamespace(&sr) == namespace &&
+			(sourceName(&sr) == name || mergesSource(&sr, name))) ||
+			(kind == KindSecret && sr.Namespace == namespace && templateValuesSecret(&sr) == name) {
 			log.Info("Source resource changed, triggering reconcile",
 				"source", kind+"/"+name,
 				"sharedresource", sr.Name)
-			requests = append(requests, ctrl.Request{
-				NamespacedName: client.ObjectKey{
-					Namespace: sr.Namespace,
-					Name:      sr.Name,
-				},
-			})
+			key := client.ObjectKey{Namespace: sr.Namespace, Name: sr.Name}
+			r.verified.invalidate(key)
+			requests = append(requests, ctrl.Request{NamespacedName: key})
 		}
 	}
 
@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Creating Target Namespaces", func() {
+	ctx := context.Background()
+
+	It("should create missing target namespaces the policies allow", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("createns-src-%d", suffix)
+		existing := fmt.Sprintf("createns-existing-%d", suffix)
+		missing := fmt.Sprintf("createns-missing-%d", suffix)
+		denied := fmt.Sprintf("createns-denied-%d", suffix)
+		for name, nsLabels := range map[string]map[string]string{sourceNSName: nil, existing: {"team": "payments"}} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		DeferCleanup(func() {
+			for _, name := range []string{missing, denied} {
+				_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}
+		})
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "createns-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &platformv1alpha1.SharedResourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "createns-policy", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourcePolicySpec{
+				Sources:                 []platformv1alpha1.PolicySource{{Kind: "Secret", Name: "createns-secret"}},
+				TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		})).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-createns", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "createns-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: existing}, {Namespace: missing}},
+				OperatorClass: "createns",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-createns", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "createns"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		targetStatus := func(sr *platformv1alpha1.SharedResource, namespace string) platformv1alpha1.TargetSyncStatus {
+			GinkgoHelper()
+			for _, target := range sr.Status.SyncedTargets {
+				if target.Namespace == namespace {
+					return target
+				}
+			}
+			Fail("no status for target namespace " + namespace)
+			return platformv1alpha1.TargetSyncStatus{}
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("failing the target in a missing namespace by default")
+		current := reconcile()
+		Expect(targetStatus(current, existing).Synced).To(BeTrue())
+		Expect(targetStatus(current, missing).Synced).To(BeFalse())
+
+		By("creating the namespace with the configured labels")
+		current.Spec.CreateTargetNamespaces = true
+		current.Spec.TargetNamespaceLabels = map[string]string{"team": "payments"}
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile()
+		Expect(targetStatus(current, missing).Synced).To(BeTrue())
+		Expect(targetStatus(current, missing).NamespaceCreated).To(BeTrue())
+		Expect(targetStatus(current, existing).NamespaceCreated).To(BeFalse())
+		created := &corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: missing}, created)).To(Succeed())
+		Expect(created.Labels).To(HaveKeyWithValue("team", "payments"))
+		Expect(created.Annotations).To(HaveKeyWithValue(AnnotationCreatedFor, sourceNSName+"/sync-createns"))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "createns-secret", Namespace: missing}, &corev1.Secret{})).To(Succeed())
+
+		By("not creating a namespace the policies deny")
+		current.Spec.TargetNamespaceLabels = map[string]string{"team": "search"}
+		current.Spec.Targets = append(current.Spec.Targets, platformv1alpha1.TargetSpec{Namespace: denied})
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile()
+		Expect(targetStatus(current, denied).Synced).To(BeFalse())
+		Expect(targetStatus(current, denied).Error).To(ContainSubstring("createns-policy"))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: denied}, &corev1.Namespace{})).NotTo(Succeed())
+
+		By("still reporting the created namespace on later syncs")
+		Expect(targetStatus(current, missing).NamespaceCreated).To(BeTrue())
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -19,14 +19,21 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
 )
@@ -135,3 +142,470 @@ var _ = Describe("Deletion Policy", func() {
 		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
 	})
 })
+
+// countingDeleteClient counts target Secret deletes per namespace and
+// refuses them in one.
+type countingDeleteClient struct {
+	client.Client
+	mu      sync.Mutex
+	refuse  string
+	deletes map[string]int
+}
+
+func (c *countingDeleteClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		c.mu.Lock()
+		c.deletes[obj.GetNamespace()]++
+		refused := obj.GetNamespace() == c.refuse
+		c.mu.Unlock()
+		if refused {
+			return apierrors.NewForbidden(corev1.Resource("secrets"), obj.GetName(), fmt.Errorf("refused for test"))
+		}
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+var _ = Describe("Deletion Cleanup Progress", func() {
+	ctx := context.Background()
+
+	It("should not redo cleaned up targets when cleanup is retried by a new controller", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("resume-src-%d", suffix)
+		targetNSNames := []string{
+			fmt.Sprintf("resume-a-%d", suffix),
+			fmt.Sprintf("resume-b-%d", suffix),
+			fmt.Sprintf("resume-c-%d", suffix),
+		}
+		for _, name := range append([]string{sourceNSName}, targetNSNames...) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "resume-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		targets := []platformv1alpha1.TargetSpec{}
+		for _, name := range targetNSNames {
+			targets = append(targets, platformv1alpha1.TargetSpec{Namespace: name})
+		}
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-resume", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "resume-secret"},
+				Targets:        targets,
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+				OperatorClass:  "resume",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-resume", Namespace: sourceNSName}
+		newReconciler := func(c client.Client) *SharedResourceReconciler {
+			return &SharedResourceReconciler{Client: c, Scheme: k8sClient.Scheme(),
+				InstanceOptions: InstanceOptions{OperatorClass: "resume"}}
+		}
+		for range 2 {
+			// The first reconcile only adds the finalizer
+			_, err := newReconciler(k8sClient).Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("recording the targets cleaned up while one is refused")
+		c := &countingDeleteClient{Client: k8sClient, refuse: targetNSNames[1], deletes: map[string]int{}}
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		_, err := newReconciler(c).Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(current.Status.Cleanup.TargetsTotal).To(Equal(int32(3)))
+		Expect(current.Status.Cleanup.TargetsRemaining).To(Equal(int32(1)))
+		Expect(current.Status.Cleanup.CleanedUp).To(ConsistOf(
+			targetNSNames[0]+"/resume-secret", targetNSNames[2]+"/resume-secret"))
+
+		By("only retrying the remaining target after a restart")
+		c.refuse = ""
+		current.Annotations = map[string]string{AnnotationSyncNow: "1"}
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		_, err = newReconciler(c).Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.deletes).To(Equal(map[string]int{targetNSNames[0]: 1, targetNSNames[1]: 2, targetNSNames[2]: 1}))
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, key, current))).To(BeTrue())
+	})
+
+	It("should report cleanup progress while a target cannot be deleted", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("cleanup-src-%d", suffix)
+		okNSName := fmt.Sprintf("cleanup-ok-%d", suffix)
+		blockedNSName := fmt.Sprintf("cleanup-blocked-%d", suffix)
+
+		for _, name := range []string{sourceNSName, okNSName, blockedNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Refuse deletion of Secrets in the blocked namespace
+		policy := &admissionregistrationv1.ValidatingAdmissionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("block-delete-%d", suffix)},
+			Spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				MatchConstraints: &admissionregistrationv1.MatchResources{
+					ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Delete},
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"secrets"},
+							},
+						},
+					}},
+				},
+				Validations: []admissionregistrationv1.Validation{{
+					Expression: fmt.Sprintf("oldObject.metadata.namespace != '%s'", blockedNSName),
+					Message:    "deletion blocked for test",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, policy) }()
+
+		binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: policy.Name},
+			Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+				PolicyName:        policy.Name,
+				ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny},
+			},
+		}
+		Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, binding) }()
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleanup-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-cleanup", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "cleanup-secret"},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: okNSName}, {Namespace: blockedNSName}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		for _, ns := range []string{okNSName, blockedNSName} {
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: "cleanup-secret", Namespace: ns}, &corev1.Secret{})
+			}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		}
+
+		// The admission policy is enforced once the API server has loaded it
+		Eventually(func() bool {
+			probe := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: blockedNSName}}
+			if err := k8sClient.Create(ctx, probe); err != nil && !apierrors.IsAlreadyExists(err) {
+				return false
+			}
+			err := k8sClient.Delete(ctx, probe)
+			return err != nil && !apierrors.IsNotFound(err)
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+
+		// The unblocked target is cleaned up regardless of the failing one
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "cleanup-secret", Namespace: okNSName}, &corev1.Secret{})
+			return apierrors.IsNotFound(err)
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// The finalizer holds the CR and status explains why
+		Eventually(func(g Gomega) {
+			var got platformv1alpha1.SharedResource
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "sync-cleanup", Namespace: sourceNSName}, &got)).To(Succeed())
+			g.Expect(got.Status.Cleanup).NotTo(BeNil())
+			g.Expect(got.Status.Cleanup.TargetsTotal).To(Equal(int32(2)))
+			g.Expect(got.Status.Cleanup.TargetsRemaining).To(Equal(int32(1)))
+			g.Expect(got.Status.Cleanup.LastError).To(ContainSubstring(blockedNSName))
+			g.Expect(got.Status.Cleanup.LastError).To(ContainSubstring("deletion blocked for test"))
+			g.Expect(got.Status.RetryCount).To(BeNumerically(">=", 1))
+			g.Expect(got.Status.NextRetryTime).NotTo(BeNil())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// Once deletion is allowed again the retry finishes cleanup
+		Expect(k8sClient.Delete(ctx, binding)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-cleanup", Namespace: sourceNSName}, &platformv1alpha1.SharedResource{})
+			return apierrors.IsNotFound(err)
+		}, time.Second*20, time.Millisecond*250).Should(BeTrue())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx,
+			types.NamespacedName{Name: "cleanup-secret", Namespace: blockedNSName}, &corev1.Secret{}))).To(BeTrue())
+	})
+})
+
+var _ = Describe("Foreground and Background Deletion", func() {
+	ctx := context.Background()
+
+	// setup creates a source namespace, a target namespace and a synced Secret,
+	// returning the SharedResource and the target key.
+	setup := func(prefix string, policy platformv1alpha1.DeletionPolicy) (*platformv1alpha1.SharedResource, types.NamespacedName) {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("%s-src-%d", prefix, suffix)
+		targetNSName := fmt.Sprintf("%s-tgt-%d", prefix, suffix)
+
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: prefix + "-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-" + prefix, Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: source.Name},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				DeletionPolicy: policy,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		targetKey := types.NamespacedName{Name: source.Name, Namespace: targetNSName}
+		Eventually(func(g Gomega) {
+			var target corev1.Secret
+			g.Expect(k8sClient.Get(ctx, targetKey, &target)).To(Succeed())
+			g.Expect(target.Annotations).To(HaveKeyWithValue("sharedresource.platform.dev/deletion-policy", string(policy)))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		return sr, targetKey
+	}
+
+	It("should keep the CR until targets are gone with deleteForeground", func() {
+		sr, targetKey := setup("fg", platformv1alpha1.DeletionPolicyDeleteForeground)
+		srKey := types.NamespacedName{Name: sr.Name, Namespace: sr.Namespace}
+
+		// Another controller holds the target with its own finalizer
+		var target corev1.Secret
+		Expect(k8sClient.Get(ctx, targetKey, &target)).To(Succeed())
+		target.Finalizers = append(target.Finalizers, "example.com/hold")
+		Expect(k8sClient.Update(ctx, &target)).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+
+		// The delete is issued but the CR waits for the target to actually go away
+		Eventually(func(g Gomega) {
+			var got platformv1alpha1.SharedResource
+			g.Expect(k8sClient.Get(ctx, srKey, &got)).To(Succeed())
+			g.Expect(got.Status.Cleanup).NotTo(BeNil())
+			g.Expect(got.Status.Cleanup.TargetsRemaining).To(Equal(int32(1)))
+			g.Expect(got.Status.Cleanup.LastError).To(ContainSubstring("still being deleted"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(k8sClient.Get(ctx, targetKey, &target)).To(Succeed())
+		Expect(target.DeletionTimestamp).NotTo(BeNil())
+
+		// Release the target; the next retry lets the CR go
+		target.Finalizers = nil
+		Expect(k8sClient.Update(ctx, &target)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, srKey, &platformv1alpha1.SharedResource{}))
+		}, time.Second*20, time.Millisecond*250).Should(BeTrue())
+	})
+
+	It("should remove the CR immediately and sweep targets with deleteBackground", func() {
+		sr, targetKey := setup("bg", platformv1alpha1.DeletionPolicyDeleteBackground)
+		srKey := types.NamespacedName{Name: sr.Name, Namespace: sr.Namespace}
+
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, srKey, &platformv1alpha1.SharedResource{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// The sweeper finds the target of the deleted CR and removes it
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+	})
+})
+
+var _ = Describe("Per-target Deletion Policy", func() {
+	ctx := context.Background()
+
+	It("should orphan and delete targets of one CR according to their own policy", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("pertarget-src-%d", suffix)
+		prodNSName := fmt.Sprintf("pertarget-prod-%d", suffix)
+		previewNSName := fmt.Sprintf("pertarget-preview-%d", suffix)
+
+		for _, name := range []string{sourceNSName, prodNSName, previewNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pertarget-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The CR defaults to delete, production opts out
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-pertarget", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "pertarget-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: prodNSName, DeletionPolicy: platformv1alpha1.DeletionPolicyOrphan},
+					{Namespace: previewNSName},
+				},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		prodKey := types.NamespacedName{Name: "pertarget-secret", Namespace: prodNSName}
+		previewKey := types.NamespacedName{Name: "pertarget-secret", Namespace: previewNSName}
+		for key, policy := range map[types.NamespacedName]string{prodKey: "orphan", previewKey: "delete"} {
+			Eventually(func(g Gomega) {
+				var target corev1.Secret
+				g.Expect(k8sClient.Get(ctx, key, &target)).To(Succeed())
+				g.Expect(target.Annotations).To(HaveKeyWithValue("sharedresource.platform.dev/deletion-policy", policy))
+			}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		}
+
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-pertarget", Namespace: sourceNSName}, &platformv1alpha1.SharedResource{})
+			return apierrors.IsNotFound(err)
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// Preview copy removed, production copy kept
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, previewKey, &corev1.Secret{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Consistently(func() error {
+			return k8sClient.Get(ctx, prodKey, &corev1.Secret{})
+		}, time.Second*3, time.Millisecond*500).Should(Succeed())
+	})
+})
+
+var _ = Describe("Source Namespace Deletion", func() {
+	ctx := context.Background()
+
+	It("should warn on the Namespace when a source namespace starts terminating", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("srcnsdel-src-%d", suffix)
+		targetNSName := fmt.Sprintf("srcnsdel-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "srcnsdel-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-srcnsdel", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "srcnsdel-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "srcnsdel-secret", Namespace: targetNSName}, &corev1.Secret{})
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// envtest has no namespace controller: the namespace stays Terminating
+		Expect(k8sClient.Delete(ctx, sourceNS)).To(Succeed())
+
+		// Events on the cluster-scoped Namespace land in "default", which outlives it
+		Eventually(func(g Gomega) {
+			var events corev1.EventList
+			g.Expect(k8sClient.List(ctx, &events, client.InNamespace(metav1.NamespaceDefault))).To(Succeed())
+			messages := []string{}
+			for _, e := range events.Items {
+				if e.InvolvedObject.Kind == "Namespace" && e.InvolvedObject.Name == sourceNSName && e.Reason == "SourceNamespaceDeleting" {
+					messages = append(messages, e.Message)
+				}
+			}
+			g.Expect(messages).To(ContainElement(ContainSubstring("sync-srcnsdel stops updating its 1 target(s)")))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// The condition records the warning, so later reconciles do not repeat it
+		Eventually(func(g Gomega) {
+			current := &platformv1alpha1.SharedResource{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "sync-srcnsdel", Namespace: sourceNSName}, current)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeSourceNamespaceDeleting)).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+})
+
+var _ = Describe("Sweep Observation", func() {
+	ctx := context.Background()
+
+	It("should report sweep candidates for the observation period before deleting them", func() {
+		// A client of its own, so the manager's sweeper does not delete the target first
+		target := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "db", Namespace: "team-a",
+			Annotations: map[string]string{
+				AnnotationManagedBy:       ManagedByValue,
+				AnnotationSourceNamespace: "security",
+				AnnotationSourceName:      "db",
+				AnnotationSourceCR:        "share-db",
+				AnnotationDeletionPolicy:  string(platformv1alpha1.DeletionPolicyDeleteBackground),
+			},
+		}}
+		c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(target).Build()
+		clock := clocktesting.NewFakeClock(time.Now())
+		r := &SharedResourceReconciler{
+			Client: c,
+			Scheme: c.Scheme(),
+			Clock:  clock,
+			CleanupOptions: CleanupOptions{
+				SweepObservationPeriod: time.Hour,
+				SweepReportNamespace:   "operator",
+			},
+		}
+		targetKey := client.ObjectKeyFromObject(target)
+		reportKey := types.NamespacedName{Namespace: "operator", Name: SweepReportName}
+
+		By("holding and reporting the candidate")
+		report := &sweepReport{}
+		Expect(r.sweep(ctx, report)).To(Succeed())
+		Expect(c.Get(ctx, targetKey, &corev1.Secret{})).To(Succeed())
+		var cm corev1.ConfigMap
+		Expect(c.Get(ctx, reportKey, &cm)).To(Succeed())
+		Expect(cm.Data[sweepReportKey]).To(And(ContainSubstring(`"sharedResource": "security/share-db"`),
+			ContainSubstring(`"name": "db"`)))
+
+		By("keeping the first sighting across restarts")
+		clock.Step(59 * time.Minute)
+		report = &sweepReport{}
+		Expect(r.sweep(ctx, report)).To(Succeed())
+		Expect(c.Get(ctx, targetKey, &corev1.Secret{})).To(Succeed())
+
+		By("deleting it once the period is over")
+		clock.Step(2 * time.Minute)
+		Expect(r.sweep(ctx, report)).To(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, targetKey, &corev1.Secret{}))).To(BeTrue())
+		Expect(c.Get(ctx, reportKey, &cm)).To(Succeed())
+		Expect(cm.Data[sweepReportKey]).To(Equal("[]"))
+	})
+})
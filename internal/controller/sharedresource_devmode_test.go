@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Dev Mode", func() {
+	ctx := context.Background()
+
+	It("should stay in its namespace and place no finalizer", func() {
+		suffix := time.Now().UnixNano() % 100000
+		devNSName := fmt.Sprintf("dev-%d", suffix)
+		otherNSName := fmt.Sprintf("dev-other-%d", suffix)
+		for _, name := range []string{devNSName, otherNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-secret", Namespace: devNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-dev", Namespace: devNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "dev-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: devNSName, Name: "dev-secret-copy"},
+					{Namespace: otherNSName},
+				},
+				OperatorClass: "dev",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-dev", Namespace: devNSName}
+
+		r := &SharedResourceReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{
+				OperatorClass: "dev",
+				DevMode:       true,
+				Namespace:     devNSName,
+			},
+		}
+		Expect(r.managesCR(&platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Namespace: otherNSName},
+			Spec:       platformv1alpha1.SharedResourceSpec{OperatorClass: "dev"},
+		})).To(BeFalse())
+
+		By("syncing targets in its namespace only")
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "dev-secret-copy", Namespace: devNSName}, &corev1.Secret{})).To(Succeed())
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: "dev-secret", Namespace: otherNSName}, &corev1.Secret{})
+		Expect(err).To(HaveOccurred())
+
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(current.Finalizers).To(BeEmpty())
+		Expect(current.Status.SyncedTargets).To(ContainElement(And(
+			HaveField("Namespace", otherNSName),
+			HaveField("Synced", false),
+			HaveField("Error", ContainSubstring("only namespace managed in dev mode")),
+		)))
+
+		By("being deleted without waiting for cleanup")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		Eventually(func() bool {
+			return k8sClient.Get(ctx, key, current) != nil
+		}, time.Second*5, time.Millisecond*250).Should(BeTrue())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "dev-secret-copy", Namespace: devNSName}, &corev1.Secret{})).To(Succeed())
+	})
+})
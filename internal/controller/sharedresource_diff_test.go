@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+var _ = Describe("Target Diffs", func() {
+	ctx := context.Background()
+
+	It("should report what a sync would change without writing", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("diff-src-%d", suffix)
+		targetNSName := fmt.Sprintf("diff-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "diff-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"user": []byte("admin"), "password": []byte("old"), "debug": []byte("1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-diff", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "diff-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				SyncPolicy: &platformv1alpha1.SyncPolicySpec{
+					Mode: platformv1alpha1.SyncModeSelective,
+					Keys: &platformv1alpha1.KeySelector{Exclude: []string{"debug"}},
+				},
+				OperatorClass: "diff",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-diff", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "diff"}}
+
+		By("listing every key of a target that does not exist yet")
+		report, err := r.DiffTargets(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Targets).To(HaveLen(1))
+		Expect(report.Targets[0].Exists).To(BeFalse())
+		Expect(report.Targets[0].Diff.Added).To(Equal([]syncengine.KeyChange{
+			{Key: "password", NewLen: 3}, {Key: "user", NewLen: 5},
+		}))
+		Expect(report.Checksum).To(Equal(syncengine.Checksum(map[string][]byte{
+			"user": []byte("admin"), "password": []byte("old"),
+		})))
+
+		By("reporting nothing for a target in sync")
+		for range 2 {
+			_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		report, err = r.DiffTargets(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Targets[0].Exists).To(BeTrue())
+		Expect(report.Targets[0].Diff.Empty()).To(BeTrue())
+		Expect(report.SyncedChecksum).To(Equal(report.Checksum))
+
+		By("reporting a source change before it is synced")
+		target := &corev1.Secret{}
+		targetKey := types.NamespacedName{Name: "diff-secret", Namespace: targetNSName}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		source.Data["password"] = []byte("rotated")
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Eventually(func(g Gomega) {
+			report, err = r.DiffTargets(ctx, key)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(report.Targets[0].Diff.Changed).To(Equal([]syncengine.KeyChange{
+				{Key: "password", OldLen: 3, NewLen: 7},
+			}))
+		}).Should(Succeed())
+		unchanged := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, targetKey, unchanged)).To(Succeed())
+		Expect(unchanged.ResourceVersion).To(Equal(target.ResourceVersion))
+
+		By("serving the report over HTTP to callers allowed to get the CR")
+		caller := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "diff-caller", Namespace: sourceNSName}}
+		Expect(k8sClient.Create(ctx, caller)).To(Succeed())
+		tokenRequest := &authenticationv1.TokenRequest{}
+		Expect(k8sClient.SubResource("token").Create(ctx, caller, tokenRequest)).To(Succeed())
+		get := func(path string, authorized bool) *httptest.ResponseRecorder {
+			GinkgoHelper()
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if authorized {
+				req.Header.Set("Authorization", "Bearer "+tokenRequest.Status.Token)
+			}
+			rec := httptest.NewRecorder()
+			r.diffHandler().ServeHTTP(rec, req)
+			return rec
+		}
+		Expect(get(DiffAPIPath+sourceNSName+"/sync-diff", false).Code).To(Equal(http.StatusUnauthorized))
+		Expect(get(DiffAPIPath+sourceNSName+"/sync-diff", true).Code).To(Equal(http.StatusForbidden))
+		Expect(k8sClient.Create(ctx, &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "diff-caller", Namespace: sourceNSName},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{platformv1alpha1.GroupVersion.Group}, Resources: []string{"sharedresources"}, Verbs: []string{"get"},
+			}},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "diff-caller", Namespace: sourceNSName},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "diff-caller"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "diff-caller", Namespace: sourceNSName}},
+		})).To(Succeed())
+		rec := get(DiffAPIPath+sourceNSName+"/sync-diff", true)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var served DiffReport
+		Expect(json.Unmarshal(rec.Body.Bytes(), &served)).To(Succeed())
+		Expect(served.Targets).To(HaveLen(1))
+		Expect(served.Targets[0].Diff.Changed).To(HaveLen(1))
+		Expect(rec.Body.String()).NotTo(ContainSubstring("rotated"))
+
+		Expect(get(DiffAPIPath+sourceNSName+"/missing", true).Code).To(Equal(http.StatusNotFound))
+		Expect(get(DiffAPIPath+sourceNSName, true).Code).To(Equal(http.StatusBadRequest))
+		Expect(get(DiffAPIPath+targetNSName+"/sync-diff", true).Code).To(Equal(http.StatusForbidden))
+
+		By("cleaning up")
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
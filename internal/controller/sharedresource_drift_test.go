@@ -23,9 +23,14 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
 )
@@ -93,4 +98,246 @@ var _ = Describe("Drift Correction", func() {
 			return string(freshTarget.Data["original"])
 		}, time.Second*10, time.Millisecond*250).Should(Equal("correct"))
 	})
+
+	It("should compare the data of a re-added target, not its checksum annotation", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("readd-src-%d", suffix)
+		keptNSName := fmt.Sprintf("readd-kept-%d", suffix)
+		readdedNSName := fmt.Sprintf("readd-tgt-%d", suffix)
+
+		// Create namespaces
+		for _, name := range []string{sourceNSName, keptNSName, readdedNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "readd-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"original": []byte("correct")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource with both targets
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-readd", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "readd-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: keptNSName}, {Namespace: readdedNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		srKey := types.NamespacedName{Name: "sync-readd", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "readd-secret", Namespace: readdedNSName}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, targetKey, &corev1.Secret{})
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		setTargets := func(targets []platformv1alpha1.TargetSpec) {
+			GinkgoHelper()
+			Eventually(func() error {
+				var latest platformv1alpha1.SharedResource
+				if err := k8sClient.Get(ctx, srKey, &latest); err != nil {
+					return err
+				}
+				latest.Spec.Targets = targets
+				return k8sClient.Update(ctx, &latest)
+			}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		}
+
+		// Exclude the target, then edit its data while leaving the checksum annotation alone
+		setTargets([]platformv1alpha1.TargetSpec{{Namespace: keptNSName}})
+		Eventually(func(g Gomega) {
+			var latest platformv1alpha1.SharedResource
+			g.Expect(k8sClient.Get(ctx, srKey, &latest)).To(Succeed())
+			g.Expect(latest.Status.ObservedGeneration).To(Equal(latest.Generation))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		var checksum string
+		Eventually(func() error {
+			target := &corev1.Secret{}
+			if err := k8sClient.Get(ctx, targetKey, target); err != nil {
+				return err
+			}
+			checksum = target.Annotations[AnnotationChecksum]
+			target.Data["original"] = []byte("drifted")
+			return k8sClient.Update(ctx, target)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+
+		// Re-adding it restores the data even though its annotation still matches
+		setTargets([]platformv1alpha1.TargetSpec{{Namespace: keptNSName}, {Namespace: readdedNSName}})
+		Eventually(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data["original"]).To(Equal([]byte("correct")))
+			g.Expect(target.Annotations[AnnotationChecksum]).To(Equal(checksum))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+})
+
+var _ = Describe("Target Deletion Tracking", func() {
+	ctx := context.Background()
+
+	// setup creates namespaces and a SharedResource with trackTargetDeletion,
+	// waits for the finalizer on the target and returns the CR and target key.
+	setup := func(prefix string) (*platformv1alpha1.SharedResource, types.NamespacedName) {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("%s-src-%d", prefix, suffix)
+		targetNSName := fmt.Sprintf("%s-tgt-%d", prefix, suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: prefix + "-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-" + prefix, Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:              platformv1alpha1.SourceSpec{Kind: "Secret", Name: source.Name},
+				Targets:             []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				DeletionPolicy:      platformv1alpha1.DeletionPolicyDelete,
+				TrackTargetDeletion: true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		targetKey := types.NamespacedName{Name: source.Name, Namespace: targetNSName}
+		Eventually(func(g Gomega) {
+			var target corev1.Secret
+			g.Expect(k8sClient.Get(ctx, targetKey, &target)).To(Succeed())
+			g.Expect(target.Finalizers).To(ContainElement(TargetFinalizerName))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		return sr, targetKey
+	}
+
+	It("should recreate and report a target deleted out-of-band", func() {
+		sr, targetKey := setup("track")
+		before := testutil.ToFloat64(targetDeletionsTotal.WithLabelValues(KindSecret, DeletionReasonExternal))
+
+		var target corev1.Secret
+		Expect(k8sClient.Get(ctx, targetKey, &target)).To(Succeed())
+		oldUID := target.UID
+		Expect(k8sClient.Delete(ctx, &target)).To(Succeed())
+
+		// Recreated as a new object with the finalizer back in place
+		Eventually(func(g Gomega) {
+			var got corev1.Secret
+			g.Expect(k8sClient.Get(ctx, targetKey, &got)).To(Succeed())
+			g.Expect(got.UID).NotTo(Equal(oldUID))
+			g.Expect(got.DeletionTimestamp).To(BeNil())
+			g.Expect(got.Finalizers).To(ContainElement(TargetFinalizerName))
+			g.Expect(got.Data).To(HaveKeyWithValue("key", []byte("value")))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		Expect(testutil.ToFloat64(targetDeletionsTotal.WithLabelValues(KindSecret, DeletionReasonExternal))).To(Equal(before + 1))
+		Eventually(func(g Gomega) {
+			var events corev1.EventList
+			g.Expect(k8sClient.List(ctx, &events, client.InNamespace(sr.Namespace))).To(Succeed())
+			reasons := []string{}
+			for _, e := range events.Items {
+				if e.InvolvedObject.Name == sr.Name {
+					reasons = append(reasons, e.Reason)
+				}
+			}
+			g.Expect(reasons).To(ContainElement("TargetDeleted"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// The operator's own cleanup is not reported as an out-of-band delete
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Expect(testutil.ToFloat64(targetDeletionsTotal.WithLabelValues(KindSecret, DeletionReasonExternal))).To(Equal(before + 1))
+	})
+
+	It("should release but not recreate targets of a namespace being deleted", func() {
+		_, targetKey := setup("tracknsdel")
+		before := testutil.ToFloat64(targetDeletionsTotal.WithLabelValues(KindSecret, DeletionReasonNamespace))
+
+		// envtest has no namespace controller: the namespace stays Terminating
+		// and we delete its contents ourselves, as the namespace controller would
+		Expect(k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetKey.Namespace}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: targetKey.Name, Namespace: targetKey.Namespace}})).To(Succeed())
+
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Consistently(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))
+		}, time.Second*2, time.Millisecond*250).Should(BeTrue())
+		Expect(testutil.ToFloat64(targetDeletionsTotal.WithLabelValues(KindSecret, DeletionReasonNamespace))).To(Equal(before + 1))
+	})
+})
+
+var _ = Describe("Target Recreation", func() {
+	ctx := context.Background()
+
+	// recreations returns how many recreations were observed for a kind.
+	recreations := func(kind string) uint64 {
+		var m dto.Metric
+		Expect(targetRecreationSeconds.WithLabelValues(kind).(prometheus.Histogram).Write(&m)).To(Succeed())
+		return m.GetHistogram().GetSampleCount()
+	}
+
+	It("should recreate a deleted target within the SLO and measure it", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("recreate-src-%d", suffix)
+		targetNSName := fmt.Sprintf("recreate-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "recreate-config", Namespace: sourceNSName},
+			Data:       map[string]string{"key": "value"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-recreate", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "recreate-config"},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		targetKey := types.NamespacedName{Name: "recreate-config", Namespace: targetNSName}
+		var target corev1.ConfigMap
+		Eventually(func() error {
+			return k8sClient.Get(ctx, targetKey, &target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// Let the CR settle so the recreate is driven by the Delete event, not a pending sync
+		Eventually(func(g Gomega) {
+			var got platformv1alpha1.SharedResource
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: sr.Name, Namespace: sr.Namespace}, &got)).To(Succeed())
+			g.Expect(got.Status.ObservedGeneration).To(Equal(got.Generation))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		before := recreations(KindConfigMap)
+		Expect(k8sClient.Delete(ctx, &target)).To(Succeed())
+		Eventually(func(g Gomega) {
+			var got corev1.ConfigMap
+			g.Expect(k8sClient.Get(ctx, targetKey, &got)).To(Succeed())
+			g.Expect(got.UID).NotTo(Equal(target.UID))
+		}, time.Second*5, time.Millisecond*100).Should(Succeed())
+		Eventually(func() uint64 { return recreations(KindConfigMap) }, time.Second*5, time.Millisecond*100).Should(Equal(before + 1))
+
+		// The operator's own deletes are not timed as recreations
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.ConfigMap{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Consistently(func() uint64 { return recreations(KindConfigMap) }, time.Second*2, time.Millisecond*250).Should(Equal(before + 1))
+	})
 })
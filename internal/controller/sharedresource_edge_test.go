@@ -18,18 +18,39 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
 )
 
+// writeRecordingClient counts Updates and Patches, to tell how a target was written.
+type writeRecordingClient struct {
+	client.Client
+	updates, patches int
+}
+
+func (c *writeRecordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.updates++
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *writeRecordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patches++
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
 var _ = Describe("Edge Cases", func() {
 	ctx := context.Background()
 
@@ -125,4 +146,304 @@ var _ = Describe("Edge Cases", func() {
 		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
 	})
 
+	It("should honor spec.sourceRetryInterval when the source is missing", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("srcretry-ns-%d", suffix)
+
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func(name string) {
+			_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}(sourceNSName)
+
+		// Create SharedResource with a long retry interval and no source
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-srcretry", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:              platformv1alpha1.SourceSpec{Kind: "Secret", Name: "later-secret"},
+				Targets:             []platformv1alpha1.TargetSpec{{Namespace: "default"}},
+				SourceRetryInterval: &metav1.Duration{Duration: time.Hour},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Next retry is scheduled roughly an hour out
+		Eventually(func() time.Duration {
+			freshSR := &platformv1alpha1.SharedResource{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-srcretry", Namespace: sourceNSName}, freshSR); err != nil {
+				return 0
+			}
+			if freshSR.Status.NextRetryTime == nil {
+				return 0
+			}
+			return time.Until(freshSR.Status.NextRetryTime.Time)
+		}, time.Second*10, time.Millisecond*250).Should(BeNumerically(">", 50*time.Minute))
+	})
+
+	It("should stamp targets with a provenance record", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("prov-src-%d", suffix)
+		targetNSName := fmt.Sprintf("prov-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "prov-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-prov", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "prov-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait for target
+		target := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "prov-secret", Namespace: targetNSName}, target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// Provenance traces the copy back to the exact source object
+		var record provenanceRecord
+		Expect(json.Unmarshal([]byte(target.Annotations[AnnotationProvenance]), &record)).To(Succeed())
+		Expect(record).To(Equal(provenanceRecord{
+			Kind:            "Secret",
+			SourceNamespace: sourceNSName,
+			SourceName:      "prov-secret",
+			SourceUID:       source.UID,
+			SharedResource:  "sync-prov",
+			Checksum:        target.Annotations[AnnotationChecksum],
+		}))
+	})
+
+	It("should record which operator build last wrote a target", func() {
+		targetNSName := fmt.Sprintf("version-tgt-%d", time.Now().UnixNano()%100000)
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// The SharedResource is never created, so only this reconciler touches the target
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-version", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "version-secret"},
+			},
+		}
+		reconciler := &SharedResourceReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{
+				OperatorVersion: "v1.2.0",
+				OperatorBuild:   "go1.24.1, rev 1a2b3c4d5e6f",
+			},
+		}
+		targetKey := types.NamespacedName{Name: "version-secret", Namespace: targetNSName}
+		data := map[string][]byte{"key": []byte("value")}
+		sync := func() bool {
+			GinkgoHelper()
+			changed, _, err := reconciler.syncToTarget(ctx, sr, targetNSName, targetKey.Name,
+				platformv1alpha1.DeletionPolicyOrphan, data, sourceMeta{}, syncengine.Checksum(data), false)
+			Expect(err).NotTo(HaveOccurred())
+			return changed
+		}
+		writtenBy := func() string {
+			GinkgoHelper()
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			return target.Annotations[AnnotationOperatorVersion]
+		}
+
+		Expect(sync()).To(BeTrue())
+		Expect(writtenBy()).To(Equal("v1.2.0 (go1.24.1, rev 1a2b3c4d5e6f)"))
+
+		// Another build alone does not rewrite targets, so the annotation names the last writer
+		reconciler.OperatorBuild = "go1.24.2, rev 6f5e4d3c2b1a"
+		Expect(sync()).To(BeFalse())
+		Expect(writtenBy()).To(Equal("v1.2.0 (go1.24.1, rev 1a2b3c4d5e6f)"))
+
+		data["key"] = []byte("rotated")
+		Expect(sync()).To(BeTrue())
+		Expect(writtenBy()).To(Equal("v1.2.0 (go1.24.2, rev 6f5e4d3c2b1a)"))
+
+		// Without a known version nothing is stamped
+		unversioned := &SharedResourceReconciler{InstanceOptions: InstanceOptions{OperatorBuild: "go1.24.1"}}
+		Expect(unversioned.operatorVersion()).To(BeEmpty())
+	})
+
+	It("should adopt an unmanaged copy with identical data by patching its metadata", func() {
+		targetNSName := fmt.Sprintf("adopt-tgt-%d", time.Now().UnixNano()%100000)
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// A manual copy made before the SharedResource existed
+		data := map[string][]byte{"key": []byte("value")}
+		manual := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "adopt-secret", Namespace: targetNSName},
+			Data:       data,
+		}
+		Expect(k8sClient.Create(ctx, manual)).To(Succeed())
+
+		// The SharedResource is never created, so only this reconciler touches the target
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-adopt", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "adopt-secret"},
+			},
+		}
+		writes := &writeRecordingClient{Client: k8sClient}
+		reconciler := &SharedResourceReconciler{Client: writes, Scheme: k8sClient.Scheme()}
+		sync := func() bool {
+			GinkgoHelper()
+			changed, _, err := reconciler.syncToTarget(ctx, sr, targetNSName, manual.Name,
+				platformv1alpha1.DeletionPolicyOrphan, data, sourceMeta{SecretType: corev1.SecretTypeOpaque},
+				syncengine.Checksum(data), false)
+			Expect(err).NotTo(HaveOccurred())
+			return changed
+		}
+
+		Expect(sync()).To(BeTrue())
+		Expect(writes.updates).To(BeZero())
+		Expect(writes.patches).To(Equal(1))
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(manual), target)).To(Succeed())
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationManagedBy, ManagedByValue))
+		Expect(target.Labels).To(HaveKeyWithValue(LabelManagedBy, ManagedByValue))
+		Expect(target.Data).To(Equal(data))
+
+		// Once managed, the target is up to date; different data is written as usual
+		Expect(sync()).To(BeFalse())
+		data["key"] = []byte("rotated")
+		Expect(sync()).To(BeTrue())
+		Expect(writes.updates).To(Equal(1))
+	})
+
+	It("should sync a target listed twice only once", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("dup-src-%d", suffix)
+		targetNSName := fmt.Sprintf("dup-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dup-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create a SharedResource whose second target renames to the source name
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-dup", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "dup-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: targetNSName},
+					{Namespace: targetNSName, Name: "dup-secret"},
+				},
+			},
+		}
+		Expect(DuplicateTargets(sr)).To(HaveLen(1))
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// The target is synced and reported once
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "sync-dup", Namespace: sourceNSName}, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.AllTargetsAtChecksum).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Status.SyncedTargets).To(ConsistOf(
+			HaveField("Name", "dup-secret"),
+		))
+	})
+
+	It("should label targets and stamp the installation's target annotations", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("exempt-src-%d", suffix)
+		targetNSName := fmt.Sprintf("exempt-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "exempt-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-exempt", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "exempt-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait for target
+		target := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "exempt-secret", Namespace: targetNSName}, target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// Policy engines can match on the label, the annotation or the field manager
+		Expect(target.Labels).To(HaveKeyWithValue(LabelManagedBy, ManagedByValue))
+		Expect(target.Annotations).To(HaveKeyWithValue(testExemptAnnotation, "true"))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationManagedBy, ManagedByValue))
+		Expect(lastFieldManager(target)).To(Equal(FieldManager))
+	})
+
+	It("should log data diffs with key names and lengths only", func() {
+		var lines []string
+		log := funcr.New(func(prefix, args string) {
+			lines = append(lines, args)
+		}, funcr.Options{Verbosity: LogLevelDataDiff})
+
+		oldData := map[string][]byte{"kept": []byte("same"), "gone": []byte("old-secret"), "rotated": []byte("v1")}
+		newData := map[string][]byte{"kept": []byte("same"), "rotated": []byte("v2-longer"), "fresh": []byte("new-secret")}
+		logDataDiff(log, KindSecret, types.NamespacedName{Namespace: "ns", Name: "name"}, oldData, newData)
+
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(ContainSubstring(`"added"=[{"key"="fresh" "newLen"=10}]`))
+		Expect(lines[0]).To(ContainSubstring(`"removed"=[{"key"="gone" "oldLen"=10}]`))
+		Expect(lines[0]).To(ContainSubstring(`"changed"=[{"key"="rotated" "oldLen"=2 "newLen"=9}]`))
+		for _, value := range []string{"old-secret", "new-secret", "v2-longer", "same"} {
+			Expect(strings.Contains(lines[0], value)).To(BeFalse(), "value %q leaked into log", value)
+		}
+
+		// Nothing is logged below the diff verbosity
+		lines = nil
+		quiet := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{})
+		logDataDiff(quiet, KindSecret, types.NamespacedName{Namespace: "ns", Name: "name"}, oldData, newData)
+		Expect(lines).To(BeEmpty())
+	})
 })
@@ -0,0 +1,278 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Share Expiry", func() {
+	ctx := context.Background()
+
+	// setupShare creates a source and target namespace and a source Secret.
+	setupShare := func(prefix string) (string, string) {
+		GinkgoHelper()
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("%s-src-%d", prefix, suffix)
+		targetNSName := fmt.Sprintf("%s-tgt-%d", prefix, suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "expiring-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+		return sourceNSName, targetNSName
+	}
+
+	It("should compute the expiry from expiresAt, duration and renewals", func() {
+		created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := clocktesting.NewFakeClock(created.Add(time.Hour))
+		r := &SharedResourceReconciler{Clock: clock}
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		}
+		Expect(r.shareExpiry(sr)).To(BeNil())
+
+		By("counting the duration from creation")
+		sr.Spec.Duration = &metav1.Duration{Duration: 24 * time.Hour}
+		Expect(r.shareExpiry(sr).Time).To(Equal(created.Add(24 * time.Hour)))
+
+		By("applying the earlier of expiresAt and duration")
+		at := metav1.NewTime(created.Add(2 * time.Hour))
+		sr.Spec.ExpiresAt = &at
+		Expect(r.shareExpiry(sr).Time).To(Equal(at.Time))
+		sr.Spec.ExpiresAt = nil
+
+		By("counting from a renewal that has happened")
+		renewed := created.Add(30 * time.Minute)
+		sr.Annotations = map[string]string{AnnotationRenewedAt: renewed.Format(time.RFC3339)}
+		Expect(r.shareExpiry(sr).Time).To(Equal(renewed.Add(24 * time.Hour)))
+
+		By("ignoring renewals in the future, before creation or malformed")
+		for _, value := range []string{
+			created.Add(2 * time.Hour).Format(time.RFC3339),
+			created.Add(-time.Hour).Format(time.RFC3339),
+			"yesterday",
+		} {
+			sr.Annotations[AnnotationRenewedAt] = value
+			Expect(r.shareExpiry(sr).Time).To(Equal(created.Add(24*time.Hour)), value)
+		}
+
+		By("waking up when renewal is due, then at the expiry")
+		sr.Spec.RenewBefore = &metav1.Duration{Duration: 3 * time.Hour}
+		expired, remaining := r.checkExpiry(sr)
+		Expect(expired).To(BeFalse())
+		Expect(remaining).To(Equal(20 * time.Hour))
+		Expect(meta.IsStatusConditionFalse(sr.Status.Conditions, ConditionTypeRenewalDue)).To(BeTrue())
+		clock.SetTime(created.Add(22 * time.Hour))
+		expired, remaining = r.checkExpiry(sr)
+		Expect(expired).To(BeFalse())
+		Expect(remaining).To(Equal(2 * time.Hour))
+		Expect(meta.IsStatusConditionTrue(sr.Status.Conditions, ConditionTypeRenewalDue)).To(BeTrue())
+
+		By("reporting whether the share has expired")
+		clock.SetTime(created.Add(24 * time.Hour))
+		expired, _ = r.checkExpiry(sr)
+		Expect(expired).To(BeTrue())
+	})
+
+	It("should report a due renewal and extend expiresAt on a renew request", func() {
+		sourceNSName, targetNSName := setupShare("renew")
+
+		expiresAt := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-renew", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:      platformv1alpha1.SourceSpec{Kind: "Secret", Name: "expiring-secret"},
+				Targets:     []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				ExpiresAt:   &expiresAt,
+				RenewBefore: &metav1.Duration{Duration: 2 * time.Hour},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-renew", Namespace: sourceNSName}
+
+		By("reporting the renewal as due")
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(freshSR.Status.Conditions, ConditionTypeRenewalDue)).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(meta.IsStatusConditionTrue(freshSR.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+
+		annotate := func(value string) {
+			GinkgoHelper()
+			Eventually(func() error {
+				var latest platformv1alpha1.SharedResource
+				if err := k8sClient.Get(ctx, key, &latest); err != nil {
+					return err
+				}
+				latest.Annotations = map[string]string{AnnotationRenew: value}
+				return k8sClient.Update(ctx, &latest)
+			}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		}
+
+		By("ignoring a renew request that is not a duration")
+		annotate("next-year")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Annotations).NotTo(HaveKey(AnnotationRenew))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Spec.ExpiresAt.Equal(&expiresAt)).To(BeTrue())
+
+		By("extending expiresAt and clearing the due renewal")
+		annotate("24h")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Annotations).NotTo(HaveKey(AnnotationRenew))
+			g.Expect(freshSR.Spec.ExpiresAt.Time).To(BeTemporally("==", expiresAt.Add(24*time.Hour)))
+			g.Expect(meta.IsStatusConditionFalse(freshSR.Status.Conditions, ConditionTypeRenewalDue)).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Status.ExpiresAt.Equal(freshSR.Spec.ExpiresAt)).To(BeTrue())
+	})
+
+	It("should delete the targets of an expired share and recreate them on renewal", func() {
+		sourceNSName, targetNSName := setupShare("expire")
+
+		expiresAt := metav1.NewTime(time.Now().Add(3 * time.Second))
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-expire", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:    platformv1alpha1.SourceSpec{Kind: "Secret", Name: "expiring-secret"},
+				Targets:   []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				ExpiresAt: &expiresAt,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-expire", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "expiring-secret", Namespace: targetNSName}
+
+		By("syncing until the expiry")
+		target := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, targetKey, target)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+
+		By("deleting the target once expired")
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeExpired)).To(
+				HaveField("Reason", "Revoked"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(meta.IsStatusConditionFalse(freshSR.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+		Expect(freshSR.Status.ExpiresAt).NotTo(BeNil())
+		Expect(freshSR.Status.ExpiresAt.Equal(freshSR.Spec.ExpiresAt)).To(BeTrue())
+
+		By("recreating the target when the share is renewed")
+		Eventually(func() error {
+			var latest platformv1alpha1.SharedResource
+			if err := k8sClient.Get(ctx, key, &latest); err != nil {
+				return err
+			}
+			renewed := metav1.NewTime(time.Now().Add(time.Hour))
+			latest.Spec.ExpiresAt = &renewed
+			return k8sClient.Update(ctx, &latest)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(ctx, targetKey, target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeExpired)).To(BeNil())
+			g.Expect(meta.IsStatusConditionTrue(freshSR.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should keep but stop refreshing the targets of an expired retained share", func() {
+		sourceNSName, targetNSName := setupShare("retain")
+
+		expiresAt := metav1.NewTime(time.Now().Add(3 * time.Second))
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-retain", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:       platformv1alpha1.SourceSpec{Kind: "Secret", Name: "expiring-secret"},
+				Targets:      []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				ExpiresAt:    &expiresAt,
+				ExpiryPolicy: platformv1alpha1.ExpiryPolicyRetain,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-retain", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "expiring-secret", Namespace: targetNSName}
+
+		By("marking the target expired")
+		target := &corev1.Secret{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Annotations).To(HaveKeyWithValue(AnnotationExpired, expiresAt.UTC().Format(time.RFC3339)))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(target.Data["password"]).To(Equal([]byte("v1")))
+
+		By("not propagating source changes")
+		source := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "expiring-secret", Namespace: sourceNSName}, source)).To(Succeed())
+		source.Data["password"] = []byte("v2")
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data["password"]).To(Equal([]byte("v1")))
+		}, time.Second*2, time.Millisecond*250).Should(Succeed())
+		freshSR := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+		Expect(meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeExpired)).To(HaveField("Reason", "Retained"))
+
+		By("refreshing and unmarking the target once the expiry is lifted")
+		Eventually(func() error {
+			var latest platformv1alpha1.SharedResource
+			if err := k8sClient.Get(ctx, key, &latest); err != nil {
+				return err
+			}
+			latest.Spec.ExpiresAt = nil
+			return k8sClient.Update(ctx, &latest)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data["password"]).To(Equal([]byte("v2")))
+			g.Expect(target.Annotations).NotTo(HaveKey(AnnotationExpired))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.ExpiresAt).To(BeNil())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+})
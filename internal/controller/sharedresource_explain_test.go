@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Explain", func() {
+	ctx := context.Background()
+
+	It("should explain filtered keys, targets and the next sync on request", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("explain-src-%d", suffix)
+		targetNSName := fmt.Sprintf("explain-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "explain-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("v1"), "debug": []byte("on")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sync-explain",
+				Namespace:   sourceNSName,
+				Annotations: map[string]string{AnnotationExplain: "1"},
+			},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "explain-secret"},
+				SyncPolicy: &platformv1alpha1.SyncPolicySpec{
+					Mode: platformv1alpha1.SyncModeSelective,
+					Keys: &platformv1alpha1.KeySelector{Include: []string{"username", "password"}},
+				},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "explain",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-explain", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "explain"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			for range 2 {
+				// The first reconcile only adds the finalizer
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+
+		By("explaining a sync")
+		current := reconcile()
+		Expect(current.Status.Explanation).NotTo(BeNil())
+		Expect(current.Status.Explanation.Request).To(Equal("1"))
+		Expect(current.Status.Explanation.Lines).To(ContainElements(
+			"Source Secret explain-secret shares 3 key(s)",
+			"Dropped by syncPolicy (mode selective): debug",
+			"Sending 2 key(s) to 1 target(s)",
+			ContainSubstring(targetNSName+"/explain-secret: synced"),
+			ContainSubstring("Next sync at"),
+		))
+
+		By("answering each request once")
+		answered := current.Status.Explanation.Time
+		current = reconcile()
+		Expect(current.Status.Explanation.Time).To(Equal(answered))
+
+		By("explaining why nothing is synced")
+		Expect(k8sClient.Delete(ctx, source)).To(Succeed())
+		current.Annotations[AnnotationExplain] = "2"
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile()
+		Expect(current.Status.Explanation.Request).To(Equal("2"))
+		Expect(current.Status.Explanation.Lines).To(ContainElement(ContainSubstring("not found; no target is written")))
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
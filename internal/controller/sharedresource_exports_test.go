@@ -0,0 +1,227 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Export and Import", func() {
+	ctx := context.Background()
+
+	It("should only copy the source while the Export admits the Import", func() {
+		suffix := time.Now().UnixNano() % 100000
+		ownerNSName := fmt.Sprintf("exports-owner-%d", suffix)
+		consumerNSName := fmt.Sprintf("exports-consumer-%d", suffix)
+		consumer := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: consumerNSName}}
+		for _, ns := range []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: ownerNSName}}, consumer} {
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "exports-secret", Namespace: ownerNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		})).To(Succeed())
+
+		imp := &platformv1alpha1.SharedResourceImport{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: consumerNSName},
+			Spec: platformv1alpha1.SharedResourceImportSpec{
+				Export:     platformv1alpha1.ExportReference{Namespace: ownerNSName, Name: "db-export"},
+				TargetName: "db-credentials",
+			},
+		}
+		Expect(k8sClient.Create(ctx, imp)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, imp) })
+		key := types.NamespacedName{Name: "db", Namespace: consumerNSName}
+		targetKey := types.NamespacedName{Name: "db-credentials", Namespace: consumerNSName}
+		r := &SharedResourceImportReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		reconcile := func(g Gomega) *platformv1alpha1.SharedResourceImport {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			g.Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResourceImport{}
+			g.Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		readyReason := func(imp *platformv1alpha1.SharedResourceImport) string {
+			return meta.FindStatusCondition(imp.Status.Conditions, ConditionTypeReady).Reason
+		}
+
+		By("waiting for an Export")
+		current := reconcile(Default)
+		Expect(readyReason(current)).To(Equal("ExportNotFound"))
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, key, &platformv1alpha1.SharedResource{}))).To(BeTrue())
+
+		By("not copying into a namespace the Export does not admit")
+		export := &platformv1alpha1.SharedResourceExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-export", Namespace: ownerNSName},
+			Spec: platformv1alpha1.SharedResourceExportSpec{
+				Source:            platformv1alpha1.ExportSource{Kind: "Secret", Name: "exports-secret"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "exports"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, export)).To(Succeed())
+		current = reconcile(Default)
+		Expect(readyReason(current)).To(Equal("NotAdmitted"))
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, key, &platformv1alpha1.SharedResource{}))).To(BeTrue())
+
+		By("copying once the Export admits the namespace")
+		consumer.Labels = map[string]string{"team": "exports"}
+		Expect(k8sClient.Update(ctx, consumer)).To(Succeed())
+		current = reconcile(Default)
+		Expect(current.Status.SharedResource).To(Equal("db"))
+		sr := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, sr)).To(Succeed())
+		Expect(metav1.IsControlledBy(sr, current)).To(BeTrue())
+		Expect(sr.Spec.Source).To(Equal(platformv1alpha1.SourceSpec{
+			Kind: "Secret", Name: "exports-secret", Namespace: ownerNSName,
+		}))
+		// The manager's reconciler syncs the SharedResource, with the Export as consent
+		target := &corev1.Secret{}
+		Eventually(func() error { return k8sClient.Get(ctx, targetKey, target) }, 10*time.Second).Should(Succeed())
+		Expect(target.Data).To(Equal(map[string][]byte{"password": []byte("v1")}))
+		Eventually(func(g Gomega) {
+			g.Expect(meta.IsStatusConditionTrue(reconcile(g).Status.Conditions, ConditionTypeReady)).To(BeTrue())
+		}, 10*time.Second).Should(Succeed())
+
+		By("deleting the copy once the Export is withdrawn")
+		Expect(k8sClient.Delete(ctx, export)).To(Succeed())
+		current = reconcile(Default)
+		Expect(readyReason(current)).To(Equal("ExportNotFound"))
+		Expect(current.Status.SharedResource).To(BeEmpty())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))
+		}, 10*time.Second).Should(BeTrue())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, key, &platformv1alpha1.SharedResource{}))
+		}, 10*time.Second).Should(BeTrue())
+	})
+
+	It("should only consent for an Import's SharedResource writing to admitted namespaces", func() {
+		suffix := time.Now().UnixNano() % 100000
+		ownerNSName := fmt.Sprintf("exports-owner-%d", suffix)
+		consumerNSName := fmt.Sprintf("exports-consumer-%d", suffix)
+		otherNSName := fmt.Sprintf("exports-other-%d", suffix)
+		for _, name := range []string{ownerNSName, consumerNSName, otherNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "exports-secret", Namespace: ownerNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &platformv1alpha1.SharedResourceExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-export", Namespace: ownerNSName},
+			Spec: platformv1alpha1.SharedResourceExportSpec{
+				Source:     platformv1alpha1.ExportSource{Kind: "Secret", Name: "exports-secret"},
+				Namespaces: []string{consumerNSName},
+			},
+		})).To(Succeed())
+		imp := &platformv1alpha1.SharedResourceImport{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: consumerNSName},
+			Spec: platformv1alpha1.SharedResourceImportSpec{
+				Export: platformv1alpha1.ExportReference{Namespace: ownerNSName, Name: "db-export"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, imp)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, imp) })
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: consumerNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "exports-secret", Namespace: ownerNSName},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: otherNSName}},
+				OperatorClass: "exports",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "db", Namespace: consumerNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "exports"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		denied := func(sr *platformv1alpha1.SharedResource) bool {
+			return meta.IsStatusConditionTrue(sr.Status.Conditions, ConditionTypeSourceExportDenied)
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("denying a plain SharedResource in an admitted namespace")
+		current := reconcile()
+		Expect(denied(current)).To(BeTrue())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Name: "exports-secret", Namespace: otherNSName},
+			&corev1.Secret{}))).To(BeTrue())
+
+		By("denying an Import's SharedResource writing to a namespace the Export does not admit")
+		Expect(controllerutil.SetControllerReference(imp, current, k8sClient.Scheme())).To(Succeed())
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		Expect(denied(reconcile())).To(BeTrue())
+
+		By("consenting once it only writes to admitted namespaces")
+		current = reconcile()
+		current.Spec.Targets = []platformv1alpha1.TargetSpec{{Namespace: consumerNSName, Name: "db-credentials"}}
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile()
+		Expect(denied(current)).To(BeFalse())
+		Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "db-credentials", Namespace: consumerNSName},
+			&corev1.Secret{})).To(Succeed())
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should admit the listed namespaces and those matching the selector", func() {
+		export := &platformv1alpha1.SharedResourceExport{Spec: platformv1alpha1.SharedResourceExportSpec{
+			Namespaces:        []string{"payments-api"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+		}}
+		Expect(exportAdmits(export, "payments-api", nil)).To(BeTrue())
+		Expect(exportAdmits(export, "payments-worker", map[string]string{"team": "payments"})).To(BeTrue())
+		Expect(exportAdmits(export, "billing", map[string]string{"team": "billing"})).To(BeFalse())
+
+		// An invalid selector admits no namespace
+		export.Spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: "Bogus"},
+		}}
+		Expect(exportAdmits(export, "payments-worker", map[string]string{"team": "payments"})).To(BeFalse())
+		Expect(exportAdmits(export, "payments-api", nil)).To(BeTrue())
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Fan-in", func() {
+	ctx := context.Background()
+
+	It("should merge the sources in order into each target", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("fanin-src-%d", suffix)
+		targetNSName := fmt.Sprintf("fanin-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		for name, data := range map[string]map[string]string{
+			"app-base": {"log-level": "info", "replicas": "1", "region": "eu"},
+			"app-prod": {"replicas": "3"},
+		} {
+			Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: sourceNSName},
+				Data:       data,
+			})).To(Succeed())
+		}
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "fanin", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "app-base"},
+				Sources: []platformv1alpha1.SourceOverlay{
+					{Name: "app-prod"},
+					{Name: "app-hotfix", Optional: true},
+				},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, sr) })
+		targetData := func() map[string]string {
+			target := &corev1.ConfigMap{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "app-base", Namespace: targetNSName}, target); err != nil {
+				return nil
+			}
+			return target.Data
+		}
+
+		By("skipping the optional source while it does not exist")
+		Eventually(targetData, 10*time.Second).Should(Equal(map[string]string{
+			"log-level": "info", "replicas": "3", "region": "eu",
+		}))
+
+		By("merging a source once it is created, over those before it")
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-hotfix", Namespace: sourceNSName},
+			Data:       map[string]string{"replicas": "5", "log-level": "debug"},
+		})).To(Succeed())
+		Eventually(targetData, 10*time.Second).Should(Equal(map[string]string{
+			"log-level": "debug", "replicas": "5", "region": "eu",
+		}))
+
+		By("reporting a missing required source by name")
+		Expect(k8sClient.Delete(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-prod", Namespace: sourceNSName},
+		})).To(Succeed())
+		Eventually(func(g Gomega) {
+			current := &platformv1alpha1.SharedResource{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "fanin", Namespace: sourceNSName}, current)).To(Succeed())
+			found := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeSourceFound)
+			g.Expect(found).NotTo(BeNil())
+			g.Expect(found.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(found.Message).To(Equal("Source ConfigMap/app-prod not found"))
+		}, 10*time.Second).Should(Succeed())
+	})
+
+	It("should reject sources with a source in another namespace", func() {
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "fanin-invalid", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "base", Namespace: "security"},
+				Sources: []platformv1alpha1.SourceOverlay{{Name: "overlay"}},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: "backend"}},
+			},
+		}
+		err := k8sClient.Create(ctx, sr)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("sources need spec.source in the SharedResource's namespace"))
+
+		sr.Spec.Source.Namespace = ""
+		sr.Spec.Sources = []platformv1alpha1.SourceOverlay{{Name: "base"}}
+		err = k8sClient.Create(ctx, sr)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("sources must not repeat spec.source.name"))
+	})
+})
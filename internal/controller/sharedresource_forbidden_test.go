@@ -0,0 +1,179 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Forbidden Targets", func() {
+	ctx := context.Background()
+
+	It("should back off the retries of forbidden targets up to a cap", func() {
+		denied := func(count int32) platformv1alpha1.TargetSyncStatus {
+			return platformv1alpha1.TargetSyncStatus{AccessDenied: true,
+				RecentErrors: []platformv1alpha1.TargetError{{Message: "forbidden", Count: count}}}
+		}
+		Expect(forbiddenRetryAfter(nil)).To(BeZero())
+		Expect(forbiddenRetryAfter([]platformv1alpha1.TargetSyncStatus{{Synced: true}})).To(BeZero())
+		Expect(forbiddenRetryAfter([]platformv1alpha1.TargetSyncStatus{denied(1)})).To(Equal(ForbiddenRetryBaseInterval))
+		Expect(forbiddenRetryAfter([]platformv1alpha1.TargetSyncStatus{denied(3)})).To(Equal(4 * ForbiddenRetryBaseInterval))
+		Expect(forbiddenRetryAfter([]platformv1alpha1.TargetSyncStatus{denied(100)})).To(Equal(ForbiddenRetryMaxInterval))
+		// The target failing for the shortest time decides
+		Expect(forbiddenRetryAfter([]platformv1alpha1.TargetSyncStatus{denied(100), denied(2)})).
+			To(Equal(2 * ForbiddenRetryBaseInterval))
+	})
+
+	It("should retry a forbidden target soon and once its namespace's RoleBindings change", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("forbidden-src-%d", suffix)
+		tenantNSName := fmt.Sprintf("forbidden-tenant-%d", suffix)
+		for name, nsLabels := range map[string]map[string]string{sourceNSName: nil, tenantNSName: {"tenant": "f"}} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "forbidden-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		})).To(Succeed())
+
+		// The tenant's identity has no permissions yet, so its writes are forbidden
+		user, err := testEnv.AddUser(envtest.User{Name: "sharedresource-tenant-f"}, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		kubeconfig, err := user.KubeConfig()
+		Expect(err).NotTo(HaveOccurred())
+		kubeconfigPath := filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+		Expect(os.WriteFile(kubeconfigPath, kubeconfig, 0o600)).To(Succeed())
+		identities, err := ParseTargetIdentities(fmt.Appendf(nil,
+			"identities:\n- name: tenant-f\n  namespaceSelector: {matchLabels: {tenant: f}}\n  kubeconfig: %s\n", kubeconfigPath))
+		Expect(err).NotTo(HaveOccurred())
+		identities[0].Client, err = NewIdentityClient(cfg, identities[0], client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-forbidden", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "forbidden-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: tenantNSName}},
+				OperatorClass: "forbidden",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-forbidden", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{
+			Client:          routeTargetWrites(k8sClient, identities),
+			Scheme:          k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "forbidden"},
+		}
+		// The first reconcile only adds the finalizer
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("marking the target accessDenied and retrying it on a short backoff")
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(ForbiddenRetryBaseInterval))
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(current.Status.SyncedTargets).To(ConsistOf(And(HaveField("AccessDenied", true), HaveField("Synced", false))))
+
+		By("retrying at once when a RoleBinding in the namespace changes")
+		Expect(k8sClient.Create(ctx, &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-f-writer", Namespace: tenantNSName},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""}, Resources: []string{"secrets"},
+				Verbs: []string{"get", "create", "update", "patch", "delete"},
+			}},
+		})).To(Succeed())
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-f-writer", Namespace: tenantNSName},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "tenant-f-writer"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "sharedresource-tenant-f"}},
+		}
+		Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+		Expect(r.findSharedResourcesForRoleBinding(ctx, binding)).To(ConsistOf(ctrl.Request{NamespacedName: key}))
+		Eventually(func(g Gomega) {
+			// Until the API server applies the binding, each failed retry waits for the next change
+			r.findSharedResourcesForRoleBinding(ctx, binding)
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			g.Expect(current.Status.SyncedTargets).To(ConsistOf(And(HaveField("AccessDenied", false), HaveField("Synced", true))))
+		}, 10*time.Second).Should(Succeed())
+		Expect(r.findSharedResourcesForRoleBinding(ctx, binding)).To(BeEmpty())
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should map binding changes to the CRs with forbidden targets they may heal", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("forbidden-map-%d", suffix)
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "map-forbidden", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "map-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: "denied"}, {Namespace: "allowed"}},
+				OperatorClass: "forbidden",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, sr) })
+		sr.Status.SyncedTargets = []platformv1alpha1.TargetSyncStatus{
+			{Namespace: "allowed", Name: "map-secret", Synced: true},
+			{Namespace: "denied", Name: "map-secret", AccessDenied: true},
+		}
+		Expect(k8sClient.Status().Update(ctx, sr)).To(Succeed())
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "map-forbidden", Namespace: sourceNSName}}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "forbidden"}}
+
+		inNamespace := func(namespace string) *rbacv1.RoleBinding {
+			return &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: namespace}}
+		}
+		Expect(r.findSharedResourcesForRoleBinding(ctx, inNamespace("denied"))).To(ContainElement(request))
+		Expect(r.findSharedResourcesForRoleBinding(ctx, inNamespace("allowed"))).NotTo(ContainElement(request))
+		Expect(r.findSharedResourcesForClusterRoleBinding(ctx, &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+		})).To(ContainElement(request))
+	})
+})
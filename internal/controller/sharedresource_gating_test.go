@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+var _ = Describe("Reconcile Gating", func() {
+	ctx := context.Background()
+
+	It("should not rewrite status when nothing changed", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("gate-src-%d", suffix)
+		targetNSName := fmt.Sprintf("gate-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gate-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-gate", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "gate-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait until status reflects the current generation
+		key := types.NamespacedName{Name: "sync-gate", Namespace: sourceNSName}
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return false
+			}
+			return freshSR.Status.ObservedGeneration == freshSR.Generation && conditionIsTrue(freshSR, ConditionTypeReady)
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// An irrelevant CR edit (new label) must not trigger a full re-sync.
+		// Wait past the one-second timestamp granularity so a re-sync would show.
+		lastSyncTime := freshSR.Status.LastSyncTime.DeepCopy()
+		time.Sleep(1500 * time.Millisecond)
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return err
+			}
+			freshSR.Labels = map[string]string{"touched": "true"}
+			return k8sClient.Update(ctx, freshSR)
+		}, time.Second*5, time.Millisecond*500).Should(Succeed())
+
+		Consistently(func() *metav1.Time {
+			current := &platformv1alpha1.SharedResource{}
+			if err := k8sClient.Get(ctx, key, current); err != nil {
+				return nil
+			}
+			return current.Status.LastSyncTime
+		}, time.Second*3, time.Millisecond*500).Should(Equal(lastSyncTime))
+	})
+
+	It("should record the operator as field manager on target writes", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("fm-src-%d", suffix)
+		targetNSName := fmt.Sprintf("fm-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "fm-config", Namespace: sourceNSName},
+			Data:       map[string]string{"key": "value"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-fm", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "fm-config"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Target is written under our field manager, so its events are filtered
+		target := &corev1.ConfigMap{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "fm-config", Namespace: targetNSName}, target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(lastFieldManager(target)).To(Equal(FieldManager))
+	})
+
+	It("should forget a recorded write once its event is ignored or the target is deleted", func() {
+		suffix := time.Now().UnixNano() % 100000
+		target := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "forget-config",
+				Namespace:   fmt.Sprintf("forget-tgt-%d", suffix),
+				Annotations: map[string]string{AnnotationManagedBy: ManagedByValue},
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: FieldManager, Time: &metav1.Time{Time: time.Now()}},
+				},
+			},
+			Data: map[string]string{"key": "value"},
+		}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		key := writeKey(KindConfigMap, target.Namespace, target.Name)
+
+		By("ignoring the event of the write once")
+		r.recordWrite(KindConfigMap, target.Namespace, target.Name, syncengine.FromStrings(target.Data))
+		Expect(r.isSelfInflicted(target)).To(BeTrue())
+		_, recorded := r.writes.Load(key)
+		Expect(recorded).To(BeFalse())
+		Expect(r.isSelfInflicted(target)).To(BeFalse())
+
+		By("forgetting a write whose target is deleted before its event")
+		r.recordWrite(KindConfigMap, target.Namespace, target.Name, syncengine.FromStrings(target.Data))
+		r.recordTargetDeleted(KindConfigMap, target)
+		_, recorded = r.writes.Load(key)
+		Expect(recorded).To(BeFalse())
+	})
+})
@@ -0,0 +1,272 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Generated Values", func() {
+	ctx := context.Background()
+
+	It("should create the source Secret with generated keys and share them", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("gen-src-%d", suffix)
+		targetNSName := fmt.Sprintf("gen-tgt-%d", suffix)
+
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "generated", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "internal-password"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				Generate: []platformv1alpha1.GenerateSpec{
+					{Key: "password", Length: 40, Charset: platformv1alpha1.GenerateCharsetHex},
+					{Key: "token"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		source := &corev1.Secret{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "internal-password", Namespace: sourceNSName}, source)).To(Succeed())
+			g.Expect(source.Data["password"]).To(MatchRegexp("^[0-9a-f]{40}$"))
+			g.Expect(source.Data["token"]).To(MatchRegexp("^[A-Za-z0-9]{32}$"))
+			g.Expect(source.Annotations).To(HaveKey(AnnotationGenerated))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "internal-password", Namespace: targetNSName}, target)).To(Succeed())
+			g.Expect(target.Data["password"]).To(Equal(source.Data["password"]))
+			g.Expect(target.Data["token"]).To(Equal(source.Data["token"]))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		By("never regenerating a value without a rotationPeriod")
+		Consistently(func(g Gomega) {
+			var current corev1.Secret
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "internal-password", Namespace: sourceNSName}, &current)).To(Succeed())
+			g.Expect(current.Data["password"]).To(Equal(source.Data["password"]))
+		}, time.Second*2, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should rotate generated values and leave owner-provided keys alone", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("rot-src-%d", suffix)
+		targetNSName := fmt.Sprintf("rot-tgt-%d", suffix)
+
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: sourceNSName},
+			Data:       map[string][]byte{"username": []byte("app"), "api-key": []byte("owner-chosen")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "rotated", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "creds"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				Generate: []platformv1alpha1.GenerateSpec{
+					{Key: "password", RotationPeriod: &metav1.Duration{Duration: 2 * time.Second}},
+					{Key: "api-key", RotationPeriod: &metav1.Duration{Duration: 2 * time.Second}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		var first []byte
+		Eventually(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "creds", Namespace: targetNSName}, target)).To(Succeed())
+			g.Expect(target.Data).To(HaveKey("password"))
+			g.Expect(target.Data["username"]).To(Equal([]byte("app")))
+			first = target.Data["password"]
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		By("rotating the generated key once its period elapses")
+		Eventually(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "creds", Namespace: targetNSName}, target)).To(Succeed())
+			g.Expect(target.Data["password"]).NotTo(Equal(first))
+			g.Expect(target.Data["api-key"]).To(Equal([]byte("owner-chosen")))
+		}, time.Second*15, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should only switch a twoPhase key once every target holds the staged value", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("twophase-src-%d", suffix)
+		okNSName := fmt.Sprintf("twophase-ok-%d", suffix)
+		blockedNSName := fmt.Sprintf("twophase-blocked-%d", suffix)
+
+		for _, name := range []string{sourceNSName, okNSName, blockedNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Refuse updates to Secrets in the blocked namespace, so the staged value cannot land there
+		policy := &admissionregistrationv1.ValidatingAdmissionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("block-update-%d", suffix)},
+			Spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				MatchConstraints: &admissionregistrationv1.MatchResources{
+					ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"secrets"},
+							},
+						},
+					}},
+				},
+				Validations: []admissionregistrationv1.Validation{{
+					Expression: fmt.Sprintf("object.metadata.namespace != '%s'", blockedNSName),
+					Message:    "update blocked for test",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, policy) }()
+
+		binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: policy.Name},
+			Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+				PolicyName:        policy.Name,
+				ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny},
+			},
+		}
+		Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, binding) }()
+
+		// updateBlocked probes whether the API server currently enforces the policy
+		updateBlocked := func() bool {
+			probe := &corev1.Secret{}
+			key := types.NamespacedName{Name: "probe", Namespace: blockedNSName}
+			if err := k8sClient.Get(ctx, key, probe); apierrors.IsNotFound(err) {
+				probe.ObjectMeta = metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}
+				if err := k8sClient.Create(ctx, probe); err != nil {
+					return false
+				}
+			}
+			probe.Labels = map[string]string{"probe": fmt.Sprint(time.Now().UnixNano())}
+			return k8sClient.Update(ctx, probe) != nil
+		}
+		Eventually(updateBlocked, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "two-phase", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "db-password"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: okNSName}, {Namespace: blockedNSName}},
+				Generate: []platformv1alpha1.GenerateSpec{{
+					Key:              "password",
+					RotationPeriod:   &metav1.Duration{Duration: 3 * time.Second},
+					RotationStrategy: platformv1alpha1.RotationStrategyTwoPhase,
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		var original []byte
+		for _, ns := range []string{okNSName, blockedNSName} {
+			Eventually(func(g Gomega) {
+				target := &corev1.Secret{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "db-password", Namespace: ns}, target)).To(Succeed())
+				g.Expect(target.Data).To(HaveKey("password"))
+				original = target.Data["password"]
+			}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		}
+
+		By("staging the new value next to the current one")
+		Eventually(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "db-password", Namespace: okNSName}, target)).To(Succeed())
+			g.Expect(target.Data).To(HaveKey("password.v2"))
+			g.Expect(target.Data["password"]).To(Equal(original))
+		}, time.Second*15, time.Millisecond*250).Should(Succeed())
+
+		By("holding the primary key while a target lacks the staged value")
+		Consistently(func(g Gomega) {
+			source := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "db-password", Namespace: sourceNSName}, source)).To(Succeed())
+			g.Expect(source.Data["password"]).To(Equal(original))
+		}, time.Second*2, time.Millisecond*250).Should(Succeed())
+
+		By("switching the primary key once the last target catches up")
+		Expect(k8sClient.Delete(ctx, binding)).To(Succeed())
+		Eventually(updateBlocked, time.Second*10, time.Millisecond*250).Should(BeFalse())
+		// Request a sync instead of waiting for the failed target's retry
+		Eventually(func() error {
+			var latest platformv1alpha1.SharedResource
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "two-phase", Namespace: sourceNSName}, &latest); err != nil {
+				return err
+			}
+			latest.Annotations = map[string]string{AnnotationSyncNow: "unblocked"}
+			return k8sClient.Update(ctx, &latest)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			source := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "db-password", Namespace: sourceNSName}, source)).To(Succeed())
+			g.Expect(source.Data["password"]).NotTo(Equal(original))
+			g.Expect(source.Data).NotTo(HaveKey("password.v2"))
+			for _, ns := range []string{okNSName, blockedNSName} {
+				target := &corev1.Secret{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "db-password", Namespace: ns}, target)).To(Succeed())
+				g.Expect(target.Data["password"]).To(Equal(source.Data["password"]))
+			}
+		}, time.Second*20, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should reject generate for ConfigMap sources", func() {
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "generate-configmap", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:   platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "settings"},
+				Targets:  []platformv1alpha1.TargetSpec{{Namespace: "other"}},
+				Generate: []platformv1alpha1.GenerateSpec{{Key: "password"}},
+			},
+		}
+		err := k8sClient.Create(ctx, sr)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("generate is only supported for Secret sources"))
+	})
+})
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("GitOps-managed Targets", func() {
+	ctx := context.Background()
+
+	It("should back off from a Flux-managed target until told to take ownership", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("gitops-src-%d", suffix)
+		targetNSName := fmt.Sprintf("gitops-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source, and a target Flux already applies
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitops-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("from-source")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gitops-secret",
+				Namespace: targetNSName,
+				Labels:    map[string]string{"kustomize.toolkit.fluxcd.io/name": "apps"},
+			},
+			Data: map[string][]byte{"key": []byte("from-git")},
+		}
+		Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-gitops", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "gitops-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		srKey := types.NamespacedName{Name: "sync-gitops", Namespace: sourceNSName}
+		Eventually(func() []platformv1alpha1.TargetSyncStatus {
+			_ = k8sClient.Get(ctx, srKey, sr)
+			return sr.Status.SyncedTargets
+		}, time.Second*10, time.Millisecond*250).Should(ConsistOf(And(
+			HaveField("Synced", false),
+			HaveField("ExternallyManagedBy", "Flux"),
+			HaveField("Error", ContainSubstring("backOff")),
+		)))
+		cond := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeExternallyManaged)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("BackedOff"))
+
+		targetKey := types.NamespacedName{Name: "gitops-secret", Namespace: targetNSName}
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(HaveKeyWithValue("key", []byte("from-git")))
+
+		By("taking ownership")
+		Expect(k8sClient.Get(ctx, srKey, sr)).To(Succeed())
+		sr.Spec.ExternallyManaged = platformv1alpha1.ExternalManagementTakeOwnership
+		Expect(k8sClient.Update(ctx, sr)).To(Succeed())
+
+		Eventually(func() []byte {
+			_ = k8sClient.Get(ctx, targetKey, target)
+			return target.Data["key"]
+		}, time.Second*10, time.Millisecond*250).Should(Equal([]byte("from-source")))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationSourceCR, "sync-gitops"))
+		Expect(target.ManagedFields).To(ContainElement(And(
+			HaveField("Manager", FieldManager),
+			HaveField("Operation", metav1.ManagedFieldsOperationApply),
+		)))
+
+		Eventually(func() *metav1.Condition {
+			_ = k8sClient.Get(ctx, srKey, sr)
+			return meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeExternallyManaged)
+		}, time.Second*10, time.Millisecond*250).Should(HaveField("Reason", "OwnershipTaken"))
+		Expect(sr.Status.SyncedTargets).To(ConsistOf(And(
+			HaveField("Synced", true),
+			HaveField("ExternallyManagedBy", "Flux"),
+		)))
+	})
+})
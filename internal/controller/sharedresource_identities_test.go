@@ -0,0 +1,162 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Target Identities", func() {
+	ctx := context.Background()
+
+	It("should validate the identities file", func() {
+		identities, err := ParseTargetIdentities([]byte(`
+identities:
+- name: tenant-a
+  namespaceSelector:
+    matchLabels:
+      tenant: a
+  tokenFile: /var/run/identities/tenant-a/token
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identities).To(HaveLen(1))
+		Expect(identities[0].Name).To(Equal("tenant-a"))
+		Expect(identities[0].Selector.String()).To(Equal("tenant=a"))
+
+		for _, invalid := range []string{
+			"identities:\n- namespaceSelector: {matchLabels: {tenant: a}}\n  tokenFile: t\n",
+			"identities:\n- name: a\n  tokenFile: t\n",
+			"identities:\n- name: a\n  namespaceSelector: {matchLabels: {tenant: a}}\n",
+			"identities:\n- name: a\n  namespaceSelector: {matchLabels: {tenant: a}}\n  tokenFile: t\n  kubeconfig: k\n",
+			"identities:\n- name: a\n  namespaceSelector: {matchLabels: {tenant: a}}\n  tokenFile: t\n" +
+				"- name: a\n  namespaceSelector: {matchLabels: {tenant: b}}\n  tokenFile: t\n",
+			"identities:\n- name: a\n  selector: {}\n",
+		} {
+			_, err := ParseTargetIdentities([]byte(invalid))
+			Expect(err).To(HaveOccurred(), invalid)
+		}
+	})
+
+	It("should write targets in tenant namespaces with the tenant's identity", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("identity-src-%d", suffix)
+		tenantNSName := fmt.Sprintf("identity-tenant-%d", suffix)
+		plainNSName := fmt.Sprintf("identity-plain-%d", suffix)
+		for name, nsLabels := range map[string]map[string]string{
+			sourceNSName: nil, tenantNSName: {"tenant": "a"}, plainNSName: nil,
+		} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "identity-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The tenant's identity is a user of its own, with no permissions yet
+		user, err := testEnv.AddUser(envtest.User{Name: "sharedresource-tenant-a"}, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		kubeconfig, err := user.KubeConfig()
+		Expect(err).NotTo(HaveOccurred())
+		kubeconfigPath := filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+		Expect(os.WriteFile(kubeconfigPath, kubeconfig, 0o600)).To(Succeed())
+		identities, err := ParseTargetIdentities(fmt.Appendf(nil,
+			"identities:\n- name: tenant-a\n  namespaceSelector: {matchLabels: {tenant: a}}\n  kubeconfig: %s\n", kubeconfigPath))
+		Expect(err).NotTo(HaveOccurred())
+		identities[0].Client, err = NewIdentityClient(cfg, identities[0], client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-identity", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "identity-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: tenantNSName}, {Namespace: plainNSName}},
+				OperatorClass: "identity",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-identity", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{
+			Client:          routeTargetWrites(k8sClient, identities),
+			Scheme:          k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "identity"},
+		}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("failing the tenant target with the tenant's identity, not the operator's")
+		current := reconcile()
+		Expect(current.Status.SyncedTargets).To(ConsistOf(
+			And(HaveField("Namespace", tenantNSName), HaveField("Error", ContainSubstring("sharedresource-tenant-a"))),
+			And(HaveField("Namespace", plainNSName), HaveField("Synced", true)),
+		))
+
+		By("syncing the tenant target once the tenant may write it")
+		role := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-a-writer", Namespace: tenantNSName},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""}, Resources: []string{"secrets"},
+				Verbs: []string{"get", "create", "update", "patch", "delete"},
+			}},
+		}
+		Expect(k8sClient.Create(ctx, role)).To(Succeed())
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-a-writer", Namespace: tenantNSName},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "tenant-a-writer"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "sharedresource-tenant-a"}},
+		}
+		Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+		current.Annotations = map[string]string{AnnotationSyncNow: "1"}
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile()
+		Expect(current.Status.AllTargetsAtChecksum).To(BeTrue())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "identity-secret", Namespace: tenantNSName},
+			&corev1.Secret{})).To(Succeed())
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
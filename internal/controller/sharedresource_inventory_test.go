@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// inventoryValue gathers an inventory gauge from the metrics registry.
+// kind selects the series of a labelled gauge; pass "" for unlabelled ones.
+func inventoryValue(name, kind string) float64 {
+	families, err := metrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if kind == "" || (len(m.GetLabel()) == 1 && m.GetLabel()[0].GetValue() == kind) {
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	Fail("metric not found: " + name)
+	return 0
+}
+
+// healthValue gathers a per-SharedResource gauge, or returns -1 if the CR has no series.
+func healthValue(name, namespace, srName string) float64 {
+	families, err := metrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["source_namespace"] == namespace && labels["sharedresource"] == srName {
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	return -1
+}
+
+var _ = Describe("Inventory Metrics", func() {
+	ctx := context.Background()
+
+	It("should count SharedResources, synced targets and managed bytes", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("inventory-src-%d", suffix)
+		targetNS1Name := fmt.Sprintf("inventory-tgt1-%d", suffix)
+		targetNS2Name := fmt.Sprintf("inventory-tgt2-%d", suffix)
+
+		// Create namespaces
+		for _, name := range []string{sourceNSName, targetNS1Name, targetNS2Name} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Create source: 3+5 + 4+6 = 18 bytes per target
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory-config", Namespace: sourceNSName},
+			Data:       map[string]string{"url": "https", "port": "443443"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		resources := inventoryValue("sharedresource_inventory_sharedresources", "")
+		targets := inventoryValue("sharedresource_inventory_targets", "")
+		configMaps := inventoryValue("sharedresource_inventory_targets_by_kind", KindConfigMap)
+		secrets := inventoryValue("sharedresource_inventory_targets_by_kind", KindSecret)
+		bytes := inventoryValue("sharedresource_inventory_managed_bytes", KindConfigMap)
+
+		// Create SharedResource with two targets
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-inventory", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "inventory-config"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: targetNS1Name},
+					{Namespace: targetNS2Name},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// A CR of another operator class is left out of the inventory
+		other := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-inventory-other", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				OperatorClass: "other",
+				Source:        platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "inventory-config"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNS1Name, Name: "other-config"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, other) }()
+
+		Eventually(func(g Gomega) {
+			g.Expect(inventoryValue("sharedresource_inventory_sharedresources", "")).To(Equal(resources + 1))
+			g.Expect(inventoryValue("sharedresource_inventory_targets", "")).To(Equal(targets + 2))
+			g.Expect(inventoryValue("sharedresource_inventory_targets_by_kind", KindConfigMap)).To(Equal(configMaps + 2))
+			g.Expect(inventoryValue("sharedresource_inventory_managed_bytes", KindConfigMap)).To(Equal(bytes + 36))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(inventoryValue("sharedresource_inventory_targets_by_kind", KindSecret)).To(Equal(secrets))
+
+		// Deleting the CR removes it from the inventory
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(inventoryValue("sharedresource_inventory_sharedresources", "")).To(Equal(resources))
+			g.Expect(inventoryValue("sharedresource_inventory_managed_bytes", KindConfigMap)).To(Equal(bytes))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should report per-SharedResource readiness and failed targets", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("health-src-%d", suffix)
+		lateNSName := fmt.Sprintf("health-late-%d", suffix)
+
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "health-config", Namespace: sourceNSName},
+			Data:       map[string]string{"url": "https"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The only target namespace does not exist yet
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-health", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "health-config"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: lateNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sr) }()
+
+		Eventually(func(g Gomega) {
+			g.Expect(healthValue("sharedresource_ready", sourceNSName, "sync-health")).To(Equal(0.0))
+			g.Expect(healthValue("sharedresource_failed_targets", sourceNSName, "sync-health")).To(Equal(1.0))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		lateNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: lateNSName}}
+		Expect(k8sClient.Create(ctx, lateNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, lateNS) }()
+
+		Eventually(func(g Gomega) {
+			g.Expect(healthValue("sharedresource_ready", sourceNSName, "sync-health")).To(Equal(1.0))
+			g.Expect(healthValue("sharedresource_failed_targets", sourceNSName, "sync-health")).To(Equal(0.0))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+})
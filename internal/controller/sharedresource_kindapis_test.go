@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Optional Kind APIs", func() {
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		optional := optionalKindAPIs
+		DeferCleanup(func() { optionalKindAPIs = optional })
+	})
+
+	It("should wait for the API of a kind until it is served", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("kindapis-src-%d", suffix)
+		targetNSName := fmt.Sprintf("kindapis-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kindapis-config", Namespace: sourceNSName},
+			Data:       map[string]string{"mode": "v1"},
+		})).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-kindapis", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "kindapis-config"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "kindapis",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-kindapis", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "kindapis-config", Namespace: targetNSName}
+		clock := clocktesting.NewFakeClock(time.Now())
+		r := &SharedResourceReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "kindapis"},
+			TargetOptions:   TargetOptions{Discovery: discovery.NewDiscoveryClientForConfigOrDie(cfg)},
+			Clock:           clock,
+		}
+		reconcile := func() ctrl.Result {
+			GinkgoHelper()
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("not syncing while the kind's API is not served")
+		// Pretend ConfigMaps need an API group this cluster lacks
+		optionalKindAPIs = map[string]schema.GroupVersion{KindConfigMap: {Group: "optional.example.com", Version: "v1"}}
+		r.probeKindAPIs(ctx)
+		Expect(r.enabledKinds()).To(Equal([]string{KindSecret}))
+		Expect(reconcile().RequeueAfter).To(Equal(kindAPIRetryInterval))
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		unavailable := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeKindUnavailable)
+		Expect(unavailable).NotTo(BeNil())
+		Expect(unavailable.Status).To(Equal(metav1.ConditionTrue))
+		Expect(unavailable.Message).To(Equal("ConfigMap is not served by this cluster (optional.example.com/v1)"))
+		Expect(meta.IsStatusConditionFalse(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.ConfigMap{}))).To(BeTrue())
+
+		By("syncing once a probe finds the API")
+		optionalKindAPIs = map[string]schema.GroupVersion{KindConfigMap: corev1.SchemeGroupVersion}
+		reconcile()
+		Expect(r.enabledKinds()).To(Equal([]string{KindSecret}), "probed again only after the retry interval")
+		clock.Step(kindAPIRetryInterval + time.Second)
+		reconcile()
+		Expect(r.enabledKinds()).To(ContainElement(KindConfigMap))
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeKindUnavailable)).To(BeNil())
+		target := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(Equal(map[string]string{"mode": "v1"}))
+
+		By("keeping the finalizer of a deleted CR while the API is not served")
+		optionalKindAPIs = map[string]schema.GroupVersion{KindConfigMap: {Group: "optional.example.com", Version: "v1"}}
+		r.probeKindAPIs(ctx)
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		Expect(reconcile().RequeueAfter).To(Equal(kindAPIRetryInterval))
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(current.Finalizers).NotTo(BeEmpty())
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+
+		By("deleting the targets once the API is served again")
+		optionalKindAPIs = map[string]schema.GroupVersion{KindConfigMap: corev1.SchemeGroupVersion}
+		r.probeKindAPIs(ctx)
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, key, current))).To(BeTrue())
+	})
+})
@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Disabled Kinds", func() {
+	ctx := context.Background()
+
+	It("should reject Secret SharedResources in ConfigMap-only mode", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("kinds-src-%d", suffix)
+		targetNSName := fmt.Sprintf("kinds-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kinds-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-kinds", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "kinds-secret"},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+				OperatorClass:  "kinds",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-kinds", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "kinds-secret", Namespace: targetNSName}
+
+		By("syncing the CR before Secrets are disabled")
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "kinds"}}
+		for range 2 {
+			// The first reconcile only adds the finalizer
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(k8sClient.Get(ctx, targetKey, &corev1.Secret{})).To(Succeed())
+
+		By("rejecting the CR once Secrets are disabled")
+		r = &SharedResourceReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "kinds"},
+			TargetOptions:   TargetOptions{DisabledKinds: []string{KindSecret}},
+		}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		rejected := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeRejected)
+		Expect(rejected).NotTo(BeNil())
+		Expect(rejected.Reason).To(Equal("KindDisabled"))
+		Expect(rejected.Message).To(ContainSubstring("spec.source.kind: Forbidden: Secret syncing is disabled"))
+		Expect(meta.IsStatusConditionFalse(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+
+		By("still deleting the target of the deleted CR per its deletionPolicy")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, key, current)).NotTo(Succeed())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))).To(BeTrue())
+	})
+
+	It("should not check the permissions of a disabled kind", func() {
+		capabilities, err := CheckCapabilities(ctx, k8sClient, []string{KindConfigMap})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capabilities).To(HaveLen(len(capabilityChecks)))
+		Expect(DisabledKindErrors(&platformv1alpha1.SharedResource{Spec: platformv1alpha1.SharedResourceSpec{
+			Source:   platformv1alpha1.SourceSpec{Kind: "Secret", Name: "db"},
+			Template: &platformv1alpha1.TemplateSpec{TargetKind: "ConfigMap"},
+		}}, []string{KindConfigMap})).To(ConsistOf(HaveField("Field", "spec.template.targetKind")))
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Storage Migration", func() {
+	ctx := context.Background()
+
+	It("should backfill status fields written by older operator versions", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("migrate-src-%d", suffix)
+
+		// Create namespace
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		// Source is never created, so the reconciler leaves the target list alone
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-migrate", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "migrate-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: "a"}, {Namespace: "b"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		key := types.NamespacedName{Name: "sync-migrate", Namespace: sourceNSName}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, sr); err != nil {
+				return false
+			}
+			return sr.Status.RetryCount > 0
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// Simulate a status written before targetSummary existed
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, key, sr); err != nil {
+				return err
+			}
+			sr.Status.TargetSummary = nil
+			sr.Status.SyncedTargets = []platformv1alpha1.TargetSyncStatus{
+				{Namespace: "a", Name: "migrate-secret", Synced: true},
+				{Namespace: "b", Name: "migrate-secret", Synced: false, Error: "namespace not found"},
+			}
+			return k8sClient.Status().Update(ctx, sr)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+
+		migrator := &StorageMigrator{Client: k8sClient, Reader: k8sClient}
+		Expect(migrator.Migrate(ctx)).To(Succeed())
+
+		// Summary is derived from the stored target list
+		Expect(k8sClient.Get(ctx, key, sr)).To(Succeed())
+		Expect(sr.Status.TargetSummary).To(Equal(&platformv1alpha1.TargetSummary{Total: 2, Synced: 1, Failed: 1}))
+
+		// storedVersions only lists the current storage version
+		for _, name := range []string{"sharedresources", "sharedresourceexports", "sharedresourceimports", "sharedresourcesets"} {
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name + ".platform.platform.dev"}, crd)).To(Succeed())
+			Expect(crd.Status.StoredVersions).To(Equal([]string{"v1alpha1"}))
+		}
+
+		// Running again is a no-op
+		Expect(migrator.Migrate(ctx)).To(Succeed())
+	})
+})
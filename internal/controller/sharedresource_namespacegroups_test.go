@@ -0,0 +1,200 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Namespace Groups", func() {
+	ctx := context.Background()
+
+	It("should sync to every namespace of a target group", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("groups-src-%d", suffix)
+		groupName := fmt.Sprintf("groups-%d", suffix)
+		labelled := []string{fmt.Sprintf("groups-a-%d", suffix), fmt.Sprintf("groups-c-%d", suffix)}
+		listed := fmt.Sprintf("groups-b-%d", suffix)
+		namespaces := map[string]map[string]string{sourceNSName: nil, listed: nil}
+		for _, name := range labelled {
+			namespaces[name] = map[string]string{"team": groupName}
+		}
+		for name, nsLabels := range namespaces {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "groups-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-groups", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "groups-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Group: groupName},
+					{Namespace: labelled[0], KeyPrefix: "listed_"},
+				},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+				OperatorClass:  "groups",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-groups", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "groups"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		targetGone := func(namespace string) bool {
+			GinkgoHelper()
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "groups-secret", Namespace: namespace}, &corev1.Secret{})
+			return apierrors.IsNotFound(err)
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("not syncing while the group does not exist")
+		current := reconcile()
+		ready := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("NamespaceGroupUnavailable"))
+		Expect(ready.Message).To(ContainSubstring(groupName))
+		Expect(targetGone(listed)).To(BeTrue())
+		Expect(targetGone(labelled[0])).To(BeTrue())
+
+		By("syncing to the listed and selected namespaces once it does")
+		group := &platformv1alpha1.NamespaceGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: groupName},
+			Spec: platformv1alpha1.NamespaceGroupSpec{
+				Namespaces: []string{listed},
+				Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": groupName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, group)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, group) })
+		current = reconcile()
+		Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue())
+		Expect(current.Status.SyncedTargets).To(HaveLen(3))
+		for _, namespace := range []string{listed, labelled[1]} {
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "groups-secret", Namespace: namespace}, target)).To(Succeed())
+			Expect(target.Data).To(Equal(source.Data))
+		}
+
+		By("letting an entry naming a member namespace override the group")
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "groups-secret", Namespace: labelled[0]}, target)).To(Succeed())
+		Expect(target.Data).To(Equal(map[string][]byte{"listed_token": []byte("abc")}))
+
+		By("re-syncing when a namespace joins the group")
+		joined := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("groups-d-%d", suffix),
+			Labels: map[string]string{"team": groupName}}}
+		Expect(k8sClient.Create(ctx, joined)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, joined) })
+		Eventually(func() []ctrl.Request {
+			return r.findSharedResourcesForNamespace(ctx, joined)
+		}).Should(ContainElement(ctrl.Request{NamespacedName: key}))
+		Expect(reconcile().Status.SyncedTargets).To(HaveLen(4))
+
+		By("cleaning up the last synced members once the group is gone")
+		Expect(k8sClient.Delete(ctx, group)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: groupName}, &platformv1alpha1.NamespaceGroup{})
+			return apierrors.IsNotFound(err)
+		}).Should(BeTrue())
+		current = reconcile()
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady).Reason).To(Equal("NamespaceGroupUnavailable"))
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		for _, namespace := range []string{listed, labelled[0], labelled[1], joined.Name} {
+			Expect(targetGone(namespace)).To(BeTrue(), namespace)
+		}
+	})
+
+	It("should re-sync the CRs naming a group when its members change", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("groups-watch-src-%d", suffix)
+		groupName := fmt.Sprintf("groups-watch-%d", suffix)
+		first := fmt.Sprintf("groups-watch-a-%d", suffix)
+		second := fmt.Sprintf("groups-watch-b-%d", suffix)
+		for _, name := range []string{sourceNSName, first, second} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "groups-watch-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+		group := &platformv1alpha1.NamespaceGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: groupName},
+			Spec:       platformv1alpha1.NamespaceGroupSpec{Namespaces: []string{first}},
+		}
+		Expect(k8sClient.Create(ctx, group)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, group) })
+
+		// Synced by the manager's reconciler, which finds the CR through the group index
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-groups-watch", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "groups-watch-secret"},
+				Targets:        []platformv1alpha1.TargetSpec{{Group: groupName}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, sr) })
+		targetIn := func(namespace string) func() error {
+			return func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: "groups-watch-secret", Namespace: namespace}, &corev1.Secret{})
+			}
+		}
+		Eventually(targetIn(first), 10*time.Second).Should(Succeed())
+
+		By("syncing to a namespace added to the group")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: groupName}, group)).To(Succeed())
+		group.Spec.Namespaces = append(group.Spec.Namespaces, second)
+		Expect(k8sClient.Update(ctx, group)).To(Succeed())
+		Eventually(targetIn(second), 10*time.Second).Should(Succeed())
+	})
+})
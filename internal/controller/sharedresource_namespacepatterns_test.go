@@ -0,0 +1,162 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Namespace Patterns", func() {
+	ctx := context.Background()
+
+	It("should sync to every namespace matching a target pattern", func() {
+		suffix := time.Now().UnixNano() % 100000
+		prefix := fmt.Sprintf("patterns-%d-", suffix)
+		// The source namespace matches the pattern too, and must be skipped
+		sourceNSName := prefix + "src"
+		for _, name := range []string{sourceNSName, prefix + "a", prefix + "b"} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "patterns-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-patterns", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "patterns-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: prefix + "*"},
+					{Namespace: prefix + "b", KeyPrefix: "listed_"},
+				},
+				OperatorClass: "patterns",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-patterns", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "patterns"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		targetData := func(namespace string) map[string][]byte {
+			GinkgoHelper()
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "patterns-secret", Namespace: namespace}, target)).To(Succeed())
+			return target.Data
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("syncing to the matching namespaces, a listed entry winning")
+		current := reconcile()
+		Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue())
+		Expect(current.Status.SyncedTargets).To(HaveLen(2))
+		Expect(targetData(prefix + "a")).To(Equal(source.Data))
+		Expect(targetData(prefix + "b")).To(Equal(map[string][]byte{"listed_token": []byte("abc")}))
+
+		By("re-syncing when a matching namespace is created")
+		created := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: prefix + "c"}}
+		Expect(k8sClient.Create(ctx, created)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, created) })
+		Expect(r.findSharedResourcesForNamespace(ctx, created)).To(ContainElement(ctrl.Request{NamespacedName: key}))
+		current = reconcile()
+		Expect(current.Status.SyncedTargets).To(HaveLen(3))
+		Expect(targetData(created.Name)).To(Equal(source.Data))
+
+		By("not syncing while the pattern is malformed")
+		current.Spec.Targets[0].Namespace = prefix + "["
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile()
+		ready := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("InvalidNamespacePattern"))
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should sync into a matching namespace as soon as it is created", func() {
+		suffix := time.Now().UnixNano() % 100000
+		prefix := fmt.Sprintf("patterns-new-%d-", suffix)
+		environment := fmt.Sprintf("new-%d", suffix)
+		sourceNSName := fmt.Sprintf("patterns-new-src-%d", suffix)
+		source := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, source) })
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "patterns-new-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		})).To(Succeed())
+
+		// Synced by the manager's reconciler, far from its periodic resync
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-patterns-new", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "patterns-new-secret"},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: prefix + "*"}},
+				TargetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": environment}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, sr) })
+		key := types.NamespacedName{Name: "sync-patterns-new", Namespace: sourceNSName}
+		Eventually(func(g Gomega) {
+			current := &platformv1alpha1.SharedResource{}
+			g.Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			g.Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue())
+		}, 10*time.Second).Should(Succeed())
+
+		By("creating a namespace matching the pattern and one matching the selector")
+		for _, ns := range []*corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: prefix + "a"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("patterns-labelled-%d", suffix),
+				Labels: map[string]string{"environment": environment}}},
+		} {
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: "patterns-new-secret", Namespace: ns.Name}, &corev1.Secret{})
+			}, 10*time.Second).Should(Succeed(), ns.Name)
+		}
+	})
+})
@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Operator Classes", func() {
+	ctx := context.Background()
+
+	It("should select CRs by class and labels", func() {
+		Expect(ValidateOperatorClass("")).To(Succeed())
+		Expect(ValidateOperatorClass("tenant-a")).To(Succeed())
+		Expect(ValidateOperatorClass("Tenant_A")).NotTo(Succeed())
+		Expect(ValidateOperatorClass(strings.Repeat("a", MaxOperatorClassLength+1))).NotTo(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{}
+		Expect((&SharedResourceReconciler{}).managesCR(sr)).To(BeTrue())
+		Expect((&SharedResourceReconciler{}).finalizer()).To(Equal(FinalizerName))
+
+		r := &SharedResourceReconciler{InstanceOptions: InstanceOptions{OperatorClass: "tenant-a"}}
+		Expect(r.managesCR(sr)).To(BeFalse())
+		Expect(r.finalizer()).To(Equal(FinalizerName + "-tenant-a"))
+		Expect(r.sweepReportName()).To(Equal(SweepReportName + "-tenant-a"))
+		sr.Spec.OperatorClass = "tenant-a"
+		Expect(r.managesCR(sr)).To(BeTrue())
+
+		By("limiting the class to CRs matching the selector")
+		r.SharedResourceSelector = labels.SelectorFromSet(labels.Set{"team": "payments"})
+		Expect(r.managesCR(sr)).To(BeFalse())
+		sr.Labels = map[string]string{"team": "payments"}
+		Expect(r.managesCR(sr)).To(BeTrue())
+
+		r.Finalizer = "example.com/sharedresource"
+		Expect(r.finalizer()).To(Equal("example.com/sharedresource"))
+	})
+
+	It("should leave CRs of another class alone and hand them over on a class change", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("class-src-%d", suffix)
+		targetNSName := fmt.Sprintf("class-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "class-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-class", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "class-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "tenant-a",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-class", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "class-secret", Namespace: targetNSName}
+
+		By("being ignored by the default instance")
+		current := &platformv1alpha1.SharedResource{}
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			g.Expect(current.Finalizers).To(BeEmpty())
+		}, time.Second*2, time.Millisecond*250).Should(Succeed())
+
+		By("being synced by the instance of its class")
+		// A reconciler of our own, standing in for a second instance
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "tenant-a"}}
+		target := &corev1.Secret{}
+		Eventually(func(g Gomega) {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(current.Finalizers).To(ConsistOf(FinalizerName + "-tenant-a"))
+
+		By("handing it over when the class is removed")
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, key, current); err != nil {
+				return err
+			}
+			current.Spec.OperatorClass = ""
+			return k8sClient.Update(ctx, current)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			g.Expect(current.Finalizers).To(ConsistOf(FinalizerName))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		Eventually(func() bool {
+			return k8sClient.Get(ctx, key, current) != nil
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+	})
+})
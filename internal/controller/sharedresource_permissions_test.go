@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Reduced RBAC", func() {
+	ctx := context.Background()
+
+	It("should orphan targets without delete permission", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("nodelete-src-%d", suffix)
+		targetNSName := fmt.Sprintf("nodelete-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodelete-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-nodelete", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "nodelete-secret"},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+				OperatorClass:  "nodelete",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-nodelete", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "nodelete-secret", Namespace: targetNSName}
+		r := &SharedResourceReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "nodelete"},
+			TargetOptions:   TargetOptions{DeletesDisabled: true},
+		}
+
+		By("syncing the target as orphaned and reporting the ignored policy")
+		for range 2 {
+			// The first reconcile only adds the finalizer
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationDeletionPolicy, string(platformv1alpha1.DeletionPolicyOrphan)))
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		disabled := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeDeletesDisabled)
+		Expect(disabled).NotTo(BeNil())
+		Expect(disabled.Message).To(ContainSubstring("deletionPolicy delete not applied"))
+
+		By("leaving the target in place when the CR is deleted")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, key, current)).NotTo(Succeed())
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+	})
+})
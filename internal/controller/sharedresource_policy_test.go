@@ -0,0 +1,131 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Source Owner Policy", func() {
+	ctx := context.Background()
+
+	It("should withhold denied targets and keys and report PolicyDenied", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("policy-src-%d", suffix)
+		allowedNSName := fmt.Sprintf("policy-allowed-%d", suffix)
+		teamNSName := fmt.Sprintf("policy-team-%d", suffix)
+		deniedNSName := fmt.Sprintf("policy-denied-%d", suffix)
+
+		// Create namespaces; the team namespace is allowed by label only
+		for _, ns := range []*corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}},
+			{ObjectMeta: metav1.ObjectMeta{Name: allowedNSName}},
+			{ObjectMeta: metav1.ObjectMeta{Name: teamNSName, Labels: map[string]string{"team": "payments"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: deniedNSName}},
+		} {
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Source owner's policy
+		policy := &platformv1alpha1.SharedResourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner-policy", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourcePolicySpec{
+				Sources:                 []platformv1alpha1.PolicySource{{Kind: "Secret", Name: "policy-secret"}},
+				AllowedTargetNamespaces: []string{"policy-allowed-*"},
+				TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				AllowedKeys:             []string{"username", "password"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy-secret", Namespace: sourceNSName},
+			Data: map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte("secret"),
+				"root-key": []byte("private"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// CR asks for everything, everywhere
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-policy", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "policy-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: allowedNSName},
+					{Namespace: teamNSName},
+					{Namespace: deniedNSName},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Violations are reported on the CR
+		key := types.NamespacedName{Name: "sync-policy", Namespace: sourceNSName}
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return false
+			}
+			return conditionIsTrue(freshSR, ConditionTypePolicyDenied)
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		cond := meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypePolicyDenied)
+		Expect(cond.Message).To(ContainSubstring(deniedNSName))
+		Expect(cond.Message).To(ContainSubstring("root-key"))
+		Expect(freshSR.Status.TargetSummary.Failed).To(Equal(int32(1)))
+
+		// Allowed targets receive only allowed keys
+		for _, ns := range []string{allowedNSName, teamNSName} {
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "policy-secret", Namespace: ns}, target)).To(Succeed())
+			Expect(target.Data).To(HaveLen(2))
+			Expect(target.Data).NotTo(HaveKey("root-key"))
+		}
+
+		// Denied target is never written
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: "policy-secret", Namespace: deniedNSName}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		// Deleting the policy lifts the restrictions
+		Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "policy-secret", Namespace: deniedNSName}, &corev1.Secret{})
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Eventually(func() *metav1.Condition {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return nil
+			}
+			return meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypePolicyDenied)
+		}, time.Second*10, time.Millisecond*250).Should(BeNil())
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// countingReader counts Gets, standing in for the manager's API reader.
+type countingReader struct {
+	client.Reader
+	gets atomic.Int32
+}
+
+func (c *countingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.gets.Add(1)
+	return c.Reader.Get(ctx, key, obj, opts...)
+}
+
+var _ = Describe("Source Polling", func() {
+	ctx := context.Background()
+
+	It("should resolve the poll interval from spec and flag", func() {
+		r := &SharedResourceReconciler{SourceOptions: SourceOptions{SourcePollInterval: time.Minute}}
+		sr := &platformv1alpha1.SharedResource{}
+		Expect(r.sourcePollInterval(sr)).To(Equal(time.Minute))
+
+		// Spec wins, short intervals are raised, and 0s turns polling off
+		sr.Spec.SourcePollInterval = &metav1.Duration{Duration: 10 * time.Second}
+		Expect(r.sourcePollInterval(sr)).To(Equal(10 * time.Second))
+		sr.Spec.SourcePollInterval = &metav1.Duration{Duration: time.Second}
+		Expect(r.sourcePollInterval(sr)).To(Equal(MinSourcePollInterval))
+		sr.Spec.SourcePollInterval = &metav1.Duration{}
+		Expect(r.sourcePollInterval(sr)).To(BeZero())
+		Expect((&SharedResourceReconciler{}).sourcePollInterval(&platformv1alpha1.SharedResource{})).To(BeZero())
+	})
+
+	It("should read polled sources from the API server and requeue at the poll interval", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("poll-src-%d", suffix)
+		targetNSName := fmt.Sprintf("poll-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "poll-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-poll", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:             platformv1alpha1.SourceSpec{Kind: "Secret", Name: "poll-secret"},
+				Targets:            []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				SourcePollInterval: &metav1.Duration{Duration: 10 * time.Second},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait for the manager's reconciler to sync the target
+		srKey := types.NamespacedName{Name: "sync-poll", Namespace: sourceNSName}
+		Eventually(func(g Gomega) {
+			var current platformv1alpha1.SharedResource
+			g.Expect(k8sClient.Get(ctx, srKey, &current)).To(Succeed())
+			g.Expect(current.Status.ObservedGeneration).To(Equal(current.Generation))
+			g.Expect(current.Status.AllTargetsAtChecksum).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// A reconciler of our own, so its verification state is not touched by watch events
+		reader := &countingReader{Reader: k8sClient}
+		reconciler := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			SourceOptions: SourceOptions{APIReader: reader}}
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: srKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(10 * time.Second))
+		Expect(reader.gets.Load()).To(BeNumerically(">=", 1))
+
+		// Once verified, a reconcile nothing else asked for is a poll
+		var current platformv1alpha1.SharedResource
+		Expect(k8sClient.Get(ctx, srKey, &current)).To(Succeed())
+		Expect(reconciler.syncTrigger(&current)).To(Equal(SyncTriggerPoll))
+		reconciler.verified.invalidate(srKey)
+		Expect(reconciler.syncTrigger(&current)).To(Equal(SyncTriggerEvent))
+
+		// Without polling the source comes from the client, as before
+		current.Spec.SourcePollInterval = &metav1.Duration{}
+		Expect(reconciler.sourceReader(&current)).To(BeIdenticalTo(reconciler.Client))
+	})
+})
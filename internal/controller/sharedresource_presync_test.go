@@ -0,0 +1,299 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Pre-sync Hooks", func() {
+	ctx := context.Background()
+
+	// setup creates a source namespace with a ConfigMap, a target namespace and
+	// a SharedResource with the given hook.
+	setup := func(prefix string, preSync *platformv1alpha1.PreSyncSpec) (*corev1.ConfigMap, types.NamespacedName, types.NamespacedName) {
+		GinkgoHelper()
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("%s-src-%d", prefix, suffix)
+		targetNSName := fmt.Sprintf("%s-tgt-%d", prefix, suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "presync-config", Namespace: sourceNSName},
+			Data:       map[string]string{"host": "db-1"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-presync", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "presync-config"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				PreSync:       preSync,
+				OperatorClass: "presync",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		return source, types.NamespacedName{Name: "sync-presync", Namespace: sourceNSName},
+			types.NamespacedName{Name: "presync-config", Namespace: targetNSName}
+	}
+
+	It("should propagate a source change only after the HTTP hook accepts it", func() {
+		var mu sync.Mutex
+		status := http.StatusServiceUnavailable
+		var requests []preSyncRequest
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			var body preSyncRequest
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			requests = append(requests, body)
+			authorization = req.Header.Get("Authorization")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("change freeze"))
+		}))
+		DeferCleanup(server.Close)
+		calls := func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(requests)
+		}
+		lastRequest := func() preSyncRequest {
+			mu.Lock()
+			defer mu.Unlock()
+			return requests[len(requests)-1]
+		}
+
+		source, key, targetKey := setup("presync-http", &platformv1alpha1.PreSyncSpec{
+			HTTP: &platformv1alpha1.PreSyncHTTP{URL: server.URL,
+				BearerTokenSecret: &platformv1alpha1.SecretKeyRef{Name: "presync-token", Key: "token"}},
+		})
+		token := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "presync-token", Namespace: key.Namespace},
+			Data:       map[string][]byte{"token": []byte("s3cret\n")},
+		}
+		Expect(k8sClient.Create(ctx, token)).To(Succeed())
+		r := &SharedResourceReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "presync"},
+			HookOptions: HookOptions{
+				HTTPClient:         server.Client(),
+				PreSyncAllowedURLs: []string{server.URL},
+			},
+		}
+		reconcile := func() (ctrl.Result, *platformv1alpha1.SharedResource) {
+			GinkgoHelper()
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return result, current
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("blocking the first sync while the hook fails")
+		result, current := reconcile()
+		Expect(calls()).To(Equal(1))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", PreSyncRetryInterval))
+		Expect(k8sClient.Get(ctx, targetKey, &corev1.ConfigMap{})).NotTo(Succeed())
+		Expect(current.Status.PreSync.Phase).To(Equal(platformv1alpha1.HookFailed))
+		// Only the status code is reported, never the answer
+		Expect(current.Status.PreSync.Message).To(HaveSuffix("answered 503"))
+		preSync := meta.FindStatusCondition(current.Status.Conditions, ConditionTypePreSync)
+		Expect(preSync.Reason).To(Equal("PreSyncFailed"))
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady).Reason).To(Equal("PreSyncFailed"))
+
+		By("waiting out the retry interval")
+		reconcile()
+		Expect(calls()).To(Equal(1))
+
+		By("syncing once the hook succeeds, retried on sync-now")
+		mu.Lock()
+		status = http.StatusOK
+		mu.Unlock()
+		current.Annotations = map[string]string{AnnotationSyncNow: "1"}
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		_, current = reconcile()
+		Expect(calls()).To(Equal(2))
+		first := lastRequest()
+		Expect(first.SharedResource).To(Equal(preSyncObject{Namespace: key.Namespace, Name: key.Name}))
+		Expect(first.Source).To(Equal(preSyncSource{Kind: "ConfigMap", Namespace: key.Namespace, Name: "presync-config"}))
+		Expect(first.PreviousChecksum).To(BeEmpty())
+		Expect(first.TargetNamespaces).To(ConsistOf(targetKey.Namespace))
+		Expect(authorization).To(Equal("Bearer s3cret"))
+		Expect(k8sClient.Get(ctx, targetKey, &corev1.ConfigMap{})).To(Succeed())
+		Expect(current.Status.SourceChecksum).To(Equal(first.Checksum))
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypePreSync)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+
+		By("not calling the hook again for unchanged data")
+		reconcile()
+		Expect(calls()).To(Equal(2))
+
+		By("calling the hook for the next change")
+		source.Data = map[string]string{"host": "db-2"}
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		_, current = reconcile()
+		Expect(calls()).To(Equal(3))
+		Expect(lastRequest().PreviousChecksum).To(Equal(first.Checksum))
+		target := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(HaveKeyWithValue("host", "db-2"))
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should propagate a source change only after the pre-sync Job completes", func() {
+		_, key, targetKey := setup("presync-job", &platformv1alpha1.PreSyncSpec{
+			Job: &runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{` +
+				`"containers":[{"name":"lock","image":"busybox","command":["true"]}]}}}}`)},
+		})
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "presync"}}
+		reconcile := func() (ctrl.Result, *platformv1alpha1.SharedResource) {
+			GinkgoHelper()
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return result, current
+		}
+		getJob := func(name string) *batchv1.Job {
+			GinkgoHelper()
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: key.Namespace, Name: name}, job)).To(Succeed())
+			return job
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("starting the Job in the SharedResource's namespace")
+		result, current := reconcile()
+		Expect(result.RequeueAfter).To(BeNumerically("<=", HookCheckInterval))
+		Expect(current.Status.PreSync.Phase).To(Equal(platformv1alpha1.HookRunning))
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypePreSync).Reason).To(Equal("PreSyncRunning"))
+		Expect(k8sClient.Get(ctx, targetKey, &corev1.ConfigMap{})).NotTo(Succeed())
+		job := getJob(current.Status.PreSync.Job)
+		Expect(metav1.IsControlledBy(job, current)).To(BeTrue())
+		Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal(hookServiceAccount))
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: "SHAREDRESOURCE_NAME", Value: key.Name},
+			corev1.EnvVar{Name: "SHAREDRESOURCE_CHECKSUM", Value: current.Status.PreSync.Checksum},
+		))
+
+		By("blocking the sync when the Job fails")
+		finishJob(ctx, job, false)
+		_, current = reconcile()
+		Expect(current.Status.PreSync.Phase).To(Equal(platformv1alpha1.HookFailed))
+		Expect(current.Status.PreSync.Message).To(ContainSubstring("login refused"))
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady).Reason).To(Equal("PreSyncFailed"))
+		Expect(k8sClient.Get(ctx, targetKey, &corev1.ConfigMap{})).NotTo(Succeed())
+
+		By("rerunning a deleted Job and syncing once it completes")
+		Expect(k8sClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))).To(Succeed())
+		_, current = reconcile()
+		Expect(current.Status.PreSync.Phase).To(Equal(platformv1alpha1.HookRunning))
+		finishJob(ctx, getJob(current.Status.PreSync.Job), true)
+		_, current = reconcile()
+		Expect(current.Status.PreSync.Phase).To(Equal(platformv1alpha1.HookSucceeded))
+		Expect(k8sClient.Get(ctx, targetKey, &corev1.ConfigMap{})).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should only call allowed URLs, without following redirects", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			calls.Add(1)
+			http.Redirect(w, req, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		}))
+		DeferCleanup(server.Close)
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "presync", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "config"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: "backend"}},
+				PreSync: &platformv1alpha1.PreSyncSpec{HTTP: &platformv1alpha1.PreSyncHTTP{URL: server.URL + "/hook"}},
+			},
+		}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			HookOptions: HookOptions{HTTPClient: server.Client()}}
+
+		By("refusing a URL the operator does not allow")
+		err := r.callPreSyncHTTP(ctx, sr, "abc")
+		Expect(err).To(MatchError(ContainSubstring("is not an allowed pre-sync URL")))
+		Expect(calls.Load()).To(BeZero())
+		r.PreSyncAllowedURLs = []string{"https://change.example.com"}
+		Expect(r.callPreSyncHTTP(ctx, sr, "abc")).NotTo(Succeed())
+		Expect(calls.Load()).To(BeZero())
+
+		By("failing on a redirect instead of following it")
+		r.PreSyncAllowedURLs = append(r.PreSyncAllowedURLs, server.URL)
+		Expect(r.callPreSyncHTTP(ctx, sr, "abc")).To(MatchError(HaveSuffix("answered 302")))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("should reject pre-sync pods reaching beyond the source", func() {
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "presync", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "db-credentials"},
+				PreSync: &platformv1alpha1.PreSyncSpec{Job: &runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{` +
+					`"volumes":[{"name":"source","secret":{"secretName":"db-credentials"}},{"name":"host","hostPath":{"path":"/"}}],` +
+					`"containers":[{"name":"lock","image":"busybox"}]}}}}`)}},
+			},
+		}
+		errs := PreSyncErrors(sr)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Detail).To(Equal("invalid preSync.job: spec.template.spec.volumes[host]: " +
+			"only emptyDir, downwardAPI, Secret, ConfigMap and projected volumes are allowed"))
+	})
+})
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Target Release", func() {
+	ctx := context.Background()
+
+	It("should hand a released target over and stop managing it", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("release-src-%d", suffix)
+		targetNSName := fmt.Sprintf("release-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "release-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource tracking target deletion, so the target carries our finalizer
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-release", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:              platformv1alpha1.SourceSpec{Kind: "Secret", Name: "release-secret"},
+				Targets:             []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				TrackTargetDeletion: true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait for target
+		targetKey := types.NamespacedName{Name: "release-secret", Namespace: targetNSName}
+		target := &corev1.Secret{}
+		Eventually(func() []string {
+			if err := k8sClient.Get(ctx, targetKey, target); err != nil {
+				return nil
+			}
+			return target.Finalizers
+		}, time.Second*10, time.Millisecond*250).Should(ContainElement(TargetFinalizerName))
+
+		By("annotating the target for release")
+		target.Annotations[AnnotationRelease] = "true"
+		Expect(k8sClient.Update(ctx, target)).To(Succeed())
+
+		Eventually(func() map[string]string {
+			_ = k8sClient.Get(ctx, targetKey, target)
+			return target.Annotations
+		}, time.Second*10, time.Millisecond*250).Should(HaveKeyWithValue(AnnotationReleasedFrom, sourceNSName+"/sync-release"))
+		Expect(target.Annotations).To(HaveKey(AnnotationReleasedAt))
+		for _, key := range []string{AnnotationManagedBy, AnnotationSourceCR, AnnotationChecksum, AnnotationRelease, testExemptAnnotation} {
+			Expect(target.Annotations).NotTo(HaveKey(key))
+		}
+		Expect(target.Labels).NotTo(HaveKey(LabelManagedBy))
+		Expect(target.Finalizers).NotTo(ContainElement(TargetFinalizerName))
+		Expect(target.Data).To(HaveKeyWithValue("key", []byte("value")))
+
+		Eventually(func() []platformv1alpha1.TargetSyncStatus {
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: "sync-release", Namespace: sourceNSName}, sr)
+			return sr.Status.SyncedTargets
+		}, time.Second*10, time.Millisecond*250).Should(ConsistOf(And(
+			HaveField("Released", true),
+			HaveField("Synced", true),
+		)))
+
+		By("changing the source; the released copy keeps its data")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "release-secret", Namespace: sourceNSName}, source)).To(Succeed())
+		source.Data["key"] = []byte("rotated")
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Consistently(func() []byte {
+			_ = k8sClient.Get(ctx, targetKey, target)
+			return target.Data["key"]
+		}, time.Second*2, time.Millisecond*250).Should(Equal([]byte("value")))
+
+		By("deleting the SharedResource; the released copy stays")
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-release", Namespace: sourceNSName}, sr)
+			return err != nil
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+	})
+})
@@ -24,8 +24,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
 )
@@ -206,4 +208,243 @@ var _ = Describe("Selective Key Filtering", func() {
 		Expect(target.Data).NotTo(HaveKey("key-c")) // excluded
 		Expect(target.Data).NotTo(HaveKey("key-d")) // not included
 	})
+
+	It("should only share keys the source owner allows via annotations", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("owner-src-%d", suffix)
+		targetNSName := fmt.Sprintf("owner-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func(name string) {
+			_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}(sourceNSName)
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func(name string) {
+			_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}(targetNSName)
+
+		// Source owner allows username/password but never the admin token
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "owner-secret",
+				Namespace: sourceNSName,
+				Annotations: map[string]string{
+					AnnotationShareKeys:   "username, password,admin-token",
+					AnnotationExcludeKeys: "admin-token",
+				},
+			},
+			Data: map[string][]byte{
+				"username":    []byte("admin"),
+				"password":    []byte("secret"),
+				"admin-token": []byte("root"),
+				"internal":    []byte("private"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// CR asks for everything in copy mode
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-owner", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "owner-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Only keys the owner allows are shared
+		targetKey := types.NamespacedName{Name: "owner-secret", Namespace: targetNSName}
+		target := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, targetKey, target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(target.Data).To(HaveLen(2))
+		Expect(target.Data).To(HaveKey("username"))
+		Expect(target.Data).To(HaveKey("password"))
+
+		// Tightening the annotation removes the key from targets
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "owner-secret", Namespace: sourceNSName}, source); err != nil {
+				return err
+			}
+			source.Annotations[AnnotationShareKeys] = "username"
+			return k8sClient.Update(ctx, source)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+
+		Eventually(func() []string {
+			if err := k8sClient.Get(ctx, targetKey, target); err != nil {
+				return nil
+			}
+			keys := make([]string, 0, len(target.Data))
+			for k := range target.Data {
+				keys = append(keys, k)
+			}
+			return keys
+		}, time.Second*10, time.Millisecond*250).Should(ConsistOf("username"))
+	})
+
+	It("should withhold owner-excluded keys even when the CR includes them", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("withheld-src-%d", suffix)
+		targetNSName := fmt.Sprintf("withheld-tgt-%d", suffix)
+
+		// Create namespaces
+		for _, name := range []string{sourceNSName, targetNSName} {
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})).To(Succeed())
+			defer func(name string) {
+				_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}(name)
+		}
+
+		// Source owner never exports the signing key
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "withheld-secret",
+				Namespace:   sourceNSName,
+				Annotations: map[string]string{AnnotationExcludeKeys: "signing-key"},
+			},
+			Data: map[string][]byte{
+				"client-id":   []byte("app"),
+				"signing-key": []byte("private"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// CR explicitly asks for the signing key
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-withheld", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "withheld-secret"},
+				SyncPolicy: &platformv1alpha1.SyncPolicySpec{
+					Mode: platformv1alpha1.SyncModeSelective,
+					Keys: &platformv1alpha1.KeySelector{Include: []string{"client-id", "signing-key"}},
+				},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		target := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "withheld-secret", Namespace: targetNSName}, target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(target.Data).To(HaveKey("client-id"))
+		Expect(target.Data).NotTo(HaveKey("signing-key"))
+
+		// The CR author is told why the key is missing
+		Eventually(func(g Gomega) {
+			var events corev1.EventList
+			g.Expect(k8sClient.List(ctx, &events, client.InNamespace(sourceNSName))).To(Succeed())
+			messages := []string{}
+			for _, e := range events.Items {
+				if e.InvolvedObject.Name == sr.Name && e.Reason == "KeysWithheldBySource" {
+					messages = append(messages, e.Message)
+				}
+			}
+			g.Expect(messages).To(ContainElement(ContainSubstring("signing-key")))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should narrow keys per target using namespace labels", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("classify-src-%d", suffix)
+		prodNSName := fmt.Sprintf("classify-prod-%d", suffix)
+		devNSName := fmt.Sprintf("classify-dev-%d", suffix)
+
+		// Create namespaces, classified by environment label
+		for _, ns := range []*corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}},
+			{ObjectMeta: metav1.ObjectMeta{Name: prodNSName, Labels: map[string]string{"environment": "prod"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: devNSName, Labels: map[string]string{"environment": "dev"}}},
+		} {
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func(name string) {
+				_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}(ns.Name)
+		}
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "classify-secret", Namespace: sourceNSName},
+			Data: map[string][]byte{
+				"username":       []byte("admin"),
+				"password":       []byte("secret"),
+				"admin-password": []byte("root"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Dev namespaces get a redacted subset
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-classify", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "classify-secret"},
+				SyncPolicy: &platformv1alpha1.SyncPolicySpec{
+					NamespaceRules: []platformv1alpha1.NamespaceKeyRule{{
+						NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"environment": "dev"}},
+						Keys:              platformv1alpha1.KeySelector{Exclude: []string{"admin-password"}},
+					}},
+				},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: prodNSName}, {Namespace: devNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Prod gets every key, dev does not get the admin password
+		prodTarget := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "classify-secret", Namespace: prodNSName}, prodTarget)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(prodTarget.Data).To(HaveLen(3))
+
+		devKey := types.NamespacedName{Name: "classify-secret", Namespace: devNSName}
+		devTarget := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, devKey, devTarget)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(devTarget.Data).To(HaveLen(2))
+		Expect(devTarget.Data).NotTo(HaveKey("admin-password"))
+
+		// Status tells which namespaces got which variant
+		srKey := types.NamespacedName{Name: "sync-classify", Namespace: sourceNSName}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, srKey, sr)).To(Succeed())
+			g.Expect(sr.Status.Variants).To(ConsistOf(
+				And(HaveField("Keys", []string{"admin-password", "password", "username"}),
+					HaveField("Targets", int32(1)), HaveField("Namespaces", []string{prodNSName})),
+				And(HaveField("Keys", []string{"password", "username"}),
+					HaveField("Targets", int32(1)), HaveField("Namespaces", []string{devNSName})),
+			))
+			g.Expect(conditionIsTrue(sr, ConditionTypeDataVaries)).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// Relabelling the namespace as prod re-syncs it with every key
+		devNS := &corev1.Namespace{}
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: devNSName}, devNS); err != nil {
+				return err
+			}
+			devNS.Labels["environment"] = "prod"
+			return k8sClient.Update(ctx, devNS)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, devKey, devTarget); err != nil {
+				return false
+			}
+			_, ok := devTarget.Data["admin-password"]
+			return ok
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// With one variant left, the summary and condition are cleared
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, srKey, sr)).To(Succeed())
+			g.Expect(sr.Status.Variants).To(BeEmpty())
+			g.Expect(meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeDataVaries)).To(BeNil())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
 })
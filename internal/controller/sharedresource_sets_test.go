@@ -0,0 +1,142 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("SharedResourceSet", func() {
+	ctx := context.Background()
+
+	It("should keep a SharedResource per source and aggregate their status", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("sets-src-%d", suffix)
+		targetNSName := fmt.Sprintf("sets-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "endpoints", Namespace: sourceNSName},
+			Data:       map[string]string{"db": "db.internal"},
+		})).To(Succeed())
+
+		set := &platformv1alpha1.SharedResourceSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSetSpec{
+				Sources: []platformv1alpha1.SetSource{
+					{Kind: "Secret", Name: "db-credentials"},
+					{Kind: "ConfigMap", Name: "endpoints"},
+				},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			},
+		}
+		Expect(k8sClient.Create(ctx, set)).To(Succeed())
+		key := types.NamespacedName{Name: "platform", Namespace: sourceNSName}
+		r := &SharedResourceSetReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		reconcile := func(g Gomega) *platformv1alpha1.SharedResourceSet {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			g.Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResourceSet{}
+			g.Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		// Without garbage collection in envtest the members are deleted by hand
+		DeferCleanup(func() {
+			for _, name := range []string{"platform-db-credentials", "platform-endpoints", "platform-tls"} {
+				_ = k8sClient.Delete(ctx, &platformv1alpha1.SharedResource{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: sourceNSName},
+				})
+			}
+			_ = k8sClient.Delete(ctx, set)
+		})
+
+		By("creating a SharedResource per source with the Set's targets")
+		current := reconcile(Default)
+		Expect(current.Status.Members).To(HaveLen(2))
+		sr := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "platform-endpoints", Namespace: sourceNSName}, sr)).To(Succeed())
+		Expect(metav1.IsControlledBy(sr, current)).To(BeTrue())
+		Expect(sr.Spec.Source).To(Equal(platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "endpoints"}))
+		Expect(sr.Spec.Targets).To(Equal(set.Spec.Targets))
+		Expect(sr.Spec.DeletionPolicy).To(Equal(platformv1alpha1.DeletionPolicyDelete))
+
+		By("reporting Ready once every member is")
+		// The manager's reconciler syncs the members
+		Eventually(func(g Gomega) {
+			current := reconcile(g)
+			g.Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+			g.Expect(current.Status.ReadyMembers).To(Equal(int32(2)))
+		}, 10*time.Second).Should(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "endpoints", Namespace: targetNSName}, &corev1.ConfigMap{})).To(Succeed())
+
+		By("leaving a SharedResource the Set does not own alone")
+		// The class keeps the manager's reconciler away from the CR
+		Expect(k8sClient.Create(ctx, &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-tls", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "other"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "sets",
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		current.Spec.Sources = []platformv1alpha1.SetSource{
+			{Kind: "Secret", Name: "db-credentials"},
+			{Kind: "Secret", Name: "tls"},
+		}
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile(Default)
+		Expect(current.Status.Members[1].Reason).To(Equal("Conflict"))
+		ready := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("MembersNotReady"))
+		Expect(ready.Message).To(Equal("1 of 2 SharedResources are Ready; not ready: platform-tls (Conflict)"))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "platform-tls", Namespace: sourceNSName}, sr)).To(Succeed())
+		Expect(sr.Spec.Source.Name).To(Equal("other"))
+
+		By("deleting the SharedResource of a removed source, and with it the copies")
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Name: "endpoints", Namespace: targetNSName},
+				&corev1.ConfigMap{}))
+		}, 10*time.Second).Should(BeTrue())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Name: "platform-endpoints", Namespace: sourceNSName},
+				&platformv1alpha1.SharedResource{}))
+		}, 10*time.Second).Should(BeTrue())
+	})
+})
@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Source Name Templates", func() {
+	ctx := context.Background()
+
+	It("should sync the source named by the template", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("nametemplate-src-%d", suffix)
+		targetNSName := fmt.Sprintf("nametemplate-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments-config", Namespace: sourceNSName},
+			Data:       map[string]string{"level": "info"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		By("rejecting a source with both name and nameTemplate")
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "payments-config",
+					NameTemplate: "{{ .Name }}-config"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "nametemplate",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(MatchError(ContainSubstring("exactly one of name and nameTemplate")))
+
+		// The class keeps the manager's reconciler away from the CR
+		sr.Spec.Source.Name = ""
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "payments", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "nametemplate"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("writing the target under the rendered name")
+		current := reconcile()
+		Expect(current.Status.AllTargetsAtChecksum).To(BeTrue())
+		target := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "payments-config", Namespace: targetNSName}, target)).To(Succeed())
+		Expect(target.Data).To(HaveKeyWithValue("level", "info"))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationSourceName, "payments-config"))
+
+		By("reporting a template that renders no valid name")
+		current.Spec.Source.NameTemplate = "{{ .Name }}_Config"
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		current = reconcile()
+		found := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeSourceFound)
+		Expect(found).NotTo(BeNil())
+		Expect(found.Status).To(Equal(metav1.ConditionFalse))
+		Expect(found.Reason).To(Equal("InvalidNameTemplate"))
+		Expect(meta.IsStatusConditionFalse(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+
+		By("cleaning up")
+		current.Spec.Source.NameTemplate = "{{ .Name }}-config"
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Sources in Other Namespaces", func() {
+	ctx := context.Background()
+
+	It("should only read another namespace's source while it consents", func() {
+		suffix := time.Now().UnixNano() % 100000
+		ownerNSName := fmt.Sprintf("sourcens-owner-%d", suffix)
+		consumerNSName := fmt.Sprintf("sourcens-consumer-%d", suffix)
+		targetNSName := fmt.Sprintf("sourcens-target-%d", suffix)
+		owner := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ownerNSName}}
+		for _, ns := range []*corev1.Namespace{owner, {ObjectMeta: metav1.ObjectMeta{Name: consumerNSName}},
+			{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}} {
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sourcens-secret", Namespace: ownerNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-sourcens", Namespace: consumerNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "sourcens-secret", Namespace: ownerNSName},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "sourcens",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-sourcens", Namespace: consumerNSName}
+		targetKey := types.NamespacedName{Name: "sourcens-secret", Namespace: targetNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "sourcens"}}
+		reconcile := func() *platformv1alpha1.SharedResource {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("not syncing without consent")
+		current := reconcile()
+		denied := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeSourceExportDenied)
+		Expect(denied).NotTo(BeNil())
+		Expect(denied.Status).To(Equal(metav1.ConditionTrue))
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady).Reason).To(Equal("SourceExportDenied"))
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, targetKey, &corev1.Secret{}))).To(BeTrue())
+
+		By("syncing once the source allows the export")
+		source.Annotations = map[string]string{AnnotationAllowExport: "true"}
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		current = reconcile()
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeSourceExportDenied)).To(BeNil())
+		Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue())
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(Equal(source.Data))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationSourceNamespace, consumerNSName))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationOrigin, ownerNSName+"/sourcens-secret"))
+		Expect(target.Annotations[AnnotationProvenance]).To(ContainSubstring(`"sourceNamespace":"` + ownerNSName + `"`))
+
+		By("accepting consent given by the namespace instead")
+		owner.Annotations = map[string]string{AnnotationAllowExport: "true"}
+		Expect(k8sClient.Update(ctx, owner)).To(Succeed())
+		source.Annotations = nil
+		source.Data = map[string][]byte{"password": []byte("v2")}
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		current = reconcile()
+		Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(Equal(source.Data))
+
+		By("leaving the target alone once consent is withdrawn")
+		owner.Annotations = nil
+		Expect(k8sClient.Update(ctx, owner)).To(Succeed())
+		source.Data = map[string][]byte{"password": []byte("v3")}
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		current = reconcile()
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeSourceExportDenied)).NotTo(BeNil())
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(Equal(map[string][]byte{"password": []byte("v2")}))
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Multi-document Splitting", func() {
+	ctx := context.Background()
+
+	It("should sync one key per document of a split key", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("split-src-%d", suffix)
+		targetNSName := fmt.Sprintf("split-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "bundle", Namespace: sourceNSName},
+			Data: map[string]string{
+				"manifests.yaml": "name: app\nreplicas: 2\n---\nname: worker\nreplicas: 1\n",
+				"README":         "bundled",
+			},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-split", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "bundle"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				SyncPolicy: &platformv1alpha1.SyncPolicySpec{
+					Split: []platformv1alpha1.SplitSpec{{Key: "manifests.yaml", NameField: "name"}},
+				},
+				OperatorClass: "split",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-split", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "split"}}
+		for range 2 {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		target := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "bundle", Namespace: targetNSName}, target)).To(Succeed())
+		Expect(target.Data).To(Equal(map[string]string{
+			"app.yaml":    "name: app\nreplicas: 2\n",
+			"worker.yaml": "name: worker\nreplicas: 1\n",
+			"README":      "bundled",
+		}))
+
+		By("failing the target when documents cannot be named")
+		source.Data["manifests.yaml"] = "name: app\n---\nreplicas: 1\n"
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Eventually(func(g Gomega) {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			g.Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			g.Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			g.Expect(current.Status.SyncedTargets).To(HaveLen(1))
+			g.Expect(current.Status.SyncedTargets[0].Synced).To(BeFalse())
+			g.Expect(current.Status.SyncedTargets[0].Error).To(ContainSubstring(`split of key "manifests.yaml": document 2`))
+		}).Should(Succeed())
+
+		By("cleaning up")
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Startup Consistency Scan", func() {
+	ctx := context.Background()
+
+	It("should find the owners of managed targets and report orphans", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("scan-src-%d", suffix)
+		targetNSName := fmt.Sprintf("scan-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "scan-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-scan", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "scan-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait for target
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: "scan-secret", Namespace: targetNSName}, &corev1.Secret{})
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		// Leftovers from a deleted CR and from a target removed from the spec
+		leftover := func(name, owner string) {
+			GinkgoHelper()
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: targetNSName,
+					Labels:    map[string]string{LabelManagedBy: ManagedByValue},
+					Annotations: map[string]string{
+						AnnotationManagedBy:       ManagedByValue,
+						AnnotationSourceNamespace: sourceNSName,
+						AnnotationSourceCR:        owner,
+					},
+				},
+			})).To(Succeed())
+		}
+		leftover("from-deleted-cr", "deleted-cr")
+		leftover("removed-target", "sync-scan")
+
+		reconciler := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		result, err := reconciler.scanManagedTargets(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Only the existing CR is enqueued; the untouched target is not an orphan
+		Expect(result.owners).To(ContainElement(client.ObjectKey{Namespace: sourceNSName, Name: "sync-scan"}))
+		Expect(result.owners).NotTo(ContainElement(client.ObjectKey{Namespace: sourceNSName, Name: "deleted-cr"}))
+
+		var orphans []orphanedTarget
+		for _, o := range result.orphans {
+			if o.Namespace == targetNSName {
+				orphans = append(orphans, o)
+			}
+		}
+		Expect(orphans).To(Equal([]orphanedTarget{
+			{Kind: KindSecret, Namespace: targetNSName, Name: "from-deleted-cr",
+				Owner: client.ObjectKey{Namespace: sourceNSName, Name: "deleted-cr"}, Reason: OrphanReasonDeleted},
+			{Kind: KindSecret, Namespace: targetNSName, Name: "removed-target",
+				Owner: client.ObjectKey{Namespace: sourceNSName, Name: "sync-scan"}, Reason: OrphanReasonNotATarget},
+		}))
+		Expect(testutil.ToFloat64(orphanedTargets.WithLabelValues(KindSecret, OrphanReasonDeleted))).To(BeNumerically(">=", 1))
+		Expect(testutil.ToFloat64(orphanedTargets.WithLabelValues(KindSecret, OrphanReasonNotATarget))).To(BeNumerically(">=", 1))
+	})
+})
@@ -0,0 +1,643 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Status Reporting", func() {
+	ctx := context.Background()
+
+	It("should list only failing targets in compact mode", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("compact-src-%d", suffix)
+		targetNSName := fmt.Sprintf("compact-tgt-%d", suffix)
+		missingNSName := fmt.Sprintf("compact-missing-%d", suffix)
+
+		// Create namespaces (missingNSName is intentionally never created)
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "compact-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource in compact status mode
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-compact", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "compact-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: targetNSName},
+					{Namespace: missingNSName},
+				},
+				StatusPolicy: &platformv1alpha1.StatusPolicySpec{Mode: platformv1alpha1.StatusModeCompact},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait for the summary to reflect one success and one failure
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func() *platformv1alpha1.TargetSummary {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-compact", Namespace: sourceNSName}, freshSR); err != nil {
+				return nil
+			}
+			return freshSR.Status.TargetSummary
+		}, time.Second*10, time.Millisecond*250).Should(Equal(&platformv1alpha1.TargetSummary{
+			Total: 2, Synced: 1, Failed: 1, Omitted: 1,
+		}))
+
+		// Only the failing target is listed
+		Expect(freshSR.Status.SyncedTargets).To(HaveLen(1))
+		Expect(freshSR.Status.SyncedTargets[0].Namespace).To(Equal(missingNSName))
+		Expect(freshSR.Status.SyncedTargets[0].Synced).To(BeFalse())
+	})
+
+	It("should write the full target list to a companion status report", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("report-src-%d", suffix)
+		targetNSName := fmt.Sprintf("report-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "report-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource in compact mode with a companion report
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-report", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "report-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				StatusPolicy: &platformv1alpha1.StatusPolicySpec{
+					Mode:   platformv1alpha1.StatusModeCompact,
+					Report: true,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Report lists the successful target even though the CR status does not
+		report := &platformv1alpha1.SharedResourceStatusReport{}
+		Eventually(func() []platformv1alpha1.TargetSyncStatus {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-report", Namespace: sourceNSName}, report); err != nil {
+				return nil
+			}
+			return report.Report.Targets
+		}, time.Second*10, time.Millisecond*250).Should(HaveLen(1))
+		Expect(report.Report.Targets[0].Synced).To(BeTrue())
+		Expect(report.Report.Summary.Synced).To(Equal(int32(1)))
+		Expect(report.OwnerReferences).To(HaveLen(1))
+		Expect(report.OwnerReferences[0].Name).To(Equal("sync-report"))
+
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func() *platformv1alpha1.TargetSummary {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "sync-report", Namespace: sourceNSName}, freshSR); err != nil {
+				return nil
+			}
+			return freshSR.Status.TargetSummary
+		}, time.Second*10, time.Millisecond*250).ShouldNot(BeNil())
+		Expect(freshSR.Status.SyncedTargets).To(BeEmpty())
+	})
+
+	It("should expose retry count and next retry time while the source is missing", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("retry-src-%d", suffix)
+		targetNSName := fmt.Sprintf("retry-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create SharedResource WITHOUT creating source first
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-retry", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "retry-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Retry tracking is visible in status
+		key := types.NamespacedName{Name: "sync-retry", Namespace: sourceNSName}
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func() int32 {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return 0
+			}
+			return freshSR.Status.RetryCount
+		}, time.Second*10, time.Millisecond*250).Should(BeNumerically(">=", 1))
+		Expect(freshSR.Status.NextRetryTime).NotTo(BeNil())
+
+		// Create the source and request an immediate sync
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "retry-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return err
+			}
+			if freshSR.Annotations == nil {
+				freshSR.Annotations = map[string]string{}
+			}
+			freshSR.Annotations[AnnotationSyncNow] = "now-1"
+			return k8sClient.Update(ctx, freshSR)
+		}, time.Second*5, time.Millisecond*500).Should(Succeed())
+
+		// Successful sync resets retry tracking and acknowledges the request
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return false
+			}
+			return freshSR.Status.RetryCount == 0 &&
+				freshSR.Status.NextRetryTime == nil &&
+				freshSR.Status.LastHandledSyncRequest == "now-1"
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+	})
+
+	It("should count only scheduled retries while the source is missing", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("retrycount-src-%d", suffix)
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, sourceNS) })
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-retrycount", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "retrycount-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: "default"}},
+				OperatorClass: "retrycount",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-retrycount", Namespace: sourceNSName}
+		clock := clocktesting.NewFakeClock(time.Now())
+		r := &SharedResourceReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "retrycount"},
+			Clock:           clock,
+		}
+		reconcile := func() (ctrl.Result, *platformv1alpha1.SharedResource) {
+			GinkgoHelper()
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return result, current
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("counting the first failed attempt")
+		result, current := reconcile()
+		Expect(current.Status.RetryCount).To(Equal(int32(1)))
+		Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+		scheduled := current.Status.NextRetryTime
+
+		By("not counting attempts before the retry is due")
+		clock.Step(10 * time.Second)
+		for range 3 {
+			result, current = reconcile()
+		}
+		Expect(current.Status.RetryCount).To(Equal(int32(1)))
+		Expect(current.Status.NextRetryTime).To(Equal(scheduled))
+		Expect(result.RequeueAfter).To(BeNumerically("~", 20*time.Second, time.Second))
+
+		By("counting the scheduled retry")
+		clock.Step(20 * time.Second)
+		_, current = reconcile()
+		Expect(current.Status.RetryCount).To(Equal(int32(2)))
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should keep status stable across syncs that change nothing", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("stable-src-%d", suffix)
+		targetA := fmt.Sprintf("stable-a-%d", suffix)
+		targetB := fmt.Sprintf("stable-b-%d", suffix)
+
+		// Create namespaces
+		for _, name := range []string{sourceNSName, targetA, targetB} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "stable-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Targets are listed out of order on purpose
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-stable", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "stable-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetB}, {Namespace: targetA}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		key := types.NamespacedName{Name: "sync-stable", Namespace: sourceNSName}
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return false
+			}
+			return conditionIsTrue(freshSR, ConditionTypeReady)
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		// Targets and conditions are sorted
+		Expect(freshSR.Status.SyncedTargets).To(HaveLen(2))
+		Expect(freshSR.Status.SyncedTargets[0].Namespace).To(Equal(targetA))
+		Expect(freshSR.Status.SyncedTargets[1].Namespace).To(Equal(targetB))
+		for i := 1; i < len(freshSR.Status.Conditions); i++ {
+			Expect(freshSR.Status.Conditions[i-1].Type < freshSR.Status.Conditions[i].Type).To(BeTrue())
+		}
+		firstSynced := freshSR.Status.SyncedTargets[0].LastSynced
+
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "stable-secret", Namespace: targetA}, target)).To(Succeed())
+		targetVersion := target.ResourceVersion
+
+		// Force a full sync once the timestamp would visibly differ
+		time.Sleep(1500 * time.Millisecond)
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return err
+			}
+			if freshSR.Annotations == nil {
+				freshSR.Annotations = map[string]string{}
+			}
+			freshSR.Annotations[AnnotationSyncNow] = "stable-1"
+			return k8sClient.Update(ctx, freshSR)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return ""
+			}
+			return freshSR.Status.LastHandledSyncRequest
+		}, time.Second*10, time.Millisecond*250).Should(Equal("stable-1"))
+
+		// Up-to-date targets are neither rewritten nor re-stamped
+		Expect(freshSR.Status.SyncedTargets[0].LastSynced).To(Equal(firstSynced))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "stable-secret", Namespace: targetA}, target)).To(Succeed())
+		Expect(target.ResourceVersion).To(Equal(targetVersion))
+	})
+
+	It("should report allTargetsAtChecksum only once every target is synced", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("atsum-src-%d", suffix)
+		targetNSName := fmt.Sprintf("atsum-tgt-%d", suffix)
+		lateNSName := fmt.Sprintf("atsum-late-%d", suffix)
+
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "rotating", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "at-checksum", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "rotating"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}, {Namespace: lateNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "at-checksum", Namespace: sourceNSName}
+
+		By("staying false while a target namespace is missing")
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.TargetSummary).NotTo(BeNil())
+			g.Expect(freshSR.Status.TargetSummary.Failed).To(Equal(int32(1)))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Status.AllTargetsAtChecksum).To(BeFalse())
+		Expect(freshSR.Status.SourceChecksum).NotTo(BeEmpty())
+		checksum := freshSR.Status.SourceChecksum
+
+		By("turning true once the last target is written")
+		lateNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: lateNSName}}
+		Expect(k8sClient.Create(ctx, lateNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, lateNS) }()
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.AllTargetsAtChecksum).To(BeTrue())
+			g.Expect(freshSR.Status.SourceChecksum).To(Equal(checksum))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		By("reporting the new checksum after the source changes")
+		Eventually(func() error {
+			var latest corev1.Secret
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: "rotating", Namespace: sourceNSName}, &latest); err != nil {
+				return err
+			}
+			latest.Data["password"] = []byte("v2")
+			return k8sClient.Update(ctx, &latest)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SourceChecksum).NotTo(Equal(checksum))
+			g.Expect(freshSR.Status.AllTargetsAtChecksum).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		for _, ns := range []string{targetNSName, lateNSName} {
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rotating", Namespace: ns}, target)).To(Succeed())
+			Expect(target.Data["password"]).To(Equal([]byte("v2")))
+		}
+	})
+
+	It("should be Ready once minReadyTargets are synced", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("quorum-src-%d", suffix)
+		targetNSNames := []string{fmt.Sprintf("quorum-a-%d", suffix), fmt.Sprintf("quorum-b-%d", suffix)}
+		missingNSName := fmt.Sprintf("quorum-missing-%d", suffix)
+
+		// Create namespaces (missingNSName is intentionally never created)
+		for _, name := range append([]string{sourceNSName}, targetNSNames...) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "quorum-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		By("rejecting an out-of-range percentage")
+		invalid := intstr.FromString("150%")
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-quorum", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "quorum-secret"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: targetNSNames[0]}, {Namespace: targetNSNames[1]}, {Namespace: missingNSName},
+				},
+				MinReadyTargets: &invalid,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(MatchError(ContainSubstring("minReadyTargets must be")))
+
+		By("turning Ready with two of three targets synced at 60%")
+		quorum := intstr.FromString("60%")
+		sr.Spec.MinReadyTargets = &quorum
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-quorum", Namespace: sourceNSName}
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			ready := meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeReady)
+			g.Expect(ready).NotTo(BeNil())
+			g.Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(ready.Reason).To(Equal("MinReadyTargetsSynced"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(meta.IsStatusConditionTrue(freshSR.Status.Conditions, ConditionTypeDegraded)).To(BeTrue())
+		Expect(freshSR.Status.AllTargetsAtChecksum).To(BeFalse())
+
+		By("staying not Ready when every target is required")
+		Eventually(func() error {
+			var latest platformv1alpha1.SharedResource
+			if err := k8sClient.Get(ctx, key, &latest); err != nil {
+				return err
+			}
+			all := intstr.FromInt32(3)
+			latest.Spec.MinReadyTargets = &all
+			return k8sClient.Update(ctx, &latest)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			ready := meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeReady)
+			g.Expect(ready).NotTo(BeNil())
+			g.Expect(ready.Reason).To(Equal("PartialSync"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should record a summary of the last change applied to each target", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("changes-src-%d", suffix)
+		targetNSName := fmt.Sprintf("changes-tgt-%d", suffix)
+
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "changing", Namespace: sourceNSName},
+			Data:       map[string][]byte{"user": []byte("app"), "password": []byte("v1"), "host": []byte("db")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "record-changes", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:       platformv1alpha1.SourceSpec{Kind: "Secret", Name: "changing"},
+				Targets:      []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				StatusPolicy: &platformv1alpha1.StatusPolicySpec{RecordChanges: true},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "record-changes", Namespace: sourceNSName}
+
+		By("counting every key as added on create")
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SyncedTargets).To(HaveLen(1))
+			g.Expect(freshSR.Status.SyncedTargets[0].LastChange).NotTo(BeNil())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Status.SyncedTargets[0].LastChange).To(And(
+			HaveField("Added", int32(3)), HaveField("Removed", int32(0)), HaveField("Changed", int32(0)),
+		))
+
+		By("counting added, removed and changed keys on update")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "changing", Namespace: sourceNSName}, source)).To(Succeed())
+		source.Data = map[string][]byte{"user": []byte("app"), "password": []byte("v2"), "port": []byte("5432")}
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SyncedTargets[0].LastChange).To(And(
+				HaveField("Added", int32(1)), HaveField("Removed", int32(1)), HaveField("Changed", int32(1)),
+			))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		lastChange := *freshSR.Status.SyncedTargets[0].LastChange
+
+		By("keeping the summary across syncs that change nothing")
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return err
+			}
+			freshSR.Annotations = map[string]string{AnnotationSyncNow: "changes-1"}
+			return k8sClient.Update(ctx, freshSR)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func() string {
+			_ = k8sClient.Get(ctx, key, freshSR)
+			return freshSR.Status.LastHandledSyncRequest
+		}, time.Second*10, time.Millisecond*250).Should(Equal("changes-1"))
+		Expect(*freshSR.Status.SyncedTargets[0].LastChange).To(Equal(lastChange))
+	})
+
+	It("should keep a bounded per-target error history across recovery", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("errhist-src-%d", suffix)
+		lateNSName := fmt.Sprintf("errhist-late-%d", suffix)
+
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "flapping", Namespace: sourceNSName},
+			Data:       map[string][]byte{"key": []byte("value")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "error-history", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "flapping"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: lateNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "error-history", Namespace: sourceNSName}
+
+		By("recording the failure while the target namespace is missing")
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SyncedTargets).To(HaveLen(1))
+			g.Expect(freshSR.Status.SyncedTargets[0].RecentErrors).To(HaveLen(1))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		failed := freshSR.Status.SyncedTargets[0]
+		Expect(failed.LastErrorTime).NotTo(BeNil())
+		Expect(failed.RecentErrors[0].Message).To(Equal(failed.Error))
+
+		By("counting a repeat of the same error in one entry")
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, key, freshSR); err != nil {
+				return err
+			}
+			freshSR.Annotations = map[string]string{AnnotationSyncNow: "errhist-1"}
+			return k8sClient.Update(ctx, freshSR)
+		}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.LastHandledSyncRequest).To(Equal("errhist-1"))
+			g.Expect(freshSR.Status.SyncedTargets[0].RecentErrors).To(HaveLen(1))
+			g.Expect(freshSR.Status.SyncedTargets[0].RecentErrors[0].Count).To(BeNumerically(">=", 2))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		By("keeping the history once the target syncs")
+		lateNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: lateNSName}}
+		Expect(k8sClient.Create(ctx, lateNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, lateNS) }()
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SyncedTargets[0].Synced).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		recovered := freshSR.Status.SyncedTargets[0]
+		Expect(recovered.Error).To(BeEmpty())
+		Expect(recovered.LastErrorTime).NotTo(BeNil())
+		Expect(recovered.RecentErrors).To(HaveLen(1))
+		Expect(recovered.RecentErrors[0].Message).To(Equal(failed.Error))
+	})
+
+	It("should drop the oldest errors beyond the history limit", func() {
+		var history []platformv1alpha1.TargetError
+		now := metav1.Now()
+		for i := range MaxTargetErrorHistory + 2 {
+			history = recordTargetError(history, fmt.Sprintf("error %d", i), now)
+		}
+		Expect(history).To(HaveLen(MaxTargetErrorHistory))
+		Expect(history[0].Message).To(Equal("error 2"))
+		Expect(history[MaxTargetErrorHistory-1]).To(And(
+			HaveField("Message", fmt.Sprintf("error %d", MaxTargetErrorHistory+1)), HaveField("Count", int32(1)),
+		))
+
+		// The input history is never modified
+		again := recordTargetError(history, history[MaxTargetErrorHistory-1].Message, now)
+		Expect(again[MaxTargetErrorHistory-1].Count).To(Equal(int32(2)))
+		Expect(history[MaxTargetErrorHistory-1].Count).To(Equal(int32(1)))
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Substitutions", func() {
+	ctx := context.Background()
+
+	It("should replace tokens by the target namespace's label", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("subst-src-%d", suffix)
+		prodNSName := fmt.Sprintf("subst-prod-%d", suffix)
+		devNSName := fmt.Sprintf("subst-dev-%d", suffix)
+		otherNSName := fmt.Sprintf("subst-other-%d", suffix)
+		for name, env := range map[string]string{sourceNSName: "", prodNSName: "prod", devNSName: "dev", otherNSName: "qa"} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			if env != "" {
+				ns.Labels = map[string]string{"environment": env}
+			}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: sourceNSName},
+			Data:       map[string]string{"app.yaml": "env: {{ENV}}\nhost: db.internal\n"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-subst", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "app-config"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: prodNSName}, {Namespace: devNSName}, {Namespace: otherNSName},
+				},
+				Substitutions: &platformv1alpha1.SubstitutionSpec{
+					NamespaceLabel: "environment",
+					Values: map[string]map[string]string{
+						"prod": {"{{ENV}}": "prod", "db.internal": "db.prod.internal"},
+						"dev":  {"{{ENV}}": "dev"},
+					},
+					Default: map[string]string{"{{ENV}}": "unknown"},
+				},
+				OperatorClass: "subst",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-subst", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "subst"}}
+		for range 2 {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		for namespace, want := range map[string]string{
+			prodNSName:  "env: prod\nhost: db.prod.internal\n",
+			devNSName:   "env: dev\nhost: db.internal\n",
+			otherNSName: "env: unknown\nhost: db.internal\n",
+		} {
+			target := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "app-config", Namespace: namespace}, target)).To(Succeed())
+			Expect(target.Data).To(HaveKeyWithValue("app.yaml", want), namespace)
+		}
+
+		By("cleaning up")
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,240 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Suspension", func() {
+	ctx := context.Background()
+
+	It("should count skipped syncs while suspended and propagate on resume", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("suspend-src-%d", suffix)
+		targetNSName := fmt.Sprintf("suspend-tgt-%d", suffix)
+
+		// Create namespaces
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "suspend-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource and wait for the first sync
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-suspend", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "suspend-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-suspend", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "suspend-secret", Namespace: targetNSName}
+		Eventually(func(g Gomega) {
+			var latest platformv1alpha1.SharedResource
+			g.Expect(k8sClient.Get(ctx, key, &latest)).To(Succeed())
+			g.Expect(latest.Status.AllTargetsAtChecksum).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		setSuspend := func(suspend bool) {
+			GinkgoHelper()
+			Eventually(func() error {
+				var latest platformv1alpha1.SharedResource
+				if err := k8sClient.Get(ctx, key, &latest); err != nil {
+					return err
+				}
+				latest.Spec.Suspend = suspend
+				return k8sClient.Update(ctx, &latest)
+			}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		}
+		setPassword := func(value string) {
+			GinkgoHelper()
+			Eventually(func() error {
+				var latest corev1.Secret
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "suspend-secret", Namespace: sourceNSName}, &latest); err != nil {
+					return err
+				}
+				latest.Data["password"] = []byte(value)
+				return k8sClient.Update(ctx, &latest)
+			}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		}
+
+		By("recording the suspension with nothing pending")
+		setSuspend(true)
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SuspendedSince).NotTo(BeNil())
+			g.Expect(meta.IsStatusConditionTrue(freshSR.Status.Conditions, ConditionTypeSuspended)).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Status.SkippedSyncs).To(BeZero())
+		Expect(freshSR.Status.PendingSourceChecksum).To(BeEmpty())
+		syncedChecksum := freshSR.Status.SourceChecksum
+
+		By("counting source changes without writing the target")
+		setPassword("v2")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SkippedSyncs).To(Equal(int32(1)))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		setPassword("v3")
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SkippedSyncs).To(Equal(int32(2)))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Status.PendingSourceChecksum).NotTo(BeEmpty())
+		Expect(freshSR.Status.PendingSourceChecksum).NotTo(Equal(syncedChecksum))
+		Expect(freshSR.Status.SourceChecksum).To(Equal(syncedChecksum))
+
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data["password"]).To(Equal([]byte("v1")))
+
+		By("propagating the pending data and clearing the suspension on resume")
+		pending := freshSR.Status.PendingSourceChecksum
+		setSuspend(false)
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data["password"]).To(Equal([]byte("v3")))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SourceChecksum).To(Equal(pending))
+			g.Expect(freshSR.Status.SuspendedSince).To(BeNil())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(freshSR.Status.SkippedSyncs).To(BeZero())
+		Expect(freshSR.Status.PendingSourceChecksum).To(BeEmpty())
+		Expect(meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeSuspended)).To(BeNil())
+	})
+
+	It("should hold a resume until the pending checksum is approved", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("approve-src-%d", suffix)
+		targetNSName := fmt.Sprintf("approve-tgt-%d", suffix)
+
+		// Create namespaces
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "approve-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create a suspended SharedResource that requires approval to resume
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-approve", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:                platformv1alpha1.SourceSpec{Kind: "Secret", Name: "approve-secret"},
+				Targets:               []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				RequireResumeApproval: true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-approve", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "approve-secret", Namespace: targetNSName}
+		freshSR := &platformv1alpha1.SharedResource{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.AllTargetsAtChecksum).To(BeTrue())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+
+		update := func(mutate func(*platformv1alpha1.SharedResource)) {
+			GinkgoHelper()
+			Eventually(func() error {
+				var latest platformv1alpha1.SharedResource
+				if err := k8sClient.Get(ctx, key, &latest); err != nil {
+					return err
+				}
+				mutate(&latest)
+				return k8sClient.Update(ctx, &latest)
+			}, time.Second*5, time.Millisecond*250).Should(Succeed())
+		}
+
+		By("changing the source while suspended")
+		update(func(sr *platformv1alpha1.SharedResource) { sr.Spec.Suspend = true })
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SuspendedSince).NotTo(BeNil())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "approve-secret", Namespace: sourceNSName}, source)).To(Succeed())
+		source.Data["password"] = []byte("v2")
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.PendingSourceChecksum).NotTo(BeEmpty())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		pending := freshSR.Status.PendingSourceChecksum
+
+		By("holding the resume, also for a stale approval")
+		update(func(sr *platformv1alpha1.SharedResource) {
+			sr.Spec.Suspend = false
+			sr.Annotations = map[string]string{AnnotationApproveChecksum: "not-the-pending-checksum"}
+		})
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.ObservedGeneration).To(Equal(freshSR.Generation))
+			g.Expect(meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeSuspended)).To(
+				HaveField("Reason", "AwaitingResumeApproval"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Consistently(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data["password"]).To(Equal([]byte("v1")))
+		}, time.Second*2, time.Millisecond*250).Should(Succeed())
+
+		By("propagating once the pending checksum is approved")
+		update(func(sr *platformv1alpha1.SharedResource) {
+			sr.Annotations = map[string]string{AnnotationApproveChecksum: pending}
+		})
+		Eventually(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data["password"]).To(Equal([]byte("v2")))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, key, freshSR)).To(Succeed())
+			g.Expect(freshSR.Status.SuspendedSince).To(BeNil())
+			g.Expect(meta.FindStatusCondition(freshSR.Status.Conditions, ConditionTypeSuspended)).To(BeNil())
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+})
@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Target Selector", func() {
+	ctx := context.Background()
+
+	It("should keep synced targets in step with the namespaces matching the selector", func() {
+		suffix := time.Now().UnixNano() % 100000
+		environment := fmt.Sprintf("dev-%d", suffix)
+		sourceNSName := fmt.Sprintf("selector-src-%d", suffix)
+		names := []string{fmt.Sprintf("selector-a-%d", suffix), fmt.Sprintf("selector-b-%d", suffix), fmt.Sprintf("selector-c-%d", suffix)}
+		byName := map[string]*corev1.Namespace{}
+		for i, name := range append([]string{sourceNSName}, names...) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			if i < 3 {
+				// The source namespace matches too, and must be skipped
+				ns.Labels = map[string]string{"environment": environment}
+			}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+			byName[name] = ns
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "selector-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-selector", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "selector-secret"},
+				TargetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": environment}},
+				OperatorClass:  "selector",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-selector", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "selector"}}
+		syncedNamespaces := func() []string {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			Expect(conditionIsTrue(current, ConditionTypeReady)).To(BeTrue())
+			var namespaces []string
+			for _, target := range current.Status.SyncedTargets {
+				namespaces = append(namespaces, target.Namespace)
+			}
+			return namespaces
+		}
+		relabel := func(name string, nsLabels map[string]string) {
+			GinkgoHelper()
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name}, ns)).To(Succeed())
+			ns.Labels = nsLabels
+			Expect(k8sClient.Update(ctx, ns)).To(Succeed())
+			Eventually(func() []ctrl.Request {
+				return r.findSharedResourcesForNamespace(ctx, ns)
+			}).Should(ContainElement(ctrl.Request{NamespacedName: key}))
+		}
+		// The first reconcile only adds the finalizer
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("syncing to the matching namespaces, not the CR's own")
+		Expect(syncedNamespaces()).To(Equal(names[:2]))
+		target := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "selector-secret", Namespace: names[0]}, target)).To(Succeed())
+		Expect(target.Data).To(Equal(source.Data))
+
+		By("adding a namespace that gains the label")
+		relabel(names[2], map[string]string{"environment": environment})
+		Expect(syncedNamespaces()).To(Equal(names))
+
+		By("dropping a namespace that loses the label from status, keeping its copy")
+		relabel(names[0], nil)
+		Expect(syncedNamespaces()).To(Equal(names[1:]))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "selector-secret", Namespace: names[0]}, target)).To(Succeed())
+
+		By("cleaning up")
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should match from the namespace snapshot until the namespace watch drops it", func() {
+		suffix := time.Now().UnixNano() % 100000
+		environment := fmt.Sprintf("snap-%d", suffix)
+		names := []string{fmt.Sprintf("snapshot-a-%d", suffix), fmt.Sprintf("snapshot-b-%d", suffix), fmt.Sprintf("snapshot-c-%d", suffix)}
+		create := func(name string) *corev1.Namespace {
+			GinkgoHelper()
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"environment": environment}}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+			return ns
+		}
+		create(names[0])
+
+		// Not created: matching needs only the selector
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-snapshot", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				TargetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": environment}},
+			},
+		}
+		clock := clocktesting.NewFakePassiveClock(time.Now())
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Clock: clock}
+		// As SetupWithManager does before watching namespaces
+		r.namespaces.watch()
+		selected := func() []string {
+			GinkgoHelper()
+			namespaces, err := r.selectedNamespaces(ctx, sr)
+			Expect(err).NotTo(HaveOccurred())
+			return namespaces
+		}
+		Expect(selected()).To(Equal(names[:1]))
+
+		By("reusing the snapshot until a namespace event")
+		b := create(names[1])
+		Expect(selected()).To(Equal(names[:1]))
+		r.findSharedResourcesForNamespace(ctx, b)
+		Expect(selected()).To(Equal(names[:2]))
+
+		By("listing again once the snapshot is too old")
+		create(names[2])
+		Expect(selected()).To(Equal(names[:2]))
+		clock.SetTime(clock.Now().Add(namespaceSetTTL))
+		Expect(selected()).To(Equal(names))
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Target Template", func() {
+	ctx := context.Background()
+
+	It("should apply template metadata, type and immutable to targets", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("tmpl-obj-src-%d", suffix)
+		targetNSName := fmt.Sprintf("tmpl-obj-tgt-%d", suffix)
+
+		// Create namespaces
+		sourceNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sourceNSName}}
+		Expect(k8sClient.Create(ctx, sourceNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, sourceNS) }()
+
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// Create source
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tmpl-obj-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"username": []byte("app"), "password": []byte("s3cret")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// Create SharedResource with a target template
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-tmpl-obj", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "tmpl-obj-secret"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				TargetTemplate: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Secret",` +
+					`"metadata":{"labels":{"team":"payments"},"annotations":{"example.com/owner":"payments"}},` +
+					`"type":"kubernetes.io/basic-auth","immutable":true}`)},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		// Wait for target
+		targetKey := types.NamespacedName{Name: "tmpl-obj-secret", Namespace: targetNSName}
+		target := &corev1.Secret{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, targetKey, target)
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(target.Labels).To(HaveKeyWithValue("team", "payments"))
+		Expect(target.Labels).To(HaveKeyWithValue(LabelManagedBy, ManagedByValue))
+		Expect(target.Annotations).To(HaveKeyWithValue("example.com/owner", "payments"))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationSourceCR, "sync-tmpl-obj"))
+		Expect(target.Type).To(Equal(corev1.SecretTypeBasicAuth))
+		Expect(target.Immutable).To(Equal(ptr.To(true)))
+
+		By("changing the source; the immutable target reports an error")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "tmpl-obj-secret", Namespace: sourceNSName}, source)).To(Succeed())
+		source.Data["password"] = []byte("rotated")
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Eventually(func() []platformv1alpha1.TargetSyncStatus {
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: "sync-tmpl-obj", Namespace: sourceNSName}, sr)
+			return sr.Status.SyncedTargets
+		}, time.Second*10, time.Millisecond*250).Should(ConsistOf(And(
+			HaveField("Synced", false),
+			HaveField("Error", ContainSubstring("immutable")),
+		)))
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(HaveKeyWithValue("password", []byte("s3cret")))
+
+		By("deleting the target; it is recreated with the new data")
+		Expect(k8sClient.Delete(ctx, target)).To(Succeed())
+		Eventually(func() []byte {
+			recreated := &corev1.Secret{}
+			_ = k8sClient.Get(ctx, targetKey, recreated)
+			return recreated.Data["password"]
+		}, time.Second*10, time.Millisecond*250).Should(Equal([]byte("rotated")))
+	})
+
+	It("should report an invalid template on every target", func() {
+		suffix := time.Now().UnixNano() % 100000
+		nsName := fmt.Sprintf("tmpl-obj-bad-%d", suffix)
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, ns) }()
+
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tmpl-obj-config", Namespace: nsName},
+			Data:       map[string]string{"key": "value"},
+		})).To(Succeed())
+
+		// The webhook is not served in this suite, so the controller rejects the template
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-tmpl-obj-bad", Namespace: nsName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "tmpl-obj-config"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: nsName, Name: "tmpl-obj-copy"}},
+				TargetTemplate: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap",` +
+					`"metadata":{"finalizers":["example.com/hold"]}}`)},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+
+		Eventually(func() []platformv1alpha1.TargetSyncStatus {
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: "sync-tmpl-obj-bad", Namespace: nsName}, sr)
+			return sr.Status.SyncedTargets
+		}, time.Second*10, time.Millisecond*250).Should(ConsistOf(And(
+			HaveField("Synced", false),
+			HaveField("Error", ContainSubstring("spec.targetTemplate.metadata.finalizers")),
+		)))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "tmpl-obj-copy", Namespace: nsName}, &corev1.ConfigMap{})).
+			NotTo(Succeed())
+	})
+})
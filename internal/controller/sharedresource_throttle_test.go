@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// throttlingClient stands in for an overloaded API server: Secret creates in
+// one namespace are rejected with 429 and a Retry-After delay.
+type throttlingClient struct {
+	client.Client
+	namespace string
+	throttle  atomic.Bool
+	creates   atomic.Int32
+}
+
+func (c *throttlingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		c.creates.Add(1)
+		if c.throttle.Load() && obj.GetNamespace() == c.namespace {
+			return apierrors.NewTooManyRequests("the server has received too many requests", 30)
+		}
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("API Server Back-pressure", func() {
+	ctx := context.Background()
+
+	It("should pause the remaining target writes after a 429", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("throttle-src-%d", suffix)
+		targetNSNames := []string{
+			fmt.Sprintf("throttle-a-%d", suffix),
+			fmt.Sprintf("throttle-b-%d", suffix),
+			fmt.Sprintf("throttle-c-%d", suffix),
+		}
+		for _, name := range append([]string{sourceNSName}, targetNSNames...) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "throttle-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"password": []byte("v1")},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		targets := []platformv1alpha1.TargetSpec{}
+		for _, name := range targetNSNames {
+			targets = append(targets, platformv1alpha1.TargetSpec{Namespace: name})
+		}
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-throttle", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "throttle-secret"},
+				Targets:       targets,
+				OperatorClass: "throttle",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-throttle", Namespace: sourceNSName}
+
+		c := &throttlingClient{Client: k8sClient, namespace: targetNSNames[1]}
+		c.throttle.Store(true)
+		clock := clocktesting.NewFakeClock(time.Now())
+		r := &SharedResourceReconciler{Client: c, Scheme: k8sClient.Scheme(), Clock: clock,
+			InstanceOptions: InstanceOptions{OperatorClass: "throttle"}}
+		reconcile := func() (ctrl.Result, *platformv1alpha1.SharedResource) {
+			GinkgoHelper()
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return result, current
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("deferring the targets after the throttled one")
+		result, current := reconcile()
+		Expect(c.creates.Load()).To(Equal(int32(2)))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 30*time.Second))
+		Expect(current.Status.SyncedTargets).To(ConsistOf(
+			And(HaveField("Namespace", targetNSNames[0]), HaveField("Synced", true)),
+			And(HaveField("Namespace", targetNSNames[1]), HaveField("Error", ContainSubstring("too many requests"))),
+			And(HaveField("Namespace", targetNSNames[2]), HaveField("Error", ContainSubstring("writes resume at"))),
+		))
+		throttled := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeThrottled)
+		Expect(throttled).NotTo(BeNil())
+		Expect(throttled.Message).To(ContainSubstring("1 target(s) not written"))
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: "throttle-secret", Namespace: targetNSNames[2]}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		By("writing nothing while paused")
+		_, _ = reconcile()
+		Expect(c.creates.Load()).To(Equal(int32(2)))
+
+		By("resuming once the pause is over")
+		c.throttle.Store(false)
+		clock.Step(31 * time.Second)
+		_, current = reconcile()
+		Expect(current.Status.AllTargetsAtChecksum).To(BeTrue())
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeThrottled)).To(BeNil())
+
+		By("cleaning up")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,122 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Namespace Tiers", func() {
+	ctx := context.Background()
+
+	const tiersFile = `
+tiers:
+  - name: prod
+    namespaceSelector:
+      matchLabels: {tier: prod}
+    resyncInterval: 1m
+  - name: nonprod
+    namespaceSelector:
+      matchLabels: {tier: nonprod}
+    resyncInterval: 30m
+    keys:
+      exclude: [admin-password]
+`
+
+	It("should parse and validate a tiers file", func() {
+		tiers, err := ParseNamespaceTiers([]byte(tiersFile))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tiers).To(HaveLen(2))
+		Expect(tiers[0]).To(HaveField("Name", "prod"))
+		Expect(tiers[0]).To(HaveField("ResyncInterval", time.Minute))
+		Expect(tiers[1].Keys).To(HaveField("Exclude", ConsistOf("admin-password")))
+
+		invalid := []struct{ file, message string }{
+			{"tiers: [{namespaceSelector: {matchLabels: {tier: prod}}}]", "name is required"},
+			{"tiers: [{name: prod}]", "must not be empty"},
+			{"tiers: [{name: prod, namespaceSelector: {matchLabels: {tier: prod}}, resync: 1m}]", "unknown field"},
+			{"tiers: [{name: prod, namespaceSelector: {matchLabels: {tier: prod}}, resyncInterval: 1s}]", "at least"},
+			{"tiers: [{name: a, namespaceSelector: {matchLabels: {a: b}}}, {name: a, namespaceSelector: {matchLabels: {c: d}}}]",
+				"duplicate tier"},
+		}
+		for _, tt := range invalid {
+			_, err := ParseNamespaceTiers([]byte(tt.file))
+			Expect(err).To(MatchError(ContainSubstring(tt.message)), tt.file)
+		}
+	})
+
+	It("should apply tier defaults to targets in tiered namespaces", func() {
+		tiers, err := ParseNamespaceTiers([]byte(tiersFile))
+		Expect(err).NotTo(HaveOccurred())
+
+		// Create namespaces
+		suffix := time.Now().UnixNano() % 100000
+		prodNS := fmt.Sprintf("tier-prod-%d", suffix)
+		devNS := fmt.Sprintf("tier-dev-%d", suffix)
+		plainNS := fmt.Sprintf("tier-plain-%d", suffix)
+		for name, tier := range map[string]string{prodNS: "prod", devNS: "nonprod", plainNS: ""} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			if tier != "" {
+				ns.Labels = map[string]string{"tier": tier}
+			}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+		}
+
+		// A reconciler of our own, so the manager's one is not affected
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			TargetOptions: TargetOptions{NamespaceTiers: tiers}}
+		sr := &platformv1alpha1.SharedResource{
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "Secret", Name: "db"},
+			},
+		}
+		data := map[string][]byte{"password": []byte("p"), "admin-password": []byte("a")}
+
+		By("redacting keys for the tier")
+		Expect(r.dataForTarget(ctx, sr, devNS, data)).To(HaveLen(1))
+		Expect(r.dataForTarget(ctx, sr, prodNS, data)).To(HaveLen(2))
+		Expect(r.dataForTarget(ctx, sr, plainNS, data)).To(HaveLen(2))
+
+		By("letting a namespace rule of the CR win over the tier")
+		sr.Spec.SyncPolicy = &platformv1alpha1.SyncPolicySpec{
+			NamespaceRules: []platformv1alpha1.NamespaceKeyRule{{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "nonprod"}},
+				Keys:              platformv1alpha1.KeySelector{Include: []string{"admin-password"}},
+			}},
+		}
+		Expect(r.dataForTarget(ctx, sr, devNS, data)).To(HaveKey("admin-password"))
+
+		By("resyncing at the shortest interval of the CR's tiers")
+		sr.Spec.Targets = []platformv1alpha1.TargetSpec{{Namespace: devNS}}
+		Expect(r.resyncInterval(ctx, sr)).To(Equal(30 * time.Minute))
+		sr.Spec.Targets = append(sr.Spec.Targets, platformv1alpha1.TargetSpec{Namespace: plainNS})
+		Expect(r.resyncInterval(ctx, sr)).To(Equal(ResyncInterval))
+		sr.Spec.Targets = append(sr.Spec.Targets, platformv1alpha1.TargetSpec{Namespace: prodNS})
+		Expect(r.resyncInterval(ctx, sr)).To(Equal(time.Minute))
+		Expect((&SharedResourceReconciler{}).resyncInterval(ctx, sr)).To(Equal(ResyncInterval))
+	})
+})
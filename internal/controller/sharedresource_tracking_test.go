@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Compact Tracking", func() {
+	ctx := context.Background()
+
+	It("should move tracking of large targets into labels and back", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("tracking-src-%d", suffix)
+		targetNSName := fmt.Sprintf("tracking-tgt-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tracking-secret", Namespace: sourceNSName},
+			Data:       map[string][]byte{"bundle": []byte(strings.Repeat("x", 4096))},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-tracking", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:        platformv1alpha1.SourceSpec{Kind: "Secret", Name: "tracking-secret"},
+				Targets:       []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				OperatorClass: "tracking",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-tracking", Namespace: sourceNSName}
+		targetKey := types.NamespacedName{Name: "tracking-secret", Namespace: targetNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "tracking"}}
+		reconcile := func() *corev1.Secret {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			return target
+		}
+		// The first reconcile only adds the finalizer
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("tracking the target with annotations below the threshold")
+		target := reconcile()
+		Expect(target.Annotations).To(HaveKey(AnnotationChecksum))
+		Expect(target.Annotations).To(HaveKey(AnnotationProvenance))
+		Expect(target.Labels).NotTo(HaveKey(LabelTracking))
+		checksum := target.Annotations[AnnotationChecksum]
+
+		By("migrating it to labels once its data reaches the threshold")
+		r.CompactTrackingThreshold = 1024
+		r.verified.forget(key)
+		target = reconcile()
+		Expect(target.Annotations).NotTo(HaveKey(AnnotationChecksum))
+		Expect(target.Annotations).NotTo(HaveKey(AnnotationProvenance))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationSourceCR, "sync-tracking"))
+		Expect(target.Labels).To(HaveKeyWithValue(LabelTracking, TrackingCompact))
+		Expect(target.Labels).To(HaveKeyWithValue(LabelChecksum, checksumLabel(checksum)))
+		Expect(target.Labels).To(HaveKeyWithValue(LabelSourceUID, string(source.UID)))
+		Expect(target.Data).To(Equal(source.Data))
+
+		By("not rewriting a compactly tracked target that is up to date")
+		version := target.ResourceVersion
+		r.verified.forget(key)
+		Expect(reconcile().ResourceVersion).To(Equal(version))
+
+		By("migrating it back to annotations when the threshold is raised")
+		r.CompactTrackingThreshold = 0
+		r.verified.forget(key)
+		target = reconcile()
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnotationChecksum, checksum))
+		Expect(target.Annotations).To(HaveKey(AnnotationProvenance))
+		for _, label := range compactTrackingLabels {
+			Expect(target.Labels).NotTo(HaveKey(label))
+		}
+
+		By("cleaning up")
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
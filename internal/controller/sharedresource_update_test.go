@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
 )
 
 var _ = Describe("Source Updates", func() {
@@ -410,4 +411,136 @@ var _ = Describe("Source Updates", func() {
 			return hasLocal
 		}, time.Second*3, time.Millisecond*500).Should(BeTrue())
 	})
+
+	It("should project several sources into one existing target under key prefixes", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("prefix-src-%d", suffix)
+		targetNSName := fmt.Sprintf("prefix-tgt-%d", suffix)
+
+		// Create namespaces
+		for _, ns := range []string{sourceNSName, targetNSName} {
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})).To(Succeed())
+			defer func(name string) {
+				_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}(ns)
+		}
+
+		// The destination team's own Secret
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: targetNSName},
+			Data:       map[string][]byte{"local": []byte("kept")},
+		})).To(Succeed())
+
+		// Two sources with colliding key names, each merged under its own prefix
+		for _, name := range []string{"upstreamdb", "cache"} {
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: sourceNSName},
+				Data:       map[string][]byte{"password": []byte(name + "-pw")},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &platformv1alpha1.SharedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "sync-" + name, Namespace: sourceNSName},
+				Spec: platformv1alpha1.SharedResourceSpec{
+					Source:     platformv1alpha1.SourceSpec{Kind: "Secret", Name: name},
+					SyncPolicy: &platformv1alpha1.SyncPolicySpec{Mode: platformv1alpha1.SyncModeMerge},
+					Targets: []platformv1alpha1.TargetSpec{{
+						Namespace: targetNSName, Name: "app-config", KeyPrefix: name + "_",
+					}},
+				},
+			})).To(Succeed())
+		}
+
+		Eventually(func(g Gomega) {
+			target := &corev1.Secret{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "app-config", Namespace: targetNSName}, target)).To(Succeed())
+			g.Expect(target.Data).To(Equal(map[string][]byte{
+				"local":               []byte("kept"),
+				"upstreamdb_password": []byte("upstreamdb-pw"),
+				"cache_password":      []byte("cache-pw"),
+			}))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
+
+	It("should track key ownership when several SharedResources merge into one target", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("owners-src-%d", suffix)
+		targetNSName := fmt.Sprintf("owners-tgt-%d", suffix)
+
+		// Create namespaces
+		for _, ns := range []string{sourceNSName, targetNSName} {
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})).To(Succeed())
+			defer func(name string) {
+				_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}(ns)
+		}
+
+		// Both sources carry "region"; each also has keys of its own
+		sources := map[string]map[string][]byte{
+			"db":    {"db_password": []byte("pw"), "db_host": []byte("db"), "region": []byte("eu")},
+			"queue": {"queue_url": []byte("amqp"), "region": []byte("us")},
+		}
+		for _, name := range []string{"db", "queue"} {
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: sourceNSName},
+				Data:       sources[name],
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &platformv1alpha1.SharedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "sync-" + name, Namespace: sourceNSName},
+				Spec: platformv1alpha1.SharedResourceSpec{
+					Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: name},
+					SyncPolicy:     &platformv1alpha1.SyncPolicySpec{Mode: platformv1alpha1.SyncModeMerge},
+					DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+					Targets:        []platformv1alpha1.TargetSpec{{Namespace: targetNSName, Name: "combined"}},
+				},
+			})).To(Succeed())
+			// Let the first one claim "region" before the second arrives
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: "combined", Namespace: targetNSName}, &corev1.Secret{})
+			}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		}
+
+		targetKey := types.NamespacedName{Name: "combined", Namespace: targetNSName}
+		target := &corev1.Secret{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data).To(HaveKey("queue_url"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+		Expect(target.Data).To(HaveKeyWithValue("region", []byte("eu")))
+		Expect(parseKeyOwners(target.Annotations)).To(Equal(syncengine.KeyOwners{
+			sourceNSName + "/sync-db":    {"db_host", "db_password", "region"},
+			sourceNSName + "/sync-queue": {"queue_url"},
+		}))
+
+		By("reporting the conflicting key on the second SharedResource")
+		queueSR := &platformv1alpha1.SharedResource{}
+		Eventually(func() string {
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: "sync-queue", Namespace: sourceNSName}, queueSR)
+			if len(queueSR.Status.SyncedTargets) == 0 {
+				return ""
+			}
+			return queueSR.Status.SyncedTargets[0].Error
+		}, time.Second*10, time.Millisecond*250).Should(ContainSubstring("region (owned by " + sourceNSName + "/sync-db)"))
+
+		By("removing a key only its owner dropped")
+		source := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "db", Namespace: sourceNSName}, source)).To(Succeed())
+		delete(source.Data, "db_host")
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		Eventually(func() map[string][]byte {
+			_ = k8sClient.Get(ctx, targetKey, target)
+			return target.Data
+		}, time.Second*10, time.Millisecond*250).ShouldNot(HaveKey("db_host"))
+		Expect(target.Data).To(HaveKey("queue_url"))
+
+		By("deleting one SharedResource removes only its keys")
+		Expect(k8sClient.Delete(ctx, &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-db", Namespace: sourceNSName},
+		})).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			g.Expect(target.Data).NotTo(HaveKey("db_password"))
+			// The remaining owner now takes over the freed key
+			g.Expect(target.Data).To(HaveKeyWithValue("region", []byte("us")))
+			g.Expect(target.Data).To(HaveKey("queue_url"))
+		}, time.Second*10, time.Millisecond*250).Should(Succeed())
+	})
 })
@@ -0,0 +1,418 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// finishJob stands in for the Job controller, which envtest does not run.
+func finishJob(ctx context.Context, job *batchv1.Job, succeeded bool) {
+	GinkgoHelper()
+	now := metav1.Now()
+	job.Status.StartTime = &now
+	if succeeded {
+		job.Status.Succeeded = 1
+		job.Status.CompletionTime = &now
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue, LastTransitionTime: now},
+			{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: now},
+		}
+	} else {
+		job.Status.Failed = 1
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue, LastTransitionTime: now,
+				Reason: batchv1.JobReasonBackoffLimitExceeded, Message: "login refused"},
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: now,
+				Reason: batchv1.JobReasonBackoffLimitExceeded, Message: "login refused"},
+		}
+	}
+	Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+}
+
+var _ = Describe("Verification Jobs", func() {
+	ctx := context.Background()
+
+	It("should hold Ready until each target's verification Job succeeds", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("verify-src-%d", suffix)
+		targetNSNames := []string{fmt.Sprintf("verify-a-%d", suffix), fmt.Sprintf("verify-b-%d", suffix)}
+		for _, name := range append([]string{sourceNSName}, targetNSNames...) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		source := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "verify-config", Namespace: sourceNSName},
+			Data:       map[string]string{"host": "db-1"},
+		}
+		Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-verify", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source: platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "verify-config"},
+				Targets: []platformv1alpha1.TargetSpec{
+					{Namespace: targetNSNames[0]}, {Namespace: targetNSNames[1], Name: "renamed-config"},
+				},
+				Verify: &platformv1alpha1.VerifySpec{JobTemplate: runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{` +
+					`"containers":[{"name":"check","image":"busybox","command":["true"],` +
+					`"envFrom":[{"configMapRef":{"name":"{{ .Target.Name }}"}}]}]}}}}`)}},
+				OperatorClass: "verify",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-verify", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "verify"}, HookOptions: HookOptions{VerifyJobs: true}}
+		reconcile := func() (ctrl.Result, *platformv1alpha1.SharedResource) {
+			GinkgoHelper()
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			return result, current
+		}
+		verification := func(sr *platformv1alpha1.SharedResource, namespace string) *platformv1alpha1.TargetVerification {
+			GinkgoHelper()
+			for _, t := range sr.Status.SyncedTargets {
+				if t.Namespace == namespace {
+					Expect(t.Verification).NotTo(BeNil())
+					return t.Verification
+				}
+			}
+			Fail("no status for target in " + namespace)
+			return nil
+		}
+		getJob := func(namespace, name string) *batchv1.Job {
+			GinkgoHelper()
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, job)).To(Succeed())
+			return job
+		}
+		// The first reconcile only adds the finalizer
+		reconcile()
+
+		By("starting a Job per target and holding Ready")
+		result, current := reconcile()
+		Expect(result.RequeueAfter).To(BeNumerically("<=", HookCheckInterval))
+		Expect(current.Status.AllTargetsAtChecksum).To(BeTrue())
+		ready := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("VerificationPending"))
+		verified := meta.FindStatusCondition(current.Status.Conditions, ConditionTypeVerified)
+		Expect(verified.Reason).To(Equal("VerificationRunning"))
+		first := verification(current, targetNSNames[0])
+		Expect(first.Phase).To(Equal(platformv1alpha1.HookRunning))
+		jobA := getJob(targetNSNames[0], first.Job)
+		Expect(jobA.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(*jobA.Spec.ActiveDeadlineSeconds).To(Equal(int64(DefaultHookJobTimeout.Seconds())))
+		Expect(jobA.Annotations).To(HaveKeyWithValue(AnnotationVerifies, "verify-config"))
+		Expect(jobA.Spec.Template.Spec.ServiceAccountName).To(Equal(hookServiceAccount))
+		Expect(*jobA.Spec.Template.Spec.AutomountServiceAccountToken).To(BeFalse())
+		Expect(*jobA.Spec.Template.Spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+		Expect(jobA.Spec.Template.Spec.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+		container := jobA.Spec.Template.Spec.Containers[0].SecurityContext
+		Expect(*container.AllowPrivilegeEscalation).To(BeFalse())
+		Expect(container.Capabilities.Drop).To(Equal([]corev1.Capability{"ALL"}))
+		jobB := getJob(targetNSNames[1], verification(current, targetNSNames[1]).Job)
+		Expect(jobB.Spec.Template.Spec.Containers[0].EnvFrom[0].ConfigMapRef.Name).To(Equal("renamed-config"))
+
+		By("recording each Job's result")
+		finishJob(ctx, jobA, true)
+		finishJob(ctx, jobB, false)
+		_, current = reconcile()
+		Expect(verification(current, targetNSNames[0]).Phase).To(Equal(platformv1alpha1.HookSucceeded))
+		failed := verification(current, targetNSNames[1])
+		Expect(failed.Phase).To(Equal(platformv1alpha1.HookFailed))
+		Expect(failed.Message).To(ContainSubstring("login refused"))
+		ready = meta.FindStatusCondition(current.Status.Conditions, ConditionTypeReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("VerificationFailed"))
+		verified = meta.FindStatusCondition(current.Status.Conditions, ConditionTypeVerified)
+		Expect(verified.Message).To(ContainSubstring(targetNSNames[1] + "/renamed-config"))
+
+		By("verifying new data with new Jobs")
+		source.Data = map[string]string{"host": "db-2"}
+		Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		_, current = reconcile()
+		second := verification(current, targetNSNames[0])
+		Expect(second.Job).NotTo(Equal(first.Job))
+		Expect(second.Phase).To(Equal(platformv1alpha1.HookRunning))
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: targetNSNames[0], Name: first.Job}, &batchv1.Job{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		finishJob(ctx, getJob(targetNSNames[0], second.Job), true)
+		finishJob(ctx, getJob(targetNSNames[1], verification(current, targetNSNames[1]).Job), true)
+		_, current = reconcile()
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeVerified)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+
+		By("deleting the Jobs with the SharedResource")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		err = k8sClient.Get(ctx, types.NamespacedName{Namespace: targetNSNames[0], Name: second.Job}, &batchv1.Job{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should sync but not verify targets while verification Jobs are disabled", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("verify-off-src-%d", suffix)
+		targetNSName := fmt.Sprintf("verify-off-dst-%d", suffix)
+		for _, name := range []string{sourceNSName, targetNSName} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "verify-config", Namespace: sourceNSName},
+			Data:       map[string]string{"host": "db-1"},
+		})).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-verify-off", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "verify-config"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSName}},
+				Verify: &platformv1alpha1.VerifySpec{JobTemplate: runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{` +
+					`"containers":[{"name":"check","image":"busybox","command":["true"]}]}}}}`)}},
+				OperatorClass: "verify-off",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-verify-off", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			InstanceOptions: InstanceOptions{OperatorClass: "verify-off"}}
+		// The first reconcile only adds the finalizer
+		for range 2 {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		current := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+		Expect(current.Status.SyncedTargets).To(HaveLen(1))
+		Expect(current.Status.SyncedTargets[0].Synced).To(BeTrue())
+		Expect(current.Status.SyncedTargets[0].Verification).To(BeNil())
+		for _, conditionType := range []string{ConditionTypeVerified, ConditionTypeReady} {
+			condition := meta.FindStatusCondition(current.Status.Conditions, conditionType)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse), conditionType)
+			Expect(condition.Reason).To(Equal("VerificationDisabled"), conditionType)
+		}
+		var jobs batchv1.JobList
+		Expect(k8sClient.List(ctx, &jobs, client.InNamespace(targetNSName))).To(Succeed())
+		Expect(jobs.Items).To(BeEmpty())
+	})
+
+	It("should keep the verifications of targets omitted from a compacted status", func() {
+		suffix := time.Now().UnixNano() % 100000
+		sourceNSName := fmt.Sprintf("verify-compact-src-%d", suffix)
+		targetNSNames := []string{fmt.Sprintf("verify-compact-a-%d", suffix), fmt.Sprintf("verify-compact-b-%d", suffix)}
+		for _, name := range append([]string{sourceNSName}, targetNSNames...) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "verify-config", Namespace: sourceNSName},
+			Data:       map[string]string{"host": "db-1"},
+		})).To(Succeed())
+
+		// The class keeps the manager's reconciler away from the CR
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-verify-compact", Namespace: sourceNSName},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "ConfigMap", Name: "verify-config"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNSNames[0]}, {Namespace: targetNSNames[1]}},
+				Verify: &platformv1alpha1.VerifySpec{JobTemplate: runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{` +
+					`"containers":[{"name":"check","image":"busybox","command":["true"]}]}}}}`)}},
+				OperatorClass: "verify-compact",
+			},
+		}
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		key := types.NamespacedName{Name: "sync-verify-compact", Namespace: sourceNSName}
+		r := &SharedResourceReconciler{
+			Client:                 k8sClient,
+			Scheme:                 k8sClient.Scheme(),
+			InstanceOptions:        InstanceOptions{OperatorClass: "verify-compact"},
+			HookOptions:            HookOptions{VerifyJobs: true},
+			CompactStatusThreshold: 1,
+		}
+		reconcile := func() (*platformv1alpha1.SharedResource, []platformv1alpha1.TargetSyncStatus) {
+			GinkgoHelper()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			current := &platformv1alpha1.SharedResource{}
+			Expect(k8sClient.Get(ctx, key, current)).To(Succeed())
+			report := &platformv1alpha1.SharedResourceStatusReport{}
+			Expect(k8sClient.Get(ctx, key, report)).To(Succeed())
+			Expect(report.Report.Targets).To(HaveLen(2))
+			return current, report.Report.Targets
+		}
+		// The first reconcile only adds the finalizer
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("keeping the omitted targets in the status report")
+		current, targets := reconcile()
+		Expect(current.Status.SyncedTargets).To(BeEmpty())
+		Expect(current.Status.TargetSummary.Omitted).To(Equal(int32(2)))
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionTypeVerified).Reason).To(Equal("VerificationRunning"))
+		for _, t := range targets {
+			Expect(t.Verification).NotTo(BeNil())
+			Expect(t.Verification.Phase).To(Equal(platformv1alpha1.HookRunning))
+		}
+
+		By("not starting the verifications again")
+		_, again := reconcile()
+		for i, t := range again {
+			Expect(t.Verification.Job).To(Equal(targets[i].Verification.Job))
+			Expect(t.LastSynced).To(Equal(targets[i].LastSynced))
+			Expect(t.SourceChecksum).To(Equal(targets[i].SourceChecksum))
+		}
+		for _, t := range targets {
+			var jobs batchv1.JobList
+			Expect(k8sClient.List(ctx, &jobs, client.InNamespace(t.Namespace))).To(Succeed())
+			Expect(jobs.Items).To(HaveLen(1))
+			finishJob(ctx, &jobs.Items[0], true)
+		}
+		current, _ = reconcile()
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeVerified)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, ConditionTypeReady)).To(BeTrue())
+
+		By("deleting the Jobs with the SharedResource")
+		Expect(k8sClient.Delete(ctx, current)).To(Succeed())
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		for _, t := range targets {
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: t.Namespace, Name: t.Verification.Job}, &batchv1.Job{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}
+	})
+
+	It("should only let verification pods read the target they verify", func() {
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "verify", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "db-credentials"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: "backend"}},
+			},
+		}
+		verify := func(pod string) field.ErrorList {
+			sr.Spec.Verify = &platformv1alpha1.VerifySpec{JobTemplate: runtime.RawExtension{
+				Raw: []byte(`{"spec":{"template":{"spec":` + pod + `}}}`),
+			}}
+			return VerifyErrors(sr)
+		}
+		Expect(verify(`{"containers":[{"name":"login","image":"postgres",` +
+			`"envFrom":[{"secretRef":{"name":"{{ .Target.Name }}"}}]}]}`)).To(BeEmpty())
+
+		errs := verify(`{"serviceAccountName":"deployer","hostNetwork":true,` +
+			`"volumes":[{"name":"root","hostPath":{"path":"/"}},{"name":"token","secret":{"secretName":"deployer-token"}}],` +
+			`"containers":[{"name":"login","image":"postgres","securityContext":{"privileged":true}}]}`)
+		Expect(errs).To(HaveLen(1))
+		for _, message := range []string{
+			`spec.template.spec.serviceAccountName: hook pods run as ServiceAccount "default"`,
+			"spec.template.spec.hostNetwork is not allowed",
+			"spec.template.spec.volumes[root]: only emptyDir",
+			`spec.template.spec.volumes[token]: Secret "deployer-token" is not the synced target`,
+			"spec.template.spec.containers[login]: securityContext.privileged is not allowed",
+		} {
+			Expect(errs[0].Detail).To(ContainSubstring(message))
+		}
+
+		By("allowing only scratch, downward API and synced target volumes")
+		Expect(verify(`{"volumes":[{"name":"scratch","emptyDir":{}},{"name":"info","downwardAPI":{}},` +
+			`{"name":"target","secret":{"secretName":"{{ .Target.Name }}"}}],` +
+			`"containers":[{"name":"login","image":"postgres"}]}`)).To(BeEmpty())
+		for pod, message := range map[string]string{
+			`{"volumes":[{"name":"data","persistentVolumeClaim":{"claimName":"tenant-db"}}],"containers":[{"name":"login"}]}`:              "volumes[data]: only emptyDir",
+			`{"volumes":[{"name":"data","csi":{"driver":"secrets-store.csi.k8s.io"}}],"containers":[{"name":"login"}]}`:                    "volumes[data]: only emptyDir",
+			`{"volumes":[{"name":"data","ephemeral":{}}],"containers":[{"name":"login"}]}`:                                                 "volumes[data]: only emptyDir",
+			`{"volumes":[{"name":"data","configMap":{"name":"tenant-config"}}],"containers":[{"name":"login"}]}`:                           `volumes[data]: ConfigMap "tenant-config" is not the synced target`,
+			`{"volumes":[{"name":"data","projected":{"sources":[{"clusterTrustBundle":{"path":"ca"}}]}}],"containers":[{"name":"login"}]}`: "volumes[data]: only Secret, ConfigMap and downwardAPI projections",
+			`{"containers":[{"name":"login","env":[{"name":"A","valueFrom":{"configMapKeyRef":{"name":"tenant-config","key":"a"}}}]}]}`:    `containers[login]: ConfigMap "tenant-config" is not the synced target`,
+		} {
+			errs := verify(pod)
+			Expect(errs).To(HaveLen(1), pod)
+			Expect(errs[0].Detail).To(ContainSubstring(message), pod)
+		}
+
+		By("rejecting containers that escalate or run as root")
+		for pod, message := range map[string]string{
+			`{"containers":[{"name":"login","securityContext":{"allowPrivilegeEscalation":true}}]}`:                                          "containers[login]: securityContext.allowPrivilegeEscalation is not allowed",
+			`{"containers":[{"name":"login","securityContext":{"capabilities":{"add":["NET_ADMIN"]}}}]}`:                                     "containers[login]: securityContext.capabilities.add is not allowed",
+			`{"containers":[{"name":"login","securityContext":{"runAsNonRoot":false}}]}`:                                                     "containers[login]: securityContext.runAsNonRoot: hook pods run as non-root",
+			`{"containers":[{"name":"login","securityContext":{"runAsUser":0}}]}`:                                                            "containers[login]: securityContext.runAsUser: hook pods run as non-root",
+			`{"securityContext":{"runAsNonRoot":false},"containers":[{"name":"login"}]}`:                                                     "securityContext.runAsNonRoot: hook pods run as non-root",
+			`{"initContainers":[{"name":"setup","securityContext":{"capabilities":{"add":["SYS_ADMIN"]}}}],"containers":[{"name":"login"}]}`: "containers[setup]: securityContext.capabilities.add is not allowed",
+		} {
+			errs := verify(pod)
+			Expect(errs).To(HaveLen(1), pod)
+			Expect(errs[0].Detail).To(ContainSubstring(message), pod)
+		}
+
+		By("admitting only the pod and container fields a check needs")
+		Expect(verify(`{"restartPolicy":"OnFailure","terminationGracePeriodSeconds":5,` +
+			`"securityContext":{"runAsUser":999,"fsGroup":999,"seccompProfile":{"type":"RuntimeDefault"}},` +
+			`"containers":[{"name":"login","image":"postgres","command":["true"],"resources":{"limits":{"cpu":"100m"}},` +
+			`"securityContext":{"readOnlyRootFilesystem":true,"capabilities":{"drop":["ALL"]}}}]}`)).To(BeEmpty())
+		for pod, message := range map[string]string{
+			`{"nodeName":"control-plane-1","containers":[{"name":"login"}]}`:                                                  "spec.template.spec.nodeName is not allowed",
+			`{"nodeSelector":{"node-role.kubernetes.io/control-plane":""},"containers":[{"name":"login"}]}`:                   "spec.template.spec.nodeSelector is not allowed",
+			`{"tolerations":[{"operator":"Exists"}],"containers":[{"name":"login"}]}`:                                         "spec.template.spec.tolerations is not allowed",
+			`{"shareProcessNamespace":true,"containers":[{"name":"login"}]}`:                                                  "spec.template.spec.shareProcessNamespace is not allowed",
+			`{"ephemeralContainers":[{"name":"debug"}],"containers":[{"name":"login"}]}`:                                      "spec.template.spec.ephemeralContainers is not allowed",
+			`{"securityContext":{"sysctls":[{"name":"kernel.shm_rmid_forced","value":"0"}]},"containers":[{"name":"login"}]}`: "spec.template.spec.securityContext.sysctls is not allowed",
+			`{"securityContext":{"seccompProfile":{"type":"Unconfined"}},"containers":[{"name":"login"}]}`:                    "spec.template.spec.securityContext.seccompProfile: only RuntimeDefault and Localhost",
+			`{"containers":[{"name":"login","ports":[{"containerPort":80,"hostPort":80}]}]}`:                                  "spec.template.spec.containers[login]: ports is not allowed",
+			`{"containers":[{"name":"login","securityContext":{"procMount":"Unmasked"}}]}`:                                    "spec.template.spec.containers[login]: securityContext.procMount is not allowed",
+			`{"containers":[{"name":"login","securityContext":{"appArmorProfile":{"type":"Unconfined"}}}]}`:                   "spec.template.spec.containers[login]: securityContext.appArmorProfile is not allowed",
+			`{"containers":[{"name":"login","securityContext":{"seccompProfile":{"type":"Unconfined"}}}]}`:                    "spec.template.spec.containers[login]: securityContext.seccompProfile: only RuntimeDefault",
+		} {
+			errs := verify(pod)
+			Expect(errs).To(HaveLen(1), pod)
+			Expect(errs[0].Detail).To(ContainSubstring(message), pod)
+		}
+
+		By("letting the pod of a ConfigMap target read that ConfigMap only")
+		sr.Spec.Source.Kind = "ConfigMap"
+		Expect(verify(`{"volumes":[{"name":"target","configMap":{"name":"{{ .Target.Name }}"}}],` +
+			`"containers":[{"name":"login","image":"postgres"}]}`)).To(BeEmpty())
+		Expect(verify(`{"containers":[{"name":"login","envFrom":[{"secretRef":{"name":"{{ .Target.Name }}"}}]}]}`)).
+			To(HaveLen(1))
+	})
+})
@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/certs"
+)
+
+var _ = Describe("Webhook Certificates", func() {
+	ctx := context.Background()
+
+	It("should issue, inject and rotate the webhook certificate", func() {
+		suffix := time.Now().UnixNano() % 100000
+		nsName := fmt.Sprintf("webhook-certs-%d", suffix)
+
+		// Create namespace
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, ns) }()
+
+		// A webhook configuration as installed by the manifests, without a CA yet.
+		// Its rules match nothing, so it never intercepts other tests.
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook-certs-" + fmt.Sprint(suffix)},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name: "vsharedresource.platform.dev",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{Namespace: nsName, Name: "webhook-service", Path: ptr.To("/validate")},
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups: []string{"example.com"}, APIVersions: []string{"v1"}, Resources: []string{"widgets"},
+					},
+				}},
+				FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+				SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+				AdmissionReviewVersions: []string{"v1"},
+			}},
+		}
+		Expect(k8sClient.Create(ctx, vwc)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, vwc) }()
+
+		rotator := &WebhookCertRotator{
+			Client:             k8sClient,
+			Reader:             k8sClient,
+			Namespace:          nsName,
+			SecretName:         "webhook-server-cert",
+			ServiceName:        "webhook-service",
+			ValidatingWebhooks: []string{vwc.Name, "not-installed"},
+			FailurePolicy:      admissionregistrationv1.Fail,
+		}
+		Expect(rotator.ReadyCheck(nil)).NotTo(Succeed())
+
+		// First run issues a certificate for the Service and injects its CA
+		wait, err := rotator.Rotate(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait).To(BeNumerically(">", 300*24*time.Hour))
+		Expect(rotator.ReadyCheck(nil)).To(Succeed())
+		served, err := rotator.GetCertificate(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(served.Leaf.DNSNames).To(ContainElement("webhook-service." + nsName + ".svc"))
+
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{Name: "webhook-server-cert", Namespace: nsName}
+		Expect(k8sClient.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+		firstCA := secret.Data[webhookCACertKey]
+
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: vwc.Name}, vwc)).To(Succeed())
+		Expect(vwc.Webhooks[0].ClientConfig.CABundle).To(Equal(firstCA))
+		Expect(*vwc.Webhooks[0].FailurePolicy).To(Equal(admissionregistrationv1.Fail))
+
+		// A valid certificate is left alone
+		version := secret.ResourceVersion
+		_, err = rotator.Rotate(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.ResourceVersion).To(Equal(version))
+
+		// Within RotateBefore of expiry, both certificates are replaced and the
+		// old CA stays trusted alongside the new one
+		rotator.RotateBefore = 2 * DefaultWebhookCertValidity
+		_, err = rotator.Rotate(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, secretKey, secret)).To(Succeed())
+		Expect(secret.Data[webhookCACertKey]).NotTo(Equal(firstCA))
+		Expect(secret.Data[webhookPreviousCAKey]).To(Equal(firstCA))
+
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: vwc.Name}, vwc)).To(Succeed())
+		Expect(vwc.Webhooks[0].ClientConfig.CABundle).To(Equal(certs.Bundle(time.Now(), secret.Data[webhookCACertKey], firstCA)))
+
+		rotated, err := rotator.GetCertificate(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotated.Leaf.SerialNumber).NotTo(Equal(served.Leaf.SerialNumber))
+	})
+})
@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// mutatingReader stands in for a mutating admission webhook: Secrets read
+// through it gain a key, as if it had been injected on write.
+type mutatingReader struct {
+	client.Reader
+	mutate atomic.Bool
+}
+
+func (m *mutatingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := m.Reader.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if secret, ok := obj.(*corev1.Secret); ok && m.mutate.Load() {
+		secret.Data["injected"] = []byte("by-webhook")
+	}
+	return nil
+}
+
+var _ = Describe("Write Verification", func() {
+	ctx := context.Background()
+
+	It("should fail a target whose data changed on write", func() {
+		targetNSName := fmt.Sprintf("verify-tgt-%d", time.Now().UnixNano()%100000)
+		targetNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: targetNSName}}
+		Expect(k8sClient.Create(ctx, targetNS)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, targetNS) }()
+
+		// The SharedResource is never created, so only this reconciler touches the target
+		sr := &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "sync-verify", Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:     platformv1alpha1.SourceSpec{Kind: "Secret", Name: "verify-secret"},
+				SyncPolicy: &platformv1alpha1.SyncPolicySpec{VerifyWrites: true},
+			},
+		}
+		reader := &mutatingReader{Reader: k8sClient}
+		reconciler := &SharedResourceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(),
+			SourceOptions: SourceOptions{APIReader: reader}}
+		targetKey := types.NamespacedName{Name: "verify-secret", Namespace: targetNSName}
+		sync := func(value string) error {
+			GinkgoHelper()
+			data := map[string][]byte{"key": []byte(value)}
+			_, _, err := reconciler.syncToTarget(ctx, sr, targetNSName, targetKey.Name,
+				platformv1alpha1.DeletionPolicyOrphan, data, sourceMeta{}, syncengine.Checksum(data), false)
+			return err
+		}
+
+		By("confirming a write that was stored as sent")
+		Expect(sync("v1")).To(Succeed())
+
+		By("failing a write that was altered")
+		reader.mutate.Store(true)
+		Expect(sync("v2")).To(MatchError(errMutatedByAdmission))
+		// What the webhook would have stored
+		target := &corev1.Secret{}
+		Expect(reader.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(k8sClient.Update(ctx, target)).To(Succeed())
+
+		By("not rewriting the altered target with the same data")
+		Expect(sync("v2")).To(MatchError(ContainSubstring("not rewritten")))
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(HaveKey("injected"))
+
+		By("trying again on a sync request")
+		sr.Annotations = map[string]string{AnnotationSyncNow: "1"}
+		Expect(sync("v2")).To(MatchError(ContainSubstring("checksum")))
+		sr.Annotations = nil
+
+		By("reporting the altered targets in a condition")
+		statuses := []platformv1alpha1.TargetSyncStatus{
+			{Namespace: targetNSName, Name: targetKey.Name, MutatedByAdmission: true},
+			{Namespace: "other", Name: targetKey.Name},
+		}
+		applyMutatedCondition(sr, statuses)
+		Expect(meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeMutatedByAdmission)).To(
+			HaveField("Message", ContainSubstring(targetNSName+"/"+targetKey.Name)))
+		applyMutatedCondition(sr, statuses[1:])
+		Expect(meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeMutatedByAdmission)).To(BeNil())
+
+		By("writing new data again")
+		reader.mutate.Store(false)
+		Expect(sync("v3")).To(Succeed())
+		Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+		Expect(target.Data).To(Equal(map[string][]byte{"key": []byte("v3")}))
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Source name templates (spec.source.nameTemplate).
+//
+// Tooling generating hundreds of CRs can derive the source name from the CR
+// instead of repeating it, e.g. "{{ .Name }}-config" for "the source is
+// always <cr-name>-config". The template only sees the CR's name and
+// namespace, so a CR's source never moves unless its spec changes.
+//
+// Everything naming the source (fetching it, watches, policies, target names
+// and annotations) goes through sourceName. A template that does not render
+// a valid name is rejected by the webhook, and otherwise reported with
+// SourceFound=False; nothing is synced until it is fixed.
+// =============================================================================
+
+// sourceName returns the name of the CR's source: spec.source.name, or what
+// spec.source.nameTemplate renders. It is "" for a template that fails to
+// render, which Reconcile reports before anything uses it.
+func sourceName(sr *platformv1alpha1.SharedResource) string {
+	name, _ := ResolveSourceName(sr)
+	return name
+}
+
+// ResolveSourceName returns the name of the CR's source, rendering
+// spec.source.nameTemplate if it is set.
+func ResolveSourceName(sr *platformv1alpha1.SharedResource) (string, error) {
+	if sr.Spec.Source.NameTemplate == "" {
+		return sr.Spec.Source.Name, nil
+	}
+	return syncengine.RenderName(sr.Spec.Source.NameTemplate,
+		syncengine.NameContext{Name: sr.Name, Namespace: sr.Namespace})
+}
+
+// SourceNameErrors returns an error if spec.source.nameTemplate does not
+// render a valid name.
+func SourceNameErrors(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	if _, err := ResolveSourceName(sr); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "source", "nameTemplate"),
+			sr.Spec.Source.NameTemplate, err.Error())}
+	}
+	return nil
+}
+
+// recordInvalidSourceName reports a nameTemplate that does not render. The
+// CR is not requeued; fixing the template reconciles it again.
+func (r *SharedResourceReconciler) recordInvalidSourceName(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	nameErr error,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	before := sr.Status.DeepCopy()
+	message := "Invalid source.nameTemplate: " + nameErr.Error()
+	setCondition(sr, ConditionTypeSourceFound, metav1.ConditionFalse, "InvalidNameTemplate", message)
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "InvalidNameTemplate", message)
+	sr.Status.ObservedGeneration = sr.Generation
+	r.explainIfRequested(sr, message, "No target is written until the template renders a valid name")
+
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Source name template does not render, not syncing targets", "error", nameErr.Error())
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
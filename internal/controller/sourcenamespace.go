@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Sources in other namespaces (spec.source.namespace).
+//
+// A SharedResource normally shares a source from its own namespace. With
+// spec.source.namespace it may read one from another namespace instead, but
+// only with that namespace's consent: the source, or its namespace, must
+// carry AnnotationAllowExport set to "true", or a SharedResourceExport there
+// must offer the source to the namespaces of the SharedResourceImport that
+// keeps the CR (see exports.go). Consent is
+// checked before every sync; without it the CR reports SourceExportDenied
+// and nothing is synced.
+// Targets already written are left in place, no longer refreshed. A missing
+// source without consent is reported the same way, so CRs elsewhere cannot
+// probe which sources exist.
+//
+// The source namespace owns the source, so its SharedResourcePolicies apply,
+// and spec.generate, which writes to the source, needs the source in the CR's
+// own namespace. Targets still name the CR in AnnotationSourceNamespace and
+// AnnotationSourceCR; their AnnotationOrigin and provenance name the source.
+// =============================================================================
+
+// sourceNamespaceIndex indexes SharedResources by a source namespace other
+// than their own, so source and policy changes find the CRs reading them.
+const sourceNamespaceIndex = "spec.source.namespace"
+
+// sourceNamespace returns the namespace of the CR's source.
+func sourceNamespace(sr *platformv1alpha1.SharedResource) string {
+	if sr.Spec.Source.Namespace != "" {
+		return sr.Spec.Source.Namespace
+	}
+	return sr.Namespace
+}
+
+// exportsSource returns true if the CR reads its source from another namespace.
+func exportsSource(sr *platformv1alpha1.SharedResource) bool {
+	return sourceNamespace(sr) != sr.Namespace
+}
+
+// indexSourceNamespace returns the source namespace of a SharedResource
+// reading from another namespace.
+func indexSourceNamespace(obj client.Object) []string {
+	sr, ok := obj.(*platformv1alpha1.SharedResource)
+	if !ok || !exportsSource(sr) {
+		return nil
+	}
+	return []string{sourceNamespace(sr)}
+}
+
+// SourceNamespaceErrors returns an error if spec.generate is set for a
+// source in another namespace.
+func SourceNamespaceErrors(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	if exportsSource(sr) && len(sr.Spec.Generate) > 0 {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "generate"),
+			"generated keys need the source in the SharedResource's namespace")}
+	}
+	return nil
+}
+
+// sourceExportDenied returns why the CR may not read its source, or "" if
+// it may.
+func (r *SharedResourceReconciler) sourceExportDenied(ctx context.Context, sr *platformv1alpha1.SharedResource) (string, error) {
+	if !exportsSource(sr) {
+		return "", nil
+	}
+	namespace := sourceNamespace(sr)
+	if len(sr.Spec.Generate) > 0 {
+		return fmt.Sprintf("spec.generate would write to %s %s/%s; generated keys need the source in this namespace",
+			sr.Spec.Source.Kind, namespace, sourceName(sr)), nil
+	}
+
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+	if ns.Annotations[AnnotationAllowExport] == "true" {
+		return "", nil
+	}
+	source, err := newTargetObject(sr.Spec.Source.Kind)
+	if err != nil {
+		return "", err
+	}
+	err = r.sourceReader(sr).Get(ctx, types.NamespacedName{Namespace: namespace, Name: sourceName(sr)}, source)
+	if client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+	if err == nil && source.GetAnnotations()[AnnotationAllowExport] == "true" {
+		return "", nil
+	}
+	// An Export admitting the CR's namespace consents too (see exports.go)
+	if exported, err := r.sourceExported(ctx, sr); err != nil || exported {
+		return "", err
+	}
+	return fmt.Sprintf("Neither %s %s/%s nor namespace %s allows the export (%s: \"true\"), "+
+		"and no SharedResourceExport there admits this SharedResourceImport's namespaces",
+		sr.Spec.Source.Kind, namespace, sourceName(sr), namespace, AnnotationAllowExport), nil
+}
+
+// recordSourceExportDenied reports a source that may not be read and leaves
+// the targets alone. The CR is synced again once the source or its
+// namespace consents.
+func (r *SharedResourceReconciler) recordSourceExportDenied(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	message string,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	// The sync after consent is given must not be skipped
+	r.verified.forget(types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name})
+
+	before := sr.Status.DeepCopy()
+	if c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeSourceExportDenied); c == nil || c.Message != message {
+		r.recordEvent(sr, corev1.EventTypeWarning, "SourceExportDenied", "%s", message)
+	}
+	setCondition(sr, ConditionTypeSourceExportDenied, metav1.ConditionTrue, "NoExportConsent", message)
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "SourceExportDenied", "Not synced: "+message)
+	sr.Status.ObservedGeneration = sr.Generation
+	sr.Status.AllTargetsAtChecksum = false
+	r.explainIfRequested(sr, message, "No target is written until the source or its namespace allows the export")
+
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Source export not allowed, not syncing targets", "source", sourceNamespace(sr)+"/"+sourceName(sr))
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update source export status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// clearSourceExportDenied drops the SourceExportDenied condition once the
+// source may be read. The status is written with the sync that follows.
+func clearSourceExportDenied(sr *platformv1alpha1.SharedResource) {
+	meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeSourceExportDenied)
+}
+
+// exportConsentChanged returns true if a namespace update changed its
+// AnnotationAllowExport.
+func exportConsentChanged(oldObj, newObj client.Object) bool {
+	return oldObj.GetAnnotations()[AnnotationAllowExport] != newObj.GetAnnotations()[AnnotationAllowExport]
+}
+
+// exportsFrom returns true if the CR reads its source from the namespace,
+// which is not its own.
+func exportsFrom(sr *platformv1alpha1.SharedResource, namespace string) bool {
+	return exportsSource(sr) && sourceNamespace(sr) == namespace
+}
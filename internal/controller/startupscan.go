@@ -0,0 +1,221 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Startup consistency scan - converge the whole estate after downtime.
+//
+// An operator that was down misses every event in between. Once per leader
+// election, the scan lists every target carrying the managed-by label and:
+//  1. Enqueues each owning SharedResource past the skip gate, in a fixed
+//     order, so drift is repaired without waiting for events
+//  2. Reports orphans: targets whose SharedResource is gone, and targets the
+//     SharedResource no longer lists. Orphans are left in place.
+//
+// Targets of deleted deleteBackground CRs are not reported; the sweeper will
+// remove them. Targets written before the label existed are found once their
+// owner has synced them again.
+// =============================================================================
+
+// Orphan reasons, used as the "reason" label of orphanedTargets.
+const (
+	// OrphanReasonDeleted means the owning SharedResource no longer exists
+	OrphanReasonDeleted = "sharedResourceDeleted"
+
+	// OrphanReasonNotATarget means the owning SharedResource no longer lists the target
+	OrphanReasonNotATarget = "notATarget"
+)
+
+// orphanedTarget is a managed target no SharedResource accounts for.
+type orphanedTarget struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Owner     client.ObjectKey
+	Reason    string
+}
+
+// scanResult is the outcome of one consistency scan.
+type scanResult struct {
+	// scanned is the number of managed targets found
+	scanned int
+
+	// owners are the existing SharedResources owning at least one target, sorted
+	owners []client.ObjectKey
+
+	// orphans are sorted by kind, namespace and name
+	orphans []orphanedTarget
+}
+
+// consistencyScanner runs the scan once and enqueues the owners through the
+// controller's channel source. Implements manager.Runnable.
+type consistencyScanner struct {
+	r      *SharedResourceReconciler
+	events chan<- event.GenericEvent
+}
+
+// Start runs the scan once.
+func (s *consistencyScanner) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("startup-scan")
+	ctx = logf.IntoContext(ctx, log)
+
+	// A failed scan must not take the operator down; events and resyncs still converge
+	result, err := s.r.scanManagedTargets(ctx)
+	if err != nil {
+		log.Error(err, "Startup consistency scan failed")
+		return nil
+	}
+	for _, key := range result.owners {
+		s.r.verified.invalidate(key)
+		sr := &platformv1alpha1.SharedResource{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+		select {
+		case s.events <- event.GenericEvent{Object: sr}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	log.Info("Startup consistency scan complete",
+		"targets", result.scanned, "sharedResources", len(result.owners), "orphans", len(result.orphans))
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader scans.
+func (s *consistencyScanner) NeedLeaderElection() bool {
+	return true
+}
+
+// scanManagedTargets lists labelled targets, resolves their owners and reports orphans.
+func (r *SharedResourceReconciler) scanManagedTargets(ctx context.Context) (*scanResult, error) {
+	log := logf.FromContext(ctx)
+	result := &scanResult{}
+	owners := map[client.ObjectKey]*platformv1alpha1.SharedResource{}
+
+	for _, kind := range r.enabledKinds() {
+		var list client.ObjectList = &corev1.SecretList{}
+		if kind == KindConfigMap {
+			list = &corev1.ConfigMapList{}
+		}
+		if err := r.List(ctx, list, client.MatchingLabels{LabelManagedBy: ManagedByValue}); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			a := obj.GetAnnotations()
+			if a[AnnotationManagedBy] != ManagedByValue || a[AnnotationSourceCR] == "" {
+				continue
+			}
+			result.scanned++
+
+			key := client.ObjectKey{Namespace: a[AnnotationSourceNamespace], Name: a[AnnotationSourceCR]}
+			sr, seen := owners[key]
+			if !seen {
+				sr = &platformv1alpha1.SharedResource{}
+				if err := r.Get(ctx, key, sr); err != nil {
+					if !apierrors.IsNotFound(err) {
+						return nil, err
+					}
+					sr = nil
+				}
+				owners[key] = sr
+			}
+
+			orphan := orphanedTarget{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Owner: key}
+			switch {
+			case sr == nil && a[AnnotationDeletionPolicy] == string(platformv1alpha1.DeletionPolicyDeleteBackground):
+				continue // The sweeper removes it
+			case sr == nil:
+				orphan.Reason = OrphanReasonDeleted
+			case !r.listsTarget(ctx, sr, kind, obj.GetNamespace(), obj.GetName()):
+				orphan.Reason = OrphanReasonNotATarget
+				r.recordEvent(sr, corev1.EventTypeWarning, "OrphanedTarget",
+					"%s %s/%s is no longer a target and was left in place", kind, obj.GetNamespace(), obj.GetName())
+			default:
+				continue
+			}
+			log.Info("Found orphaned target", "kind", kind, "namespace", orphan.Namespace, "name", orphan.Name,
+				"sharedresource", key, "reason", orphan.Reason)
+			result.orphans = append(result.orphans, orphan)
+		}
+	}
+
+	for key, sr := range owners {
+		if sr != nil {
+			result.owners = append(result.owners, key)
+		}
+	}
+	sort.Slice(result.owners, func(i, j int) bool {
+		return result.owners[i].String() < result.owners[j].String()
+	})
+	sort.Slice(result.orphans, func(i, j int) bool {
+		a, b := result.orphans[i], result.orphans[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return targetKey(a.Namespace, a.Name) < targetKey(b.Namespace, b.Name)
+	})
+
+	orphanedTargets.Reset()
+	for _, o := range result.orphans {
+		orphanedTargets.WithLabelValues(o.Kind, o.Reason).Inc()
+	}
+	return result, nil
+}
+
+// listsTarget returns true if the SharedResource writes a target of this kind,
+// namespace and name.
+func (r *SharedResourceReconciler) listsTarget(ctx context.Context, sr *platformv1alpha1.SharedResource, kind, namespace, name string) bool {
+	if targetKind(sr) != kind {
+		return false
+	}
+	targets, err := r.knownTargets(ctx, sr)
+	if err != nil {
+		// Not reported as an orphan on doubt
+		return true
+	}
+	for _, target := range targets {
+		targetName := target.Name
+		if targetName == "" {
+			targetName = sourceName(sr)
+		}
+		if target.Namespace == namespace && targetName == name {
+			return true
+		}
+	}
+	return false
+}
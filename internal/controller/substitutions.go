@@ -0,0 +1,52 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Substitutions - per-environment token replacement (spec.substitutions).
+//
+// Each target gets the replacements of the value its namespace has for
+// spec.substitutions.namespaceLabel, or the default ones. They apply to every
+// value after splitting and before templates are rendered, so tokens such as
+// {{ENV}} never reach the template engine. Relabelling a namespace re-syncs
+// its targets through the Namespace watch.
+// =============================================================================
+
+// substitutionsFor returns the replacements for targets in a namespace, or
+// nil if there are none.
+func (r *SharedResourceReconciler) substitutionsFor(ctx context.Context, sr *platformv1alpha1.SharedResource, namespace string) (map[string]string, error) {
+	spec := sr.Spec.Substitutions
+	if spec == nil {
+		return nil, nil
+	}
+	nsLabels, err := r.targetNamespaceLabels(ctx, sr, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := nsLabels[spec.NamespaceLabel]; ok {
+		if replacements, ok := spec.Values[value]; ok {
+			return replacements, nil
+		}
+	}
+	return spec.Default, nil
+}
@@ -21,10 +21,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,6 +50,10 @@ var (
 	k8sClient client.Client
 )
 
+// testExemptAnnotation is the policy-exemption annotation the suite's
+// reconciler stamps on every target.
+const testExemptAnnotation = "policies.example.com/exempt"
+
 func TestControllers(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -63,6 +69,9 @@ var _ = BeforeSuite(func() {
 	err = platformv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = apiextensionsv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	// +kubebuilder:scaffold:scheme
 
 	By("bootstrapping test environment")
@@ -93,8 +102,12 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 
 	err = (&SharedResourceReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:         k8sManager.GetClient(),
+		Scheme:         k8sManager.GetScheme(),
+		CleanupOptions: CleanupOptions{SweepInterval: time.Second, StartupScan: true},
+		Recorder:       k8sManager.GetEventRecorderFor("sharedresource-controller"),
+		// Mirrors --target-annotation, as used for policy-engine exemptions
+		TargetOptions: TargetOptions{TargetAnnotations: map[string]string{testExemptAnnotation: "true"}},
 	}).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Suspension - spec.suspend.
+//
+// A suspended CR still reads its source, so status can tell whoever resumes it
+// what to expect:
+//   - suspendedSince: when the controller first saw the suspension
+//   - skippedSyncs: source changes that were not propagated
+//   - pendingSourceChecksum: the data resuming would write, or empty if the
+//     targets are still current and resuming writes nothing
+//
+// Nothing is written to targets or the source (no generation or rotation).
+// Deletion is not affected.
+//
+// With spec.requireResumeApproval, turning suspend off is not enough if the
+// source changed in the meantime: the CR stays held until the approve-checksum
+// annotation names the pending checksum. Approving a checksum approves exactly
+// that data; if the source changes again, the new checksum needs approval.
+// =============================================================================
+
+// suspensionHeld returns true if the CR is suspended, or was and may still be
+// held for resume approval once the source checksum is known.
+func suspensionHeld(sr *platformv1alpha1.SharedResource) bool {
+	return sr.Spec.Suspend || (sr.Spec.RequireResumeApproval && sr.Status.SuspendedSince != nil)
+}
+
+// resumeAwaitsApproval returns true if a resumed CR must stay held: its
+// targets are not at checksum and nobody has approved propagating it.
+func resumeAwaitsApproval(sr *platformv1alpha1.SharedResource, checksum string) bool {
+	return sr.Spec.RequireResumeApproval && !syncengine.ChecksumEqual(checksum, sr.Status.SourceChecksum) &&
+		!syncengine.ChecksumEqual(sr.Annotations[AnnotationApproveChecksum], checksum)
+}
+
+// recordSuspended updates the suspension status for the freshly computed
+// source checksum, and writes it if anything changed.
+func (r *SharedResourceReconciler) recordSuspended(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	checksum string,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	before := sr.Status.DeepCopy()
+	if sr.Status.SuspendedSince == nil {
+		now := metav1.NewTime(r.now())
+		sr.Status.SuspendedSince = &now
+		r.recordEvent(sr, corev1.EventTypeNormal, "Suspended", "Target syncs suspended")
+	}
+
+	// Count each distinct source state not propagated; a source changed back
+	// to what the targets hold leaves nothing pending
+	switch {
+	case syncengine.ChecksumEqual(checksum, sr.Status.SourceChecksum):
+		sr.Status.PendingSourceChecksum = ""
+	case syncengine.ChecksumEqual(checksum, sr.Status.PendingSourceChecksum):
+	default:
+		sr.Status.SkippedSyncs++
+		sr.Status.PendingSourceChecksum = checksum
+	}
+	sr.Status.ObservedGeneration = sr.Generation
+
+	since := sr.Status.SuspendedSince.UTC().Format(time.RFC3339)
+	reason := "Suspended"
+	message := fmt.Sprintf("Suspended since %s; targets hold the current source data", since)
+	switch {
+	case !sr.Spec.Suspend:
+		reason = "AwaitingResumeApproval"
+		message = fmt.Sprintf("Resume held: the source changed while suspended (%d skipped sync(s)); "+
+			"set annotation %s=%s to propagate it", sr.Status.SkippedSyncs, AnnotationApproveChecksum, checksum)
+		if c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeSuspended); c == nil || c.Reason != reason {
+			r.recordEvent(sr, corev1.EventTypeNormal, reason, "%s", message)
+		}
+	case sr.Status.PendingSourceChecksum != "":
+		message = fmt.Sprintf("Suspended since %s; the source changed (%d skipped sync(s)), resuming propagates it",
+			since, sr.Status.SkippedSyncs)
+		if sr.Spec.RequireResumeApproval {
+			message += " once approved"
+		}
+	}
+	setCondition(sr, ConditionTypeSuspended, metav1.ConditionTrue, reason, message)
+	r.explainIfRequested(sr, message, "No target is written while suspended")
+
+	if equality.Semantic.DeepEqual(before, &sr.Status) {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Suspended, not syncing targets", "skippedSyncs", sr.Status.SkippedSyncs,
+		"pendingSourceChecksum", sr.Status.PendingSourceChecksum)
+	if err := r.Status().Update(ctx, sr); err != nil {
+		log.Error(err, "Failed to update suspension status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// clearSuspended drops the suspension status of a resumed CR. The status is
+// written with the sync that follows.
+func (r *SharedResourceReconciler) clearSuspended(sr *platformv1alpha1.SharedResource) {
+	if sr.Status.SuspendedSince == nil {
+		return
+	}
+	r.recordEvent(sr, corev1.EventTypeNormal, "Resumed", "Resumed after %s; %d skipped sync(s)",
+		r.now().Sub(sr.Status.SuspendedSince.Time).Round(time.Second), sr.Status.SkippedSyncs)
+	sr.Status.SuspendedSince = nil
+	sr.Status.SkippedSyncs = 0
+	sr.Status.PendingSourceChecksum = ""
+	meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeSuspended)
+}
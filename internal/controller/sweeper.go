@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Target sweeper - deferred cleanup for deletionPolicy "deleteBackground".
+//
+// A deleteBackground CR drops its finalizer without touching its targets, so
+// deleting it is instant. Every target is stamped with the deletion policy in
+// effect, and the sweeper periodically removes managed targets that:
+//   - carry deletion-policy "deleteBackground"
+//   - point at a SharedResource that no longer exists
+//
+// Access grants are removed along with the data. ServiceAccount links are not:
+// the CR's spec.access is gone, so the sweeper cannot know which to undo.
+//
+// With a SweepObservationPeriod, candidates are held and reported before they
+// are deleted (see sweepreport.go).
+// =============================================================================
+
+// targetSweeper runs sweeps on a fixed interval. Implements manager.Runnable.
+type targetSweeper struct {
+	r      *SharedResourceReconciler
+	report sweepReport
+}
+
+// Start sweeps immediately, then every SweepInterval until the context ends.
+func (s *targetSweeper) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("sweeper")
+	ctx = logf.IntoContext(ctx, log)
+
+	interval := s.r.SweepInterval
+	if interval <= 0 {
+		interval = DefaultSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.r.sweep(ctx, &s.report); err != nil {
+			// A failed sweep is retried on the next tick
+			log.Error(err, "Sweep failed")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection ensures only the leader deletes targets.
+func (s *targetSweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// sweep deletes managed targets left behind by deleted deleteBackground CRs,
+// once they have been candidates for the observation period.
+func (r *SharedResourceReconciler) sweep(ctx context.Context, report *sweepReport) error {
+	if err := r.loadSweepReport(ctx, report); err != nil {
+		return err
+	}
+	log := logf.FromContext(ctx)
+	now := r.now()
+	held := map[string]sweepCandidate{}
+	swept := 0
+	for _, kind := range r.enabledKinds() {
+		var list client.ObjectList = &corev1.SecretList{}
+		if kind == KindConfigMap {
+			list = &corev1.ConfigMapList{}
+		}
+		if err := r.List(ctx, list); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			sr, err := r.sweepOwner(ctx, kind, obj)
+			if err != nil {
+				return err
+			}
+			if sr == nil {
+				continue
+			}
+
+			// Candidates are held until observed for the whole period
+			candidate := sweepCandidate{
+				Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName(),
+				SharedResource: sr.Namespace + "/" + sr.Name, FirstSeen: metav1.NewTime(now),
+			}
+			if previous, ok := report.candidates[candidate.key()]; ok {
+				candidate.FirstSeen = previous.FirstSeen
+			}
+			candidate.DeleteAfter = metav1.NewTime(candidate.FirstSeen.Add(r.SweepObservationPeriod))
+			if now.Before(candidate.DeleteAfter.Time) {
+				if _, ok := report.candidates[candidate.key()]; !ok {
+					log.Info("Holding target of deleted SharedResource for observation before sweeping it",
+						"sharedresource", candidate.SharedResource, "kind", kind, "namespace", candidate.Namespace,
+						"name", candidate.Name, "deleteAfter", candidate.DeleteAfter.UTC())
+				}
+				held[candidate.key()] = candidate
+				continue
+			}
+
+			log.Info("Sweeping target of deleted SharedResource",
+				"sharedresource", candidate.SharedResource, "kind", kind, "namespace", candidate.Namespace, "name", candidate.Name)
+			if err := r.deleteTarget(ctx, sr, obj.GetNamespace(), obj.GetName()); err != nil {
+				return err
+			}
+			swept++
+		}
+	}
+	if swept > 0 {
+		log.Info("Swept targets of deleted SharedResources", "count", swept)
+	}
+
+	// Targets accounted for again, or deleted, drop off the report
+	report.candidates = held
+	return r.saveSweepReport(ctx, report)
+}
+
+// sweepOwner returns a stand-in for the SharedResource of a target the
+// sweeper may delete: one whose SharedResource is gone and asked for
+// background deletion. Returns nil for any other target.
+func (r *SharedResourceReconciler) sweepOwner(ctx context.Context, kind string, obj client.Object) (*platformv1alpha1.SharedResource, error) {
+	a := obj.GetAnnotations()
+	if a[AnnotationManagedBy] != ManagedByValue ||
+		a[AnnotationDeletionPolicy] != string(platformv1alpha1.DeletionPolicyDeleteBackground) {
+		return nil, nil
+	}
+
+	key := types.NamespacedName{Namespace: a[AnnotationSourceNamespace], Name: a[AnnotationSourceCR]}
+	err := r.Get(ctx, key, &platformv1alpha1.SharedResource{})
+	if err == nil {
+		return nil, nil // Still exists; it deletes its own targets
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	// The CR is gone: rebuild just enough of it to clean up the target
+	return &platformv1alpha1.SharedResource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: platformv1alpha1.SharedResourceSpec{
+			Source: platformv1alpha1.SourceSpec{Kind: kind, Name: a[AnnotationSourceName]},
+		},
+	}, nil
+}
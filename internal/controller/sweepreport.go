@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// =============================================================================
+// Sweep observation - report before deleting.
+//
+// With a SweepObservationPeriod, a target the sweeper would delete is first
+// only reported, and deleted once it has been a candidate for the whole
+// period. Enabling the sweeper (or an upgrade that finds many candidates at
+// once) therefore deletes nothing right away; a target that merely looks
+// abandoned, e.g. because its SharedResource is being recreated, drops off the
+// list as soon as it is accounted for again.
+//
+// Candidates are listed in the sweepCandidates gauge and, with a report
+// namespace, in the ConfigMap SweepReportName, which also carries their first
+// sighting across restarts and leader changes.
+// =============================================================================
+
+const (
+	// SweepReportName is the ConfigMap listing held sweep candidates,
+	// suffixed with the operator class if one is set
+	SweepReportName = "sharedresource-operator-sweep-report"
+
+	// sweepReportKey is the data key of the candidate list in the report
+	sweepReportKey = "candidates.json"
+)
+
+// sweepCandidate is a target the sweeper would delete, held for observation.
+type sweepCandidate struct {
+	Kind           string      `json:"kind"`
+	Namespace      string      `json:"namespace"`
+	Name           string      `json:"name"`
+	SharedResource string      `json:"sharedResource"`
+	FirstSeen      metav1.Time `json:"firstSeen"`
+	DeleteAfter    metav1.Time `json:"deleteAfter"`
+}
+
+// key identifies the candidate's target.
+func (c sweepCandidate) key() string {
+	return c.Kind + "/" + c.Namespace + "/" + c.Name
+}
+
+// sweepReport carries the held candidates from one sweep to the next.
+type sweepReport struct {
+	loaded     bool
+	candidates map[string]sweepCandidate
+}
+
+// loadSweepReport reads the persisted candidates once, so their first
+// sighting survives restarts. Without a report namespace it starts empty.
+func (r *SharedResourceReconciler) loadSweepReport(ctx context.Context, report *sweepReport) error {
+	if report.loaded {
+		return nil
+	}
+	report.candidates = map[string]sweepCandidate{}
+	if r.SweepReportNamespace != "" {
+		var cm corev1.ConfigMap
+		err := r.Get(ctx, types.NamespacedName{Namespace: r.SweepReportNamespace, Name: r.sweepReportName()}, &cm)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		var candidates []sweepCandidate
+		if value := cm.Data[sweepReportKey]; value != "" {
+			// A damaged report only restarts the observation period
+			_ = json.Unmarshal([]byte(value), &candidates)
+		}
+		for _, c := range candidates {
+			report.candidates[c.key()] = c
+		}
+	}
+	report.loaded = true
+	return nil
+}
+
+// saveSweepReport updates the candidates gauge and the report ConfigMap.
+func (r *SharedResourceReconciler) saveSweepReport(ctx context.Context, report *sweepReport) error {
+	candidates := make([]sweepCandidate, 0, len(report.candidates))
+	for _, c := range report.candidates {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].key() < candidates[j].key() })
+
+	sweepCandidates.Reset()
+	for _, c := range candidates {
+		sweepCandidates.WithLabelValues(c.Kind).Inc()
+	}
+	if r.SweepReportNamespace == "" {
+		return nil
+	}
+
+	value, err := json.MarshalIndent(candidates, "", "  ")
+	if err != nil {
+		return err
+	}
+	var cm corev1.ConfigMap
+	err = r.Get(ctx, types.NamespacedName{Namespace: r.SweepReportNamespace, Name: r.sweepReportName()}, &cm)
+	if apierrors.IsNotFound(err) {
+		if len(candidates) == 0 {
+			return nil
+		}
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: r.SweepReportNamespace, Name: r.sweepReportName()},
+			Data:       map[string]string{sweepReportKey: string(value)},
+		}
+		return r.Create(ctx, &cm)
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data[sweepReportKey] == string(value) {
+		return nil
+	}
+	cm.Data = map[string]string{sweepReportKey: string(value)}
+	return r.Update(ctx, &cm)
+}
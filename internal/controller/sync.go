@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,9 +27,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
 )
 
 // =============================================================================
@@ -38,42 +41,67 @@ import (
 // namespaces, including creation, updates, and deletion.
 // =============================================================================
 
+// sourceMeta carries the source attributes needed when writing targets.
+type sourceMeta struct {
+	// SecretType is the secret type (only for Secrets, e.g., kubernetes.io/tls)
+	SecretType corev1.SecretType
+
+	// UID identifies the exact source object, recorded in target provenance
+	UID types.UID
+
+	// Withheld lists, sorted, the source keys the owner's annotations do not allow to be shared
+	Withheld []string
+
+	// Origin and Hops place a source that is itself a managed target in its
+	// chain of shares; Hops is 0 for other sources (see chain.go)
+	Origin string
+	Hops   int
+}
+
 // fetchSourceResource retrieves the source Secret or ConfigMap.
 //
 // Returns:
-// - data: The key-value data from the source resource
-// - secretType: The secret type (only for Secrets, e.g., kubernetes.io/tls)
+// - data: The key-value data the source owner allows to be shared (see restrictToSharedKeys)
+// - source: The secret type and UID of the source object, and the keys withheld by its owner
 // - error: Any error encountered
 //
-// Note: Source must be in the SAME namespace as the SharedResource CR.
-func (r *SharedResourceReconciler) fetchSourceResource(ctx context.Context, sr *platformv1alpha1.SharedResource) (map[string][]byte, corev1.SecretType, error) {
-	sourceKey := types.NamespacedName{
-		Namespace: sr.Namespace, // Source is in same namespace as CR
-		Name:      sr.Spec.Source.Name,
+// The source is in the CR's namespace unless spec.source.namespace is set
+// (see sourcenamespace.go). The sources of spec.sources are merged on top
+// (see fanin.go).
+func (r *SharedResourceReconciler) fetchSourceResource(ctx context.Context, sr *platformv1alpha1.SharedResource) (map[string][]byte, sourceMeta, error) {
+	data, source, err := r.readSource(ctx, sr, types.NamespacedName{Namespace: sourceNamespace(sr), Name: sourceName(sr)})
+	if err != nil || len(sr.Spec.Sources) == 0 {
+		return data, source, err
 	}
+	return r.mergeSources(ctx, sr, data, source)
+}
 
+// readSource retrieves one source of the CR's source kind.
+func (r *SharedResourceReconciler) readSource(ctx context.Context, sr *platformv1alpha1.SharedResource, sourceKey types.NamespacedName) (map[string][]byte, sourceMeta, error) {
 	switch sr.Spec.Source.Kind {
 	case KindSecret:
 		var secret corev1.Secret
-		if err := r.Get(ctx, sourceKey, &secret); err != nil {
-			return nil, "", err
+		if err := r.sourceReader(sr).Get(ctx, sourceKey, &secret); err != nil {
+			return nil, sourceMeta{}, err
 		}
-		return secret.Data, secret.Type, nil
+		shared := restrictToSharedKeys(secret.Data, secret.Annotations)
+		origin, hops := sourceChain(secret.Annotations)
+		return shared, sourceMeta{SecretType: secret.Type, UID: secret.UID, Withheld: withheldKeys(secret.Data, shared),
+			Origin: origin, Hops: hops}, nil
 
 	case KindConfigMap:
 		var cm corev1.ConfigMap
-		if err := r.Get(ctx, sourceKey, &cm); err != nil {
-			return nil, "", err
+		if err := r.sourceReader(sr).Get(ctx, sourceKey, &cm); err != nil {
+			return nil, sourceMeta{}, err
 		}
 		// Convert string data to []byte for uniform handling
-		data := make(map[string][]byte)
-		for k, v := range cm.Data {
-			data[k] = []byte(v)
-		}
-		return data, "", nil
+		data := syncengine.FromStrings(cm.Data)
+		shared := restrictToSharedKeys(data, cm.Annotations)
+		origin, hops := sourceChain(cm.Annotations)
+		return shared, sourceMeta{UID: cm.UID, Withheld: withheldKeys(data, shared), Origin: origin, Hops: hops}, nil
 
 	default:
-		return nil, "", fmt.Errorf("unsupported source kind: %s", sr.Spec.Source.Kind)
+		return nil, sourceMeta{}, fmt.Errorf("unsupported source kind: %s", sr.Spec.Source.Kind)
 	}
 }
 
@@ -83,43 +111,148 @@ func (r *SharedResourceReconciler) fetchSourceResource(ctx context.Context, sr *
 // 1. Builds the required annotations for tracking
 // 2. Delegates to syncSecret or syncConfigMap based on source kind
 // 3. Uses syncPolicy.mode to determine sync behavior (copy vs merge)
+//
+// Returns true if the target was created or updated, false if it was already up to date.
 func (r *SharedResourceReconciler) syncToTarget(
 	ctx context.Context,
 	sr *platformv1alpha1.SharedResource,
 	targetNamespace string,
 	targetName string,
+	deletion platformv1alpha1.DeletionPolicy,
 	data map[string][]byte,
-	secretType corev1.SecretType,
+	source sourceMeta,
 	checksum string,
-) error {
+	forceApply bool,
+) (bool, syncengine.DataDiff, error) {
 	log := logf.FromContext(ctx)
 
-	// Determine sync mode (default to "copy" for strict behavior)
-	syncMode := "copy"
-	if sr.Spec.SyncPolicy != nil && sr.Spec.SyncPolicy.Mode != "" {
-		syncMode = string(sr.Spec.SyncPolicy.Mode)
-	}
+	syncMode := targetSyncMode(sr)
 
-	// Build annotations for tracking and drift detection
-	annotations := map[string]string{
+	// Build labels and annotations for tracking and drift detection
+	labels, annotations := r.targetMetadata(map[string]string{
 		AnnotationManagedBy:       ManagedByValue,
 		AnnotationSourceNamespace: sr.Namespace,
-		AnnotationSourceName:      sr.Spec.Source.Name,
+		AnnotationSourceName:      sourceName(sr),
 		AnnotationSourceCR:        sr.Name,
 		AnnotationChecksum:        checksum,
-		AnnotationLastSynced:      time.Now().UTC().Format(time.RFC3339),
+		AnnotationProvenance:      r.provenance(sr, source, checksum),
+		AnnotationDeletionPolicy:  string(deletion),
+		AnnotationLastSynced:      r.now().UTC().Format(time.RFC3339),
+	})
+	if version := r.operatorVersion(); version != "" {
+		annotations[AnnotationOperatorVersion] = version
+	}
+	// Targets of a chained source name where the chain started (see chain.go)
+	applyChain(annotations, source)
+	// AnnotationSourceNamespace names the CR's namespace; the origin names another one's source
+	if exportsSource(sr) && annotations[AnnotationOrigin] == "" {
+		annotations[AnnotationOrigin] = sourceNamespace(sr) + "/" + sourceName(sr)
+	}
+	// Large targets keep their checksum and provenance in labels (see tracking.go)
+	if r.compactTracking(data) {
+		applyCompactTracking(labels, annotations, checksum, source.UID)
+	}
+
+	tmpl, errs := ParseTargetTemplate(sr)
+	if len(errs) > 0 {
+		return false, syncengine.DataDiff{}, fmt.Errorf("invalid targetTemplate: %w", errs.ToAggregate())
+	}
+	var immutable *bool
+	if tmpl != nil {
+		applyTargetTemplate(tmpl, labels, annotations)
+		immutable = tmpl.Immutable
 	}
 
 	targetKey := types.NamespacedName{Namespace: targetNamespace, Name: targetName}
 
-	switch sr.Spec.Source.Kind {
+	// A target being deleted is released first and recreated once it is gone
+	if err := r.checkTargetDeletion(ctx, sr, targetKey); err != nil {
+		return false, syncengine.DataDiff{}, err
+	}
+
+	// Rewriting data admission alters again would only fight the webhook
+	kind := targetKind(sr)
+	if err := r.checkMutation(ctx, sr, kind, targetKey, checksum); err != nil {
+		return false, syncengine.DataDiff{}, err
+	}
+
+	finalizer := sr.Spec.TrackTargetDeletion
+	var changed bool
+	var diff syncengine.DataDiff
+	var err error
+	switch kind {
 	case KindSecret:
-		return r.syncSecret(ctx, targetKey, data, secretType, annotations, syncMode, log)
+		secretType := source.SecretType
+		if secretType == "" {
+			secretType = corev1.SecretTypeOpaque // Rendered from a ConfigMap template
+		}
+		if tmpl != nil && tmpl.Type != "" {
+			secretType = tmpl.Type
+		}
+		changed, diff, err = r.syncSecret(ctx, targetKey, data, secretType, labels, annotations, immutable, finalizer, forceApply, syncMode, log)
 	case KindConfigMap:
-		return r.syncConfigMap(ctx, targetKey, data, annotations, syncMode, log)
+		changed, diff, err = r.syncConfigMap(ctx, targetKey, data, labels, annotations, immutable, finalizer, forceApply, syncMode, log)
 	default:
-		return fmt.Errorf("unsupported source kind: %s", sr.Spec.Source.Kind)
+		return false, syncengine.DataDiff{}, fmt.Errorf("unsupported target kind: %s", kind)
+	}
+
+	// A write altered on admission fails the target now (see writeverify.go)
+	if changed && verifyWrites(sr) {
+		if verifyErr := r.verifyWrite(ctx, kind, targetKey, checksum); verifyErr != nil {
+			return changed, diff, verifyErr
+		}
 	}
+	return changed, diff, err
+}
+
+// targetSyncMode returns the CR's sync mode, defaulting to "copy" for strict behavior.
+func targetSyncMode(sr *platformv1alpha1.SharedResource) string {
+	if sr.Spec.SyncPolicy != nil && sr.Spec.SyncPolicy.Mode != "" {
+		return string(sr.Spec.SyncPolicy.Mode)
+	}
+	return "copy"
+}
+
+// splitSpecs returns the CR's syncPolicy.split.
+func splitSpecs(sr *platformv1alpha1.SharedResource) []platformv1alpha1.SplitSpec {
+	if sr.Spec.SyncPolicy == nil {
+		return nil
+	}
+	return sr.Spec.SyncPolicy.Split
+}
+
+// provenanceRecord is the JSON stored in AnnotationProvenance on every target.
+// Field names are part of the contract with scanners; do not rename them.
+type provenanceRecord struct {
+	Kind            string    `json:"kind"`
+	SourceNamespace string    `json:"sourceNamespace"`
+	SourceName      string    `json:"sourceName"`
+	SourceUID       types.UID `json:"sourceUID"`
+	SharedResource  string    `json:"sharedResource"`
+	OperatorVersion string    `json:"operatorVersion,omitempty"`
+	Checksum        string    `json:"checksum"`
+	Origin          string    `json:"origin,omitempty"`
+	Hops            int       `json:"hops,omitempty"`
+}
+
+// provenance builds the provenance annotation value for a target.
+func (r *SharedResourceReconciler) provenance(sr *platformv1alpha1.SharedResource, source sourceMeta, checksum string) string {
+	record, err := json.Marshal(provenanceRecord{
+		Kind:            sr.Spec.Source.Kind,
+		SourceNamespace: sourceNamespace(sr),
+		SourceName:      sourceName(sr),
+		SourceUID:       source.UID,
+		SharedResource:  sr.Name,
+		OperatorVersion: r.OperatorVersion,
+		Checksum:        checksum,
+		Origin:          source.Origin,
+		Hops:            chainHops(source),
+	})
+	if err != nil {
+		// Marshalling a struct of strings cannot fail
+		return ""
+	}
+	return string(record)
 }
 
 // syncSecret creates or updates a Secret in the target namespace.
@@ -132,80 +265,96 @@ func (r *SharedResourceReconciler) syncSecret(
 	targetKey types.NamespacedName,
 	data map[string][]byte,
 	secretType corev1.SecretType,
+	labels map[string]string,
 	annotations map[string]string,
+	immutable *bool,
+	finalizer bool,
+	forceApply bool,
 	syncMode string,
 	log logr.Logger,
-) error {
+) (bool, syncengine.DataDiff, error) {
 	var existing corev1.Secret
 	err := r.Get(ctx, targetKey, &existing)
 
 	if apierrors.IsNotFound(err) {
 		// Create new Secret
+		data, _ = mergeForTarget(nil, nil, data, annotations, syncMode)
 		secret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:        targetKey.Name,
 				Namespace:   targetKey.Namespace,
+				Labels:      labels,
 				Annotations: annotations,
 			},
-			Type: secretType,
-			Data: data,
+			Type:      secretType,
+			Data:      data,
+			Immutable: immutable,
 		}
+		setTargetFinalizer(secret, finalizer)
 		log.Info("Creating target Secret", "namespace", targetKey.Namespace, "name", targetKey.Name)
-		return r.Create(ctx, secret)
+		logDataDiff(log, KindSecret, targetKey, nil, data)
+		if err := r.Create(ctx, secret); err != nil {
+			return false, syncengine.DataDiff{}, err
+		}
+		r.recordWrite(KindSecret, targetKey.Namespace, targetKey.Name, data)
+		r.recordTargetCreated(KindSecret, targetKey.Namespace, targetKey.Name)
+		return true, syncengine.Diff(nil, data), nil
 	} else if err != nil {
-		return err
+		return false, syncengine.DataDiff{}, err
 	}
 
 	// Secret exists - determine what data to use based on sync mode
-	var targetData map[string][]byte
-	if syncMode == "merge" {
-		// Merge mode: Start with existing data, overlay source data
-		targetData = make(map[string][]byte)
-		for k, v := range existing.Data {
-			targetData[k] = v
-		}
-		for k, v := range data {
-			targetData[k] = v
-		}
-	} else {
-		// Copy mode (default): Target = Source exactly
-		targetData = data
-	}
+	base := existing.DeepCopy()
+	targetData, conflicts := mergeForTarget(existing.Data, existing.Annotations, data, annotations, syncMode)
 
 	// Check if update is needed by comparing actual data
-	existingDataChecksum := computeChecksum(existing.Data)
-	newDataChecksum := computeChecksum(targetData)
-
-	// Always update annotations (e.g., last-synced timestamp)
-	annotationsChanged := false
-	if existing.Annotations == nil {
-		existing.Annotations = make(map[string]string)
-		annotationsChanged = true
-	}
-	for k, v := range annotations {
-		if existing.Annotations[k] != v {
-			existing.Annotations[k] = v
-			annotationsChanged = true
-		}
+	sameData := syncengine.SameData(existing.Data, targetData)
+
+	// The last-synced timestamp alone never forces a write
+	annotationsChanged := trackingAnnotationsChanged(existing.Annotations, comparableAnnotations(annotations)) ||
+		trackingAnnotationsChanged(existing.Labels, comparableLabels(labels, annotations)) ||
+		staleTracking(&existing, labels, annotations)
+	finalizerChanged := setTargetFinalizer(&existing, finalizer)
+	immutableChanged, err := immutableUpdate(existing.Immutable, immutable, !sameData)
+	if err != nil {
+		return false, syncengine.DataDiff{}, err
 	}
 
-	if existingDataChecksum == newDataChecksum && !annotationsChanged {
+	if sameData && !annotationsChanged && !finalizerChanged && !immutableChanged {
 		log.Info("Target Secret already up to date", "namespace", targetKey.Namespace, "name", targetKey.Name, "mode", syncMode)
-		return nil
+		return false, syncengine.DataDiff{}, keyConflictError(conflicts)
+	}
+	if adoptable(&existing, sameData && existing.Type == secretType && !immutableChanged, forceApply) {
+		if err := r.adoptTarget(ctx, KindSecret, &existing, base, labels, annotations, targetData, log); err != nil {
+			return false, syncengine.DataDiff{}, err
+		}
+		return true, syncengine.DataDiff{}, keyConflictError(conflicts)
 	}
 
 	// Update existing Secret
+	diff := syncengine.Diff(existing.Data, targetData)
+	logDataDiff(log, KindSecret, targetKey, existing.Data, targetData)
 	existing.Data = targetData
 	existing.Type = secretType
-	if existing.Annotations == nil {
-		existing.Annotations = make(map[string]string)
+	if immutableChanged {
+		existing.Immutable = immutable
+	}
+	existing.Labels = mergeInto(existing.Labels, labels)
+	existing.Annotations = mergeInto(existing.Annotations, annotations)
+	delete(existing.Annotations, AnnotationExpired)
+	dropStaleTracking(&existing, labels, annotations)
+
+	log.Info("Updating target Secret", "namespace", targetKey.Namespace, "name", targetKey.Name, "mode", syncMode, "forceApply", forceApply)
+	if forceApply {
+		err = r.applySecret(ctx, targetKey, targetData, secretType, labels, annotations, existing.Immutable, finalizer)
+	} else {
+		err = r.Update(ctx, &existing)
 	}
-	for k, v := range annotations {
-		existing.Annotations[k] = v
+	if err != nil {
+		return false, syncengine.DataDiff{}, err
 	}
-
-	log.Info("Updating target Secret", "namespace", targetKey.Namespace, "name", targetKey.Name, "mode", syncMode)
-	return r.Update(ctx, &existing)
+	r.recordWrite(KindSecret, targetKey.Namespace, targetKey.Name, targetData)
+	return true, diff, keyConflictError(conflicts)
 }
 
 // syncConfigMap creates or updates a ConfigMap in the target namespace.
@@ -217,143 +366,320 @@ func (r *SharedResourceReconciler) syncConfigMap(
 	ctx context.Context,
 	targetKey types.NamespacedName,
 	data map[string][]byte,
+	labels map[string]string,
 	annotations map[string]string,
+	immutable *bool,
+	finalizer bool,
+	forceApply bool,
 	syncMode string,
 	log logr.Logger,
-) error {
-	// Convert []byte back to string for ConfigMap
-	stringData := make(map[string]string)
-	for k, v := range data {
-		stringData[k] = string(v)
-	}
-
+) (bool, syncengine.DataDiff, error) {
 	var existing corev1.ConfigMap
 	err := r.Get(ctx, targetKey, &existing)
 
 	if apierrors.IsNotFound(err) {
 		// Create new ConfigMap
+		data, _ = mergeForTarget(nil, nil, data, annotations, syncMode)
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:        targetKey.Name,
 				Namespace:   targetKey.Namespace,
+				Labels:      labels,
 				Annotations: annotations,
 			},
-			Data: stringData,
+			Data:      syncengine.ToStrings(data),
+			Immutable: immutable,
 		}
+		setTargetFinalizer(cm, finalizer)
 		log.Info("Creating target ConfigMap", "namespace", targetKey.Namespace, "name", targetKey.Name)
-		return r.Create(ctx, cm)
+		logDataDiff(log, KindConfigMap, targetKey, nil, data)
+		if err := r.Create(ctx, cm); err != nil {
+			return false, syncengine.DataDiff{}, err
+		}
+		r.recordWrite(KindConfigMap, targetKey.Namespace, targetKey.Name, data)
+		r.recordTargetCreated(KindConfigMap, targetKey.Namespace, targetKey.Name)
+		return true, syncengine.Diff(nil, data), nil
 	} else if err != nil {
-		return err
+		return false, syncengine.DataDiff{}, err
 	}
 
 	// ConfigMap exists - determine what data to use based on sync mode
-	var targetData map[string]string
-	if syncMode == "merge" {
-		// Merge mode: Start with existing data, overlay source data
-		targetData = make(map[string]string)
-		for k, v := range existing.Data {
-			targetData[k] = v
-		}
-		for k, v := range stringData {
-			targetData[k] = v
-		}
-	} else {
-		// Copy mode (default): Target = Source exactly
-		targetData = stringData
-	}
+	base := existing.DeepCopy()
+	existingByteData := syncengine.FromStrings(existing.Data)
+	targetByteData, conflicts := mergeForTarget(existingByteData, existing.Annotations, data, annotations, syncMode)
 
 	// Check if update is needed by comparing actual data
-	existingByteData := make(map[string][]byte)
-	for k, v := range existing.Data {
-		existingByteData[k] = []byte(v)
-	}
-	targetByteData := make(map[string][]byte)
-	for k, v := range targetData {
-		targetByteData[k] = []byte(v)
-	}
-	existingDataChecksum := computeChecksum(existingByteData)
-	newDataChecksum := computeChecksum(targetByteData)
-
-	// Always update annotations (e.g., last-synced timestamp)
-	annotationsChanged := false
-	if existing.Annotations == nil {
-		existing.Annotations = make(map[string]string)
-		annotationsChanged = true
-	}
-	for k, v := range annotations {
-		if existing.Annotations[k] != v {
-			existing.Annotations[k] = v
-			annotationsChanged = true
-		}
+	sameData := syncengine.SameData(existingByteData, targetByteData)
+
+	// The last-synced timestamp alone never forces a write
+	annotationsChanged := trackingAnnotationsChanged(existing.Annotations, comparableAnnotations(annotations)) ||
+		trackingAnnotationsChanged(existing.Labels, comparableLabels(labels, annotations)) ||
+		staleTracking(&existing, labels, annotations)
+	finalizerChanged := setTargetFinalizer(&existing, finalizer)
+	immutableChanged, err := immutableUpdate(existing.Immutable, immutable, !sameData)
+	if err != nil {
+		return false, syncengine.DataDiff{}, err
 	}
 
-	if existingDataChecksum == newDataChecksum && !annotationsChanged {
+	if sameData && !annotationsChanged && !finalizerChanged && !immutableChanged {
 		log.Info("Target ConfigMap already up to date", "namespace", targetKey.Namespace, "name", targetKey.Name, "mode", syncMode)
-		return nil
+		return false, syncengine.DataDiff{}, keyConflictError(conflicts)
+	}
+	if adoptable(&existing, sameData && !immutableChanged, forceApply) {
+		if err := r.adoptTarget(ctx, KindConfigMap, &existing, base, labels, annotations, targetByteData, log); err != nil {
+			return false, syncengine.DataDiff{}, err
+		}
+		return true, syncengine.DataDiff{}, keyConflictError(conflicts)
 	}
 
 	// Update existing ConfigMap
-	existing.Data = targetData
-	if existing.Annotations == nil {
-		existing.Annotations = make(map[string]string)
+	existing.Data = syncengine.ToStrings(targetByteData)
+	if immutableChanged {
+		existing.Immutable = immutable
+	}
+	existing.Labels = mergeInto(existing.Labels, labels)
+	existing.Annotations = mergeInto(existing.Annotations, annotations)
+	delete(existing.Annotations, AnnotationExpired)
+	dropStaleTracking(&existing, labels, annotations)
+
+	log.Info("Updating target ConfigMap", "namespace", targetKey.Namespace, "name", targetKey.Name, "mode", syncMode, "forceApply", forceApply)
+	diff := syncengine.Diff(existingByteData, targetByteData)
+	logDataDiff(log, KindConfigMap, targetKey, existingByteData, targetByteData)
+	if forceApply {
+		err = r.applyConfigMap(ctx, targetKey, existing.Data, labels, annotations, existing.Immutable, finalizer)
+	} else {
+		err = r.Update(ctx, &existing)
+	}
+	if err != nil {
+		return false, syncengine.DataDiff{}, err
 	}
-	for k, v := range annotations {
-		existing.Annotations[k] = v
+	r.recordWrite(KindConfigMap, targetKey.Namespace, targetKey.Name, targetByteData)
+	return true, diff, keyConflictError(conflicts)
+}
+
+// adoptable returns true if an existing target not yet managed by the operator
+// already holds the desired data (dataCurrent), so only its metadata needs to
+// change. Force-applied targets take the apply path instead.
+func adoptable(existing client.Object, dataCurrent, forceApply bool) bool {
+	return dataCurrent && !forceApply && existing.GetAnnotations()[AnnotationManagedBy] != ManagedByValue
+}
+
+// adoptTarget takes over an unmanaged target, e.g. a manual copy being
+// migrated, by patching only its labels, annotations and finalizer. Its data is
+// never rewritten, so consumers watching the data do not reload.
+func (r *SharedResourceReconciler) adoptTarget(
+	ctx context.Context,
+	kind string,
+	obj, base client.Object,
+	labels, annotations map[string]string,
+	data map[string][]byte,
+	log logr.Logger,
+) error {
+	obj.SetLabels(mergeInto(obj.GetLabels(), labels))
+	obj.SetAnnotations(mergeInto(obj.GetAnnotations(), annotations))
+	log.Info("Adopting existing target "+kind+" with identical data", "namespace", obj.GetNamespace(), "name", obj.GetName(),
+		"data", syncengine.Redacted(data))
+	if err := r.Patch(ctx, obj, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return err
 	}
+	r.recordWrite(kind, obj.GetNamespace(), obj.GetName(), data)
+	return nil
+}
 
-	log.Info("Updating target ConfigMap", "namespace", targetKey.Namespace, "name", targetKey.Name, "mode", syncMode)
-	return r.Update(ctx, &existing)
+// trackingAnnotationsChanged reports whether any desired tracking annotation
+// (or label) differs from the existing ones, ignoring the last-synced timestamp
+// and the writer's version, or an expired mark is to be cleared.
+func trackingAnnotationsChanged(existing, desired map[string]string) bool {
+	// A renewed share clears the expired mark with its next write
+	if _, ok := existing[AnnotationExpired]; ok {
+		return true
+	}
+	for k, v := range desired {
+		if k == AnnotationLastSynced || k == AnnotationOperatorVersion {
+			continue
+		}
+		if existing[k] != v {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeInto sets every entry of src in dst, allocating dst if needed.
+func mergeInto(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
 }
 
-// deleteTargetResources removes all synced resources when DeletionPolicy is "delete".
+// deleteTargetResources removes synced resources whose deletion policy is
+// "delete" or "deleteForeground", confirming the latter are gone.
 //
 // Safety checks:
 // - Only deletes resources with our managed-by annotation
 // - Continues on NotFound errors (idempotent)
-func (r *SharedResourceReconciler) deleteTargetResources(ctx context.Context, sr *platformv1alpha1.SharedResource) error {
-	log := logf.FromContext(ctx)
-
-	for _, target := range sr.Spec.Targets {
+//
+// A failing target does not stop the others from being cleaned up. Targets
+// done are recorded in status.cleanup.cleanedUp and skipped by later
+// attempts; every CleanupCheckpointInterval targets the record is written,
+// so a restart mid-cleanup of hundreds of targets resumes where it stopped.
+// Returns the number of targets to clean up, how many are not done yet, and
+// the last error seen.
+func (r *SharedResourceReconciler) deleteTargetResources(ctx context.Context, sr *platformv1alpha1.SharedResource) (int, int, error) {
+	total, remaining := 0, 0
+	var lastErr error
+	done := cleanedUpTargets(sr)
+	progressed := 0
+	targets, err := r.knownTargets(ctx, sr)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, target := range targets {
 		targetName := target.Name
 		if targetName == "" {
-			targetName = sr.Spec.Source.Name
+			targetName = sourceName(sr)
 		}
+		key := targetKey(target.Namespace, targetName)
 
-		targetKey := types.NamespacedName{Namespace: target.Namespace, Name: targetName}
-
-		switch sr.Spec.Source.Kind {
-		case KindSecret:
-			var secret corev1.Secret
-			if err := r.Get(ctx, targetKey, &secret); err != nil {
-				if apierrors.IsNotFound(err) {
-					continue // Already deleted
-				}
-				return err
+		policy := r.effectiveDeletionPolicy(sr, target)
+		deletes := policy == platformv1alpha1.DeletionPolicyDelete || policy == platformv1alpha1.DeletionPolicyDeleteForeground
+		if done[key] {
+			if deletes {
+				total++
 			}
-			// Only delete if managed by us (safety check)
-			if secret.Annotations[AnnotationManagedBy] == ManagedByValue {
-				log.Info("Deleting target Secret", "namespace", target.Namespace, "name", targetName)
-				if err := r.Delete(ctx, &secret); err != nil && !apierrors.IsNotFound(err) {
-					return err
-				}
+			continue
+		}
+		if !deletes {
+			// Orphaned, or left to the sweeper: only let go of the target finalizer
+			if err := r.releaseTarget(ctx, sr, target.Namespace, targetName); err != nil {
+				total++
+				remaining++
+				lastErr = fmt.Errorf("target %s/%s: %w", target.Namespace, targetName, err)
+				continue
 			}
-
-		case KindConfigMap:
-			var cm corev1.ConfigMap
-			if err := r.Get(ctx, targetKey, &cm); err != nil {
-				if apierrors.IsNotFound(err) {
-					continue
-				}
-				return err
+		} else {
+			total++
+			err := r.deleteTarget(ctx, sr, target.Namespace, targetName)
+			if err == nil && policy == platformv1alpha1.DeletionPolicyDeleteForeground {
+				err = r.confirmTargetGone(ctx, sr, target.Namespace, targetName)
 			}
-			if cm.Annotations[AnnotationManagedBy] == ManagedByValue {
-				log.Info("Deleting target ConfigMap", "namespace", target.Namespace, "name", targetName)
-				if err := r.Delete(ctx, &cm); err != nil && !apierrors.IsNotFound(err) {
-					return err
-				}
+			if err != nil {
+				remaining++
+				lastErr = fmt.Errorf("target %s/%s: %w", target.Namespace, targetName, err)
+				continue
 			}
 		}
+
+		done[key] = true
+		markCleanedUp(sr, key)
+		if progressed++; progressed%CleanupCheckpointInterval == 0 {
+			r.saveCleanupProgress(ctx, sr)
+		}
+	}
+	return total, remaining, lastErr
+}
+
+// cleanedUpTargets returns the targets status.cleanup records as done.
+func cleanedUpTargets(sr *platformv1alpha1.SharedResource) map[string]bool {
+	done := map[string]bool{}
+	if sr.Status.Cleanup != nil {
+		for _, key := range sr.Status.Cleanup.CleanedUp {
+			done[key] = true
+		}
 	}
+	return done
+}
+
+// markCleanedUp records a target as done in status.cleanup.
+func markCleanedUp(sr *platformv1alpha1.SharedResource, key string) {
+	if sr.Status.Cleanup == nil {
+		sr.Status.Cleanup = &platformv1alpha1.CleanupStatus{}
+	}
+	sr.Status.Cleanup.CleanedUp = append(sr.Status.Cleanup.CleanedUp, key)
+}
 
+// saveCleanupProgress writes the targets cleaned up so far. A failure is only
+// logged: the targets are cleaned up again, idempotently, after a restart.
+func (r *SharedResourceReconciler) saveCleanupProgress(ctx context.Context, sr *platformv1alpha1.SharedResource) {
+	now := metav1.NewTime(r.now())
+	sr.Status.Cleanup.LastAttemptTime = &now
+	if err := r.Status().Update(ctx, sr); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to save cleanup progress", "cleanedUp", len(sr.Status.Cleanup.CleanedUp))
+	}
+}
+
+// newTargetObject returns an empty object of the given source kind.
+func newTargetObject(kind string) (client.Object, error) {
+	switch kind {
+	case KindSecret:
+		return &corev1.Secret{}, nil
+	case KindConfigMap:
+		return &corev1.ConfigMap{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported source kind: %s", kind)
+	}
+}
+
+// confirmTargetGone returns an error while a managed target still exists,
+// e.g. because its own finalizers are still running after the delete was issued.
+func (r *SharedResourceReconciler) confirmTargetGone(ctx context.Context, sr *platformv1alpha1.SharedResource, namespace, name string) error {
+	obj, err := newTargetObject(targetKind(sr))
+	if err != nil {
+		return err
+	}
+	if err := r.targetReader(sr).Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if _, shared := parseKeyOwners(obj.GetAnnotations())[releaseOwner(sr)]; ownedByCR(obj, sr) || shared {
+		return fmt.Errorf("still being deleted")
+	}
+	return nil
+}
+
+// deleteTarget removes one target resource along with its access grants and links.
+func (r *SharedResourceReconciler) deleteTarget(ctx context.Context, sr *platformv1alpha1.SharedResource, namespace, name string) error {
+	log := logf.FromContext(ctx)
+	targetKey := types.NamespacedName{Namespace: namespace, Name: name}
+
+	// Access grants go with the data they protect
+	if err := r.deleteAccessGrant(ctx, sr, namespace, name); err != nil {
+		return err
+	}
+	if err := r.deleteServiceAccountLinks(ctx, sr, namespace, name); err != nil {
+		return err
+	}
+
+	obj, err := newTargetObject(targetKind(sr))
+	if err != nil {
+		return err
+	}
+	if err := r.targetReader(sr).Get(ctx, targetKey, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // Already deleted
+		}
+		return err
+	}
+	// Only delete if managed by us (safety check)
+	if obj.GetAnnotations()[AnnotationManagedBy] != ManagedByValue {
+		return nil
+	}
+	// A target other SharedResources still merge into only loses our keys
+	if shared, err := r.releaseKeyOwnership(ctx, sr, obj, true); shared || err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// Our own deletes must not be held by (or reported through) the target finalizer
+	if err := r.releaseTargetFinalizer(ctx, obj); err != nil {
+		return err
+	}
+	log.Info("Deleting target "+targetKind(sr), "namespace", namespace, "name", name)
+	r.recordSelfDelete(targetKind(sr), namespace, name)
+	if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		r.writes.Delete(writeKey(targetKind(sr), namespace, name))
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Target finalizers - observing out-of-band deletes (spec.trackTargetDeletion).
+//
+// Without a finalizer a deleted target is simply gone, and drift correction
+// recreates it on the next event or resync with no record of what happened.
+// With TargetFinalizerName on the target, the delete is held until we have
+// looked at it:
+//   - namespace being deleted: release the finalizer, do not recreate
+//   - anything else: release, emit a TargetDeleted event and recreate
+//
+// Both cases are counted in sharedresource_target_deletions_total. The
+// operator strips the finalizer itself before any delete it initiates, so
+// its own cleanup is never reported.
+// =============================================================================
+
+// checkTargetDeletion handles a target that is being deleted. Returns nil if the
+// target is not being deleted, otherwise an error so the sync is retried once
+// the target is gone (its delete event triggers the recreate).
+func (r *SharedResourceReconciler) checkTargetDeletion(ctx context.Context, sr *platformv1alpha1.SharedResource, key types.NamespacedName) error {
+	obj, err := newTargetObject(targetKind(sr))
+	if err != nil {
+		return err
+	}
+	if err := r.Get(ctx, key, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if obj.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	if !controllerutil.ContainsFinalizer(obj, TargetFinalizerName) {
+		return fmt.Errorf("target is being deleted")
+	}
+
+	reason, err := r.targetDeletionReason(ctx, key.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := r.releaseTargetFinalizer(ctx, obj); err != nil {
+		return err
+	}
+	targetDeletionsTotal.WithLabelValues(targetKind(sr), reason).Inc()
+
+	log := logf.FromContext(ctx)
+	if reason == DeletionReasonNamespace {
+		log.Info("Target deleted with its namespace", "kind", targetKind(sr), "namespace", key.Namespace, "name", key.Name)
+		r.recordEvent(sr, corev1.EventTypeNormal, "TargetNamespaceDeleted",
+			"%s %s was removed because its namespace is being deleted", targetKind(sr), key)
+		return fmt.Errorf("target namespace %s is being deleted", key.Namespace)
+	}
+	log.Info("Target deleted out-of-band, recreating", "kind", targetKind(sr), "namespace", key.Namespace, "name", key.Name)
+	r.recordEvent(sr, corev1.EventTypeWarning, "TargetDeleted",
+		"%s %s was deleted outside the operator; recreating it", targetKind(sr), key)
+	return fmt.Errorf("target was deleted out-of-band, recreating")
+}
+
+// targetDeletionReason tells namespace cleanup apart from a direct delete.
+func (r *SharedResourceReconciler) targetDeletionReason(ctx context.Context, namespace string) (string, error) {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return DeletionReasonNamespace, nil
+		}
+		return "", err
+	}
+	if !ns.DeletionTimestamp.IsZero() {
+		return DeletionReasonNamespace, nil
+	}
+	return DeletionReasonExternal, nil
+}
+
+// releaseTargetFinalizer removes our finalizer from a target, if present.
+func (r *SharedResourceReconciler) releaseTargetFinalizer(ctx context.Context, obj client.Object) error {
+	if !controllerutil.ContainsFinalizer(obj, TargetFinalizerName) {
+		return nil
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	controllerutil.RemoveFinalizer(obj, TargetFinalizerName)
+	return client.IgnoreNotFound(r.Patch(ctx, obj, patch))
+}
+
+// releaseTarget removes our finalizer from a target that is left in place.
+func (r *SharedResourceReconciler) releaseTarget(ctx context.Context, sr *platformv1alpha1.SharedResource, namespace, name string) error {
+	obj, err := newTargetObject(targetKind(sr))
+	if err != nil {
+		return err
+	}
+	if err := r.targetReader(sr).Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// Our keys stay in a shared target, but other owners may now claim them
+	if shared, err := r.releaseKeyOwnership(ctx, sr, obj, false); shared || err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return r.releaseTargetFinalizer(ctx, obj)
+}
+
+// setTargetFinalizer adds or removes our finalizer. Returns true if it changed.
+func setTargetFinalizer(obj client.Object, want bool) bool {
+	if want {
+		return controllerutil.AddFinalizer(obj, TargetFinalizerName)
+	}
+	return controllerutil.RemoveFinalizer(obj, TargetFinalizerName)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Target namespaces by label (spec.targetSelector).
+//
+// Instead of, or next to, spec.targets a CR can target every namespace whose
+// labels match a selector. resolveTargets adds an entry for each match on
+// every reconcile, after spec.targets and its groups, so a listed entry for
+// the same namespace and name wins. The CR's own namespace, and that of a
+// source read from another namespace, never match: the copy there would be
+// the source itself.
+//
+// The namespace watch re-syncs the CR when a namespace gains or loses the
+// labels, which keeps status.syncedTargets in step. A namespace losing them
+// keeps its copy, like a target removed from spec.targets.
+//
+// An invalid selector is rejected by the webhook and lint; otherwise the CR
+// is reported with Ready=False, InvalidTargetSelector, and not synced. A valid
+// one is admitted with a warning saying how many namespaces it matches now,
+// as is each namespace pattern (TargetMatchWarnings), so a selector matching
+// none or thousands shows on apply.
+// =============================================================================
+
+// errInvalidTargetSelector is returned for a spec.targetSelector that does not parse.
+var errInvalidTargetSelector = errors.New("invalid targetSelector")
+
+// TargetSelectorErrors returns an error if spec.targetSelector is invalid.
+func TargetSelectorErrors(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	if sr.Spec.TargetSelector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(sr.Spec.TargetSelector); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "targetSelector"), sr.Spec.TargetSelector, err.Error())}
+	}
+	return nil
+}
+
+// SelectedNamespaces returns the namespaces spec.targetSelector matches,
+// sorted, from namespaces, name to labels. Used by offline linting too.
+func SelectedNamespaces(sr *platformv1alpha1.SharedResource, namespaces map[string]labels.Set) ([]string, error) {
+	if sr.Spec.TargetSelector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sr.Spec.TargetSelector)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidTargetSelector, err)
+	}
+	var names []string
+	for name, nsLabels := range namespaces {
+		if name != sr.Namespace && name != sourceNamespace(sr) && selector.Matches(nsLabels) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// selectedNamespaces returns the namespaces spec.targetSelector matches.
+// Terminating namespaces do not match.
+func (r *SharedResourceReconciler) selectedNamespaces(ctx context.Context, sr *platformv1alpha1.SharedResource) ([]string, error) {
+	if sr.Spec.TargetSelector == nil {
+		return nil, nil
+	}
+	namespaces, err := r.activeNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return SelectedNamespaces(sr, namespaces)
+}
+
+// ActiveNamespaces returns the labels of every namespace not terminating.
+// The reconciler reads them through its snapshot (see namespacecache.go).
+func ActiveNamespaces(ctx context.Context, reader client.Reader) (map[string]labels.Set, error) {
+	var list corev1.NamespaceList
+	if err := reader.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	namespaces := make(map[string]labels.Set, len(list.Items))
+	for _, ns := range list.Items {
+		if ns.DeletionTimestamp.IsZero() {
+			namespaces[ns.Name] = labels.Set(ns.Labels)
+		}
+	}
+	return namespaces, nil
+}
+
+// maxMatchSample caps how many matching namespaces a match warning names
+const maxMatchSample = 5
+
+// TargetMatchWarnings returns, for admission warnings, how many of the
+// namespaces, name to labels, spec.targetSelector and each namespace pattern
+// in spec.targets match, with a sample of them. Invalid selectors and
+// patterns are left to the errors.
+func TargetMatchWarnings(sr *platformv1alpha1.SharedResource, namespaces map[string]labels.Set) []string {
+	var warnings []string
+	if names, err := SelectedNamespaces(sr, namespaces); err == nil && sr.Spec.TargetSelector != nil {
+		warnings = append(warnings, matchWarning("spec.targetSelector", names))
+	}
+	for i, target := range sr.Spec.Targets {
+		if !IsNamespacePattern(target.Namespace) {
+			continue
+		}
+		if names, err := PatternNamespaces(sr, target.Namespace, namespaces); err == nil {
+			warnings = append(warnings, matchWarning(fmt.Sprintf("spec.targets[%d].namespace %q", i, target.Namespace), names))
+		}
+	}
+	return warnings
+}
+
+// matchWarning says how many namespaces a selector or pattern matches.
+func matchWarning(what string, names []string) string {
+	switch {
+	case len(names) == 0:
+		return what + " currently matches no namespace"
+	case len(names) == 1:
+		return fmt.Sprintf("%s currently matches 1 namespace: %s", what, names[0])
+	case len(names) <= maxMatchSample:
+		return fmt.Sprintf("%s currently matches %d namespaces: %s", what, len(names), strings.Join(names, ", "))
+	}
+	return fmt.Sprintf("%s currently matches %d namespaces, e.g. %s", what, len(names),
+		strings.Join(names[:maxMatchSample], ", "))
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Target object template - spec.targetTemplate.
+//
+// A partial Secret or ConfigMap whose metadata.labels, metadata.annotations,
+// immutable and (Secrets only) type are merged into every target. Any other
+// field is an error, so a template can never smuggle in finalizers, owner
+// references or data. The same parser backs the SharedResource webhook and the
+// controller, which reports an invalid template as a sync error when the
+// webhook is not installed.
+// =============================================================================
+
+// targetTemplatePrefix is the annotation/label prefix reserved for tracking.
+const targetTemplatePrefix = "sharedresource.platform.dev/"
+
+// TargetTemplate is what a valid spec.targetTemplate sets on targets.
+type TargetTemplate struct {
+	Labels      map[string]string
+	Annotations map[string]string
+	Type        corev1.SecretType
+	Immutable   *bool
+}
+
+// targetTemplateObject is the typed view of the allowed template fields.
+type targetTemplateObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Type      corev1.SecretType `json:"type"`
+	Immutable *bool             `json:"immutable"`
+}
+
+// ParseTargetTemplate validates spec.targetTemplate and returns what it sets.
+// Returns nil and no errors if the SharedResource has no template.
+func ParseTargetTemplate(sr *platformv1alpha1.SharedResource) (*TargetTemplate, field.ErrorList) {
+	if sr.Spec.TargetTemplate == nil || len(sr.Spec.TargetTemplate.Raw) == 0 {
+		return nil, nil
+	}
+	path := field.NewPath("spec", "targetTemplate")
+	raw := sr.Spec.TargetTemplate.Raw
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, field.ErrorList{field.Invalid(path, string(raw), err.Error())}
+	}
+	var errs field.ErrorList
+	allowed := map[string]bool{"apiVersion": true, "kind": true, "metadata": true, "immutable": true}
+	kind := targetKind(sr)
+	if kind == KindSecret {
+		allowed["type"] = true
+	}
+	errs = append(errs, unknownFields(path, fields, allowed)...)
+
+	var metadata map[string]json.RawMessage
+	if value, ok := fields["metadata"]; ok && json.Unmarshal(value, &metadata) == nil {
+		errs = append(errs, unknownFields(path.Child("metadata"), metadata,
+			map[string]bool{"labels": true, "annotations": true})...)
+	}
+
+	var obj targetTemplateObject
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, append(errs, field.Invalid(path, string(raw), err.Error()))
+	}
+	if obj.APIVersion != "" && obj.APIVersion != "v1" {
+		errs = append(errs, field.NotSupported(path.Child("apiVersion"), obj.APIVersion, []string{"v1"}))
+	}
+	if obj.Kind != "" && obj.Kind != kind {
+		errs = append(errs, field.NotSupported(path.Child("kind"), obj.Kind, []string{kind}))
+	}
+	for _, key := range sortedMapKeys(obj.Metadata.Labels) {
+		if strings.HasPrefix(key, targetTemplatePrefix) || key == LabelManagedBy {
+			errs = append(errs, field.Forbidden(path.Child("metadata", "labels").Key(key), "reserved for the operator"))
+		}
+	}
+	for _, key := range sortedMapKeys(obj.Metadata.Annotations) {
+		if strings.HasPrefix(key, targetTemplatePrefix) {
+			errs = append(errs, field.Forbidden(path.Child("metadata", "annotations").Key(key), "reserved for the operator"))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return &TargetTemplate{
+		Labels:      obj.Metadata.Labels,
+		Annotations: obj.Metadata.Annotations,
+		Type:        obj.Type,
+		Immutable:   obj.Immutable,
+	}, nil
+}
+
+// unknownFields reports every field not in allowed, sorted.
+func unknownFields(path *field.Path, fields map[string]json.RawMessage, allowed map[string]bool) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range sortedMapKeys(fields) {
+		if !allowed[name] {
+			errs = append(errs, field.Forbidden(path.Child(name), "not allowed in a target template"))
+		}
+	}
+	return errs
+}
+
+// sortedMapKeys returns the keys of m, sorted.
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyTargetTemplate merges the template's labels and annotations under the
+// tracking ones, which always win. Both maps are modified in place.
+func applyTargetTemplate(tmpl *TargetTemplate, labels, annotations map[string]string) {
+	if tmpl == nil {
+		return
+	}
+	for k, v := range tmpl.Labels {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+	for k, v := range tmpl.Annotations {
+		if _, ok := annotations[k]; !ok {
+			annotations[k] = v
+		}
+	}
+}
+
+// errImmutableTarget is returned when an immutable target's data is out of date.
+var errImmutableTarget = errors.New("target is immutable and its data changed; delete it to have it recreated")
+
+// immutableUpdate reports whether an update to an existing target must mark it
+// immutable. Immutable targets cannot be made mutable again, and their data
+// cannot change.
+func immutableUpdate(existing, desired *bool, dataChanged bool) (bool, error) {
+	if ptr.Deref(existing, false) {
+		if dataChanged {
+			return false, errImmutableTarget
+		}
+		return false, nil
+	}
+	return ptr.Deref(desired, false), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Per-target value templates (spec.template, targets[].values).
+//
+// Rendering runs after key filtering, so every target gets its own data and
+// checksum. A template that fails to render (bad syntax, or a value the
+// target does not define) fails only that target.
+//
+// With template.valuesFrom, a source ConfigMap can hold the template while a
+// Secret holds the sensitive values; template.targetKind=Secret then keeps
+// the rendered result out of ConfigMaps.
+// =============================================================================
+
+// targetKind returns the kind written to target namespaces.
+func targetKind(sr *platformv1alpha1.SharedResource) string {
+	if sr.Spec.Template != nil && sr.Spec.Template.TargetKind != "" {
+		return sr.Spec.Template.TargetKind
+	}
+	return sr.Spec.Source.Kind
+}
+
+// templateValuesSecret returns the name of the template.valuesFrom Secret, or "".
+func templateValuesSecret(sr *platformv1alpha1.SharedResource) string {
+	if sr.Spec.Template == nil || sr.Spec.Template.ValuesFrom == nil {
+		return ""
+	}
+	return sr.Spec.Template.ValuesFrom.SecretName
+}
+
+// TemplateErrors returns an error if spec.template.valuesFrom is set without
+// targetKind Secret, which would render Secret values into ConfigMaps.
+func TemplateErrors(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	if templateValuesSecret(sr) == "" || sr.Spec.Template.TargetKind == KindSecret {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(field.NewPath("spec", "template", "targetKind"),
+		sr.Spec.Template.TargetKind, "must be Secret when template.valuesFrom is set")}
+}
+
+// fetchTemplateSecrets reads the template.valuesFrom Secret.
+// Returns nil if no valuesFrom is configured.
+func (r *SharedResourceReconciler) fetchTemplateSecrets(ctx context.Context, sr *platformv1alpha1.SharedResource) (map[string]string, error) {
+	name := templateValuesSecret(sr)
+	if name == "" {
+		return nil, nil
+	}
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: sr.Namespace, Name: name}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("template values Secret %q not found", name)
+		}
+		return nil, err
+	}
+	return syncengine.ToStrings(secret.Data), nil
+}
+
+// renderForTarget renders the template keys for one target.
+// Returns the data unchanged if spec.template is not set.
+func renderForTarget(sr *platformv1alpha1.SharedResource, target platformv1alpha1.TargetSpec, targetName string, secrets map[string]string, data map[string][]byte) (map[string][]byte, error) {
+	if sr.Spec.Template == nil {
+		return data, nil
+	}
+	return syncengine.Render(data, sr.Spec.Template.Keys, syncengine.TemplateContext{
+		Values:  syncengine.MergeValues(sr.Spec.Template.Values, target.Values),
+		Secrets: secrets,
+		Target:  syncengine.TemplateTarget{Namespace: target.Namespace, Name: targetName},
+	})
+}
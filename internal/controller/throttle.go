@@ -0,0 +1,111 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// API server back-pressure - 429 Too Many Requests during fan-out.
+//
+// client-go already retries a 429 that carries a Retry-After delay; an error
+// that reaches us means the API server (API Priority and Fairness, or an
+// overloaded aggregated API) keeps rejecting writes. Writing the remaining
+// targets of a large fan-out would only fail each of them in turn and add to
+// the load. Instead the first 429 pauses target writes for this instance,
+// across all CRs, for the Retry-After delay (DefaultThrottlePause without one,
+// at most MaxThrottlePause):
+//   - targets not reached keep their previous status, and are retried once
+//     the pause ends
+//   - the Throttled condition reports the resume time
+// =============================================================================
+
+// apiThrottle remembers until when target writes are paused.
+type apiThrottle struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// pause pauses target writes until the given time, unless already paused longer.
+func (t *apiThrottle) pause(until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if until.After(t.until) {
+		t.until = until
+	}
+}
+
+// resumeAt returns when target writes resume, or the zero time if they are not paused.
+func (t *apiThrottle) resumeAt(now time.Time) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Before(t.until) {
+		return t.until
+	}
+	return time.Time{}
+}
+
+// throttlePause returns how long to pause target writes after err, and false
+// if err is not a 429.
+func throttlePause(err error) (time.Duration, bool) {
+	if !apierrors.IsTooManyRequests(err) {
+		return 0, false
+	}
+	seconds, ok := apierrors.SuggestsClientDelay(err)
+	if !ok || seconds <= 0 {
+		return DefaultThrottlePause, true
+	}
+	return min(time.Duration(seconds)*time.Second, MaxThrottlePause), true
+}
+
+// deferredTarget returns the status of a target not written during a pause:
+// its previous status if it has one, otherwise an explicit pending error.
+func deferredTarget(
+	previous []platformv1alpha1.TargetSyncStatus,
+	status platformv1alpha1.TargetSyncStatus,
+	resume time.Time,
+) platformv1alpha1.TargetSyncStatus {
+	for _, t := range previous {
+		if t.Namespace == status.Namespace && t.Name == status.Name {
+			return t
+		}
+	}
+	status.Error = fmt.Sprintf("not written yet: the API server is throttling requests; writes resume at %s",
+		resume.UTC().Format(time.RFC3339))
+	return status
+}
+
+// applyThrottledCondition reports paused target writes. The condition is
+// only present while writes are paused.
+func applyThrottledCondition(sr *platformv1alpha1.SharedResource, resume time.Time, deferred int) {
+	if resume.IsZero() {
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeThrottled)
+		return
+	}
+	setCondition(sr, ConditionTypeThrottled, metav1.ConditionTrue, "TooManyRequests",
+		fmt.Sprintf("The API server answered 429 Too Many Requests; %d target(s) not written, target writes resume at %s",
+			deferred, resume.UTC().Format(time.RFC3339)))
+}
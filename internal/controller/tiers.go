@@ -0,0 +1,142 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Namespace tiers - the --namespace-tiers file.
+//
+// Tiers classify target namespaces by label (e.g. prod and nonprod) and give
+// every target in them defaults, so SharedResources need not repeat them:
+//   - resyncInterval: how often targets are re-checked for drift. A CR syncs
+//     all of its targets together, so it uses the shortest interval among
+//     its targets' tiers, and ResyncInterval for targets in no tier
+//   - keys: keys withheld from (or the only keys sent to) the tier, for
+//     targets no syncPolicy.namespaceRules entry matches
+//
+// A namespace belongs to the first tier whose selector matches it.
+// =============================================================================
+
+// NamespaceTier is one tier of the --namespace-tiers file.
+type NamespaceTier struct {
+	// Name identifies the tier in logs.
+	Name string
+
+	// Selector matches the labels of the tier's namespaces.
+	Selector labels.Selector
+
+	// ResyncInterval re-checks targets in the tier this often. Zero keeps ResyncInterval.
+	ResyncInterval time.Duration
+
+	// Keys filters the data of targets in the tier. Nil sends all keys.
+	Keys *platformv1alpha1.KeySelector
+}
+
+// namespaceTiersFile is the on-disk format of --namespace-tiers.
+type namespaceTiersFile struct {
+	Tiers []namespaceTierSpec `json:"tiers"`
+}
+
+type namespaceTierSpec struct {
+	Name              string                        `json:"name"`
+	NamespaceSelector metav1.LabelSelector          `json:"namespaceSelector"`
+	ResyncInterval    *metav1.Duration              `json:"resyncInterval,omitempty"`
+	Keys              *platformv1alpha1.KeySelector `json:"keys,omitempty"`
+}
+
+// ParseNamespaceTiers parses and validates a --namespace-tiers file.
+func ParseNamespaceTiers(data []byte) ([]NamespaceTier, error) {
+	var file namespaceTiersFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(file.Tiers))
+	tiers := make([]NamespaceTier, 0, len(file.Tiers))
+	for i, spec := range file.Tiers {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("tiers[%d]: name is required", i)
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("tiers[%d]: duplicate tier %q", i, spec.Name)
+		}
+		names[spec.Name] = true
+
+		selector, err := metav1.LabelSelectorAsSelector(&spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("tier %q: invalid namespaceSelector: %w", spec.Name, err)
+		}
+		// An empty selector would put every namespace in the tier by accident
+		if selector.Empty() {
+			return nil, fmt.Errorf("tier %q: namespaceSelector must not be empty", spec.Name)
+		}
+		tier := NamespaceTier{Name: spec.Name, Selector: selector, Keys: spec.Keys}
+		if spec.ResyncInterval != nil {
+			if spec.ResyncInterval.Duration < MinResyncInterval {
+				return nil, fmt.Errorf("tier %q: resyncInterval must be at least %s", spec.Name, MinResyncInterval)
+			}
+			tier.ResyncInterval = spec.ResyncInterval.Duration
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers, nil
+}
+
+// namespaceTier returns the tier of a namespace with the given labels, or nil.
+func (r *SharedResourceReconciler) namespaceTier(nsLabels labels.Set) *NamespaceTier {
+	for i := range r.NamespaceTiers {
+		if r.NamespaceTiers[i].Selector.Matches(nsLabels) {
+			return &r.NamespaceTiers[i]
+		}
+	}
+	return nil
+}
+
+// resyncInterval returns how often the CR's targets are re-checked: the
+// shortest resyncInterval among its targets' tiers, or ResyncInterval.
+// Namespaces whose labels cannot be read count as being in no tier.
+func (r *SharedResourceReconciler) resyncInterval(ctx context.Context, sr *platformv1alpha1.SharedResource) time.Duration {
+	if len(r.NamespaceTiers) == 0 {
+		return ResyncInterval
+	}
+	// The default interval applies if the targets cannot be resolved
+	targets, _ := r.knownTargets(ctx, sr)
+	var resync time.Duration
+	for _, target := range targets {
+		interval := ResyncInterval
+		if nsLabels, err := r.targetNamespaceLabels(ctx, sr, target.Namespace); err == nil {
+			if tier := r.namespaceTier(nsLabels); tier != nil && tier.ResyncInterval > 0 {
+				interval = tier.ResyncInterval
+			}
+		}
+		resync = sooner(resync, interval)
+	}
+	if resync == 0 {
+		return ResyncInterval
+	}
+	return resync
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// =============================================================================
+// Compact tracking - targets too large for the full tracking annotations.
+//
+// A Secret or ConfigMap close to the 1MiB object limit has little room left
+// for metadata, and every byte of annotations is written (and watched) again
+// on each sync. Targets whose data is at least --compact-tracking-threshold
+// bytes are tracked compactly instead:
+//   - the checksum annotation becomes the LabelChecksum label (the digest in
+//     base32, which fits a label value)
+//   - the provenance annotation is dropped; its source UID becomes the
+//     LabelSourceUID label, the rest is in the identity annotations
+//     (source-namespace, source-name, source-cr) every target keeps
+//   - LabelTracking marks the target as compactly tracked
+//
+// Which data keys the operator wrote is recorded by the API server in the
+// target's managedFields under the operator's field manager, so none of it
+// needs an annotation of its own.
+//
+// Targets move between the two forms with their next sync: crossing the
+// threshold in either direction rewrites the metadata, dropping the other
+// form's entries, without touching the data. On a target several
+// SharedResources merge into, the form only changes with a data write, so
+// owners on either side of the threshold do not rewrite it back and forth.
+// =============================================================================
+
+// checksumEncoding encodes a digest into a valid label value.
+var checksumEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// compactTrackingAnnotations are the annotations compact tracking drops.
+var compactTrackingAnnotations = []string{AnnotationChecksum, AnnotationProvenance}
+
+// optionalTrackingAnnotations are the tracking annotations only some targets
+// carry, removed from targets that no longer need them.
+var optionalTrackingAnnotations = append(append([]string{}, compactTrackingAnnotations...), chainAnnotations...)
+
+// compactTrackingLabels are the labels only compact tracking sets.
+var compactTrackingLabels = []string{LabelChecksum, LabelSourceUID, LabelTracking}
+
+// compactTracking returns true if a target holding data is tracked compactly.
+func (r *SharedResourceReconciler) compactTracking(data map[string][]byte) bool {
+	return r.CompactTrackingThreshold > 0 && dataSize(data) >= int64(r.CompactTrackingThreshold)
+}
+
+// checksumLabel returns the label value for a hex checksum.
+func checksumLabel(checksum string) string {
+	digest, err := hex.DecodeString(checksum)
+	if err != nil {
+		// Checksums are hex; keep whatever fits rather than fail the sync
+		return checksum[:min(len(checksum), 63)]
+	}
+	return strings.ToLower(checksumEncoding.EncodeToString(digest))
+}
+
+// applyCompactTracking moves the checksum and provenance of the desired
+// tracking metadata, modified in place, into labels.
+func applyCompactTracking(labels, annotations map[string]string, checksum string, sourceUID types.UID) {
+	for _, k := range compactTrackingAnnotations {
+		delete(annotations, k)
+	}
+	labels[LabelChecksum] = checksumLabel(checksum)
+	if sourceUID != "" {
+		labels[LabelSourceUID] = string(sourceUID)
+	}
+	labels[LabelTracking] = TrackingCompact
+}
+
+// comparableLabels returns the desired labels that decide whether a target
+// needs a write. On a shared target the checksum and source UID only describe
+// the last writer, so they are left out, like the identity annotations.
+func comparableLabels(labels, annotations map[string]string) map[string]string {
+	if len(parseKeyOwners(annotations)) < 2 {
+		return labels
+	}
+	compared := make(map[string]string, len(labels))
+	for k, v := range labels {
+		compared[k] = v
+	}
+	delete(compared, LabelChecksum)
+	delete(compared, LabelSourceUID)
+	return compared
+}
+
+// staleTracking returns true if the existing target carries a renamed
+// marker under its legacy key (see compat.go), tracking metadata of the other
+// form than the desired one, or chain annotations it no longer needs. A
+// shared target is only reported for legacy keys, see the file comment.
+func staleTracking(existing client.Object, labels, annotations map[string]string) bool {
+	if hasLegacyMarkers(existing) {
+		return true
+	}
+	if len(parseKeyOwners(annotations)) >= 2 {
+		return false
+	}
+	for _, k := range optionalTrackingAnnotations {
+		if _, desired := annotations[k]; !desired && existing.GetAnnotations()[k] != "" {
+			return true
+		}
+	}
+	for _, k := range compactTrackingLabels {
+		if _, desired := labels[k]; !desired && existing.GetLabels()[k] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// dropStaleTracking removes from the existing target legacy marker keys, the
+// tracking metadata of the other form than the desired one, and chain
+// annotations it no longer needs.
+func dropStaleTracking(existing client.Object, labels, annotations map[string]string) {
+	dropLegacyMarkers(existing)
+	existingAnnotations := existing.GetAnnotations()
+	for _, k := range optionalTrackingAnnotations {
+		if _, desired := annotations[k]; !desired {
+			delete(existingAnnotations, k)
+		}
+	}
+	existing.SetAnnotations(existingAnnotations)
+	existingLabels := existing.GetLabels()
+	for _, k := range compactTrackingLabels {
+		if _, desired := labels[k]; !desired {
+			delete(existingLabels, k)
+		}
+	}
+	existing.SetLabels(existingLabels)
+}
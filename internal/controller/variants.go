@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Data variants - which targets got which data.
+//
+// namespaceKeyRules and templates can give each target different data. Every
+// successfully synced target is grouped by the checksum of the data written
+// to it; when there is more than one group, status.variants lists them and
+// the DataVaries condition is set, so operators can check which namespaces
+// received which keys.
+// =============================================================================
+
+const (
+	// maxVariants caps how many variants are listed in status
+	maxVariants = 10
+
+	// maxVariantNamespaces caps how many namespaces are listed per variant
+	maxVariantNamespaces = 10
+)
+
+// variantSet groups synced targets by the checksum of their data.
+type variantSet struct {
+	variants   map[string]*platformv1alpha1.DataVariant
+	namespaces map[string]map[string]struct{}
+}
+
+// newVariantSet returns an empty variantSet.
+func newVariantSet() *variantSet {
+	return &variantSet{
+		variants:   map[string]*platformv1alpha1.DataVariant{},
+		namespaces: map[string]map[string]struct{}{},
+	}
+}
+
+// add records that data was written to a target in namespace.
+func (s *variantSet) add(data map[string][]byte, namespace string) {
+	checksum := syncengine.Checksum(data)
+	v, ok := s.variants[checksum]
+	if !ok {
+		v = &platformv1alpha1.DataVariant{Checksum: checksum, Keys: sortedKeys(data)}
+		s.variants[checksum] = v
+		s.namespaces[checksum] = map[string]struct{}{}
+	}
+	v.Targets++
+	s.namespaces[checksum][namespace] = struct{}{}
+}
+
+// list returns the variants, most common first, or nil if there are fewer than two.
+func (s *variantSet) list() []platformv1alpha1.DataVariant {
+	if len(s.variants) < 2 {
+		return nil
+	}
+	list := make([]platformv1alpha1.DataVariant, 0, len(s.variants))
+	for checksum, v := range s.variants {
+		namespaces := make([]string, 0, len(s.namespaces[checksum]))
+		for ns := range s.namespaces[checksum] {
+			namespaces = append(namespaces, ns)
+		}
+		sort.Strings(namespaces)
+		if len(namespaces) > maxVariantNamespaces {
+			namespaces = namespaces[:maxVariantNamespaces]
+		}
+		v.Namespaces = namespaces
+		list = append(list, *v)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Targets != list[j].Targets {
+			return list[i].Targets > list[j].Targets
+		}
+		return list[i].Checksum < list[j].Checksum
+	})
+	return list
+}
+
+// applyVariants records the variants and the DataVaries condition on the CR.
+// The condition is only present while targets received different data.
+func applyVariants(sr *platformv1alpha1.SharedResource, variants []platformv1alpha1.DataVariant) {
+	if len(variants) == 0 {
+		sr.Status.Variants = nil
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeDataVaries)
+		return
+	}
+	var targets int32
+	for _, v := range variants {
+		targets += v.Targets
+	}
+	setCondition(sr, ConditionTypeDataVaries, metav1.ConditionTrue, "MultipleVariants",
+		fmt.Sprintf("%d targets received %d different data variants", targets, len(variants)))
+	if len(variants) > maxVariants {
+		variants = variants[:maxVariants]
+	}
+	sr.Status.Variants = variants
+}
+
+// sortedKeys returns the keys of data, sorted.
+func sortedKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Verification Jobs - spec.verify.
+//
+// A synced target is only useful if what it holds works, e.g. the database
+// accepts the credentials. With spec.verify every written target gets a Job
+// in its namespace, rendered from spec.verify.jobTemplate, that checks the
+// data; Ready waits for the Jobs to succeed.
+//
+// There is one Job per target and data checksum, named after both (see
+// hooks.go), so:
+//   - unchanged data is verified once; the result is kept in status
+//   - new data gets a new Job, and the previous one is deleted
+//
+// Running Jobs are read back every HookCheckInterval. Failures are
+// recorded per target and in the Verified condition; they are not retried
+// until the data changes or the Job is deleted.
+//
+// The Jobs run pods written by the CR's author in namespaces of other teams,
+// so they only run with --enable-verify-jobs (see hooks.go for what such a
+// pod may do). Without it, a CR with spec.verify syncs its targets, but
+// Verified and Ready are False with reason VerificationDisabled.
+// =============================================================================
+
+const (
+	// verifyJobSuffix separates the target name from the checksum in Job names
+	verifyJobSuffix = "-verify-"
+
+	// maxVerifyFailures caps how many failed targets the Verified condition names
+	maxVerifyFailures = 10
+)
+
+// verifyJobName returns the name of the Job verifying the target's data.
+func verifyJobName(targetName, checksum string) string {
+	return hookJobName(targetName, verifyJobSuffix, checksum)
+}
+
+// verifyJobTemplate renders spec.verify.jobTemplate for a target.
+func verifyJobTemplate(sr *platformv1alpha1.SharedResource, namespace, name string) (*batchv1.JobTemplateSpec, error) {
+	rendered, err := syncengine.Render(map[string][]byte{"jobTemplate": sr.Spec.Verify.JobTemplate.Raw}, nil,
+		syncengine.TemplateContext{Target: syncengine.TemplateTarget{Namespace: namespace, Name: name}})
+	if err != nil {
+		return nil, err
+	}
+	var tmpl batchv1.JobTemplateSpec
+	if err := json.Unmarshal(rendered["jobTemplate"], &tmpl); err != nil {
+		return nil, fmt.Errorf("invalid verify.jobTemplate: %w", err)
+	}
+	if len(tmpl.Spec.Template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("invalid verify.jobTemplate: spec.template.spec.containers is required")
+	}
+	// The pod may read the target it verifies, and nothing else (see hooks.go)
+	if err := checkHookPod("verify.jobTemplate", &tmpl, targetKind(sr), name); err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// VerifyErrors returns an error if spec.verify.jobTemplate is not a
+// JobTemplateSpec with containers, or its pod would get more than the target
+// (see hookPodErrors). The template is rendered for an example
+// target, so template syntax errors are found as well.
+func VerifyErrors(sr *platformv1alpha1.SharedResource) field.ErrorList {
+	if sr.Spec.Verify == nil {
+		return nil
+	}
+	if _, err := verifyJobTemplate(sr, sr.Namespace, sourceName(sr)); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "verify", "jobTemplate"), "", err.Error())}
+	}
+	return nil
+}
+
+// verifyTarget starts or checks the Job verifying the data just written to a
+// target, and returns the target's verification. previous is the
+// verification status reports for the target, if any.
+func (r *SharedResourceReconciler) verifyTarget(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	namespace, targetName, checksum string,
+	previous *platformv1alpha1.TargetVerification,
+) *platformv1alpha1.TargetVerification {
+	name := verifyJobName(targetName, checksum)
+	if previous != nil && previous.Job == name && previous.Phase != platformv1alpha1.HookRunning {
+		return previous
+	}
+	verification := &platformv1alpha1.TargetVerification{Job: name, Checksum: checksum,
+		Phase: platformv1alpha1.HookRunning}
+	failed := func(format string, args ...any) *platformv1alpha1.TargetVerification {
+		verification.Phase = platformv1alpha1.HookFailed
+		verification.Message = fmt.Sprintf(format, args...)
+		return verification
+	}
+
+	job, err := r.getHookJob(ctx, namespace, name)
+	if apierrors.IsNotFound(err) {
+		if previous != nil && previous.Job != name {
+			r.deleteHookJob(ctx, namespace, previous.Job)
+		}
+		if err := r.createVerifyJob(ctx, sr, namespace, targetName, name, checksum); err != nil {
+			return failed("failed to create verification Job: %v", err)
+		}
+		return verification
+	}
+	if err != nil {
+		return failed("failed to read verification Job: %v", err)
+	}
+	if !hookJobOwnedBy(job, sr) {
+		return failed("Job %s exists and is not managed by this SharedResource", name)
+	}
+	phase, completed, message := hookJobPhase(job)
+	verification.CompletionTime = completed
+	if phase == platformv1alpha1.HookFailed {
+		return failed("%s", message)
+	}
+	verification.Phase = phase
+	return verification
+}
+
+// createVerifyJob creates the verification Job for a target.
+func (r *SharedResourceReconciler) createVerifyJob(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	namespace, targetName, name, checksum string,
+) error {
+	tmpl, err := verifyJobTemplate(sr, namespace, targetName)
+	if err != nil {
+		return err
+	}
+	tracking := map[string]string{
+		AnnotationManagedBy:       ManagedByValue,
+		AnnotationSourceNamespace: sr.Namespace,
+		AnnotationSourceCR:        sr.Name,
+		AnnotationChecksum:        checksum,
+		AnnotationVerifies:        targetName,
+	}
+	// Jobs in other namespaces cannot be owned by the CR; deleteVerifyJobs removes them
+	timeout := hookTimeout(sr.Spec.Verify.Timeout, DefaultHookJobTimeout)
+	if err := r.createHookJob(ctx, tmpl, namespace, name, tracking, timeout, nil); err != nil {
+		return err
+	}
+	r.recordEvent(sr, corev1.EventTypeNormal, "VerificationStarted",
+		"Started Job %s/%s to verify target %s", namespace, name, targetName)
+	return nil
+}
+
+// deleteVerifyJobs deletes the verification Jobs of the last sync, for a
+// SharedResource being deleted.
+func (r *SharedResourceReconciler) deleteVerifyJobs(ctx context.Context, sr *platformv1alpha1.SharedResource) {
+	for _, t := range r.previousTargets(ctx, sr) {
+		if t.Verification != nil {
+			r.deleteHookJob(ctx, t.Namespace, t.Verification.Job)
+		}
+	}
+}
+
+// previousVerifications returns each target's verification as of the last sync.
+func previousVerifications(targets []platformv1alpha1.TargetSyncStatus) map[string]*platformv1alpha1.TargetVerification {
+	verifications := make(map[string]*platformv1alpha1.TargetVerification, len(targets))
+	for _, t := range targets {
+		if t.Verification != nil {
+			verifications[targetKey(t.Namespace, t.Name)] = t.Verification
+		}
+	}
+	return verifications
+}
+
+// verificationRunning returns true while the Verified condition waits for Jobs.
+func verificationRunning(sr *platformv1alpha1.SharedResource) bool {
+	c := meta.FindStatusCondition(sr.Status.Conditions, ConditionTypeVerified)
+	return c != nil && c.Reason == "VerificationRunning"
+}
+
+// countVerified counts the targets verified (or released), still being
+// verified, and failed.
+func countVerified(targets []platformv1alpha1.TargetSyncStatus) (verified, running int, failures []string) {
+	for _, t := range targets {
+		switch {
+		case t.Released:
+			verified++
+		case !t.Synced || t.Verification == nil:
+		case t.Verification.Phase == platformv1alpha1.HookSucceeded:
+			verified++
+		case t.Verification.Phase == platformv1alpha1.HookRunning:
+			running++
+		default:
+			failures = append(failures, t.Namespace+"/"+t.Name)
+		}
+	}
+	return verified, running, failures
+}
+
+// applyVerifiedCondition reports the targets' verifications. Without
+// spec.verify the condition is removed.
+func (r *SharedResourceReconciler) applyVerifiedCondition(sr *platformv1alpha1.SharedResource, targets []platformv1alpha1.TargetSyncStatus) {
+	if sr.Spec.Verify == nil {
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeVerified)
+		return
+	}
+	if !r.VerifyJobs {
+		setCondition(sr, ConditionTypeVerified, metav1.ConditionFalse, "VerificationDisabled",
+			"The operator runs no verification Jobs (--enable-verify-jobs); targets are synced but not verified")
+		return
+	}
+	verified, running, failures := countVerified(targets)
+	reason, message := "AllTargetsVerified", fmt.Sprintf("%d of %d targets verified", verified, len(targets))
+	switch {
+	case len(failures) > 0:
+		reason = "VerificationFailed"
+		if len(failures) > maxVerifyFailures {
+			failures = append(failures[:maxVerifyFailures], fmt.Sprintf("and %d more", len(failures)-maxVerifyFailures))
+		}
+		message += "; failed: " + strings.Join(failures, ", ")
+	case running > 0:
+		reason = "VerificationRunning"
+		message += fmt.Sprintf("; %d Job(s) running", running)
+	}
+	status := metav1.ConditionTrue
+	if verified < len(targets) {
+		status = metav1.ConditionFalse
+	}
+	setCondition(sr, ConditionTypeVerified, status, reason, message)
+}
+
+// holdReadyForVerification sets Ready to False while fewer synced targets
+// than Ready needs (all, or spec.minReadyTargets) are verified, and for good
+// while verification Jobs are disabled.
+func (r *SharedResourceReconciler) holdReadyForVerification(sr *platformv1alpha1.SharedResource, targets []platformv1alpha1.TargetSyncStatus) {
+	if sr.Spec.Verify == nil || !conditionIsTrue(sr, ConditionTypeReady) {
+		return
+	}
+	if !r.VerifyJobs {
+		setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, "VerificationDisabled",
+			"Synced, but spec.verify is set and the operator runs no verification Jobs")
+		return
+	}
+	verified, _, failures := countVerified(targets)
+	if verified >= minReadyTargets(sr, len(targets)) {
+		return
+	}
+	reason := "VerificationPending"
+	if len(failures) > 0 {
+		reason = "VerificationFailed"
+	}
+	setCondition(sr, ConditionTypeReady, metav1.ConditionFalse, reason,
+		fmt.Sprintf("Synced, but only %d of %d targets verified", verified, len(targets)))
+}
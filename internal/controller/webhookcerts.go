@@ -0,0 +1,308 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/certs"
+)
+
+// =============================================================================
+// Webhook certificates - built-in issuance and rotation.
+//
+// Clusters without cert-manager still need a serving certificate for the
+// webhook server and a matching caBundle in the webhook configurations. The
+// rotator, run by every replica:
+//  1. Keeps a self-signed CA and serving certificate in a Secret, replacing
+//     both once the certificate is within RotateBefore of expiry
+//  2. Serves the certificate from memory, so no files are written
+//  3. Injects the CA (plus the previous CA, until it expires) into the named
+//     webhook configurations, so replicas still serving the old certificate
+//     stay trusted until they pick up the new one
+//
+// Replicas race to write the Secret with optimistic concurrency; the loser
+// re-reads it. With --webhook-cert-path set, certificates come from files
+// (e.g. cert-manager) and the rotator is not started; neither is it when the
+// operator serves no webhook.
+// =============================================================================
+
+// Keys of the webhook certificate Secret.
+const (
+	webhookCACertKey     = "ca.crt"
+	webhookCAKeyKey      = "ca.key"
+	webhookPreviousCAKey = "ca-previous.crt"
+)
+
+const (
+	// DefaultWebhookCertValidity is the lifetime of issued certificates
+	DefaultWebhookCertValidity = 365 * 24 * time.Hour
+
+	// DefaultWebhookCertRotateBefore is how long before expiry certificates are replaced
+	DefaultWebhookCertRotateBefore = 30 * 24 * time.Hour
+
+	// WebhookCertCheckInterval is how often replicas re-read the Secret to pick
+	// up certificates rotated by another replica
+	WebhookCertCheckInterval = 10 * time.Minute
+
+	// WebhookCertRetryInterval is the delay before retrying a failed rotation
+	WebhookCertRetryInterval = 10 * time.Second
+)
+
+// WebhookCertRotator issues and rotates the webhook serving certificate.
+type WebhookCertRotator struct {
+	// Client is used for writes
+	Client client.Client
+
+	// Reader is used for reads; pass the manager's API reader to bypass the cache
+	Reader client.Reader
+
+	// Namespace and SecretName locate the Secret holding the certificates
+	Namespace  string
+	SecretName string
+
+	// ServiceName is the webhook Service; the certificate covers its DNS names
+	ServiceName string
+
+	// ValidatingWebhooks and MutatingWebhooks name the configurations whose
+	// caBundle is kept in sync. Configurations that do not exist are skipped.
+	ValidatingWebhooks []string
+	MutatingWebhooks   []string
+
+	// FailurePolicy, if set, is enforced on every webhook in the configurations
+	FailurePolicy admissionregistrationv1.FailurePolicyType
+
+	// Validity and RotateBefore default to DefaultWebhookCertValidity and
+	// DefaultWebhookCertRotateBefore
+	Validity     time.Duration
+	RotateBefore time.Duration
+
+	cert atomic.Pointer[tls.Certificate]
+}
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations;mutatingwebhookconfigurations,verbs=get;update
+
+// Start rotates immediately, then re-checks until the context ends.
+// Implements manager.Runnable.
+func (w *WebhookCertRotator) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("webhook-certs")
+	ctx = logf.IntoContext(ctx, log)
+
+	for {
+		wait, err := w.Rotate(ctx)
+		if err != nil {
+			log.Error(err, "Webhook certificate rotation failed")
+			wait = WebhookCertRetryInterval
+		}
+		wait = min(wait, WebhookCertCheckInterval)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// NeedLeaderElection is false: every replica serves webhooks and needs the certificate.
+func (w *WebhookCertRotator) NeedLeaderElection() bool {
+	return false
+}
+
+// GetCertificate serves the current certificate. Use as tls.Config.GetCertificate.
+func (w *WebhookCertRotator) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if cert := w.cert.Load(); cert != nil {
+		return cert, nil
+	}
+	return nil, errors.New("webhook certificate not issued yet")
+}
+
+// ReadyCheck fails until a certificate is loaded. Use as a readyz check, so the
+// webhook Service only routes to replicas that can complete a TLS handshake.
+func (w *WebhookCertRotator) ReadyCheck(*http.Request) error {
+	if w.cert.Load() == nil {
+		return errors.New("webhook certificate not issued yet")
+	}
+	return nil
+}
+
+// dnsNames returns the names the API server may use to reach the Service.
+func (w *WebhookCertRotator) dnsNames() []string {
+	svc := w.ServiceName + "." + w.Namespace + ".svc"
+	return []string{svc, svc + ".cluster.local"}
+}
+
+// Rotate loads, and if needed replaces, the certificates, then injects the CA
+// bundle. Returns the time until the certificate is next due for rotation.
+func (w *WebhookCertRotator) Rotate(ctx context.Context) (time.Duration, error) {
+	validity, rotateBefore := w.Validity, w.RotateBefore
+	if validity <= 0 {
+		validity = DefaultWebhookCertValidity
+	}
+	if rotateBefore <= 0 {
+		rotateBefore = DefaultWebhookCertRotateBefore
+	}
+
+	key := types.NamespacedName{Namespace: w.Namespace, Name: w.SecretName}
+	secret := &corev1.Secret{}
+	exists := true
+	if err := w.Reader.Get(ctx, key, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to get webhook certificate Secret: %w", err)
+		}
+		exists = false
+	}
+
+	now := time.Now()
+	ca := certs.KeyPair{CertPEM: secret.Data[webhookCACertKey], KeyPEM: secret.Data[webhookCAKeyKey]}
+	serving := certs.KeyPair{CertPEM: secret.Data[corev1.TLSCertKey], KeyPEM: secret.Data[corev1.TLSPrivateKeyKey]}
+	expiry, err := certs.Verify(ca, serving, w.dnsNames(), now)
+	if err != nil || now.After(expiry.Add(-rotateBefore)) {
+		reason := "expiring"
+		if err != nil {
+			reason = err.Error()
+		}
+		logf.FromContext(ctx).Info("Issuing webhook certificate", "secret", key, "reason", reason)
+
+		previousCA := ca.CertPEM
+		if ca, err = certs.NewCA("sharedresource-operator-webhook-ca", validity, now); err != nil {
+			return 0, err
+		}
+		if serving, err = certs.NewServing(ca, w.dnsNames(), validity, now); err != nil {
+			return 0, err
+		}
+		secret.Name, secret.Namespace = key.Name, key.Namespace
+		if !exists {
+			secret.Type = corev1.SecretTypeTLS
+		}
+		secret.Data = map[string][]byte{
+			webhookCACertKey:        ca.CertPEM,
+			webhookCAKeyKey:         ca.KeyPEM,
+			webhookPreviousCAKey:    previousCA,
+			corev1.TLSCertKey:       serving.CertPEM,
+			corev1.TLSPrivateKeyKey: serving.KeyPEM,
+		}
+		if exists {
+			// Carries the resourceVersion read above: a replica that lost the race conflicts
+			err = w.Client.Update(ctx, secret)
+		} else {
+			err = w.Client.Create(ctx, secret)
+		}
+		if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+			// Another replica rotated first; pick up its certificate
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to write webhook certificate Secret: %w", err)
+		}
+		expiry = now.Add(validity)
+	}
+
+	cert, err := tls.X509KeyPair(serving.CertPEM, serving.KeyPEM)
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook serving certificate: %w", err)
+	}
+	w.cert.Store(&cert)
+
+	bundle := certs.Bundle(now, ca.CertPEM, secret.Data[webhookPreviousCAKey])
+	if err := w.injectCABundle(ctx, bundle); err != nil {
+		return 0, err
+	}
+	return time.Until(expiry.Add(-rotateBefore)), nil
+}
+
+// injectCABundle writes the CA bundle (and FailurePolicy, if set) into every
+// webhook of the configured webhook configurations.
+func (w *WebhookCertRotator) injectCABundle(ctx context.Context, bundle []byte) error {
+	for _, name := range w.ValidatingWebhooks {
+		if err := w.updateWebhookConfiguration(ctx, name, &admissionregistrationv1.ValidatingWebhookConfiguration{}, func(obj client.Object) bool {
+			cfg := obj.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+			changed := false
+			for i := range cfg.Webhooks {
+				changed = w.setClientConfig(&cfg.Webhooks[i].ClientConfig, &cfg.Webhooks[i].FailurePolicy, bundle) || changed
+			}
+			return changed
+		}); err != nil {
+			return err
+		}
+	}
+	for _, name := range w.MutatingWebhooks {
+		if err := w.updateWebhookConfiguration(ctx, name, &admissionregistrationv1.MutatingWebhookConfiguration{}, func(obj client.Object) bool {
+			cfg := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
+			changed := false
+			for i := range cfg.Webhooks {
+				changed = w.setClientConfig(&cfg.Webhooks[i].ClientConfig, &cfg.Webhooks[i].FailurePolicy, bundle) || changed
+			}
+			return changed
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setClientConfig applies the CA bundle and failure policy to one webhook.
+// Returns true if anything changed.
+func (w *WebhookCertRotator) setClientConfig(cfg *admissionregistrationv1.WebhookClientConfig, policy **admissionregistrationv1.FailurePolicyType, bundle []byte) bool {
+	changed := false
+	if !bytes.Equal(cfg.CABundle, bundle) { //nolint:forbidigo // CA bundles are public
+		cfg.CABundle = bundle
+		changed = true
+	}
+	if w.FailurePolicy != "" && (*policy == nil || **policy != w.FailurePolicy) {
+		fp := w.FailurePolicy
+		*policy = &fp
+		changed = true
+	}
+	return changed
+}
+
+// updateWebhookConfiguration re-reads and updates a webhook configuration if
+// mutate reports a change, retrying on conflicts.
+func (w *WebhookCertRotator) updateWebhookConfiguration(ctx context.Context, name string, obj client.Object, mutate func(client.Object) bool) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := w.Reader.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+			return err
+		}
+		if !mutate(obj) {
+			return nil
+		}
+		logf.FromContext(ctx).Info("Injecting webhook CA bundle", "webhookConfiguration", name)
+		return w.Client.Update(ctx, obj)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil // Not installed (yet); injected on the next check
+	}
+	if err != nil {
+		return fmt.Errorf("failed to inject CA bundle into %s: %w", name, err)
+	}
+	return nil
+}
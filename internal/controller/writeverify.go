@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/pkg/syncengine"
+)
+
+// =============================================================================
+// Write verification - spec.syncPolicy.verifyWrites.
+//
+// A mutating admission webhook can change the data of a target as it is
+// written. The write succeeds, so the target would be reported synced, and
+// the change only shows at the next drift check. With verifyWrites every
+// written target is read back straight from the API server (APIReader, a
+// quorum read rather than the possibly stale cache) and its data checksum
+// compared with the one recorded for the write; a mismatch fails the target.
+//
+// Correcting that drift would rewrite the data only for the webhook to alter
+// it again, on every event and resync. So a mutated target is not rewritten
+// while it still holds the altered data and we would send the same data
+// again: it stays failed with an explicit error, and the MutatedByAdmission
+// condition lists it. A new source value, an edit to the target or a sync-now
+// request tries the write again.
+// =============================================================================
+
+const (
+	// maxMutatedTargets caps how many targets the MutatedByAdmission condition names
+	maxMutatedTargets = 10
+)
+
+// errMutatedByAdmission marks a target whose written data admission altered.
+var errMutatedByAdmission = errors.New("data changed on write")
+
+// admissionMutation is a write that admission altered.
+type admissionMutation struct {
+	// sent is the checksum of the data the CR sends to the target
+	sent string
+
+	// stored is the checksum of the data the target holds afterwards
+	stored string
+}
+
+// verifyWrites returns true if the CR asks for written targets to be read back.
+func verifyWrites(sr *platformv1alpha1.SharedResource) bool {
+	return sr.Spec.SyncPolicy != nil && sr.Spec.SyncPolicy.VerifyWrites
+}
+
+// verifyWrite reads a target we just wrote back from the API server and
+// checks that it holds the data we wrote; sent is the checksum of the data
+// the CR sends to it. Without an APIReader the client is used, whose cache
+// may not have seen the write yet.
+func (r *SharedResourceReconciler) verifyWrite(ctx context.Context, kind string, key types.NamespacedName, sent string) error {
+	wk := writeKey(kind, key.Namespace, key.Name)
+	expected, ok := r.writes.Load(wk)
+	if !ok {
+		// Forgotten once the watch event showed the data as written (see predicates.go)
+		r.mutations.Delete(wk)
+		return nil
+	}
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+
+	obj, err := newTargetObject(kind)
+	if err != nil {
+		return err
+	}
+	if err := reader.Get(ctx, key, obj); err != nil {
+		return fmt.Errorf("failed to verify write: %w", err)
+	}
+	actual := syncengine.Checksum(objectData(obj))
+	if syncengine.ChecksumEqual(actual, expected.(string)) {
+		r.mutations.Delete(wk)
+		return nil
+	}
+
+	// The watch event of the altered object is ours too; do not act on it
+	r.writes.Store(wk, actual)
+	r.mutations.Store(wk, admissionMutation{sent: sent, stored: actual})
+	return fmt.Errorf("%w (checksum %s, expected %s); a mutating admission webhook may alter %s %s",
+		errMutatedByAdmission, actual, expected, kind, key)
+}
+
+// checkMutation returns an error instead of rewriting a target whose last
+// write admission altered, while it still holds the altered data and the CR
+// would send it the same data again.
+func (r *SharedResourceReconciler) checkMutation(
+	ctx context.Context,
+	sr *platformv1alpha1.SharedResource,
+	kind string,
+	key types.NamespacedName,
+	sent string,
+) error {
+	wk := writeKey(kind, key.Namespace, key.Name)
+	value, ok := r.mutations.Load(wk)
+	if !ok {
+		return nil
+	}
+	mutation := value.(admissionMutation)
+	if syncRequestPending(sr) || !syncengine.ChecksumEqual(mutation.sent, sent) {
+		r.mutations.Delete(wk)
+		return nil
+	}
+	obj, err := newTargetObject(kind)
+	if err != nil {
+		return err
+	}
+	if err := r.Get(ctx, key, obj); err != nil || !syncengine.ChecksumEqual(syncengine.Checksum(objectData(obj)), mutation.stored) {
+		// Gone or edited since; write it again
+		r.mutations.Delete(wk)
+		return nil
+	}
+	return fmt.Errorf("%w by admission; not rewritten until its data changes or %s is set",
+		errMutatedByAdmission, AnnotationSyncNow)
+}
+
+// objectData returns the data of a target Secret or ConfigMap.
+func objectData(obj client.Object) map[string][]byte {
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		return o.Data
+	case *corev1.ConfigMap:
+		return syncengine.FromStrings(o.Data)
+	}
+	return nil
+}
+
+// applyMutatedCondition records targets whose data admission altered. The
+// condition is only present while there are any.
+func applyMutatedCondition(sr *platformv1alpha1.SharedResource, targets []platformv1alpha1.TargetSyncStatus) {
+	var names []string
+	for _, t := range targets {
+		if t.MutatedByAdmission {
+			names = append(names, t.Namespace+"/"+t.Name)
+		}
+	}
+	if len(names) == 0 {
+		meta.RemoveStatusCondition(&sr.Status.Conditions, ConditionTypeMutatedByAdmission)
+		return
+	}
+	sort.Strings(names)
+	list := strings.Join(names, ", ")
+	if len(names) > maxMutatedTargets {
+		list = fmt.Sprintf("%s and %d more", strings.Join(names[:maxMutatedTargets], ", "), len(names)-maxMutatedTargets)
+	}
+	setCondition(sr, ConditionTypeMutatedByAdmission, metav1.ConditionTrue, "DataMutated",
+		"Admission altered the data written to "+list+"; not rewritten until the data changes")
+}
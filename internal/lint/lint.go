@@ -0,0 +1,437 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint validates SharedResource manifests offline, the way the API
+// server and the operator would, for use in CI before they reach a cluster.
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	apiextensionsinternal "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/objectmeta"
+	structuralpruning "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+)
+
+// =============================================================================
+// Offline manifest validation (srlint).
+//
+// Every object of a kind defined by the loaded CRDs is checked like the API
+// server would on create:
+//  1. Unknown fields, which the API server would silently drop
+//  2. The OpenAPI schema, after defaulting
+//  3. CEL validation rules (x-kubernetes-validations)
+//
+// SharedResources are then checked like the operator would:
+//  4. Everything the SharedResource webhook checks except disabled kinds:
+//     spec.targetTemplate, duplicate targets, the source name and namespace,
+//     namespace patterns, targetSelector, hook Jobs and template.valuesFrom
+//  5. With a namespace list: target namespaces must exist, and
+//     SharedResourcePolicies among the manifests must allow them. Group
+//     targets are checked for every member, and their NamespaceGroup must
+//     be among the manifests; so are the namespaces targetSelector matches
+// =============================================================================
+
+// Finding is a problem in one object.
+type Finding struct {
+	// Source is the file and document the object came from, e.g. "team.yaml#2"
+	Source string
+
+	// Object is "Kind namespace/name"
+	Object string
+
+	Message string
+}
+
+// String renders the finding as "source: object: message".
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Source, f.Object, f.Message)
+}
+
+// crdSchema validates objects of one CRD version.
+type crdSchema struct {
+	namespaced bool
+	structural *structuralschema.Structural
+	validator  apiservervalidation.SchemaValidator
+	cel        *cel.Validator
+}
+
+// object is a decoded manifest document.
+type object struct {
+	source string
+	obj    *unstructured.Unstructured
+}
+
+// Linter collects CRDs, namespaces and manifests, then validates the manifests.
+type Linter struct {
+	// DefaultNamespace is used for namespaced objects without one
+	DefaultNamespace string
+
+	schemas    map[schema.GroupVersionKind]*crdSchema
+	namespaces map[string]labels.Set
+	objects    []object
+}
+
+// New returns a Linter with no CRDs, no namespace list and "default" as the
+// default namespace.
+func New() *Linter {
+	return &Linter{DefaultNamespace: "default", schemas: map[schema.GroupVersionKind]*crdSchema{}}
+}
+
+// AddCRDs loads every CustomResourceDefinition in a YAML stream; other
+// documents are ignored, so a full install manifest can be passed.
+func (l *Linter) AddCRDs(manifests []byte) error {
+	docs, err := splitDocuments(manifests)
+	if err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := yaml.Unmarshal(doc, &crd); err != nil || crd.Kind != "CustomResourceDefinition" {
+			continue
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Schema == nil {
+				continue
+			}
+			s, err := newCRDSchema(version.Schema)
+			if err != nil {
+				return fmt.Errorf("CRD %s version %s: %w", crd.Name, version.Name, err)
+			}
+			s.namespaced = crd.Spec.Scope == apiextensionsv1.NamespaceScoped
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+			l.schemas[gvk] = s
+		}
+	}
+	return nil
+}
+
+// newCRDSchema builds the validators for one CRD version schema.
+func newCRDSchema(v *apiextensionsv1.CustomResourceValidation) (*crdSchema, error) {
+	var internal apiextensionsinternal.CustomResourceValidation
+	if err := apiextensionsv1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(v, &internal, nil); err != nil {
+		return nil, err
+	}
+	structural, err := structuralschema.NewStructural(internal.OpenAPIV3Schema)
+	if err != nil {
+		return nil, err
+	}
+	validator, _, err := apiservervalidation.NewSchemaValidator(internal.OpenAPIV3Schema)
+	if err != nil {
+		return nil, err
+	}
+	return &crdSchema{
+		structural: structural,
+		validator:  validator,
+		cel:        cel.NewValidator(structural, true, celconfig.PerCallLimit),
+	}, nil
+}
+
+// AddNamespaces loads the namespaces that exist, from Namespace documents or
+// a List of them (as printed by kubectl get namespaces -o yaml). Once any are
+// added, target namespaces are checked against them.
+func (l *Linter) AddNamespaces(manifests []byte) error {
+	if l.namespaces == nil {
+		l.namespaces = map[string]labels.Set{}
+	}
+	docs, err := splitDocuments(manifests)
+	if err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		var obj unstructured.Unstructured
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return fmt.Errorf("failed to decode namespace list: %w", err)
+		}
+		items := []unstructured.Unstructured{obj}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return err
+			}
+			items = list.Items
+		}
+		for _, item := range items {
+			if item.GetKind() == "Namespace" {
+				l.namespaces[item.GetName()] = labels.Set(item.GetLabels())
+			}
+		}
+	}
+	return nil
+}
+
+// AddManifests loads the objects to validate from a YAML stream. source names
+// the stream in findings.
+func (l *Linter) AddManifests(source string, manifests []byte) error {
+	docs, err := splitDocuments(manifests)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	for i, doc := range docs {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return fmt.Errorf("%s#%d: %w", source, i+1, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		l.objects = append(l.objects, object{source: fmt.Sprintf("%s#%d", source, i+1), obj: obj})
+	}
+	return nil
+}
+
+// Lint validates every loaded manifest and returns the findings, in input order.
+func (l *Linter) Lint(ctx context.Context) []Finding {
+	var findings []Finding
+	var policies []platformv1alpha1.SharedResourcePolicy
+	groups := map[string]*platformv1alpha1.NamespaceGroup{}
+	var sharedResources []object
+	for _, o := range l.objects {
+		gvk := o.obj.GroupVersionKind()
+		s, ok := l.schemas[gvk]
+		if !ok {
+			if gvk.Group == platformv1alpha1.GroupVersion.Group {
+				findings = append(findings, Finding{Source: o.source, Object: describe(o.obj),
+					Message: fmt.Sprintf("no CRD defines %s", gvk)})
+			}
+			continue
+		}
+		if s.namespaced && o.obj.GetNamespace() == "" {
+			o.obj.SetNamespace(l.DefaultNamespace)
+		}
+		errs := s.validate(ctx, o.obj)
+		for _, err := range errs {
+			findings = append(findings, Finding{Source: o.source, Object: describe(o.obj), Message: err.Error()})
+		}
+		if len(errs) > 0 {
+			continue
+		}
+
+		switch o.obj.GetKind() {
+		case "SharedResourcePolicy":
+			var policy platformv1alpha1.SharedResourcePolicy
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.obj.Object, &policy); err == nil {
+				policies = append(policies, policy)
+			}
+		case "NamespaceGroup":
+			var group platformv1alpha1.NamespaceGroup
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.obj.Object, &group); err == nil {
+				groups[group.Name] = &group
+			}
+		case "SharedResource":
+			sharedResources = append(sharedResources, o)
+		}
+	}
+
+	for _, o := range sharedResources {
+		var sr platformv1alpha1.SharedResource
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.obj.Object, &sr); err != nil {
+			findings = append(findings, Finding{Source: o.source, Object: describe(o.obj), Message: err.Error()})
+			continue
+		}
+		for _, message := range l.lintSharedResource(&sr, policies, groups) {
+			findings = append(findings, Finding{Source: o.source, Object: describe(o.obj), Message: message})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return l.index(findings[i].Source) < l.index(findings[j].Source) })
+	return findings
+}
+
+// validate checks an object against its CRD schema, like the API server on create.
+func (s *crdSchema) validate(ctx context.Context, obj *unstructured.Unstructured) field.ErrorList {
+	var errs field.ErrorList
+	if name := obj.GetName(); name == "" {
+		errs = append(errs, field.Required(field.NewPath("metadata", "name"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), name, msg))
+		}
+	}
+
+	content := obj.DeepCopy().Object
+	for _, path := range structuralpruning.PruneWithOptions(content, s.structural, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true}) {
+		errs = append(errs, field.Forbidden(field.NewPath(path), "unknown field, which the API server would drop"))
+	}
+	structuraldefaulting.Default(content, s.structural)
+
+	errs = append(errs, apiservervalidation.ValidateCustomResource(nil, content, s.validator)...)
+	errs = append(errs, objectmeta.Validate(nil, content, s.structural, true)...)
+	if len(errs) > 0 {
+		// CEL rules assume a schema-valid object
+		return errs
+	}
+	celErrs, _ := s.cel.Validate(ctx, nil, s.structural, content, nil, celconfig.RuntimeCELCostBudget)
+	return append(errs, celErrs...)
+}
+
+// lintSharedResource checks a schema-valid SharedResource like the operator would.
+func (l *Linter) lintSharedResource(
+	sr *platformv1alpha1.SharedResource,
+	policies []platformv1alpha1.SharedResourcePolicy,
+	groups map[string]*platformv1alpha1.NamespaceGroup,
+) []string {
+	var messages []string
+	if _, errs := controller.ParseTargetTemplate(sr); len(errs) > 0 {
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+	}
+	for _, err := range controller.DuplicateTargets(sr) {
+		messages = append(messages, err.Error())
+	}
+	for _, err := range controller.SourceNameErrors(sr) {
+		messages = append(messages, err.Error())
+	}
+	for _, err := range controller.SourceNamespaceErrors(sr) {
+		messages = append(messages, err.Error())
+	}
+	for _, err := range controller.NamespacePatternErrors(sr) {
+		messages = append(messages, err.Error())
+	}
+	for _, err := range controller.TargetSelectorErrors(sr) {
+		messages = append(messages, err.Error())
+	}
+	for _, err := range controller.VerifyErrors(sr) {
+		messages = append(messages, err.Error())
+	}
+	for _, err := range controller.PreSyncErrors(sr) {
+		messages = append(messages, err.Error())
+	}
+	for _, err := range controller.TemplateErrors(sr) {
+		messages = append(messages, err.Error())
+	}
+	if l.namespaces == nil {
+		return messages
+	}
+
+	if _, ok := l.namespaces[sr.Namespace]; !ok {
+		messages = append(messages, fmt.Sprintf("namespace %q does not exist", sr.Namespace))
+	}
+	for i, target := range sr.Spec.Targets {
+		path := field.NewPath("spec", "targets").Index(i).Child("namespace")
+		namespaces := []string{target.Namespace}
+		if target.Group != "" {
+			path = field.NewPath("spec", "targets").Index(i).Child("group")
+			group, ok := groups[target.Group]
+			if !ok {
+				messages = append(messages, fmt.Sprintf("%s: NamespaceGroup %q is not among the manifests", path, target.Group))
+				continue
+			}
+			members, err := controller.GroupNamespaces(group, l.namespaces)
+			if err != nil {
+				messages = append(messages, fmt.Sprintf("%s: %v", path, err))
+				continue
+			}
+			namespaces = members
+		} else if controller.IsNamespacePattern(target.Namespace) {
+			// A malformed pattern was reported above
+			namespaces, _ = controller.PatternNamespaces(sr, target.Namespace, l.namespaces)
+		}
+		for _, namespace := range namespaces {
+			messages = append(messages, l.lintTargetNamespace(path, sr, policies, namespace)...)
+		}
+	}
+	// An invalid selector was reported above
+	selected, _ := controller.SelectedNamespaces(sr, l.namespaces)
+	for _, namespace := range selected {
+		messages = append(messages, l.lintTargetNamespace(field.NewPath("spec", "targetSelector"), sr, policies, namespace)...)
+	}
+	return messages
+}
+
+// lintTargetNamespace checks that a target namespace exists and that the
+// SharedResourcePolicies allow it.
+func (l *Linter) lintTargetNamespace(
+	path *field.Path,
+	sr *platformv1alpha1.SharedResource,
+	policies []platformv1alpha1.SharedResourcePolicy,
+	namespace string,
+) []string {
+	nsLabels, ok := l.namespaces[namespace]
+	if !ok {
+		return []string{fmt.Sprintf("%s: target namespace %q does not exist", path, namespace)}
+	}
+	denied, err := controller.DeniedTargetNamespace(policies, sr, namespace, func() (labels.Set, error) {
+		return nsLabels, nil
+	})
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", path, err)}
+	}
+	if denied != "" {
+		return []string{fmt.Sprintf("%s: target namespace %q is not allowed by SharedResourcePolicy %q",
+			path, namespace, denied)}
+	}
+	return nil
+}
+
+// index returns the position of the object loaded from source.
+func (l *Linter) index(source string) int {
+	for i, o := range l.objects {
+		if o.source == source {
+			return i
+		}
+	}
+	return len(l.objects)
+}
+
+// describe renders an object as "Kind namespace/name".
+func describe(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetKind() + " " + obj.GetName()
+	}
+	return obj.GetKind() + " " + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// splitDocuments splits a YAML or JSON stream into non-empty documents.
+func splitDocuments(manifests []byte) ([][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifests)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) > 0 {
+			docs = append(docs, doc)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const namespaces = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: security
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: backend
+    labels:
+      tier: backend
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: frontend
+`
+
+const policy = `
+apiVersion: platform.platform.dev/v1alpha1
+kind: SharedResourcePolicy
+metadata:
+  name: backend-only
+  namespace: security
+spec:
+  targetNamespaceSelector:
+    matchLabels:
+      tier: backend
+---
+`
+
+// newLinter returns a Linter with the repository CRDs and, if set, namespaces.
+func newLinter(t *testing.T, withNamespaces bool) *Linter {
+	t.Helper()
+	l := New()
+	dir := filepath.Join("..", "..", "config", "crd", "bases")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := l.AddCRDs(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if withNamespaces {
+		if err := l.AddNamespaces([]byte(namespaces)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return l
+}
+
+// sharedResource renders a SharedResource in security with the given spec lines.
+func sharedResource(spec string) string {
+	return `
+apiVersion: platform.platform.dev/v1alpha1
+kind: SharedResource
+metadata:
+  name: share-db
+  namespace: security
+spec:
+` + spec
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name       string
+		manifests  string
+		namespaces bool
+		want       []string // substrings, one finding each
+	}{
+		{
+			name: "valid",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend}]
+`),
+		},
+		{
+			name: "unknown field",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend, namespaces: [a]}]
+`),
+			want: []string{"spec.targets[0].namespaces: Forbidden: unknown field"},
+		},
+		{
+			name: "schema",
+			manifests: sharedResource(`
+  source: {kind: Deployment, name: db}
+  targets: [{namespace: backend}]
+`),
+			want: []string{`spec.source.kind: Unsupported value: "Deployment"`},
+		},
+		{
+			name: "CEL rule",
+			manifests: sharedResource(`
+  source: {kind: ConfigMap, name: db}
+  generate: [{key: password}]
+  targets: [{namespace: backend}]
+`),
+			want: []string{"generate is only supported for Secret sources"},
+		},
+		{
+			name: "target template",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend}]
+  targetTemplate:
+    apiVersion: v1
+    kind: Secret
+    metadata:
+      finalizers: [example.com/hold]
+`),
+			want: []string{"spec.targetTemplate.metadata.finalizers"},
+		},
+		{
+			name: "duplicate target",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend}, {namespace: backend, name: db}]
+`),
+			want: []string{`spec.targets[1]: Duplicate value: "backend/db"`},
+		},
+		{
+			name: "source name template",
+			manifests: sharedResource(`
+  source: {kind: Secret, nameTemplate: "{{ .Name }}_Config"}
+  targets: [{namespace: backend}]
+`),
+			want: []string{"spec.source.nameTemplate: Invalid value"},
+		},
+		{
+			name: "verification Job template",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend}]
+  verify:
+    jobTemplate: {spec: {template: {spec: {}}}}
+`),
+			want: []string{"spec.verify.jobTemplate"},
+		},
+		{
+			name: "pre-sync Job template",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend}]
+  preSync:
+    job: {spec: {template: {spec: {}}}}
+`),
+			want: []string{"spec.preSync.job"},
+		},
+		{
+			name: "generated keys for a source in another namespace",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db, namespace: backend}
+  generate: [{key: password}]
+  targets: [{namespace: backend}]
+`),
+			want: []string{"spec.generate: Forbidden"},
+		},
+		{
+			name: "missing namespaces are not checked without a namespace list",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: payments}]
+`),
+		},
+		{
+			name: "missing target namespace",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: payments}]
+`),
+			namespaces: true,
+			want:       []string{`spec.targets[0].namespace: target namespace "payments" does not exist`},
+		},
+		{
+			name: "policy",
+			manifests: policy + sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend}, {namespace: frontend}]
+`),
+			namespaces: true,
+			want:       []string{`spec.targets[1].namespace: target namespace "frontend" is not allowed by SharedResourcePolicy "backend-only"`},
+		},
+		{
+			name: "group target",
+			manifests: policy + `
+apiVersion: platform.platform.dev/v1alpha1
+kind: NamespaceGroup
+metadata:
+  name: web
+spec:
+  namespaces: [frontend, payments]
+  selector:
+    matchLabels:
+      tier: backend
+---
+` + sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{group: web}]
+`),
+			namespaces: true,
+			want: []string{
+				`spec.targets[0].group: target namespace "frontend" is not allowed by SharedResourcePolicy "backend-only"`,
+				`spec.targets[0].group: target namespace "payments" does not exist`,
+			},
+		},
+		{
+			name: "missing group",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{group: web}]
+`),
+			namespaces: true,
+			want:       []string{`spec.targets[0].group: NamespaceGroup "web" is not among the manifests`},
+		},
+		{
+			name: "namespace and group",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: backend, group: web}]
+`),
+			want: []string{"exactly one of namespace and group is required"},
+		},
+		{
+			name: "target selector",
+			manifests: policy + sharedResource(`
+  source: {kind: Secret, name: db}
+  targetSelector: {matchExpressions: [{key: tier, operator: DoesNotExist}]}
+`),
+			namespaces: true,
+			want:       []string{`spec.targetSelector: target namespace "frontend" is not allowed by SharedResourcePolicy "backend-only"`},
+		},
+		{
+			name: "namespace pattern",
+			manifests: policy + sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: "*end"}]
+`),
+			namespaces: true,
+			want:       []string{`spec.targets[0].namespace: target namespace "frontend" is not allowed by SharedResourcePolicy "backend-only"`},
+		},
+		{
+			name: "malformed namespace pattern",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+  targets: [{namespace: "team-["}]
+`),
+			want: []string{"spec.targets[0].namespace: Invalid value: \"team-[\": malformed glob pattern"},
+		},
+		{
+			name: "template values without targetKind Secret",
+			manifests: sharedResource(`
+  source: {kind: ConfigMap, name: db-template}
+  template: {valuesFrom: {secretName: db-values}}
+  targets: [{namespace: backend}]
+`),
+			want: []string{"template.valuesFrom requires template.targetKind Secret"},
+		},
+		{
+			name: "no targets",
+			manifests: sharedResource(`
+  source: {kind: Secret, name: db}
+`),
+			want: []string{"targets or targetSelector is required"},
+		},
+		{
+			name: "unknown kind in the API group",
+			manifests: `
+apiVersion: platform.platform.dev/v1alpha1
+kind: SharedResources
+metadata:
+  name: typo
+`,
+			want: []string{"no CRD defines"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLinter(t, tt.namespaces)
+			if err := l.AddManifests("test.yaml", []byte(tt.manifests)); err != nil {
+				t.Fatal(err)
+			}
+			findings := l.Lint(context.Background())
+			if len(findings) != len(tt.want) {
+				t.Fatalf("Lint() = %v, want %d findings", findings, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got := findings[i].String(); !strings.Contains(got, want) {
+					t.Errorf("finding %d = %q, want it to contain %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultNamespace(t *testing.T) {
+	l := newLinter(t, false)
+	l.DefaultNamespace = "team-a"
+	if err := l.AddManifests("test.yaml", []byte(`
+apiVersion: platform.platform.dev/v1alpha1
+kind: SharedResource
+metadata:
+  name: share-db
+spec:
+  source: {kind: Secret, name: db}
+  targets: []
+`)); err != nil {
+		t.Fatal(err)
+	}
+	findings := l.Lint(context.Background())
+	if len(findings) != 1 || findings[0].Object != "SharedResource team-a/share-db" {
+		t.Errorf("Lint() = %v, want one finding for SharedResource team-a/share-db", findings)
+	}
+}
@@ -0,0 +1,512 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadgen generates SharedResource load against a cluster and
+// measures how fast the operator converges.
+//
+// A run creates, under a run ID:
+//   - one source namespace holding Config.Sources Secrets and
+//     Config.SharedResources SharedResources, spread over the sources
+//   - Config.Namespaces target namespaces; each SharedResource targets
+//     Config.TargetsPerSharedResource of them, so the load spreads evenly
+//
+// Two phases are measured. "create" times each SharedResource from its
+// creation until it is Ready with every target synced; "update" changes every
+// source and times each SharedResource until all its targets hold the new
+// data. Convergence is observed by listing the SharedResources every
+// Config.PollInterval, which bounds the latency resolution.
+package loadgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+)
+
+// LabelRun marks every object of a run with its run ID, for cleanup.
+const LabelRun = "loadgen.sharedresource.platform.dev/run"
+
+// Config sizes a run.
+type Config struct {
+	// RunID names the run's namespaces and labels its objects
+	RunID string
+
+	// Sources is the number of source Secrets
+	Sources int
+
+	// SharedResources is the number of SharedResources
+	SharedResources int
+
+	// Namespaces is the number of target namespaces
+	Namespaces int
+
+	// TargetsPerSharedResource is how many target namespaces each
+	// SharedResource targets, at most Namespaces
+	TargetsPerSharedResource int
+
+	// Keys and ValueSize shape each source's data
+	Keys      int
+	ValueSize int
+
+	// OperatorClass is set as spec.operatorClass, to load one instance
+	OperatorClass string
+
+	// Workers is the number of concurrent creates and updates
+	Workers int
+
+	// PollInterval is how often convergence is checked
+	PollInterval time.Duration
+
+	// Timeout bounds each measured phase
+	Timeout time.Duration
+}
+
+// Validate returns an error if the config cannot be run.
+func (c Config) Validate() error {
+	switch {
+	case c.RunID == "":
+		return fmt.Errorf("a run ID is required")
+	case c.Sources < 1 || c.SharedResources < 1 || c.Namespaces < 1:
+		return fmt.Errorf("sources, sharedresources and namespaces must be at least 1")
+	case c.TargetsPerSharedResource < 1 || c.TargetsPerSharedResource > c.Namespaces:
+		return fmt.Errorf("targets per SharedResource must be between 1 and the number of namespaces (%d)", c.Namespaces)
+	case c.Keys < 1 || c.ValueSize < 1:
+		return fmt.Errorf("keys and value size must be at least 1")
+	case c.Workers < 1 || c.PollInterval <= 0 || c.Timeout <= 0:
+		return fmt.Errorf("workers, poll interval and timeout must be positive")
+	}
+	return nil
+}
+
+// SourceNamespace returns the run's source namespace.
+func (c Config) SourceNamespace() string {
+	return "loadgen-" + c.RunID
+}
+
+// TargetNamespace returns the name of the i-th target namespace.
+func (c Config) TargetNamespace(i int) string {
+	return fmt.Sprintf("loadgen-%s-t%d", c.RunID, i)
+}
+
+// SharedResource returns the i-th SharedResource. Its targets start at
+// namespace i, wrapping around, and are named after it, so SharedResources
+// sharing a source never write the same target.
+func (c Config) SharedResource(i int) *platformv1alpha1.SharedResource {
+	name := fmt.Sprintf("loadgen-sr-%d", i)
+	targets := make([]platformv1alpha1.TargetSpec, 0, c.TargetsPerSharedResource)
+	for j := range c.TargetsPerSharedResource {
+		targets = append(targets, platformv1alpha1.TargetSpec{
+			Namespace: c.TargetNamespace((i + j) % c.Namespaces),
+			Name:      name,
+		})
+	}
+	return &platformv1alpha1.SharedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.SourceNamespace(),
+			Labels:    map[string]string{LabelRun: c.RunID},
+		},
+		Spec: platformv1alpha1.SharedResourceSpec{
+			Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: sourceName(i % c.Sources)},
+			Targets:        targets,
+			DeletionPolicy: platformv1alpha1.DeletionPolicyDelete,
+			OperatorClass:  c.OperatorClass,
+		},
+	}
+}
+
+// sourceData returns a source's data for a generation of the run.
+func (c Config) sourceData(generation int) map[string][]byte {
+	data := make(map[string][]byte, c.Keys)
+	value := strings.Repeat(fmt.Sprintf("%d", generation%10), c.ValueSize)
+	for k := range c.Keys {
+		data[fmt.Sprintf("key-%d", k)] = []byte(value)
+	}
+	return data
+}
+
+// sourceName returns the name of the i-th source.
+func sourceName(i int) string {
+	return fmt.Sprintf("loadgen-src-%d", i)
+}
+
+// Phase is the result of one measured phase.
+type Phase struct {
+	Name string `json:"name"`
+
+	// Converged is how many SharedResources converged within the timeout
+	Converged int `json:"converged"`
+
+	// TimedOut is how many did not
+	TimedOut int `json:"timedOut"`
+
+	// Elapsed is the time until the last SharedResource converged
+	Elapsed time.Duration `json:"elapsed"`
+
+	// SharedResourcesPerSecond and TargetsPerSecond are the throughput
+	SharedResourcesPerSecond float64 `json:"sharedResourcesPerSecond"`
+	TargetsPerSecond         float64 `json:"targetsPerSecond"`
+
+	// Latency percentiles of the converged SharedResources. Durations are
+	// in nanoseconds in JSON.
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// newPhase summarizes latencies measured over elapsed.
+func newPhase(name string, latencies []time.Duration, timedOut, targetsEach int, elapsed time.Duration) Phase {
+	slices.Sort(latencies)
+	phase := Phase{Name: name, Converged: len(latencies), TimedOut: timedOut, Elapsed: elapsed}
+	if len(latencies) == 0 {
+		return phase
+	}
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		phase.SharedResourcesPerSecond = float64(len(latencies)) / seconds
+		phase.TargetsPerSecond = float64(len(latencies)*targetsEach) / seconds
+	}
+	phase.P50 = percentile(latencies, 50)
+	phase.P90 = percentile(latencies, 90)
+	phase.P99 = percentile(latencies, 99)
+	phase.Max = latencies[len(latencies)-1]
+	return phase
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// Report is the result of a run.
+type Report struct {
+	Config Config  `json:"config"`
+	Phases []Phase `json:"phases"`
+}
+
+// WriteText writes the report as a table.
+func (r *Report) WriteText(w io.Writer) error {
+	c := r.Config
+	if _, err := fmt.Fprintf(w, "run %s: %d sources, %d SharedResources, %d namespaces, %d targets each (%d targets)\n\n",
+		c.RunID, c.Sources, c.SharedResources, c.Namespaces, c.TargetsPerSharedResource,
+		c.SharedResources*c.TargetsPerSharedResource); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%-8s %9s %8s %10s %8s %10s %10s %10s %10s %10s\n",
+		"phase", "converged", "timeout", "elapsed", "SR/s", "targets/s", "p50", "p90", "p99", "max"); err != nil {
+		return err
+	}
+	for _, p := range r.Phases {
+		if _, err := fmt.Fprintf(w, "%-8s %9d %8d %10s %8.1f %10.1f %10s %10s %10s %10s\n",
+			p.Name, p.Converged, p.TimedOut, round(p.Elapsed), p.SharedResourcesPerSecond, p.TargetsPerSecond,
+			round(p.P50), round(p.P90), round(p.P99), round(p.Max)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// round shortens a duration for the table.
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
+
+// Runner runs a load test against a cluster.
+type Runner struct {
+	// Client creates the load; it needs no cache
+	Client client.Client
+
+	Config Config
+
+	// Progress, if set, receives a line per step
+	Progress io.Writer
+}
+
+// Run sets up the run's objects, measures both phases and returns the report.
+// The objects are left in place; see Cleanup.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	if err := r.Config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := r.setup(ctx); err != nil {
+		return nil, err
+	}
+	report := &Report{Config: r.Config}
+
+	r.progress("creating %d SharedResources", r.Config.SharedResources)
+	created, err := r.createSharedResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	phase, checksums, err := r.measure(ctx, "create", created, func(sr *platformv1alpha1.SharedResource, _ string) bool {
+		return ready(sr)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	report.Phases = append(report.Phases, phase)
+
+	r.progress("updating %d sources", r.Config.Sources)
+	updated, err := r.updateSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	phase, _, err = r.measure(ctx, "update", updated, func(sr *platformv1alpha1.SharedResource, before string) bool {
+		return sr.Status.SourceChecksum != before && sr.Status.AllTargetsAtChecksum
+	}, checksums)
+	if err != nil {
+		return nil, err
+	}
+	report.Phases = append(report.Phases, phase)
+	return report, nil
+}
+
+// setup creates the run's namespaces and sources.
+func (r *Runner) setup(ctx context.Context) error {
+	c := r.Config
+	r.progress("creating %d namespaces and %d sources", c.Namespaces+1, c.Sources)
+	names := []string{c.SourceNamespace()}
+	for i := range c.Namespaces {
+		names = append(names, c.TargetNamespace(i))
+	}
+	if err := r.parallel(ctx, len(names), func(ctx context.Context, i int) error {
+		return r.Client.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: names[i], Labels: map[string]string{LabelRun: c.RunID},
+		}})
+	}); err != nil {
+		return fmt.Errorf("failed to create namespaces: %w", err)
+	}
+	if err := r.parallel(ctx, c.Sources, func(ctx context.Context, i int) error {
+		return r.Client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sourceName(i), Namespace: c.SourceNamespace(), Labels: map[string]string{LabelRun: c.RunID},
+			},
+			Data: c.sourceData(0),
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to create sources: %w", err)
+	}
+	return nil
+}
+
+// createSharedResources creates the SharedResources and returns when each
+// was created.
+func (r *Runner) createSharedResources(ctx context.Context) (map[string]time.Time, error) {
+	var mu sync.Mutex
+	created := make(map[string]time.Time, r.Config.SharedResources)
+	err := r.parallel(ctx, r.Config.SharedResources, func(ctx context.Context, i int) error {
+		sr := r.Config.SharedResource(i)
+		start := time.Now()
+		if err := r.Client.Create(ctx, sr); err != nil {
+			return err
+		}
+		mu.Lock()
+		created[sr.Name] = start
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SharedResources: %w", err)
+	}
+	return created, nil
+}
+
+// updateSources gives every source new data and returns, for each
+// SharedResource, when its source was updated.
+func (r *Runner) updateSources(ctx context.Context) (map[string]time.Time, error) {
+	c := r.Config
+	updatedAt := make([]time.Time, c.Sources)
+	err := r.parallel(ctx, c.Sources, func(ctx context.Context, i int) error {
+		source := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: c.SourceNamespace(), Name: sourceName(i)}, source); err != nil {
+			return err
+		}
+		source.Data = c.sourceData(1)
+		updatedAt[i] = time.Now()
+		return r.Client.Update(ctx, source)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sources: %w", err)
+	}
+	updated := make(map[string]time.Time, c.SharedResources)
+	for i := range c.SharedResources {
+		updated[c.SharedResource(i).Name] = updatedAt[i%c.Sources]
+	}
+	return updated, nil
+}
+
+// measure polls the SharedResources until each started one satisfies
+// converged, given its source checksum before the phase, or the timeout
+// passes. It returns the phase and each SharedResource's source checksum at
+// the end.
+func (r *Runner) measure(
+	ctx context.Context,
+	name string,
+	started map[string]time.Time,
+	converged func(sr *platformv1alpha1.SharedResource, before string) bool,
+	before map[string]string,
+) (Phase, map[string]string, error) {
+	begin := time.Now()
+	for _, t := range started {
+		if t.Before(begin) {
+			begin = t
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.Config.Timeout)
+	defer cancel()
+
+	checksums := make(map[string]string, len(started))
+	latencies := make([]time.Duration, 0, len(started))
+	var last time.Time
+	ticker := time.NewTicker(r.Config.PollInterval)
+	defer ticker.Stop()
+poll:
+	for len(latencies) < len(started) {
+		var list platformv1alpha1.SharedResourceList
+		if err := r.Client.List(ctx, &list, client.InNamespace(r.Config.SourceNamespace()),
+			client.MatchingLabels{LabelRun: r.Config.RunID}); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return Phase{}, nil, fmt.Errorf("failed to list SharedResources: %w", err)
+		}
+		now := time.Now()
+		for i := range list.Items {
+			sr := &list.Items[i]
+			start, ok := started[sr.Name]
+			if _, done := checksums[sr.Name]; !ok || done || !converged(sr, before[sr.Name]) {
+				continue
+			}
+			checksums[sr.Name] = sr.Status.SourceChecksum
+			latencies = append(latencies, now.Sub(start))
+			last = now
+		}
+		r.progress("%s: %d/%d converged", name, len(latencies), len(started))
+		if len(latencies) == len(started) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break poll
+		case <-ticker.C:
+		}
+	}
+	if last.IsZero() {
+		last = begin
+	}
+	return newPhase(name, latencies, len(started)-len(latencies), r.Config.TargetsPerSharedResource, last.Sub(begin)),
+		checksums, nil
+}
+
+// Cleanup deletes the run's SharedResources, whose deletion policy removes
+// their targets, and then its namespaces.
+func (r *Runner) Cleanup(ctx context.Context) error {
+	c := r.Config
+	r.progress("deleting run %s", c.RunID)
+	if err := r.Client.DeleteAllOf(ctx, &platformv1alpha1.SharedResource{}, client.InNamespace(c.SourceNamespace()),
+		client.MatchingLabels{LabelRun: c.RunID}); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	var namespaces corev1.NamespaceList
+	if err := r.Client.List(ctx, &namespaces, client.MatchingLabels{LabelRun: c.RunID}); err != nil {
+		return err
+	}
+	for i := range namespaces.Items {
+		if err := r.Client.Delete(ctx, &namespaces.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parallel calls fn for 0..n-1 on Config.Workers goroutines. The first
+// error cancels the rest and is returned.
+func (r *Runner) parallel(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		first error
+		wg    sync.WaitGroup
+	)
+	indexes := make(chan int)
+	for range min(r.Config.Workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(ctx, i); err != nil {
+					mu.Lock()
+					if first == nil {
+						first = err
+						cancel()
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+feed:
+	for i := range n {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	if first != nil {
+		return first
+	}
+	return ctx.Err()
+}
+
+// progress writes a progress line if progress is enabled.
+func (r *Runner) progress(format string, args ...any) {
+	if r.Progress != nil {
+		_, _ = fmt.Fprintf(r.Progress, format+"\n", args...)
+	}
+}
+
+// ready returns true if the SharedResource is Ready with its current spec
+// synced to every target.
+func ready(sr *platformv1alpha1.SharedResource) bool {
+	return sr.Status.ObservedGeneration == sr.Generation && sr.Status.AllTargetsAtChecksum &&
+		meta.IsStatusConditionTrue(sr.Status.Conditions, controller.ConditionTypeReady)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSharedResourceTargets(t *testing.T) {
+	c := Config{RunID: "t", Sources: 2, SharedResources: 4, Namespaces: 3, TargetsPerSharedResource: 2}
+	sr := c.SharedResource(2)
+	if sr.Spec.Source.Name != "loadgen-src-0" {
+		t.Errorf("source = %q, want loadgen-src-0", sr.Spec.Source.Name)
+	}
+	// Targets wrap around the namespaces
+	want := []string{"loadgen-t-t2", "loadgen-t-t0"}
+	if len(sr.Spec.Targets) != len(want) {
+		t.Fatalf("targets = %v, want namespaces %v", sr.Spec.Targets, want)
+	}
+	for i, target := range sr.Spec.Targets {
+		if target.Namespace != want[i] || target.Name != sr.Name {
+			t.Errorf("target %d = %s/%s, want %s/%s", i, target.Namespace, target.Name, want[i], sr.Name)
+		}
+	}
+}
+
+func TestNewPhase(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	phase := newPhase("create", latencies, 1, 3, 2*time.Second)
+	if phase.Converged != 100 || phase.TimedOut != 1 {
+		t.Errorf("converged, timed out = %d, %d, want 100, 1", phase.Converged, phase.TimedOut)
+	}
+	if phase.SharedResourcesPerSecond != 50 || phase.TargetsPerSecond != 150 {
+		t.Errorf("throughput = %v, %v, want 50, 150", phase.SharedResourcesPerSecond, phase.TargetsPerSecond)
+	}
+	if phase.P50 != 50*time.Millisecond || phase.P99 != 99*time.Millisecond || phase.Max != 100*time.Millisecond {
+		t.Errorf("p50, p99, max = %v, %v, %v, want 50ms, 99ms, 100ms", phase.P50, phase.P99, phase.Max)
+	}
+
+	// Nothing converged
+	if phase := newPhase("update", nil, 4, 3, 0); phase.TimedOut != 4 || phase.P50 != 0 {
+		t.Errorf("newPhase() = %+v, want 4 timed out and no latencies", phase)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certs generates the self-signed CA and serving certificate used by
+// the webhook server when no external certificate manager is installed.
+//
+// Nothing here talks to the Kubernetes API; the controller stores the PEM
+// output in a Secret and injects the CA into webhook configurations.
+package certs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// clockSkew backdates NotBefore so freshly issued certificates are valid on
+// API servers whose clock runs slightly behind ours.
+const clockSkew = 5 * time.Minute
+
+// KeyPair is a PEM-encoded certificate and its private key.
+type KeyPair struct {
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// NewCA returns a self-signed CA valid from now for the given duration.
+func NewCA(commonName string, validity time.Duration, now time.Time) (KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return issue(template, nil, nil)
+}
+
+// NewServing returns a serving certificate for the DNS names, signed by the CA.
+// It expires no later than the CA.
+func NewServing(ca KeyPair, dnsNames []string, validity time.Duration, now time.Time) (KeyPair, error) {
+	if len(dnsNames) == 0 {
+		return KeyPair{}, errors.New("at least one DNS name is required")
+	}
+	caCert, caKey, err := parse(ca)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("invalid CA: %w", err)
+	}
+	notAfter := now.Add(validity)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-clockSkew),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return issue(template, caCert, caKey)
+}
+
+// Verify checks that the serving certificate chains to the CA, covers the
+// DNS names and is valid at the given time. Returns its expiry.
+func Verify(ca, serving KeyPair, dnsNames []string, now time.Time) (time.Time, error) {
+	caCert, _, err := parse(ca)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid CA: %w", err)
+	}
+	cert, _, err := parse(serving)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid serving certificate: %w", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	for _, name := range dnsNames {
+		if _, err := cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots, CurrentTime: now}); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return cert.NotAfter, nil
+}
+
+// Bundle concatenates PEM certificates, skipping empty entries and any that
+// have expired at the given time.
+func Bundle(now time.Time, certPEMs ...[]byte) []byte {
+	var out bytes.Buffer
+	for _, certPEM := range certPEMs {
+		block, _ := pem.Decode(certPEM)
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || now.After(cert.NotAfter) {
+			continue
+		}
+		_ = pem.Encode(&out, block)
+	}
+	return out.Bytes()
+}
+
+// issue signs the template with the parent, or self-signs it if parent is nil.
+func issue(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return KeyPair{}, err
+	}
+	template.SerialNumber = serial
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	return KeyPair{
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// parse decodes a key pair issued by this package.
+func parse(kp KeyPair) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(kp.CertPEM)
+	if certBlock == nil {
+		return nil, nil, errors.New("no PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBlock, _ := pem.Decode(kp.KeyPEM)
+	if keyBlock == nil {
+		return nil, nil, errors.New("no PEM private key")
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
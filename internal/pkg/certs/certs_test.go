@@ -0,0 +1,80 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"crypto/tls"
+	"testing"
+	"time"
+)
+
+func TestIssueAndVerify(t *testing.T) {
+	now := time.Now()
+	names := []string{"webhook-service.system.svc", "webhook-service.system.svc.cluster.local"}
+
+	ca, err := NewCA("test-ca", 24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("NewCA() error = %v", err)
+	}
+	serving, err := NewServing(ca, names, 48*time.Hour, now)
+	if err != nil {
+		t.Fatalf("NewServing() error = %v", err)
+	}
+	if _, err := tls.X509KeyPair(serving.CertPEM, serving.KeyPEM); err != nil {
+		t.Errorf("serving key pair unusable by crypto/tls: %v", err)
+	}
+
+	expiry, err := Verify(ca, serving, names, now)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if want := now.Add(24 * time.Hour); expiry.After(want) {
+		t.Errorf("serving expiry %v outlives the CA (%v)", expiry, want)
+	}
+
+	if _, err := Verify(ca, serving, []string{"other.svc"}, now); err == nil {
+		t.Error("expected error for a DNS name not on the certificate")
+	}
+	if _, err := Verify(ca, serving, names, now.Add(25*time.Hour)); err == nil {
+		t.Error("expected error for an expired certificate")
+	}
+	otherCA, _ := NewCA("other-ca", time.Hour, now)
+	if _, err := Verify(otherCA, serving, names, now); err == nil {
+		t.Error("expected error for a certificate signed by another CA")
+	}
+	if _, err := NewServing(ca, nil, time.Hour, now); err == nil {
+		t.Error("expected error without DNS names")
+	}
+}
+
+func TestBundle(t *testing.T) {
+	now := time.Now()
+	current, _ := NewCA("current", 24*time.Hour, now)
+	expired, _ := NewCA("expired", time.Hour, now.Add(-2*time.Hour))
+
+	got := Bundle(now, current.CertPEM, nil, expired.CertPEM, []byte("garbage"))
+	if !bytes.Equal(got, current.CertPEM) {
+		t.Errorf("Bundle() = %q, want only the current CA", got)
+	}
+
+	previous, _ := NewCA("previous", 24*time.Hour, now)
+	got = Bundle(now, current.CertPEM, previous.CertPEM)
+	if want := append(append([]byte{}, current.CertPEM...), previous.CertPEM...); !bytes.Equal(got, want) {
+		t.Error("Bundle() did not keep both valid CAs in order")
+	}
+}
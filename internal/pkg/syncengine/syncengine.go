@@ -0,0 +1,665 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package syncengine holds the pure data logic behind a sync: filtering,
+// merging, rendering, generating, checksumming and diffing key/value data.
+//
+// Nothing here talks to the Kubernetes API. The controller feeds it data it
+// has fetched, and other tools (e.g. a diff command) can reuse it to predict
+// exactly what the controller would write.
+package syncengine
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// =============================================================================
+// Checksums
+// =============================================================================
+
+// Checksum generates a SHA256 hash of the data for drift detection.
+//
+// Why checksums?
+// - Avoids unnecessary updates when data hasn't changed
+// - Keys are sorted for deterministic hashes regardless of map iteration order
+// - Stored as annotation on target resources for comparison
+func Checksum(data map[string][]byte) string {
+	// Sort keys for deterministic ordering
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// Hash key-value pairs
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte("="))
+		h.Write(data[k])
+		h.Write([]byte("\n"))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChecksumEqual compares two checksums in constant time, so how long a
+// comparison takes reveals nothing about how much of a checksum of secret data
+// matched. Compare checksums with this, never with ==.
+func ChecksumEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// SameData reports whether two data maps hold the same keys and values,
+// comparing their checksums in constant time.
+func SameData(a, b map[string][]byte) bool {
+	return ChecksumEqual(Checksum(a), Checksum(b))
+}
+
+// ValueEqual compares two values in constant time for equal lengths. Compare
+// values with this, never with bytes.Equal.
+func ValueEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// =============================================================================
+// Redaction
+// =============================================================================
+
+// Redacted guards data on its way to a log, event or error message: every way
+// of formatting it (fmt verbs, JSON, logr) shows only the keys and the length
+// of their values. Wrap data before handing it to anything that may print it.
+type Redacted map[string][]byte
+
+// sizes maps each key to the length of its value.
+func (d Redacted) sizes() map[string]int {
+	sizes := make(map[string]int, len(d))
+	for k, v := range d {
+		sizes[k] = len(v)
+	}
+	return sizes
+}
+
+// String lists the keys (sorted) and the length of their values.
+func (d Redacted) String() string {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s: %d bytes", k, len(d[k]))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// Format prints String for every verb, including %#v and %x.
+func (d Redacted) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, d.String())
+}
+
+// MarshalJSON encodes the value lengths by key.
+func (d Redacted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.sizes())
+}
+
+// MarshalLog implements logr.Marshaler, so structured loggers log the value
+// lengths by key.
+func (d Redacted) MarshalLog() any {
+	return d.sizes()
+}
+
+// =============================================================================
+// Conversions
+// =============================================================================
+
+// FromStrings converts ConfigMap string data to []byte for uniform handling.
+func FromStrings(data map[string]string) map[string][]byte {
+	out := make(map[string][]byte, len(data))
+	for k, v := range data {
+		out[k] = []byte(v)
+	}
+	return out
+}
+
+// ToStrings converts []byte data back to strings for ConfigMaps.
+func ToStrings(data map[string][]byte) map[string]string {
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		out[k] = string(v)
+	}
+	return out
+}
+
+// =============================================================================
+// Filtering
+// =============================================================================
+
+// Restriction is a key policy imposed on the source data, independent of any
+// SharedResource: the source owner's share-keys/exclude-keys annotations.
+type Restriction struct {
+	// Allowed lists the only keys that may be shared, if AllowedSet is true.
+	// An empty list with AllowedSet means nothing may be shared.
+	Allowed    []string
+	AllowedSet bool
+
+	// Excluded lists keys that are never shared
+	Excluded []string
+}
+
+// Restrict applies a Restriction. Data is returned unchanged if it restricts nothing.
+func Restrict(data map[string][]byte, r Restriction) map[string][]byte {
+	if !r.AllowedSet && len(r.Excluded) == 0 {
+		return data
+	}
+	keys := &platformv1alpha1.KeySelector{Exclude: r.Excluded}
+	if r.AllowedSet {
+		if len(r.Allowed) == 0 {
+			return map[string][]byte{}
+		}
+		keys.Include = r.Allowed
+	}
+	return FilterKeys(data, keys)
+}
+
+// profileKeys lists the keys each SyncProfile syncs.
+var profileKeys = map[platformv1alpha1.SyncProfile][]string{
+	platformv1alpha1.SyncProfileTLS:          {"tls.crt", "tls.key", "ca.crt"},
+	platformv1alpha1.SyncProfileDockerConfig: {".dockerconfigjson", ".dockercfg"},
+}
+
+// ProfileKeys returns the keys a SyncProfile syncs, or nil for no (or an unknown) profile.
+func ProfileKeys(profile platformv1alpha1.SyncProfile) []string {
+	return profileKeys[profile]
+}
+
+// Filter applies the SyncPolicy to filter which keys to sync.
+//
+// Filtering modes:
+// - "copy" (default): All keys are synced
+// - "selective" (or "merge" with keys): Only keys matching Include/Exclude rules are synced
+//
+// A profile first limits the data to its keys, in every mode.
+func Filter(data map[string][]byte, policy *platformv1alpha1.SyncPolicySpec) map[string][]byte {
+	if policy == nil {
+		return data
+	}
+	if keys := ProfileKeys(policy.Profile); keys != nil {
+		data = FilterKeys(data, &platformv1alpha1.KeySelector{Include: keys})
+	}
+
+	// If copy mode, return all data
+	if policy.Mode == "" || policy.Mode == platformv1alpha1.SyncModeCopy {
+		return data
+	}
+
+	// Selective mode - apply key filtering
+	if policy.Keys == nil {
+		// Warning: selective mode without keys specification returns all data
+		// This is likely a user configuration error
+		return data
+	}
+
+	return FilterKeys(data, policy.Keys)
+}
+
+// FilterKeys applies Include, then Exclude, rules from a KeySelector.
+func FilterKeys(data map[string][]byte, keys *platformv1alpha1.KeySelector) map[string][]byte {
+	filtered := make(map[string][]byte)
+
+	// If Include is specified, only include those keys
+	if len(keys.Include) > 0 {
+		for _, key := range keys.Include {
+			if val, ok := data[key]; ok {
+				filtered[key] = val
+			}
+		}
+	} else {
+		// No Include list means start with all keys
+		for k, v := range data {
+			filtered[k] = v
+		}
+	}
+
+	// Apply Exclude filter
+	for _, key := range keys.Exclude {
+		delete(filtered, key)
+	}
+
+	return filtered
+}
+
+// =============================================================================
+// Merging
+// =============================================================================
+
+// Merge computes the data a target should hold, given its existing data and
+// the (filtered) source data.
+//
+// Behavior depends on mode:
+// - "merge": Source keys are synced, extra target keys are preserved (source wins conflicts)
+// - anything else ("copy", "selective"): Target data = source data exactly
+func Merge(existing, source map[string][]byte, mode platformv1alpha1.SyncMode) map[string][]byte {
+	if mode != platformv1alpha1.SyncModeMerge {
+		return source
+	}
+	merged := make(map[string][]byte, len(existing)+len(source))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range source {
+		merged[k] = v
+	}
+	return merged
+}
+
+// KeyOwners maps each SharedResource (as namespace/name) merging into a shared
+// target to the keys it wrote there.
+type KeyOwners map[string][]string
+
+// Owner returns the owner of key other than self, or "" if there is none.
+func (o KeyOwners) Owner(key, self string) string {
+	owners := make([]string, 0, len(o))
+	for owner := range o {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners) // Deterministic if a key was ever claimed twice
+	for _, owner := range owners {
+		if owner == self {
+			continue
+		}
+		for _, k := range o[owner] {
+			if k == key {
+				return owner
+			}
+		}
+	}
+	return ""
+}
+
+// MergeOwned merges source into a target shared by several SharedResources.
+//
+// Keys self wrote before and are no longer in source are removed; keys owned
+// by another SharedResource are left alone and reported as conflicts (key ->
+// owner). Other existing keys are kept, as in merge mode. Returns the merged
+// data and the sorted keys self now owns. Neither input is modified.
+func MergeOwned(existing, source map[string][]byte, owners KeyOwners, self string) (map[string][]byte, []string, map[string]string) {
+	merged := make(map[string][]byte, len(existing)+len(source))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for _, k := range owners[self] {
+		if _, ok := source[k]; !ok && owners.Owner(k, self) == "" {
+			delete(merged, k)
+		}
+	}
+
+	var owned []string
+	var conflicts map[string]string
+	for k, v := range source {
+		if other := owners.Owner(k, self); other != "" {
+			if conflicts == nil {
+				conflicts = map[string]string{}
+			}
+			conflicts[k] = other
+			continue
+		}
+		merged[k] = v
+		owned = append(owned, k)
+	}
+	sort.Strings(owned)
+	return merged, owned, conflicts
+}
+
+// Prefix returns a copy of data with every key prefixed. Data is returned
+// unchanged if prefix is empty.
+func Prefix(data map[string][]byte, prefix string) map[string][]byte {
+	if prefix == "" {
+		return data
+	}
+	prefixed := make(map[string][]byte, len(data))
+	for k, v := range data {
+		prefixed[prefix+k] = v
+	}
+	return prefixed
+}
+
+// =============================================================================
+// Splitting
+// =============================================================================
+
+// DefaultSplitKeySuffix is appended to document names without a keySuffix.
+const DefaultSplitKeySuffix = ".yaml"
+
+// Split returns a copy of data with each key named by a split replaced by
+// one key per document of the multi-document YAML it holds, named by the
+// document's nameField plus keySuffix. Documents keep their original text;
+// empty documents are skipped. Data is returned unchanged without splits.
+func Split(data map[string][]byte, splits []platformv1alpha1.SplitSpec) (map[string][]byte, error) {
+	if len(splits) == 0 {
+		return data, nil
+	}
+	out := make(map[string][]byte, len(data))
+	for k, v := range data {
+		out[k] = v
+	}
+	for _, split := range splits {
+		bundle, ok := data[split.Key]
+		if !ok {
+			continue
+		}
+		if !split.KeepSource {
+			delete(out, split.Key)
+		}
+		docs, err := yamlDocuments(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("split of key %q: %w", split.Key, err)
+		}
+		suffix := DefaultSplitKeySuffix
+		if split.KeySuffix != nil {
+			suffix = *split.KeySuffix
+		}
+		for i, doc := range docs {
+			name, err := documentName(doc, splitNameField(split))
+			if err != nil {
+				return nil, fmt.Errorf("split of key %q: document %d: %w", split.Key, i+1, err)
+			}
+			key := name + suffix
+			if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+				return nil, fmt.Errorf("split of key %q: document %d: invalid key %q: %s", split.Key, i+1, key, errs[0])
+			}
+			if _, exists := out[key]; exists {
+				return nil, fmt.Errorf("split of key %q: document %d: key %q already exists", split.Key, i+1, key)
+			}
+			out[key] = doc
+		}
+	}
+	return out, nil
+}
+
+// splitNameField returns the field naming the documents of a split.
+func splitNameField(split platformv1alpha1.SplitSpec) string {
+	if split.NameField == "" {
+		return "metadata.name"
+	}
+	return split.NameField
+}
+
+// yamlDocuments returns the non-empty documents of a multi-document YAML.
+func yamlDocuments(data []byte) ([][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var content any
+		if err := yaml.Unmarshal(doc, &content); err != nil {
+			return nil, fmt.Errorf("document %d: %w", len(docs)+1, err)
+		}
+		if content != nil {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// documentName returns the string at a dot-separated path in a YAML document.
+func documentName(doc []byte, path string) (string, error) {
+	var value any
+	if err := yaml.Unmarshal(doc, &value); err != nil {
+		return "", err
+	}
+	for _, field := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("no field %s", path)
+		}
+		value = object[field]
+	}
+	name, ok := value.(string)
+	if !ok || name == "" {
+		return "", fmt.Errorf("field %s is not a non-empty string", path)
+	}
+	return name, nil
+}
+
+// =============================================================================
+// Substitution
+// =============================================================================
+
+// Substitute returns a copy of data with every token of replacements
+// replaced in every value. Tokens are replaced in one pass, longest first,
+// so replaced text is never replaced again; empty tokens are ignored. Data
+// is returned unchanged without replacements.
+func Substitute(data map[string][]byte, replacements map[string]string) map[string][]byte {
+	tokens := make([]string, 0, len(replacements))
+	for token := range replacements {
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		return data
+	}
+	// At one position the replacer tries tokens in argument order
+	sort.Slice(tokens, func(i, j int) bool {
+		if len(tokens[i]) != len(tokens[j]) {
+			return len(tokens[i]) > len(tokens[j])
+		}
+		return tokens[i] < tokens[j]
+	})
+	pairs := make([]string, 0, 2*len(tokens))
+	for _, token := range tokens {
+		pairs = append(pairs, token, replacements[token])
+	}
+	replacer := strings.NewReplacer(pairs...)
+	substituted := make(map[string][]byte, len(data))
+	for k, v := range data {
+		substituted[k] = []byte(replacer.Replace(string(v)))
+	}
+	return substituted
+}
+
+// =============================================================================
+// Rendering
+// =============================================================================
+
+// TemplateTarget identifies the target a template is rendered for.
+type TemplateTarget struct {
+	Namespace string
+	Name      string
+}
+
+// TemplateContext is the data a value template is executed with.
+type TemplateContext struct {
+	Values  map[string]string
+	Secrets map[string]string
+	Target  TemplateTarget
+}
+
+// MergeValues overlays target values on the shared defaults.
+// Neither input is modified.
+func MergeValues(defaults, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(overrides))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Render executes the listed keys' values as Go templates; an empty key list
+// renders every key. Referencing a value that is not set is an error, so a
+// missing per-target variable never silently renders as "<no value>".
+func Render(data map[string][]byte, keys []string, tctx TemplateContext) (map[string][]byte, error) {
+	rendered := make(map[string][]byte, len(data))
+	for k, v := range data {
+		if len(keys) > 0 && !slices.Contains(keys, k) {
+			rendered[k] = v
+			continue
+		}
+		tmpl, err := template.New(k).Option("missingkey=error").Parse(string(v))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template in key %q: %w", k, err)
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, tctx); err != nil {
+			return nil, fmt.Errorf("failed to render key %q: %w", k, err)
+		}
+		rendered[k] = out.Bytes()
+	}
+	return rendered, nil
+}
+
+// NameContext is the data a source.nameTemplate is executed with: the
+// SharedResource's name and namespace, which never change.
+type NameContext struct {
+	Name      string
+	Namespace string
+}
+
+// RenderName executes a name template as a Go template. The result must be a
+// valid object name.
+func RenderName(nameTemplate string, nctx NameContext) (string, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse name template: %w", err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, nctx); err != nil {
+		return "", fmt.Errorf("failed to render name template: %w", err)
+	}
+	name := out.String()
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("name template renders %q, not a valid name: %s", name, errs[0])
+	}
+	return name, nil
+}
+
+// =============================================================================
+// Generation
+// =============================================================================
+
+// charsets maps each GenerateCharset to its alphabet.
+var charsets = map[platformv1alpha1.GenerateCharset]string{
+	platformv1alpha1.GenerateCharsetAlphanumeric: "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+	platformv1alpha1.GenerateCharsetASCII:        "!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~",
+	platformv1alpha1.GenerateCharsetHex:          "0123456789abcdef",
+}
+
+// GenerateValue returns a cryptographically random string of the given length.
+// An empty charset defaults to alphanumeric.
+func GenerateValue(length int, charset platformv1alpha1.GenerateCharset) (string, error) {
+	if charset == "" {
+		charset = platformv1alpha1.GenerateCharsetAlphanumeric
+	}
+	alphabet, ok := charsets[charset]
+	if !ok {
+		return "", fmt.Errorf("unsupported charset %q", charset)
+	}
+	if length <= 0 {
+		return "", fmt.Errorf("invalid length %d", length)
+	}
+	n := big.NewInt(int64(len(alphabet)))
+	out := make([]byte, length)
+	for i := range out {
+		// rand.Int is uniform, so no character is favoured by modulo bias
+		idx, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", err
+		}
+		out[i] = alphabet[idx.Int64()]
+	}
+	return string(out), nil
+}
+
+// =============================================================================
+// Diffing
+// =============================================================================
+
+// KeyChange describes a changed key without revealing its value.
+type KeyChange struct {
+	Key    string `json:"key"`
+	OldLen int    `json:"oldLen,omitempty"`
+	NewLen int    `json:"newLen,omitempty"`
+}
+
+// DataDiff lists the keys that differ between two data maps.
+type DataDiff struct {
+	Added   []KeyChange `json:"added,omitempty"`
+	Removed []KeyChange `json:"removed,omitempty"`
+	Changed []KeyChange `json:"changed,omitempty"`
+}
+
+// Empty returns true if the two data maps were identical.
+func (d DataDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff lists added, removed and changed keys between two data maps.
+// Values are compared but only their lengths are reported. Results are sorted by key.
+func Diff(oldData, newData map[string][]byte) DataDiff {
+	var d DataDiff
+	for k, v := range newData {
+		old, ok := oldData[k]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, KeyChange{Key: k, NewLen: len(v)})
+		case !ValueEqual(old, v):
+			d.Changed = append(d.Changed, KeyChange{Key: k, OldLen: len(old), NewLen: len(v)})
+		}
+	}
+	for k, v := range oldData {
+		if _, ok := newData[k]; !ok {
+			d.Removed = append(d.Removed, KeyChange{Key: k, OldLen: len(v)})
+		}
+	}
+	for _, list := range [][]KeyChange{d.Added, d.Removed, d.Changed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	}
+	return d
+}
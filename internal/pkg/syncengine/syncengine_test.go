@@ -0,0 +1,468 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+// data builds a []byte map from key/value string pairs.
+func data(kv ...string) map[string][]byte {
+	out := make(map[string][]byte, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		out[kv[i]] = []byte(kv[i+1])
+	}
+	return out
+}
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b map[string][]byte
+		same bool
+	}{
+		{"identical data", data("a", "1", "b", "2"), data("b", "2", "a", "1"), true},
+		{"empty and nil", map[string][]byte{}, nil, true},
+		{"different value", data("a", "1"), data("a", "2"), false},
+		{"different key", data("a", "1"), data("b", "1"), false},
+		{"key/value boundary", data("ab", "c"), data("a", "bc"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Checksum(tt.a) == Checksum(tt.b); got != tt.same {
+				t.Errorf("Checksum equality = %v, want %v", got, tt.same)
+			}
+			if got := SameData(tt.a, tt.b); got != tt.same {
+				t.Errorf("SameData() = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
+
+func TestConstantTimeEqual(t *testing.T) {
+	sum := Checksum(data("a", "1"))
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"same checksum", sum, Checksum(data("a", "1")), true},
+		{"different checksum", sum, Checksum(data("a", "2")), false},
+		{"prefix", sum, sum[:10], false},
+		{"empty", "", "", true},
+		{"one empty", sum, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChecksumEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("ChecksumEqual() = %v, want %v", got, tt.want)
+			}
+			if got := ValueEqual([]byte(tt.a), []byte(tt.b)); got != tt.want {
+				t.Errorf("ValueEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	secret := Redacted(data("password", "hunter2", "user", "admin"))
+	want := "{password: 7 bytes, user: 5 bytes}"
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x"} {
+		if got := fmt.Sprintf(verb, secret); got != want {
+			t.Errorf("Sprintf(%s) = %q, want %q", verb, got, want)
+		}
+	}
+	if got := fmt.Sprint(map[string]any{"data": secret}); strings.Contains(got, "hunter2") {
+		t.Errorf("nested Sprint() = %q, leaks a value", got)
+	}
+	encoded, err := json.Marshal(map[string]any{"data": secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(encoded); got != `{"data":{"password":7,"user":5}}` {
+		t.Errorf("json.Marshal() = %s", got)
+	}
+	if got := secret.MarshalLog(); !reflect.DeepEqual(got, map[string]int{"password": 7, "user": 5}) {
+		t.Errorf("MarshalLog() = %v", got)
+	}
+}
+
+func TestStringConversions(t *testing.T) {
+	in := map[string]string{"a": "1", "b": ""}
+	if got := ToStrings(FromStrings(in)); !reflect.DeepEqual(got, in) {
+		t.Errorf("round trip = %v, want %v", got, in)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	source := data("a", "1", "b", "2", "c", "3")
+	tests := []struct {
+		name   string
+		policy *platformv1alpha1.SyncPolicySpec
+		want   map[string][]byte
+	}{
+		{"no policy", nil, source},
+		{"copy mode", &platformv1alpha1.SyncPolicySpec{Mode: platformv1alpha1.SyncModeCopy}, source},
+		{"merge mode applies keys", &platformv1alpha1.SyncPolicySpec{
+			Mode: platformv1alpha1.SyncModeMerge,
+			Keys: &platformv1alpha1.KeySelector{Include: []string{"a"}},
+		}, data("a", "1")},
+		{"selective without keys", &platformv1alpha1.SyncPolicySpec{Mode: platformv1alpha1.SyncModeSelective}, source},
+		{"selective include", &platformv1alpha1.SyncPolicySpec{
+			Mode: platformv1alpha1.SyncModeSelective,
+			Keys: &platformv1alpha1.KeySelector{Include: []string{"a", "missing"}},
+		}, data("a", "1")},
+		{"selective exclude", &platformv1alpha1.SyncPolicySpec{
+			Mode: platformv1alpha1.SyncModeSelective,
+			Keys: &platformv1alpha1.KeySelector{Exclude: []string{"b"}},
+		}, data("a", "1", "c", "3")},
+		{"exclude wins over include", &platformv1alpha1.SyncPolicySpec{
+			Mode: platformv1alpha1.SyncModeSelective,
+			Keys: &platformv1alpha1.KeySelector{Include: []string{"a", "b"}, Exclude: []string{"b"}},
+		}, data("a", "1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Filter(source, tt.policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterProfile(t *testing.T) {
+	tlsSource := data("tls.crt", "c", "tls.key", "k", "ca.crt", "ca", "notes", "n")
+	tests := []struct {
+		name   string
+		source map[string][]byte
+		policy *platformv1alpha1.SyncPolicySpec
+		want   map[string][]byte
+	}{
+		{"tls in copy mode", tlsSource, &platformv1alpha1.SyncPolicySpec{
+			Profile: platformv1alpha1.SyncProfileTLS,
+		}, data("tls.crt", "c", "tls.key", "k", "ca.crt", "ca")},
+		{"tls with keys", tlsSource, &platformv1alpha1.SyncPolicySpec{
+			Mode:    platformv1alpha1.SyncModeSelective,
+			Profile: platformv1alpha1.SyncProfileTLS,
+			Keys:    &platformv1alpha1.KeySelector{Exclude: []string{"ca.crt"}},
+		}, data("tls.crt", "c", "tls.key", "k")},
+		{"keys cannot add to a profile", tlsSource, &platformv1alpha1.SyncPolicySpec{
+			Mode:    platformv1alpha1.SyncModeSelective,
+			Profile: platformv1alpha1.SyncProfileTLS,
+			Keys:    &platformv1alpha1.KeySelector{Include: []string{"tls.crt", "notes"}},
+		}, data("tls.crt", "c")},
+		{"dockerconfig", data(".dockerconfigjson", "{}", "token", "t"), &platformv1alpha1.SyncPolicySpec{
+			Profile: platformv1alpha1.SyncProfileDockerConfig,
+		}, data(".dockerconfigjson", "{}")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Filter(tt.source, tt.policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	source := data("a", "1", "b", "2", "c", "3")
+	tests := []struct {
+		name        string
+		restriction Restriction
+		want        map[string][]byte
+	}{
+		{"no restriction", Restriction{}, source},
+		{"allowed keys", Restriction{Allowed: []string{"a", "c"}, AllowedSet: true}, data("a", "1", "c", "3")},
+		{"allowed set but empty", Restriction{AllowedSet: true}, map[string][]byte{}},
+		{"allowed list ignored when not set", Restriction{Allowed: []string{"a"}}, source},
+		{"excluded keys", Restriction{Excluded: []string{"b"}}, data("a", "1", "c", "3")},
+		{"allowed and excluded", Restriction{Allowed: []string{"a", "b"}, AllowedSet: true, Excluded: []string{"a"}}, data("b", "2")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Restrict(source, tt.restriction); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Restrict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	existing := data("a", "old", "extra", "kept")
+	source := data("a", "new", "b", "2")
+	tests := []struct {
+		name string
+		mode platformv1alpha1.SyncMode
+		want map[string][]byte
+	}{
+		{"copy replaces", platformv1alpha1.SyncModeCopy, source},
+		{"selective replaces", platformv1alpha1.SyncModeSelective, source},
+		{"default replaces", "", source},
+		{"merge keeps extra keys, source wins", platformv1alpha1.SyncModeMerge, data("a", "new", "b", "2", "extra", "kept")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Merge(existing, source, tt.mode); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("merge does not modify its inputs", func(t *testing.T) {
+		Merge(existing, source, platformv1alpha1.SyncModeMerge)
+		if !reflect.DeepEqual(existing, data("a", "old", "extra", "kept")) {
+			t.Errorf("existing modified: %v", existing)
+		}
+	})
+}
+
+func TestMergeOwned(t *testing.T) {
+	owners := KeyOwners{
+		"ns/a": {"a_user", "a_stale"},
+		"ns/b": {"b_user", "shared"},
+	}
+	existing := data("a_user", "old", "a_stale", "x", "b_user", "b", "shared", "b", "local", "kept")
+
+	merged, owned, conflicts := MergeOwned(existing, data("a_user", "new", "shared", "a"), owners, "ns/a")
+	if want := data("a_user", "new", "b_user", "b", "shared", "b", "local", "kept"); !reflect.DeepEqual(merged, want) {
+		t.Errorf("merged = %v, want %v", merged, want)
+	}
+	if want := []string{"a_user"}; !reflect.DeepEqual(owned, want) {
+		t.Errorf("owned = %v, want %v", owned, want)
+	}
+	if want := map[string]string{"shared": "ns/b"}; !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("conflicts = %v, want %v", conflicts, want)
+	}
+	if len(existing) != 5 {
+		t.Errorf("existing modified: %v", existing)
+	}
+
+	t.Run("unowned keys are claimed", func(t *testing.T) {
+		merged, owned, conflicts := MergeOwned(data("local", "old"), data("local", "new"), nil, "ns/a")
+		if !reflect.DeepEqual(merged, data("local", "new")) || !reflect.DeepEqual(owned, []string{"local"}) || conflicts != nil {
+			t.Errorf("MergeOwned() = %v, %v, %v", merged, owned, conflicts)
+		}
+	})
+}
+
+func TestPrefix(t *testing.T) {
+	in := data("user", "app", "password", "secret")
+	if got := Prefix(in, ""); !reflect.DeepEqual(got, in) {
+		t.Errorf("Prefix() with no prefix = %v, want %v", got, in)
+	}
+	want := data("upstreamdb_user", "app", "upstreamdb_password", "secret")
+	if got := Prefix(in, "upstreamdb_"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Prefix() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(in, data("user", "app", "password", "secret")) {
+		t.Errorf("input modified: %v", in)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	bundle := "# bundled by the release tooling\n---\nkind: ConfigMap\nmetadata:\n  name: app\n" +
+		"---\nkind: ConfigMap\nmetadata:\n  name: worker\n---\n"
+	none := ""
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		splits  []platformv1alpha1.SplitSpec
+		want    map[string][]byte
+		wantErr bool
+	}{
+		{"one key per document", data("bundle", bundle, "other", "x"),
+			[]platformv1alpha1.SplitSpec{{Key: "bundle"}},
+			data("app.yaml", "kind: ConfigMap\nmetadata:\n  name: app\n",
+				"worker.yaml", "kind: ConfigMap\nmetadata:\n  name: worker\n", "other", "x"), false},
+		{"custom field and suffix, source kept", data("bundle", "id: a\n---\nid: b\n"),
+			[]platformv1alpha1.SplitSpec{{Key: "bundle", NameField: "id", KeySuffix: &none, KeepSource: true}},
+			data("bundle", "id: a\n---\nid: b\n", "a", "id: a\n", "b", "id: b\n"), false},
+		{"missing key", data("other", "x"), []platformv1alpha1.SplitSpec{{Key: "bundle"}}, data("other", "x"), false},
+		{"missing name", data("bundle", "kind: ConfigMap\n"), []platformv1alpha1.SplitSpec{{Key: "bundle"}}, nil, true},
+		{"duplicate name", data("bundle", "metadata:\n  name: a\n---\nmetadata:\n  name: a\n"),
+			[]platformv1alpha1.SplitSpec{{Key: "bundle"}}, nil, true},
+		{"invalid key", data("bundle", "metadata:\n  name: a/b\n"), []platformv1alpha1.SplitSpec{{Key: "bundle"}}, nil, true},
+		{"invalid YAML", data("bundle", "a: [\n"), []platformv1alpha1.SplitSpec{{Key: "bundle"}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Split(tt.data, tt.splits)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	in := data("app.yaml", "env: {{ENV}}\nhost: db.internal:5432\n", "raw", "{{ENV}}{{ENV_NAME}}")
+	if got := Substitute(in, nil); !reflect.DeepEqual(got, in) {
+		t.Errorf("Substitute() without replacements = %q, want %q", got, in)
+	}
+	got := Substitute(in, map[string]string{
+		"{{ENV}}": "prod", "{{ENV_NAME}}": "Production", "db.internal": "db.prod.internal", "": "x",
+		"prod": "never",
+	})
+	want := data("app.yaml", "env: prod\nhost: db.prod.internal:5432\n", "raw", "prodProduction")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Substitute() = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(in, data("app.yaml", "env: {{ENV}}\nhost: db.internal:5432\n", "raw", "{{ENV}}{{ENV_NAME}}")) {
+		t.Errorf("input modified: %q", in)
+	}
+}
+
+func TestRender(t *testing.T) {
+	tctx := TemplateContext{
+		Values: MergeValues(map[string]string{"env": "dev", "level": "info"}, map[string]string{"env": "prod"}),
+		Target: TemplateTarget{Namespace: "backend", Name: "app-config"},
+	}
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		keys    []string
+		want    map[string][]byte
+		wantErr bool
+	}{
+		{"values and target", data("cfg", "{{ .Values.env }}/{{ .Values.level }}@{{ .Target.Namespace }}"),
+			nil, data("cfg", "prod/info@backend"), false},
+		{"plain values unchanged", data("a", "1"), nil, data("a", "1"), false},
+		{"only listed keys rendered", data("cfg", "{{ .Target.Name }}", "raw", "{{ .Values.env }}"),
+			[]string{"cfg"}, data("cfg", "app-config", "raw", "{{ .Values.env }}"), false},
+		{"missing value", data("cfg", "{{ .Values.region }}"), nil, nil, true},
+		{"invalid template", data("cfg", "{{ .Values.env "), nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.data, tt.keys, tctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Render() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderName(t *testing.T) {
+	nctx := NameContext{Name: "payments", Namespace: "team-a"}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{"name suffix", "{{ .Name }}-config", "payments-config", false},
+		{"namespace and name", "{{ .Namespace }}.{{ .Name }}", "team-a.payments", false},
+		{"constant", "shared-config", "shared-config", false},
+		{"unknown field", "{{ .Labels }}", "", true},
+		{"invalid template", "{{ .Name ", "", true},
+		{"invalid name", "{{ .Name }}_Config", "", true},
+		{"empty result", "{{ if false }}x{{ end }}", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderName(tt.template, nctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateValue(t *testing.T) {
+	tests := []struct {
+		charset  platformv1alpha1.GenerateCharset
+		alphabet string
+	}{
+		{"", "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"},
+		{platformv1alpha1.GenerateCharsetHex, "0123456789abcdef"},
+		{platformv1alpha1.GenerateCharsetASCII, charsets[platformv1alpha1.GenerateCharsetASCII]},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.charset), func(t *testing.T) {
+			a, err := GenerateValue(64, tt.charset)
+			if err != nil {
+				t.Fatalf("GenerateValue() error = %v", err)
+			}
+			b, _ := GenerateValue(64, tt.charset)
+			if len(a) != 64 {
+				t.Errorf("len = %d, want 64", len(a))
+			}
+			if a == b {
+				t.Errorf("two values are identical: %q", a)
+			}
+			for _, c := range a {
+				if !strings.ContainsRune(tt.alphabet, c) {
+					t.Errorf("character %q not in charset", c)
+				}
+			}
+		})
+	}
+
+	if _, err := GenerateValue(8, "emoji"); err == nil {
+		t.Error("expected error for unknown charset")
+	}
+	if len(charsets[platformv1alpha1.GenerateCharsetASCII]) != 94 {
+		t.Errorf("ascii charset has %d characters, want 94", len(charsets[platformv1alpha1.GenerateCharsetASCII]))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new map[string][]byte
+		want     DataDiff
+	}{
+		{"identical", data("a", "1"), data("a", "1"), DataDiff{}},
+		{"create", nil, data("b", "22", "a", "1"), DataDiff{
+			Added: []KeyChange{{Key: "a", NewLen: 1}, {Key: "b", NewLen: 2}},
+		}},
+		{"add, remove and change", data("a", "1", "gone", "xyz"), data("a", "11", "new", "x"), DataDiff{
+			Added:   []KeyChange{{Key: "new", NewLen: 1}},
+			Removed: []KeyChange{{Key: "gone", OldLen: 3}},
+			Changed: []KeyChange{{Key: "a", OldLen: 1, NewLen: 2}},
+		}},
+		{"same length change", data("a", "1"), data("a", "2"), DataDiff{
+			Changed: []KeyChange{{Key: "a", OldLen: 1, NewLen: 1}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+			if got.Empty() != tt.want.Empty() {
+				t.Errorf("Empty() = %v, want %v", got.Empty(), tt.want.Empty())
+			}
+		})
+	}
+}
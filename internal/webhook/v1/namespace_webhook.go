@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+)
+
+// =============================================================================
+// Source namespace deletion protection.
+//
+// Deleting a namespace deletes the sources in it, and every copy in other
+// namespaces silently stops updating. The webhook intercepts namespace DELETE
+// and, depending on the protection mode:
+//   - warn: allows the delete with an admission warning naming the SharedResources
+//   - deny: refuses it until those SharedResources are removed, or the
+//     namespace is annotated sharedresource.platform.dev/allow-deletion=true
+//
+// failurePolicy is Ignore: an unavailable operator must never block namespace
+// deletion cluster-wide.
+// =============================================================================
+
+// namespacelog is for logging in this package.
+var namespacelog = logf.Log.WithName("namespace-resource")
+
+// ProtectionMode selects what the namespace webhook does with a protected delete.
+type ProtectionMode string
+
+const (
+	// ProtectionOff does not register the webhook
+	ProtectionOff ProtectionMode = "off"
+
+	// ProtectionWarn allows the delete with an admission warning
+	ProtectionWarn ProtectionMode = "warn"
+
+	// ProtectionDeny refuses the delete
+	ProtectionDeny ProtectionMode = "deny"
+)
+
+// SetupNamespaceWebhookWithManager registers the webhook for Namespace in the manager.
+func SetupNamespaceWebhookWithManager(mgr ctrl.Manager, mode ProtectionMode) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1.Namespace{}).
+		WithValidator(&NamespaceCustomValidator{Client: mgr.GetClient(), Mode: mode}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate--v1-namespace,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=namespaces,verbs=delete,versions=v1,name=vnamespace-v1.platform.dev,admissionReviewVersions=v1,timeoutSeconds=5
+
+// NamespaceCustomValidator guards deletion of namespaces holding shared sources.
+type NamespaceCustomValidator struct {
+	// Client reads SharedResources
+	Client client.Reader
+
+	// Mode is ProtectionWarn or ProtectionDeny
+	Mode ProtectionMode
+}
+
+var _ webhook.CustomValidator = &NamespaceCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator; creates are not intercepted.
+func (v *NamespaceCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator; updates are not intercepted.
+func (v *NamespaceCustomValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete warns about, or refuses, deleting a namespace whose
+// SharedResources still sync to other namespaces.
+func (v *NamespaceCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil, fmt.Errorf("expected a Namespace object but got %T", obj)
+	}
+	if ns.Annotations[controller.AnnotationAllowDeletion] == "true" {
+		return nil, nil
+	}
+
+	names, err := controller.SharedResourcesWithRemoteTargets(ctx, v.Client, ns.Name)
+	if err != nil {
+		// Fail open, like the webhook's failurePolicy
+		namespacelog.Error(err, "Failed to check SharedResources", "namespace", ns.Name)
+		return nil, nil
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("namespace %s holds the source of SharedResource(s) %s, which sync to other namespaces; "+
+		"deleting it freezes those copies", ns.Name, strings.Join(names, ", "))
+	if v.Mode != ProtectionDeny {
+		return admission.Warnings{message}, nil
+	}
+	namespacelog.Info("Denying namespace deletion", "namespace", ns.Name, "sharedResources", names)
+	return nil, fmt.Errorf("%s. Delete the SharedResources first, or annotate the namespace %s=true",
+		message, controller.AnnotationAllowDeletion)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+)
+
+var _ = Describe("Namespace Webhook", func() {
+	// sourceNamespace creates a namespace holding a SharedResource with the given target namespace.
+	sourceNamespace := func(prefix, targetNamespace string) *corev1.Namespace {
+		GinkgoHelper()
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano()%100000),
+		}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		if targetNamespace == "" {
+			targetNamespace = ns.Name
+		}
+		Expect(k8sClient.Create(ctx, &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-db", Namespace: ns.Name},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:  platformv1alpha1.SourceSpec{Kind: "Secret", Name: "db"},
+				Targets: []platformv1alpha1.TargetSpec{{Namespace: targetNamespace, Name: "db-copy"}},
+			},
+		})).To(Succeed())
+		return ns
+	}
+
+	It("should deny deleting a namespace whose sources are shared elsewhere", func() {
+		ns := sourceNamespace("protected", "backend")
+
+		// The webhook reads SharedResources from the manager's cache
+		Eventually(func() error {
+			return k8sClient.Delete(ctx, ns)
+		}, time.Second*10, time.Millisecond*250).Should(MatchError(And(
+			ContainSubstring("shared-db"),
+			ContainSubstring(controller.AnnotationAllowDeletion),
+		)))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ns.Name}, ns)).To(Succeed())
+		Expect(ns.DeletionTimestamp).To(BeNil())
+
+		By("annotating the namespace to allow deletion")
+		ns.Annotations = map[string]string{controller.AnnotationAllowDeletion: "true"}
+		Expect(k8sClient.Update(ctx, ns)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, ns)).To(Succeed())
+	})
+
+	It("should allow deleting a namespace that only syncs within itself", func() {
+		ns := sourceNamespace("local-only", "")
+		Expect(k8sClient.Delete(ctx, ns)).To(Succeed())
+	})
+
+	It("should only warn in warn mode", func() {
+		ns := sourceNamespace("warned", "frontend")
+		validator := &NamespaceCustomValidator{Client: k8sClient, Mode: ProtectionWarn}
+
+		warnings, err := validator.ValidateDelete(ctx, ns)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("shared-db")))
+
+		By("ignoring SharedResources that are already being deleted")
+		validator.Mode = ProtectionDeny
+		sr := &platformv1alpha1.SharedResource{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "shared-db", Namespace: ns.Name}, sr)).To(Succeed())
+		sr.Finalizers = []string{controller.FinalizerName}
+		Expect(k8sClient.Update(ctx, sr)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+
+		warnings, err = validator.ValidateDelete(ctx, ns)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		// Release the finalizer; nothing else will in this suite
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "shared-db", Namespace: ns.Name}, sr)).To(Succeed())
+		sr.Finalizers = nil
+		Expect(k8sClient.Update(ctx, sr)).To(Succeed())
+	})
+})
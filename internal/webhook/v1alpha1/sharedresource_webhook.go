@@ -0,0 +1,144 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+)
+
+// =============================================================================
+// SharedResource validation.
+//
+// Rejects SharedResources the CRD schema cannot fully check, so mistakes are
+// reported on apply instead of as sync errors:
+//   - spec.targetTemplate may only set metadata.labels, metadata.annotations,
+//     immutable and (Secrets only) type, and no operator-reserved keys
+//   - no two spec.targets may resolve to the same namespace and name
+//   - spec.source.nameTemplate must render a valid name
+//   - spec.verify.jobTemplate must be a JobTemplateSpec with containers
+//   - spec.template.valuesFrom needs spec.template.targetKind Secret
+//   - no kind disabled with --disable-secrets or --disable-configmaps may be
+//     needed (see controller.DisabledKindErrors)
+//
+// An admitted spec.targetSelector or target namespace pattern comes back with
+// a warning naming how many namespaces it matches now, and a few of them
+// (see controller.TargetMatchWarnings). The warnings never block admission.
+// =============================================================================
+
+// sharedresourcelog is for logging in this package.
+var sharedresourcelog = logf.Log.WithName("sharedresource-resource")
+
+// SetupSharedResourceWebhookWithManager registers the webhook for SharedResource in the manager.
+func SetupSharedResourceWebhookWithManager(mgr ctrl.Manager, disabledKinds []string) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&platformv1alpha1.SharedResource{}).
+		WithValidator(&SharedResourceCustomValidator{DisabledKinds: disabledKinds, Reader: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-platform-platform-dev-v1alpha1-sharedresource,mutating=false,failurePolicy=fail,sideEffects=None,groups=platform.platform.dev,resources=sharedresources,verbs=create;update,versions=v1alpha1,name=vsharedresource-v1alpha1.platform.dev,admissionReviewVersions=v1,timeoutSeconds=5
+
+// SharedResourceCustomValidator validates SharedResources on create and update.
+type SharedResourceCustomValidator struct {
+	// DisabledKinds are the kinds SharedResources may not need
+	DisabledKinds []string
+
+	// Reader lists namespaces for the match warnings. Nil disables them.
+	Reader client.Reader
+}
+
+var _ webhook.CustomValidator = &SharedResourceCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *SharedResourceCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	sr, ok := obj.(*platformv1alpha1.SharedResource)
+	if !ok {
+		return nil, fmt.Errorf("expected a SharedResource object but got %T", obj)
+	}
+	if err := validateSharedResource(sr, v.DisabledKinds); err != nil {
+		return nil, err
+	}
+	return v.matchWarnings(ctx, sr), nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *SharedResourceCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	sr, ok := newObj.(*platformv1alpha1.SharedResource)
+	if !ok {
+		return nil, fmt.Errorf("expected a SharedResource object for the newObj but got %T", newObj)
+	}
+	if sr.DeletionTimestamp != nil {
+		// Never block finalizer removal on a SharedResource that predates the webhook
+		return nil, nil
+	}
+	if err := validateSharedResource(sr, v.DisabledKinds); err != nil {
+		return nil, err
+	}
+	return v.matchWarnings(ctx, sr), nil
+}
+
+// ValidateDelete implements webhook.CustomValidator; deletes are not intercepted.
+func (v *SharedResourceCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// matchWarnings returns the namespace match warnings for the CR's target
+// selector and patterns. Without them, or if namespaces cannot be listed,
+// there are none.
+func (v *SharedResourceCustomValidator) matchWarnings(ctx context.Context, sr *platformv1alpha1.SharedResource) admission.Warnings {
+	if v.Reader == nil || (sr.Spec.TargetSelector == nil && !slices.ContainsFunc(sr.Spec.Targets,
+		func(t platformv1alpha1.TargetSpec) bool { return controller.IsNamespacePattern(t.Namespace) })) {
+		return nil
+	}
+	namespaces, err := controller.ActiveNamespaces(ctx, v.Reader)
+	if err != nil {
+		sharedresourcelog.Error(err, "Failed to list namespaces for match warnings")
+		return nil
+	}
+	return controller.TargetMatchWarnings(sr, namespaces)
+}
+
+// validateSharedResource returns an Invalid error listing every problem, or nil.
+func validateSharedResource(sr *platformv1alpha1.SharedResource, disabledKinds []string) error {
+	_, errs := controller.ParseTargetTemplate(sr)
+	errs = append(errs, controller.DuplicateTargets(sr)...)
+	errs = append(errs, controller.SourceNameErrors(sr)...)
+	errs = append(errs, controller.SourceNamespaceErrors(sr)...)
+	errs = append(errs, controller.NamespacePatternErrors(sr)...)
+	errs = append(errs, controller.TargetSelectorErrors(sr)...)
+	errs = append(errs, controller.VerifyErrors(sr)...)
+	errs = append(errs, controller.PreSyncErrors(sr)...)
+	errs = append(errs, controller.TemplateErrors(sr)...)
+	errs = append(errs, controller.DisabledKindErrors(sr, disabledKinds)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	sharedresourcelog.Info("Rejecting SharedResource", "namespace", sr.Namespace, "name", sr.Name, "errors", errs.ToAggregate().Error())
+	return apierrors.NewInvalid(platformv1alpha1.GroupVersion.WithKind("SharedResource").GroupKind(), sr.Name, errs)
+}
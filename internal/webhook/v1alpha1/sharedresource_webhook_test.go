@@ -0,0 +1,187 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+var _ = Describe("SharedResource Webhook", func() {
+	// sharedResource returns a Secret SharedResource with the given target template.
+	sharedResource := func(name, template string) *platformv1alpha1.SharedResource {
+		return &platformv1alpha1.SharedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: platformv1alpha1.SharedResourceSpec{
+				Source:         platformv1alpha1.SourceSpec{Kind: "Secret", Name: "db"},
+				Targets:        []platformv1alpha1.TargetSpec{{Namespace: "backend"}},
+				TargetTemplate: &runtime.RawExtension{Raw: []byte(template)},
+			},
+		}
+	}
+
+	It("should accept a template setting labels, annotations, type and immutable", func() {
+		sr := sharedResource("template-valid", `{"apiVersion":"v1","kind":"Secret",`+
+			`"metadata":{"labels":{"team":"payments"},"annotations":{"example.com/owner":"payments"}},`+
+			`"type":"kubernetes.io/basic-auth","immutable":true}`)
+		Expect(k8sClient.Create(ctx, sr)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, sr)).To(Succeed())
+	})
+
+	It("should reject finalizers, data and reserved keys", func() {
+		sr := sharedResource("template-invalid", `{"apiVersion":"v1","kind":"Secret",`+
+			`"metadata":{"finalizers":["example.com/hold"],"annotations":{"sharedresource.platform.dev/checksum":"x"}},`+
+			`"data":{"key":"dmFsdWU="}}`)
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(And(
+			ContainSubstring("spec.targetTemplate.data"),
+			ContainSubstring("spec.targetTemplate.metadata.finalizers"),
+			ContainSubstring("spec.targetTemplate.metadata.annotations[sharedresource.platform.dev/checksum]"),
+		)))
+	})
+
+	It("should reject a kind that does not match the target", func() {
+		sr := sharedResource("template-kind", `{"apiVersion":"v1","kind":"ConfigMap"}`)
+		Expect(k8sClient.Create(ctx, sr)).To(MatchError(ContainSubstring("spec.targetTemplate.kind")))
+	})
+
+	It("should reject type on ConfigMap targets", func() {
+		sr := sharedResource("template-configmap", `{"apiVersion":"v1","kind":"ConfigMap","type":"Opaque"}`)
+		sr.Spec.Source.Kind = "ConfigMap"
+		Expect(k8sClient.Create(ctx, sr)).To(MatchError(ContainSubstring("spec.targetTemplate.type")))
+	})
+
+	It("should reject targets resolving to the same namespace and name", func() {
+		sr := sharedResource("duplicate-targets", "")
+		sr.Spec.TargetTemplate = nil
+		sr.Spec.Targets = []platformv1alpha1.TargetSpec{
+			{Namespace: "default"},
+			{Namespace: "default", Name: "other"},
+			{Namespace: "default", Name: sr.Spec.Source.Name},
+		}
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(ContainSubstring(`spec.targets[2]: Duplicate value: "default/` + sr.Spec.Source.Name)))
+		Expect(err).NotTo(MatchError(ContainSubstring("spec.targets[1]")))
+	})
+
+	It("should reject SharedResources needing a disabled kind", func() {
+		validator := &SharedResourceCustomValidator{DisabledKinds: []string{"Secret"}}
+		sr := sharedResource("disabled-kind", "")
+		sr.Spec.TargetTemplate = nil
+		_, err := validator.ValidateCreate(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(ContainSubstring("spec.source.kind: Forbidden: Secret syncing is disabled")))
+
+		sr.Spec.Source.Kind = "ConfigMap"
+		_, err = validator.ValidateCreate(ctx, sr)
+		Expect(err).NotTo(HaveOccurred())
+
+		sr.Spec.Template = &platformv1alpha1.TemplateSpec{TargetKind: "Secret"}
+		_, err = validator.ValidateUpdate(ctx, sr, sr)
+		Expect(err).To(MatchError(ContainSubstring("spec.template.targetKind")))
+	})
+
+	It("should reject a source name template that renders no valid name", func() {
+		sr := sharedResource("name-template", "")
+		sr.Spec.TargetTemplate = nil
+		sr.Spec.Source.Name = ""
+		sr.Spec.Source.NameTemplate = "{{ .Name }}_Config"
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(ContainSubstring("spec.source.nameTemplate")))
+	})
+
+	It("should reject generated keys for a source in another namespace", func() {
+		sr := sharedResource("generate-exported", "")
+		sr.Spec.TargetTemplate = nil
+		sr.Spec.Source.Namespace = "security"
+		sr.Spec.Generate = []platformv1alpha1.GenerateSpec{{Key: "password"}}
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(ContainSubstring("spec.generate")))
+	})
+
+	It("should warn how many namespaces the target selector and patterns match", func() {
+		for _, name := range []string{"match-warn-a", "match-warn-b"} {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"match-warn": "yes"}}}
+			Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, ns) })
+		}
+		validator := &SharedResourceCustomValidator{Reader: k8sClient}
+		sr := sharedResource("match-warnings", "")
+		sr.Spec.TargetTemplate = nil
+		sr.Spec.TargetSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"match-warn": "yes"}}
+		sr.Spec.Targets = []platformv1alpha1.TargetSpec{{Namespace: "backend"}, {Namespace: "match-warn-*"}, {Namespace: "nomatch-*"}}
+		warnings, err := validator.ValidateCreate(ctx, sr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(Equal(admission.Warnings{
+			"spec.targetSelector currently matches 2 namespaces: match-warn-a, match-warn-b",
+			`spec.targets[1].namespace "match-warn-*" currently matches 2 namespaces: match-warn-a, match-warn-b`,
+			`spec.targets[2].namespace "nomatch-*" currently matches no namespace`,
+		}))
+
+		By("not warning about plain targets")
+		sr.Spec.TargetSelector = nil
+		sr.Spec.Targets = sr.Spec.Targets[:1]
+		warnings, err = validator.ValidateUpdate(ctx, sr, sr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should reject a verification Job template without containers", func() {
+		sr := sharedResource("verify-template", "")
+		sr.Spec.TargetTemplate = nil
+		sr.Spec.Verify = &platformv1alpha1.VerifySpec{
+			JobTemplate: runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{}}}}`)},
+		}
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(ContainSubstring("spec.verify.jobTemplate")))
+	})
+
+	It("should reject a verification Job running as another ServiceAccount", func() {
+		sr := sharedResource("verify-serviceaccount", "")
+		sr.Spec.TargetTemplate = nil
+		sr.Spec.Verify = &platformv1alpha1.VerifySpec{
+			JobTemplate: runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{"serviceAccountName":"deployer",` +
+				`"containers":[{"name":"check","image":"busybox"}]}}}}`)},
+		}
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(ContainSubstring("spec.template.spec.serviceAccountName")))
+	})
+
+	It("should reject a pre-sync Job template without containers", func() {
+		sr := sharedResource("presync-template", "")
+		sr.Spec.TargetTemplate = nil
+		sr.Spec.PreSync = &platformv1alpha1.PreSyncSpec{
+			Job: &runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{}}}}`)},
+		}
+		err := k8sClient.Create(ctx, sr)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "got %v", err)
+		Expect(err).To(MatchError(ContainSubstring("spec.preSync.job")))
+	})
+})
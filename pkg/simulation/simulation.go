@@ -0,0 +1,366 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulation runs the SharedResource controller against an in-memory
+// client and a fake clock, so platform teams can check what their
+// SharedResource manifests create, and where, in CI without a cluster:
+//
+//	sim, err := simulation.New()
+//	err = sim.ApplyYAML(ctx, manifests) // Namespaces, sources, SharedResources
+//	err = sim.Settle(ctx)
+//	targets, err := sim.Targets(ctx)
+//
+// There are no watches, admission webhooks or API server validation: every
+// Settle reconciles all SharedResources until nothing changes, and Advance
+// moves the clock to trigger retries, resyncs and key rotation. Namespaces
+// must be applied like on a real cluster.
+package simulation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+	"github.com/vijay-papanaboina/sharedresource-operator/internal/controller"
+)
+
+// MaxRounds caps how many times Settle reconciles every SharedResource.
+const MaxRounds = 10
+
+// Simulation is an in-memory cluster with the SharedResource controller.
+type Simulation struct {
+	// Client reads and writes the simulated cluster
+	Client client.Client
+
+	// Clock is the controller's clock; Advance steps it
+	Clock *clocktesting.FakeClock
+
+	scheme   *runtime.Scheme
+	recorder *recorder
+
+	// writes counts object writes (not status updates) made through Client
+	mu     sync.Mutex
+	writes int
+}
+
+// Target is an object managed by a SharedResource.
+type Target struct {
+	Kind      string
+	Namespace string
+	Name      string
+
+	// SharedResource is the namespace/name of the SharedResource that wrote it last
+	SharedResource string
+
+	// Keys are the data keys, sorted
+	Keys []string
+}
+
+// Event is an event the controller recorded.
+type Event struct {
+	// Object is the Kind namespace/name the event is about
+	Object  string
+	Type    string
+	Reason  string
+	Message string
+}
+
+// New returns a Simulation holding objects, starting at the current time.
+func New(objects ...client.Object) (*Simulation, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := platformv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	s := &Simulation{
+		Clock:    clocktesting.NewFakeClock(time.Now()),
+		scheme:   scheme,
+		recorder: &recorder{scheme: scheme},
+	}
+	count := func() {
+		s.mu.Lock()
+		s.writes++
+		s.mu.Unlock()
+	}
+	s.Client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&platformv1alpha1.SharedResource{}, &platformv1alpha1.SharedResourceStatusReport{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				count()
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				count()
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				count()
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				count()
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	return s, nil
+}
+
+// Apply creates objects, or replaces them if they exist. Like the API
+// server, Secret stringData is folded into data.
+func (s *Simulation) Apply(ctx context.Context, objects ...client.Object) error {
+	for _, obj := range objects {
+		if secret, ok := obj.(*corev1.Secret); ok && len(secret.StringData) > 0 {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte, len(secret.StringData))
+			}
+			for k, v := range secret.StringData {
+				secret.Data[k] = []byte(v)
+			}
+			secret.StringData = nil
+		}
+		existing, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return fmt.Errorf("unexpected object type %T", obj)
+		}
+		err := s.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		switch {
+		case apierrors.IsNotFound(err):
+			err = s.Client.Create(ctx, obj)
+		case err == nil:
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			err = s.Client.Update(ctx, obj)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply %s: %w", describe(s.scheme, obj), err)
+		}
+	}
+	return nil
+}
+
+// ApplyYAML applies every object in a multi-document YAML or JSON stream.
+func (s *Simulation) ApplyYAML(ctx context.Context, manifests []byte) error {
+	decoder := serializer.NewCodecFactory(s.scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifests)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		decoded, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		obj, ok := decoded.(client.Object)
+		if !ok {
+			return fmt.Errorf("unsupported manifest type %T", decoded)
+		}
+		if err := s.Apply(ctx, obj); err != nil {
+			return err
+		}
+	}
+}
+
+// Delete deletes objects; SharedResources are cleaned up by the next Settle.
+func (s *Simulation) Delete(ctx context.Context, objects ...client.Object) error {
+	for _, obj := range objects {
+		if err := client.IgnoreNotFound(s.Client.Delete(ctx, obj)); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", describe(s.scheme, obj), err)
+		}
+	}
+	return nil
+}
+
+// Settle reconciles every SharedResource until a round writes nothing.
+// Returns the first reconcile error, or an error if MaxRounds are not enough.
+func (s *Simulation) Settle(ctx context.Context) error {
+	// A fresh controller per Settle, like after a watch event for every CR
+	reconciler := &controller.SharedResourceReconciler{
+		Client:   s.Client,
+		Scheme:   s.scheme,
+		Recorder: s.recorder,
+		Clock:    s.Clock,
+	}
+	for round := 0; round < MaxRounds; round++ {
+		var list platformv1alpha1.SharedResourceList
+		if err := s.Client.List(ctx, &list); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.writes = 0
+		s.mu.Unlock()
+
+		for _, sr := range list.Items {
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&sr)}
+			if _, err := reconciler.Reconcile(ctx, req); err != nil {
+				return fmt.Errorf("reconcile %s: %w", req.NamespacedName, err)
+			}
+		}
+
+		s.mu.Lock()
+		writes := s.writes
+		s.mu.Unlock()
+		if writes == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("simulation did not settle after %d rounds", MaxRounds)
+}
+
+// Advance steps the clock by d and settles.
+func (s *Simulation) Advance(ctx context.Context, d time.Duration) error {
+	s.Clock.Step(d)
+	return s.Settle(ctx)
+}
+
+// Targets returns every Secret and ConfigMap managed by a SharedResource,
+// sorted by kind, namespace and name.
+func (s *Simulation) Targets(ctx context.Context) ([]Target, error) {
+	var targets []Target
+	var secrets corev1.SecretList
+	if err := s.Client.List(ctx, &secrets); err != nil {
+		return nil, err
+	}
+	for i := range secrets.Items {
+		if t, ok := target(controller.KindSecret, &secrets.Items[i], keys(secrets.Items[i].Data)); ok {
+			targets = append(targets, t)
+		}
+	}
+	var configMaps corev1.ConfigMapList
+	if err := s.Client.List(ctx, &configMaps); err != nil {
+		return nil, err
+	}
+	for i := range configMaps.Items {
+		if t, ok := target(controller.KindConfigMap, &configMaps.Items[i], keys(configMaps.Items[i].Data)); ok {
+			targets = append(targets, t)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return targets, nil
+}
+
+// Events returns the events recorded so far, oldest first.
+func (s *Simulation) Events() []Event {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	return append([]Event(nil), s.recorder.events...)
+}
+
+// target describes obj if it is managed by a SharedResource.
+func target(kind string, obj client.Object, keys []string) (Target, bool) {
+	annotations := obj.GetAnnotations()
+	if annotations[controller.AnnotationManagedBy] != controller.ManagedByValue {
+		return Target{}, false
+	}
+	return Target{
+		Kind:           kind,
+		Namespace:      obj.GetNamespace(),
+		Name:           obj.GetName(),
+		SharedResource: annotations[controller.AnnotationSourceNamespace] + "/" + annotations[controller.AnnotationSourceCR],
+		Keys:           keys,
+	}, true
+}
+
+// keys returns the keys of data, sorted.
+func keys[V any](data map[string]V) []string {
+	list := make([]string, 0, len(data))
+	for k := range data {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// describe renders obj as "Kind namespace/name" for errors and events.
+func describe(scheme *runtime.Scheme, obj runtime.Object) string {
+	kind := fmt.Sprintf("%T", obj)
+	if gvks, _, err := scheme.ObjectKinds(obj); err == nil && len(gvks) > 0 {
+		kind = gvks[0].Kind
+	}
+	if o, ok := obj.(client.Object); ok {
+		return strings.TrimPrefix(kind+" "+o.GetNamespace()+"/"+o.GetName(), "/")
+	}
+	return kind
+}
+
+// recorder is a record.EventRecorder that keeps every event.
+type recorder struct {
+	scheme *runtime.Scheme
+	mu     sync.Mutex
+	events []Event
+}
+
+// Event implements record.EventRecorder.
+func (r *recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, Event{
+		Object:  describe(r.scheme, object),
+		Type:    eventtype,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// Eventf implements record.EventRecorder.
+func (r *recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf implements record.EventRecorder.
+func (r *recorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...any) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
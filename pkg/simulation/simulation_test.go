@@ -0,0 +1,224 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1alpha1 "github.com/vijay-papanaboina/sharedresource-operator/api/v1alpha1"
+)
+
+const manifests = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: security
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: backend
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: frontend
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: security
+stringData:
+  username: app
+  password: s3cret
+---
+apiVersion: platform.platform.dev/v1alpha1
+kind: SharedResource
+metadata:
+  name: share-db
+  namespace: security
+spec:
+  source:
+    kind: Secret
+    name: db
+  deletionPolicy: delete
+  targets:
+    - namespace: backend
+    - namespace: frontend
+      name: db-copy
+`
+
+// newSimulation returns a settled Simulation holding manifests.
+func newSimulation(t *testing.T, yaml string) *Simulation {
+	t.Helper()
+	sim, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.ApplyYAML(context.Background(), []byte(yaml)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.Settle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return sim
+}
+
+// targetData returns the data of a target Secret.
+func targetData(t *testing.T, sim *Simulation, namespace, name string) map[string][]byte {
+	t.Helper()
+	var secret corev1.Secret
+	if err := sim.Client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		t.Fatal(err)
+	}
+	return secret.Data
+}
+
+func TestTargets(t *testing.T) {
+	sim := newSimulation(t, manifests)
+
+	targets, err := sim.Targets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Target{
+		{Kind: "Secret", Namespace: "backend", Name: "db", SharedResource: "security/share-db", Keys: []string{"password", "username"}},
+		{Kind: "Secret", Namespace: "frontend", Name: "db-copy", SharedResource: "security/share-db", Keys: []string{"password", "username"}},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("Targets() = %+v, want %+v", targets, want)
+	}
+
+	var sr platformv1alpha1.SharedResource
+	if err := sim.Client.Get(context.Background(), types.NamespacedName{Namespace: "security", Name: "share-db"}, &sr); err != nil {
+		t.Fatal(err)
+	}
+	if len(sr.Status.SyncedTargets) != 2 {
+		t.Fatalf("status.syncedTargets = %+v, want 2 entries", sr.Status.SyncedTargets)
+	}
+	for _, target := range sr.Status.SyncedTargets {
+		if !target.Synced {
+			t.Errorf("target %s/%s not synced: %s", target.Namespace, target.Name, target.Error)
+		}
+	}
+}
+
+func TestSourceUpdate(t *testing.T) {
+	sim := newSimulation(t, manifests)
+	ctx := context.Background()
+
+	var source corev1.Secret
+	if err := sim.Client.Get(ctx, types.NamespacedName{Namespace: "security", Name: "db"}, &source); err != nil {
+		t.Fatal(err)
+	}
+	source.Data["password"] = []byte("rotated")
+	if err := sim.Apply(ctx, &source); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.Settle(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(targetData(t, sim, "frontend", "db-copy")["password"]); got != "rotated" {
+		t.Errorf("target password = %q, want %q", got, "rotated")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	sim := newSimulation(t, manifests)
+	ctx := context.Background()
+
+	sr := &platformv1alpha1.SharedResource{ObjectMeta: metav1.ObjectMeta{Namespace: "security", Name: "share-db"}}
+	if err := sim.Delete(ctx, sr); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.Settle(ctx); err != nil {
+		t.Fatal(err)
+	}
+	targets, err := sim.Targets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 0 {
+		t.Errorf("Targets() after delete = %+v, want none", targets)
+	}
+}
+
+func TestAdvanceRotates(t *testing.T) {
+	sim := newSimulation(t, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: security
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: backend
+---
+apiVersion: platform.platform.dev/v1alpha1
+kind: SharedResource
+metadata:
+  name: share-token
+  namespace: security
+spec:
+  source:
+    kind: Secret
+    name: token
+  generate:
+    - key: token
+      rotationPeriod: 1h
+  targets:
+    - namespace: backend
+`)
+	ctx := context.Background()
+	initial := string(targetData(t, sim, "backend", "token")["token"])
+	if initial == "" {
+		t.Fatal("token was not generated")
+	}
+
+	if err := sim.Advance(ctx, 30*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(targetData(t, sim, "backend", "token")["token"]); got != initial {
+		t.Errorf("token rotated after 30m")
+	}
+
+	if err := sim.Advance(ctx, 31*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(targetData(t, sim, "backend", "token")["token"]); got == initial {
+		t.Errorf("token not rotated after 61m")
+	}
+
+	var rotated bool
+	for _, e := range sim.Events() {
+		if e.Object == "SharedResource security/share-token" && e.Reason == "KeysRotated" {
+			rotated = true
+		}
+	}
+	if !rotated {
+		t.Errorf("no KeysRotated event in %+v", sim.Events())
+	}
+}
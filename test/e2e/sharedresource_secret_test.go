@@ -127,6 +127,18 @@ spec:
 		Expect(err).NotTo(HaveOccurred())
 		Expect(output).To(Equal("YWRtaW4=")) // base64 of "admin"
 
+		By("verifying the target and status record the operator version")
+		cmd = exec.Command("kubectl", "get", "secret", "test-secret", "-n", targetNS,
+			"-o", "jsonpath={.metadata.annotations.sharedresource\\.platform\\.dev/operator-version}")
+		output, err = utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).NotTo(BeEmpty())
+		Eventually(func() (string, error) {
+			cmd := exec.Command("kubectl", "get", "sharedresource", "sync-secret-test", "-n", sourceNS,
+				"-o", "jsonpath={.status.operatorVersion}")
+			return utils.Run(cmd)
+		}, 30*time.Second, 2*time.Second).Should(Equal(output))
+
 		By("cleaning up SharedResource CR")
 		cmd = exec.Command("kubectl", "delete", "sharedresource", "sync-secret-test", "-n", sourceNS)
 		_, _ = utils.Run(cmd)
@@ -0,0 +1,172 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vijay-papanaboina/sharedresource-operator/test/utils"
+)
+
+var _ = Describe("SharedResource Upgrade Path", Ordered, func() {
+	const (
+		sourceNS   = "sr-upgrade-source"
+		targetNS   = "sr-upgrade-target"
+		deployment = "sharedresource-operator-controller-manager"
+	)
+
+	// waitForReady waits until the controller-manager reports the given ready replicas
+	waitForReady := func(replicas string) {
+		Eventually(func() error {
+			cmd := exec.Command("kubectl", "get", "deployment", "-n", namespace,
+				deployment, "-o", "jsonpath={.status.readyReplicas}")
+			output, err := utils.Run(cmd)
+			if err != nil {
+				return err
+			}
+			if output != replicas {
+				return fmt.Errorf("controller has %q ready replicas, want %q", output, replicas)
+			}
+			return nil
+		}, 120*time.Second, 2*time.Second).Should(Succeed())
+	}
+
+	BeforeAll(func() {
+		By("installing CRDs")
+		cmd := exec.Command("make", "install")
+		_, err := utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred(), "Failed to install CRDs")
+
+		By("deploying the controller-manager")
+		cmd = exec.Command("make", "deploy", fmt.Sprintf("IMG=%s", projectImage))
+		_, err = utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred(), "Failed to deploy the controller-manager")
+
+		By("waiting for controller to be ready")
+		waitForReady("1")
+
+		By("creating test namespaces")
+		cmd = exec.Command("kubectl", "create", "ns", sourceNS)
+		_, _ = utils.Run(cmd)
+		cmd = exec.Command("kubectl", "create", "ns", targetNS)
+		_, _ = utils.Run(cmd)
+	})
+
+	AfterAll(func() {
+		By("cleaning up test namespaces")
+		cmd := exec.Command("kubectl", "delete", "ns", sourceNS, "--ignore-not-found")
+		_, _ = utils.Run(cmd)
+		cmd = exec.Command("kubectl", "delete", "ns", targetNS, "--ignore-not-found")
+		_, _ = utils.Run(cmd)
+
+		By("undeploying the controller-manager")
+		cmd = exec.Command("make", "undeploy")
+		_, _ = utils.Run(cmd)
+
+		By("uninstalling CRDs")
+		cmd = exec.Command("make", "uninstall")
+		_, _ = utils.Run(cmd)
+	})
+
+	It("should migrate stored objects when the operator restarts", func() {
+		By("creating source Secret and SharedResource CR")
+		cmd := exec.Command("kubectl", "create", "secret", "generic", "upgrade-secret",
+			"-n", sourceNS, "--from-literal=key=value")
+		_, err := utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred())
+
+		srYAML := fmt.Sprintf(`
+apiVersion: platform.platform.dev/v1alpha1
+kind: SharedResource
+metadata:
+  name: sync-upgrade-test
+  namespace: %s
+spec:
+  source:
+    kind: Secret
+    name: upgrade-secret
+  targets:
+    - namespace: %s
+`, sourceNS, targetNS)
+
+		cmd = exec.Command("kubectl", "apply", "-f", "-")
+		cmd.Stdin = stringReader(srYAML)
+		_, err = utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred())
+
+		getSummary := func() (string, error) {
+			cmd := exec.Command("kubectl", "get", "sharedresource", "sync-upgrade-test", "-n", sourceNS,
+				"-o", "jsonpath={.status.targetSummary.synced}")
+			return utils.Run(cmd)
+		}
+		Eventually(getSummary, 60*time.Second, 2*time.Second).Should(Equal("1"))
+
+		By("stopping the operator and simulating status written by an older version")
+		cmd = exec.Command("kubectl", "scale", "deployment", deployment, "-n", namespace, "--replicas=0")
+		_, err = utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		waitForReady("")
+
+		cmd = exec.Command("kubectl", "patch", "sharedresource", "sync-upgrade-test", "-n", sourceNS,
+			"--subresource=status", "--type=json", "-p", `[{"op":"remove","path":"/status/targetSummary"}]`)
+		_, err = utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getSummary()).To(BeEmpty())
+
+		By("restarting the operator")
+		cmd = exec.Command("kubectl", "scale", "deployment", deployment, "-n", namespace, "--replicas=1")
+		_, err = utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		waitForReady("1")
+
+		By("verifying the storage migration ran")
+		Eventually(func() error {
+			cmd := exec.Command("kubectl", "logs", "deployment/"+deployment, "-n", namespace)
+			output, err := utils.Run(cmd)
+			if err != nil {
+				return err
+			}
+			if !strings.Contains(output, "Storage migration complete") {
+				return fmt.Errorf("storage migration has not completed yet")
+			}
+			return nil
+		}, 60*time.Second, 2*time.Second).Should(Succeed())
+
+		By("verifying status was backfilled")
+		Eventually(getSummary, 60*time.Second, 2*time.Second).Should(Equal("1"))
+
+		By("verifying storedVersions only lists the storage version")
+		cmd = exec.Command("kubectl", "get", "crd", "sharedresources.platform.platform.dev",
+			"-o", "jsonpath={.status.storedVersions}")
+		output, err := utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(Equal(`["v1alpha1"]`))
+
+		By("cleaning up SharedResource CR")
+		cmd = exec.Command("kubectl", "delete", "sharedresource", "sync-upgrade-test", "-n", sourceNS)
+		_, _ = utils.Run(cmd)
+	})
+})